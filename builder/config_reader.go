@@ -24,6 +24,7 @@ type Config struct {
 	Lifecycle       LifecycleConfig  `toml:"lifecycle"`
 	Run             RunConfig        `toml:"run"`
 	Build           BuildConfig      `toml:"build"`
+	Targets         []dist.Target    `toml:"targets"`
 }
 
 // ModuleCollection is a list of ModuleConfigs
@@ -118,6 +119,17 @@ func ValidateConfig(c Config) error {
 		return errors.New("run.images and stack.run-image do not match")
 	}
 
+	platforms := map[string]bool{}
+	for _, target := range c.Targets {
+		if target.OS == "" {
+			return errors.New("targets.os is required")
+		}
+		if platforms[target.Platform()] {
+			return errors.Errorf("target %s is declared more than once", style.Symbol(target.Platform()))
+		}
+		platforms[target.Platform()] = true
+	}
+
 	return nil
 }
 
@@ -10,6 +10,7 @@ import (
 	"github.com/sclevine/spec/report"
 
 	"github.com/buildpacks/pack/builder"
+	"github.com/buildpacks/pack/pkg/dist"
 	h "github.com/buildpacks/pack/testhelpers"
 )
 
@@ -79,6 +80,35 @@ func testConfig(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("targets are declared", func() {
+			it.Before(func() {
+				h.AssertNil(t, os.WriteFile(builderConfigPath, []byte(`
+[[targets]]
+  os = "linux"
+  arch = "amd64"
+
+[[targets]]
+  os = "linux"
+  arch = "arm64"
+  variant = "v8"
+
+[[order]]
+[[order.group]]
+  id = "buildpack/1"
+`), 0666))
+			})
+
+			it("returns the targets", func() {
+				builderConfig, _, err := builder.ReadConfig(builderConfigPath)
+				h.AssertNil(t, err)
+
+				h.AssertEq(t, builderConfig.Targets, []dist.Target{
+					{OS: "linux", Arch: "amd64"},
+					{OS: "linux", Arch: "arm64", ArchVariant: "v8"},
+				})
+			})
+		})
+
 		when("an error occurs while reading", func() {
 			it("bubbles up the error", func() {
 				_, _, err := builder.ReadConfig(builderConfigPath)
@@ -228,5 +258,31 @@ uri = "noop-buildpack.tgz"
 			config := builder.Config{}
 			h.AssertError(t, builder.ValidateConfig(config), "build.image is required")
 		})
+
+		when("targets are declared", func() {
+			var config builder.Config
+
+			it.Before(func() {
+				config = builder.Config{
+					Build: builder.BuildConfig{Image: testBuildImage},
+					Run:   builder.RunConfig{Images: []builder.RunImageConfig{{Image: testRunImage}}},
+				}
+			})
+
+			it("returns error if a target has no os", func() {
+				config.Targets = []dist.Target{{Arch: "arm64"}}
+				h.AssertError(t, builder.ValidateConfig(config), "targets.os is required")
+			})
+
+			it("returns error if a target is declared twice", func() {
+				config.Targets = []dist.Target{{OS: "linux", Arch: "arm64"}, {OS: "linux", Arch: "arm64"}}
+				h.AssertError(t, builder.ValidateConfig(config), "target 'linux/arm64' is declared more than once")
+			})
+
+			it("accepts distinct targets", func() {
+				config.Targets = []dist.Target{{OS: "linux", Arch: "amd64"}, {OS: "linux", Arch: "arm64"}}
+				h.AssertNil(t, builder.ValidateConfig(config))
+			})
+		})
 	})
 }
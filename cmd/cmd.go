@@ -10,6 +10,7 @@ import (
 	"github.com/buildpacks/pack/internal/commands"
 	"github.com/buildpacks/pack/internal/config"
 	imagewriter "github.com/buildpacks/pack/internal/inspectimage/writer"
+	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/internal/term"
 	"github.com/buildpacks/pack/pkg/client"
 	"github.com/buildpacks/pack/pkg/logging"
@@ -23,6 +24,11 @@ type ConfigurableLogger interface {
 	WantVerbose(f bool)
 }
 
+// JSONLogger is implemented by the loggers that can write their entries as JSON objects, for --log-format json
+type JSONLogger interface {
+	WantJSON(f bool)
+}
+
 // NewPackCommand generates a Pack command
 //
 //nolint:staticcheck
@@ -41,7 +47,7 @@ func NewPackCommand(logger ConfigurableLogger) (*cobra.Command, error) {
 	rootCmd := &cobra.Command{
 		Use:   "pack",
 		Short: "CLI for building apps using Cloud Native Buildpacks",
-		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 			if fs := cmd.Flags(); fs != nil {
 				if flag, err := fs.GetBool("no-color"); err == nil && flag {
 					color.Disable(flag)
@@ -52,6 +58,21 @@ func NewPackCommand(logger ConfigurableLogger) (*cobra.Command, error) {
 					color.Disable(true)
 				}
 
+				if format, err := fs.GetString("log-format"); err == nil {
+					switch format {
+					case "text":
+					case "json":
+						jsonLogger, ok := logger.(JSONLogger)
+						if !ok {
+							return errors.New("the logger does not support the json log format")
+						}
+						color.Disable(true)
+						jsonLogger.WantJSON(true)
+					default:
+						return errors.Errorf("invalid log format %s, must be one of 'text' or 'json'", style.Symbol(format))
+					}
+				}
+
 				if flag, err := fs.GetBool("quiet"); err == nil {
 					logger.WantQuiet(flag)
 				}
@@ -61,7 +82,17 @@ func NewPackCommand(logger ConfigurableLogger) (*cobra.Command, error) {
 				if flag, err := fs.GetBool("timestamps"); err == nil {
 					logger.WantTime(flag)
 				}
+				// the client is created before flags are parsed, it is recreated when the flag overrides the config
+				if flag, err := fs.GetBool("offline"); err == nil && fs.Changed("offline") && flag != cfg.Offline {
+					cfg.Offline = flag
+					offlineClient, err := initClient(logger, cfg)
+					if err != nil {
+						return err
+					}
+					*packClient = *offlineClient
+				}
 			}
+			return nil
 		},
 	}
 
@@ -69,6 +100,8 @@ func NewPackCommand(logger ConfigurableLogger) (*cobra.Command, error) {
 	rootCmd.PersistentFlags().Bool("timestamps", false, "Enable timestamps in output")
 	rootCmd.PersistentFlags().BoolP("quiet", "q", false, "Show less output")
 	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "Show more output")
+	rootCmd.PersistentFlags().Bool("offline", false, "Run without network access: images are never pulled, and downloads and buildpack registries are only read from local caches.\nDefaults to the value of 'pack config offline'")
+	rootCmd.PersistentFlags().String("log-format", "text", "Format of the output, 'text' or 'json'. With 'json', every log entry is written as a JSON object")
 	rootCmd.Flags().Bool("version", false, "Show current 'pack' version")
 
 	commands.AddHelpFlag(rootCmd, "pack")
@@ -79,9 +112,13 @@ func NewPackCommand(logger ConfigurableLogger) (*cobra.Command, error) {
 	rootCmd.AddCommand(commands.NewExtensionCommand(logger, cfg, packClient, buildpackage.NewConfigReader()))
 	rootCmd.AddCommand(commands.NewConfigCommand(logger, cfg, cfgPath, packClient))
 	rootCmd.AddCommand(commands.InspectImage(logger, imagewriter.NewFactory(), cfg, packClient))
+	rootCmd.AddCommand(commands.NewImageCommand(logger, packClient))
 	rootCmd.AddCommand(commands.NewStackCommand(logger))
 	rootCmd.AddCommand(commands.Rebase(logger, cfg, packClient))
 	rootCmd.AddCommand(commands.NewSBOMCommand(logger, cfg, packClient))
+	rootCmd.AddCommand(commands.NewManifestCommand(logger, packClient))
+	rootCmd.AddCommand(commands.NewProjectCommand(logger, cfg, packClient))
+	rootCmd.AddCommand(commands.NewCacheCommand(logger, cfg, packClient))
 
 	rootCmd.AddCommand(commands.InspectBuildpack(logger, cfg, packClient))
 	rootCmd.AddCommand(commands.InspectBuilder(logger, cfg, packClient, builderwriter.NewFactory()))
@@ -139,5 +176,5 @@ func initClient(logger logging.Logger, cfg config.Config) (*client.Client, error
 	if err != nil {
 		return nil, err
 	}
-	return client.NewClient(client.WithLogger(logger), client.WithExperimental(cfg.Experimental), client.WithRegistryMirrors(cfg.RegistryMirrors), client.WithDockerClient(dc))
+	return client.NewClient(client.WithLogger(logger), client.WithExperimental(cfg.Experimental), client.WithRegistryMirrors(cfg.RegistryMirrors), client.WithDockerClient(dc), client.WithRequireDigests(cfg.RequireDigests), client.WithOffline(cfg.Offline))
 }
@@ -124,4 +124,4 @@ require (
 	gotest.tools/v3 v3.4.0 // indirect
 )
 
-go 1.20
+go 1.21
@@ -0,0 +1,317 @@
+package attest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/buildpacks/lifecycle/platform/files"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+
+	"github.com/buildpacks/pack/pkg/dist"
+	projectTypes "github.com/buildpacks/pack/pkg/project/types"
+)
+
+const (
+	StatementType               = "https://in-toto.io/Statement/v1"
+	SLSAProvenancePredicateType = "https://slsa.dev/provenance/v1"
+
+	// BuildType identifies the meaning of the parameters of provenance statements generated by pack
+	BuildType = "https://buildpacks.io/pack/build/v1"
+
+	// PackBuilderID identifies pack as the builder in provenance statements, followed by the version of pack
+	PackBuilderID = "https://github.com/buildpacks/pack"
+)
+
+// Statement is an in-toto statement holding a SLSA provenance predicate.
+type Statement struct {
+	Type          string     `json:"_type"`
+	Subject       []Subject  `json:"subject"`
+	PredicateType string     `json:"predicateType"`
+	Predicate     Provenance `json:"predicate"`
+}
+
+// Subject is an artifact described by a statement.
+type Subject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest,omitempty"`
+}
+
+// Provenance is a SLSA v1 provenance predicate.
+type Provenance struct {
+	BuildDefinition BuildDefinition `json:"buildDefinition"`
+	RunDetails      RunDetails      `json:"runDetails"`
+}
+
+type BuildDefinition struct {
+	BuildType            string                 `json:"buildType"`
+	ExternalParameters   map[string]interface{} `json:"externalParameters"`
+	InternalParameters   map[string]interface{} `json:"internalParameters,omitempty"`
+	ResolvedDependencies []ResourceDescriptor   `json:"resolvedDependencies,omitempty"`
+}
+
+// ResourceDescriptor describes an artifact the build depends on.
+type ResourceDescriptor struct {
+	Name        string                 `json:"name,omitempty"`
+	URI         string                 `json:"uri,omitempty"`
+	Digest      map[string]string      `json:"digest,omitempty"`
+	Annotations map[string]interface{} `json:"annotations,omitempty"`
+}
+
+type RunDetails struct {
+	Builder  Builder       `json:"builder"`
+	Metadata BuildMetadata `json:"metadata"`
+}
+
+type Builder struct {
+	ID      string            `json:"id"`
+	Version map[string]string `json:"version,omitempty"`
+}
+
+type BuildMetadata struct {
+	StartedOn  *time.Time `json:"startedOn,omitempty"`
+	FinishedOn *time.Time `json:"finishedOn,omitempty"`
+}
+
+// BuildInfo describes how an app image was built.
+type BuildInfo struct {
+	// Repository the app image was published to, and digest of its manifest when known.
+	Image  string
+	Digest v1.Hash
+
+	// Builder image and its digest, when known.
+	Builder       string
+	BuilderDigest string
+
+	// Run image the app image is based on and its digest, when known.
+	RunImage       string
+	RunImageDigest string
+
+	// Version of the lifecycle that ran the build.
+	LifecycleVersion string
+
+	// Buildpacks of the group that took part in the build.
+	Buildpacks []dist.ModuleInfo
+
+	// Project section of the project descriptor of the app.
+	Project projectTypes.Project
+
+	// Source of the app, when known.
+	Source *files.ProjectSource
+
+	PackVersion string
+	StartedOn   time.Time
+	FinishedOn  time.Time
+}
+
+// NewProvenanceStatement returns a SLSA provenance statement describing the build of an app image.
+func NewProvenanceStatement(info BuildInfo) Statement {
+	externalParameters := map[string]interface{}{
+		"builder": info.Builder,
+	}
+	var buildpacks []string
+	for _, bp := range info.Buildpacks {
+		buildpacks = append(buildpacks, bp.FullName())
+	}
+	if len(buildpacks) > 0 {
+		externalParameters["buildpacks"] = buildpacks
+	}
+
+	if info.RunImage != "" {
+		externalParameters["runImage"] = info.RunImage
+	}
+	if project := projectParameters(info.Project); len(project) > 0 {
+		externalParameters["project"] = project
+	}
+
+	dependencies := []ResourceDescriptor{imageDescriptor("builder", info.Builder, info.BuilderDigest)}
+	if info.RunImage != "" {
+		dependencies = append(dependencies, imageDescriptor("run-image", info.RunImage, info.RunImageDigest))
+	}
+
+	if source, ok := sourceDescriptor(info.Source); ok {
+		externalParameters["source"] = source.URI
+		dependencies = append(dependencies, source)
+	}
+
+	for _, bp := range info.Buildpacks {
+		annotations := map[string]interface{}{"version": bp.Version}
+		if bp.Homepage != "" {
+			annotations["homepage"] = bp.Homepage
+		}
+		dependencies = append(dependencies, ResourceDescriptor{Name: bp.ID, Annotations: annotations})
+	}
+
+	statement := Statement{
+		Type:          StatementType,
+		Subject:       []Subject{{Name: info.Image}},
+		PredicateType: SLSAProvenancePredicateType,
+		Predicate: Provenance{
+			BuildDefinition: BuildDefinition{
+				BuildType:            BuildType,
+				ExternalParameters:   externalParameters,
+				ResolvedDependencies: dependencies,
+			},
+			RunDetails: RunDetails{
+				Builder: Builder{ID: PackBuilderID},
+			},
+		},
+	}
+	if info.Digest != (v1.Hash{}) {
+		statement.Subject[0].Digest = map[string]string{info.Digest.Algorithm: info.Digest.Hex}
+	}
+	if info.LifecycleVersion != "" {
+		statement.Predicate.BuildDefinition.InternalParameters = map[string]interface{}{"lifecycleVersion": info.LifecycleVersion}
+	}
+	versions := map[string]string{}
+	if info.PackVersion != "" {
+		versions["pack"] = info.PackVersion
+	}
+	if info.LifecycleVersion != "" {
+		versions["lifecycle"] = info.LifecycleVersion
+	}
+	if len(versions) > 0 {
+		statement.Predicate.RunDetails.Builder.Version = versions
+	}
+	if !info.StartedOn.IsZero() {
+		startedOn := info.StartedOn.UTC()
+		statement.Predicate.RunDetails.Metadata.StartedOn = &startedOn
+	}
+	if !info.FinishedOn.IsZero() {
+		finishedOn := info.FinishedOn.UTC()
+		statement.Predicate.RunDetails.Metadata.FinishedOn = &finishedOn
+	}
+	return statement
+}
+
+// imageDescriptor describes an image the build depends on, with its digest when it is valid
+func imageDescriptor(descriptorName, image, digest string) ResourceDescriptor {
+	descriptor := ResourceDescriptor{Name: descriptorName, URI: image}
+	if hash, err := v1.NewHash(digest); err == nil {
+		descriptor.Digest = map[string]string{hash.Algorithm: hash.Hex}
+	}
+	return descriptor
+}
+
+// projectParameters returns the fields of the project descriptor that are set
+func projectParameters(project projectTypes.Project) map[string]interface{} {
+	parameters := map[string]interface{}{}
+	if project.Name != "" {
+		parameters["name"] = project.Name
+	}
+	if project.Version != "" {
+		parameters["version"] = project.Version
+	}
+	if project.SourceURL != "" {
+		parameters["sourceUrl"] = project.SourceURL
+	}
+	var licenses []string
+	for _, license := range project.Licenses {
+		if license.Type != "" {
+			licenses = append(licenses, license.Type)
+		} else if license.URI != "" {
+			licenses = append(licenses, license.URI)
+		}
+	}
+	if len(licenses) > 0 {
+		parameters["licenses"] = licenses
+	}
+	return parameters
+}
+
+// WriteProvenance writes the provenance statement to path as JSON.
+func WriteProvenance(statement Statement, path string) error {
+	content, err := json.MarshalIndent(statement, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(content, '\n'), 0644)
+}
+
+// sourceDescriptor describes the source of the app, as recorded in the project metadata of the build
+func sourceDescriptor(source *files.ProjectSource) (ResourceDescriptor, bool) {
+	if source == nil {
+		return ResourceDescriptor{}, false
+	}
+
+	url, _ := source.Metadata["url"].(string)
+	switch source.Type {
+	case "git":
+		commit, _ := source.Version["commit"].(string)
+		if url == "" && commit == "" {
+			return ResourceDescriptor{}, false
+		}
+		descriptor := ResourceDescriptor{Name: "source"}
+		if url != "" {
+			descriptor.URI = "git+" + strings.TrimPrefix(url, "git+")
+		}
+		if commit != "" {
+			descriptor.Digest = map[string]string{"gitCommit": commit}
+		}
+		return descriptor, true
+	default:
+		if url == "" {
+			return ResourceDescriptor{}, false
+		}
+		descriptor := ResourceDescriptor{Name: "source", URI: url}
+		if version, ok := source.Version["declared"].(string); ok && version != "" {
+			descriptor.Annotations = map[string]interface{}{"version": version}
+		}
+		return descriptor, true
+	}
+}
+
+// ProvenanceArtifact returns the provenance statement as an artifact to attach to the app image. When signer is set,
+// the statement is wrapped in a DSSE envelope signed by signer.
+func ProvenanceArtifact(statement Statement, signer *Signer) (Artifact, error) {
+	payload, err := json.Marshal(statement)
+	if err != nil {
+		return Artifact{}, err
+	}
+
+	artifact := Artifact{
+		ArtifactType: InTotoMediaType,
+		MediaType:    InTotoMediaType,
+		Content:      payload,
+		Annotations:  map[string]string{PredicateTypeAnnotation: statement.PredicateType},
+	}
+	if signer == nil {
+		return artifact, nil
+	}
+
+	signature, err := signer.Sign(pae(InTotoMediaType, payload))
+	if err != nil {
+		return Artifact{}, err
+	}
+	envelope, err := json.Marshal(dsseEnvelope{
+		PayloadType: InTotoMediaType,
+		Payload:     base64.StdEncoding.EncodeToString(payload),
+		Signatures:  []dsseSignature{{Sig: base64.StdEncoding.EncodeToString(signature)}},
+	})
+	if err != nil {
+		return Artifact{}, err
+	}
+
+	artifact.MediaType = DSSEMediaType
+	artifact.Content = envelope
+	return artifact, nil
+}
+
+type dsseEnvelope struct {
+	PayloadType string          `json:"payloadType"`
+	Payload     string          `json:"payload"`
+	Signatures  []dsseSignature `json:"signatures"`
+}
+
+type dsseSignature struct {
+	KeyID string `json:"keyid"`
+	Sig   string `json:"sig"`
+}
+
+// pae is the pre-authentication encoding of DSSE, which is what envelopes sign
+func pae(payloadType string, payload []byte) []byte {
+	return []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload))
+}
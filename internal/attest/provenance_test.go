@@ -0,0 +1,214 @@
+package attest_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/buildpacks/lifecycle/platform/files"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/attest"
+	"github.com/buildpacks/pack/pkg/dist"
+	projectTypes "github.com/buildpacks/pack/pkg/project/types"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestProvenance(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Provenance", testProvenance, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testProvenance(t *testing.T, when spec.G, it spec.S) {
+	var (
+		info      attest.BuildInfo
+		startedOn = time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	)
+
+	it.Before(func() {
+		digest, err := v1.NewHash("sha256:" + fmt.Sprintf("%064d", 1))
+		h.AssertNil(t, err)
+
+		info = attest.BuildInfo{
+			Image:            "registry.example.com/some/app",
+			Digest:           digest,
+			Builder:          "registry.example.com/some/builder:latest",
+			BuilderDigest:    "sha256:" + fmt.Sprintf("%064d", 2),
+			RunImage:         "registry.example.com/some/run:latest",
+			RunImageDigest:   "sha256:" + fmt.Sprintf("%064d", 3),
+			LifecycleVersion: "0.17.0",
+			Project: projectTypes.Project{
+				Name:      "some-app",
+				Version:   "1.0.0",
+				SourceURL: "https://example.com/some/app",
+				Licenses:  []projectTypes.License{{Type: "MIT"}},
+			},
+			Buildpacks: []dist.ModuleInfo{
+				{ID: "some/buildpack", Version: "1.2.3", Homepage: "https://example.com/some/buildpack"},
+				{ID: "other/buildpack", Version: "4.5.6"},
+			},
+			Source: &files.ProjectSource{
+				Type:     "git",
+				Version:  map[string]interface{}{"commit": "abc123"},
+				Metadata: map[string]interface{}{"url": "https://github.com/some/app"},
+			},
+			PackVersion: "1.2.3",
+			StartedOn:   startedOn,
+			FinishedOn:  startedOn.Add(time.Minute),
+		}
+	})
+
+	when("#NewProvenanceStatement", func() {
+		it("describes the build", func() {
+			statement := attest.NewProvenanceStatement(info)
+
+			h.AssertEq(t, statement.Type, attest.StatementType)
+			h.AssertEq(t, statement.PredicateType, attest.SLSAProvenancePredicateType)
+			h.AssertEq(t, statement.Subject, []attest.Subject{{
+				Name:   "registry.example.com/some/app",
+				Digest: map[string]string{"sha256": fmt.Sprintf("%064d", 1)},
+			}})
+
+			definition := statement.Predicate.BuildDefinition
+			h.AssertEq(t, definition.BuildType, attest.BuildType)
+			h.AssertEq(t, definition.ExternalParameters, map[string]interface{}{
+				"builder":    "registry.example.com/some/builder:latest",
+				"buildpacks": []string{"some/buildpack@1.2.3", "other/buildpack@4.5.6"},
+				"runImage":   "registry.example.com/some/run:latest",
+				"project": map[string]interface{}{
+					"name":      "some-app",
+					"version":   "1.0.0",
+					"sourceUrl": "https://example.com/some/app",
+					"licenses":  []string{"MIT"},
+				},
+				"source": "git+https://github.com/some/app",
+			})
+			h.AssertEq(t, definition.InternalParameters, map[string]interface{}{"lifecycleVersion": "0.17.0"})
+			h.AssertEq(t, definition.ResolvedDependencies, []attest.ResourceDescriptor{
+				{Name: "builder", URI: "registry.example.com/some/builder:latest", Digest: map[string]string{"sha256": fmt.Sprintf("%064d", 2)}},
+				{Name: "run-image", URI: "registry.example.com/some/run:latest", Digest: map[string]string{"sha256": fmt.Sprintf("%064d", 3)}},
+				{Name: "source", URI: "git+https://github.com/some/app", Digest: map[string]string{"gitCommit": "abc123"}},
+				{Name: "some/buildpack", Annotations: map[string]interface{}{"version": "1.2.3", "homepage": "https://example.com/some/buildpack"}},
+				{Name: "other/buildpack", Annotations: map[string]interface{}{"version": "4.5.6"}},
+			})
+
+			details := statement.Predicate.RunDetails
+			h.AssertEq(t, details.Builder, attest.Builder{ID: attest.PackBuilderID, Version: map[string]string{"pack": "1.2.3", "lifecycle": "0.17.0"}})
+			h.AssertEq(t, *details.Metadata.StartedOn, startedOn)
+			h.AssertEq(t, *details.Metadata.FinishedOn, startedOn.Add(time.Minute))
+		})
+
+		it("describes project sources", func() {
+			info.Source = &files.ProjectSource{
+				Type:     "project",
+				Version:  map[string]interface{}{"declared": "1.0.0"},
+				Metadata: map[string]interface{}{"url": "https://example.com/some/app"},
+			}
+
+			statement := attest.NewProvenanceStatement(info)
+			h.AssertEq(t, statement.Predicate.BuildDefinition.ResolvedDependencies[2], attest.ResourceDescriptor{
+				Name:        "source",
+				URI:         "https://example.com/some/app",
+				Annotations: map[string]interface{}{"version": "1.0.0"},
+			})
+		})
+
+		it("omits the source when unknown", func() {
+			info.Source = nil
+
+			statement := attest.NewProvenanceStatement(info)
+			_, ok := statement.Predicate.BuildDefinition.ExternalParameters["source"]
+			h.AssertEq(t, ok, false)
+			h.AssertEq(t, len(statement.Predicate.BuildDefinition.ResolvedDependencies), 4)
+		})
+
+		it("omits the digest of the subject when unknown", func() {
+			info.Digest = v1.Hash{}
+
+			statement := attest.NewProvenanceStatement(info)
+			h.AssertEq(t, statement.Subject, []attest.Subject{{Name: "registry.example.com/some/app"}})
+		})
+
+		it("omits the run image and project when unknown", func() {
+			info.RunImage = ""
+			info.Project = projectTypes.Project{}
+
+			statement := attest.NewProvenanceStatement(info)
+			_, ok := statement.Predicate.BuildDefinition.ExternalParameters["runImage"]
+			h.AssertEq(t, ok, false)
+			_, ok = statement.Predicate.BuildDefinition.ExternalParameters["project"]
+			h.AssertEq(t, ok, false)
+			h.AssertEq(t, statement.Predicate.BuildDefinition.ResolvedDependencies[1].Name, "source")
+		})
+	})
+
+	when("#WriteProvenance", func() {
+		it("writes the statement as JSON", func() {
+			path := filepath.Join(t.TempDir(), "provenance.json")
+			h.AssertNil(t, attest.WriteProvenance(attest.NewProvenanceStatement(info), path))
+
+			content, err := os.ReadFile(path)
+			h.AssertNil(t, err)
+			var statement attest.Statement
+			h.AssertNil(t, json.Unmarshal(content, &statement))
+			h.AssertEq(t, statement.PredicateType, attest.SLSAProvenancePredicateType)
+			h.AssertEq(t, statement.Predicate.BuildDefinition.ExternalParameters["runImage"], "registry.example.com/some/run:latest")
+		})
+	})
+
+	when("#ProvenanceArtifact", func() {
+		it("holds the statement", func() {
+			artifact, err := attest.ProvenanceArtifact(attest.NewProvenanceStatement(info), nil)
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, artifact.MediaType, attest.InTotoMediaType)
+			h.AssertEq(t, artifact.Annotations[attest.PredicateTypeAnnotation], attest.SLSAProvenancePredicateType)
+
+			var statement attest.Statement
+			h.AssertNil(t, json.Unmarshal(artifact.Content, &statement))
+			h.AssertEq(t, statement.Subject[0].Name, "registry.example.com/some/app")
+		})
+
+		it("wraps the statement in a signed DSSE envelope when a signer is provided", func() {
+			key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			h.AssertNil(t, err)
+
+			artifact, err := attest.ProvenanceArtifact(attest.NewProvenanceStatement(info), attest.NewSigner(key))
+			h.AssertNil(t, err)
+			h.AssertEq(t, artifact.ArtifactType, attest.InTotoMediaType)
+			h.AssertEq(t, artifact.MediaType, attest.DSSEMediaType)
+
+			var envelope struct {
+				PayloadType string `json:"payloadType"`
+				Payload     string `json:"payload"`
+				Signatures  []struct {
+					Sig string `json:"sig"`
+				} `json:"signatures"`
+			}
+			h.AssertNil(t, json.Unmarshal(artifact.Content, &envelope))
+			h.AssertEq(t, envelope.PayloadType, attest.InTotoMediaType)
+			h.AssertEq(t, len(envelope.Signatures), 1)
+
+			payload, err := base64.StdEncoding.DecodeString(envelope.Payload)
+			h.AssertNil(t, err)
+			signature, err := base64.StdEncoding.DecodeString(envelope.Signatures[0].Sig)
+			h.AssertNil(t, err)
+
+			pae := fmt.Sprintf("DSSEv1 %d %s %d %s", len(attest.InTotoMediaType), attest.InTotoMediaType, len(payload), payload)
+			digest := sha256.Sum256([]byte(pae))
+			h.AssertTrue(t, ecdsa.VerifyASN1(&key.PublicKey, digest[:], signature))
+		})
+	})
+}
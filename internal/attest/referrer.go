@@ -0,0 +1,193 @@
+package attest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+const (
+	// SignatureArtifactType and SimpleSigningMediaType are the types cosign uses for signatures stored as referrers
+	SignatureArtifactType  = "application/vnd.dev.cosign.artifact.sig.v1+json"
+	SimpleSigningMediaType = "application/vnd.dev.cosign.simplesigning.v1+json"
+	SignatureAnnotation    = "dev.cosignproject.cosign/signature"
+
+	InTotoMediaType         = "application/vnd.in-toto+json"
+	DSSEMediaType           = "application/vnd.dsse.envelope.v1+json"
+	PredicateTypeAnnotation = "in-toto.io/predicate-type"
+
+	CycloneDXMediaType = "application/vnd.cyclonedx+json"
+	SPDXMediaType      = "application/spdx+json"
+	SyftMediaType      = "application/vnd.syft+json"
+
+	titleAnnotation = "org.opencontainers.image.title"
+)
+
+var sbomMediaTypes = map[string]string{
+	".cdx.json":  CycloneDXMediaType,
+	".spdx.json": SPDXMediaType,
+	".syft.json": SyftMediaType,
+}
+
+// Artifact is content attached to an image as an OCI referrer.
+type Artifact struct {
+	// ArtifactType is the media type of the config of the referrer manifest, which registries report as its artifact type.
+	ArtifactType string
+
+	// MediaType of the content, which is the only layer of the referrer manifest.
+	MediaType string
+	Content   []byte
+
+	// Annotations of the layer holding the content.
+	Annotations map[string]string
+}
+
+// Attacher attaches artifacts to an image as OCI referrers. On registries without support for the referrers API,
+// the referrers are tracked by the index tagged with the fallback tag of the image digest.
+type Attacher struct {
+	subject    name.Digest
+	descriptor v1.Descriptor
+	options    []remote.Option
+}
+
+// NewAttacher returns an Attacher for the image with the given digest, whose manifest is described by descriptor.
+func NewAttacher(subject name.Digest, descriptor v1.Descriptor, options ...remote.Option) *Attacher {
+	return &Attacher{
+		subject: subject,
+		descriptor: v1.Descriptor{
+			MediaType: descriptor.MediaType,
+			Digest:    descriptor.Digest,
+			Size:      descriptor.Size,
+		},
+		options: options,
+	}
+}
+
+// Attach pushes artifact to the repository of the image as a manifest referring to the image, and returns its digest.
+func (a *Attacher) Attach(artifact Artifact) (v1.Hash, error) {
+	img := mutate.MediaType(empty.Image, types.OCIManifestSchema1)
+	img = mutate.ConfigMediaType(img, types.MediaType(artifact.ArtifactType))
+	img, err := mutate.Append(img, mutate.Addendum{
+		Layer:       static.NewLayer(artifact.Content, types.MediaType(artifact.MediaType)),
+		Annotations: artifact.Annotations,
+	})
+	if err != nil {
+		return v1.Hash{}, err
+	}
+	img = mutate.Subject(img, a.descriptor).(v1.Image)
+
+	digest, err := img.Digest()
+	if err != nil {
+		return v1.Hash{}, err
+	}
+	if err := remote.Write(a.subject.Context().Digest(digest.String()), img, a.options...); err != nil {
+		return v1.Hash{}, errors.Wrapf(err, "pushing %s referrer of %s", style.Symbol(artifact.ArtifactType), style.Symbol(a.subject.Name()))
+	}
+	return digest, nil
+}
+
+// simpleSigningPayload is the payload signed by cosign, which identifies an image by repository and digest
+type simpleSigningPayload struct {
+	Critical struct {
+		Identity struct {
+			DockerReference string `json:"docker-reference"`
+		} `json:"identity"`
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+		Type string `json:"type"`
+	} `json:"critical"`
+	Optional map[string]interface{} `json:"optional"`
+}
+
+// SignatureArtifact signs the image with the given digest in repository, in the format cosign verifies.
+func SignatureArtifact(signer *Signer, repository name.Repository, digest v1.Hash) (Artifact, error) {
+	var payload simpleSigningPayload
+	payload.Critical.Identity.DockerReference = repository.Name()
+	payload.Critical.Image.DockerManifestDigest = digest.String()
+	payload.Critical.Type = "cosign container image signature"
+
+	content, err := json.Marshal(payload)
+	if err != nil {
+		return Artifact{}, err
+	}
+	signature, err := signer.Sign(content)
+	if err != nil {
+		return Artifact{}, errors.Wrap(err, "signing image")
+	}
+
+	return Artifact{
+		ArtifactType: SignatureArtifactType,
+		MediaType:    SimpleSigningMediaType,
+		Content:      content,
+		Annotations:  map[string]string{SignatureAnnotation: base64.StdEncoding.EncodeToString(signature)},
+	}, nil
+}
+
+// SBOMArtifacts returns an artifact for each CycloneDX, SPDX or Syft document found in dir, such as the SBOM files
+// written by the lifecycle. The path of each document relative to dir is recorded as its title.
+func SBOMArtifacts(dir string) ([]Artifact, error) {
+	var artifacts []Artifact
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+
+		mediaType, ok := sbomMediaType(entry.Name())
+		if !ok {
+			return nil
+		}
+
+		content, err := os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		artifacts = append(artifacts, Artifact{
+			ArtifactType: mediaType,
+			MediaType:    mediaType,
+			Content:      content,
+			Annotations:  map[string]string{titleAnnotation: filepath.ToSlash(relPath)},
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading SBOM files in %s", style.Symbol(dir))
+	}
+
+	sort.Slice(artifacts, func(i, j int) bool {
+		return artifacts[i].Annotations[titleAnnotation] < artifacts[j].Annotations[titleAnnotation]
+	})
+	return artifacts, nil
+}
+
+func sbomMediaType(fileName string) (string, bool) {
+	for extension, mediaType := range sbomMediaTypes {
+		if strings.HasSuffix(fileName, extension) {
+			return mediaType, true
+		}
+	}
+	return "", false
+}
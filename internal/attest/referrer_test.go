@@ -0,0 +1,158 @@
+package attest_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/attest"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestReferrer(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Referrer", testReferrer, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testReferrer(t *testing.T, when spec.G, it spec.S) {
+	var (
+		server *httptest.Server
+		repo   name.Repository
+		digest v1.Hash
+	)
+
+	startRegistry := func(opts ...registry.Option) {
+		server = httptest.NewServer(registry.New(append(opts, registry.Logger(log.New(io.Discard, "", 0)))...))
+
+		var err error
+		repo, err = name.NewRepository(strings.TrimPrefix(server.URL, "http://") + "/some/app")
+		h.AssertNil(t, err)
+
+		img, err := random.Image(1024, 1)
+		h.AssertNil(t, err)
+		h.AssertNil(t, remote.Write(repo.Tag("latest"), img))
+		digest, err = img.Digest()
+		h.AssertNil(t, err)
+	}
+
+	it.After(func() {
+		server.Close()
+	})
+
+	when("#Attacher", func() {
+		artifact := attest.Artifact{
+			ArtifactType: attest.InTotoMediaType,
+			MediaType:    attest.InTotoMediaType,
+			Content:      []byte(`{"some":"statement"}`),
+			Annotations:  map[string]string{attest.PredicateTypeAnnotation: attest.SLSAProvenancePredicateType},
+		}
+
+		assertAttached := func() {
+			t.Helper()
+			descriptor, err := remote.Head(repo.Digest(digest.String()))
+			h.AssertNil(t, err)
+			attacher := attest.NewAttacher(repo.Digest(digest.String()), *descriptor)
+
+			referrerDigest, err := attacher.Attach(artifact)
+			h.AssertNil(t, err)
+
+			index, err := remote.Referrers(repo.Digest(digest.String()))
+			h.AssertNil(t, err)
+			manifest, err := index.IndexManifest()
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(manifest.Manifests), 1)
+			h.AssertEq(t, manifest.Manifests[0].Digest, referrerDigest)
+			h.AssertEq(t, manifest.Manifests[0].ArtifactType, attest.InTotoMediaType)
+
+			referrer, err := remote.Image(repo.Digest(referrerDigest.String()))
+			h.AssertNil(t, err)
+			referrerManifest, err := referrer.Manifest()
+			h.AssertNil(t, err)
+			h.AssertEq(t, referrerManifest.Subject.Digest, digest)
+			h.AssertEq(t, referrerManifest.Layers[0].Annotations, artifact.Annotations)
+
+			layers, err := referrer.Layers()
+			h.AssertNil(t, err)
+			rc, err := layers[0].Compressed()
+			h.AssertNil(t, err)
+			defer rc.Close()
+			content, err := io.ReadAll(rc)
+			h.AssertNil(t, err)
+			h.AssertEq(t, string(content), `{"some":"statement"}`)
+		}
+
+		it("attaches artifacts with the referrers API", func() {
+			startRegistry(registry.WithReferrersSupport(true))
+			assertAttached()
+		})
+
+		it("attaches artifacts with the fallback tag", func() {
+			startRegistry()
+			assertAttached()
+		})
+	})
+
+	when("#SignatureArtifact", func() {
+		it("signs the simple signing payload of the image", func() {
+			startRegistry()
+			key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			h.AssertNil(t, err)
+
+			artifact, err := attest.SignatureArtifact(attest.NewSigner(key), repo, digest)
+			h.AssertNil(t, err)
+			h.AssertEq(t, artifact.ArtifactType, attest.SignatureArtifactType)
+			h.AssertEq(t, artifact.MediaType, attest.SimpleSigningMediaType)
+
+			var payload map[string]map[string]interface{}
+			h.AssertNil(t, json.Unmarshal(artifact.Content, &payload))
+			h.AssertEq(t, payload["critical"]["identity"], map[string]interface{}{"docker-reference": repo.Name()})
+			h.AssertEq(t, payload["critical"]["image"], map[string]interface{}{"docker-manifest-digest": digest.String()})
+
+			signature, err := base64.StdEncoding.DecodeString(artifact.Annotations[attest.SignatureAnnotation])
+			h.AssertNil(t, err)
+			contentDigest := sha256.Sum256(artifact.Content)
+			h.AssertTrue(t, ecdsa.VerifyASN1(&key.PublicKey, contentDigest[:], signature))
+		})
+	})
+
+	when("#SBOMArtifacts", func() {
+		it("returns the SBOM documents of a directory", func() {
+			startRegistry()
+			sbomDir := t.TempDir()
+			bpDir := filepath.Join(sbomDir, "sbom", "launch", "some_buildpack")
+			h.AssertNil(t, os.MkdirAll(bpDir, 0750))
+			h.AssertNil(t, os.WriteFile(filepath.Join(bpDir, "sbom.cdx.json"), []byte(`{"bomFormat":"CycloneDX"}`), 0600))
+			h.AssertNil(t, os.WriteFile(filepath.Join(bpDir, "sbom.spdx.json"), []byte(`{"spdxVersion":"SPDX-2.2"}`), 0600))
+			h.AssertNil(t, os.WriteFile(filepath.Join(bpDir, "some-file.toml"), []byte(`some = "file"`), 0600))
+
+			artifacts, err := attest.SBOMArtifacts(sbomDir)
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, len(artifacts), 2)
+			h.AssertEq(t, artifacts[0].MediaType, attest.CycloneDXMediaType)
+			h.AssertEq(t, artifacts[0].Annotations["org.opencontainers.image.title"], "sbom/launch/some_buildpack/sbom.cdx.json")
+			h.AssertEq(t, string(artifacts[0].Content), `{"bomFormat":"CycloneDX"}`)
+			h.AssertEq(t, artifacts[1].MediaType, attest.SPDXMediaType)
+		})
+	})
+}
@@ -0,0 +1,156 @@
+// Package attest signs app images and attaches attestations to them as OCI referrers.
+package attest
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// PEM block types of the private keys generated by cosign, which are encrypted with a password
+const (
+	cosignPrivateKeyPEMType   = "ENCRYPTED COSIGN PRIVATE KEY"
+	sigstorePrivateKeyPEMType = "ENCRYPTED SIGSTORE PRIVATE KEY"
+)
+
+// Signer signs payloads with a private key.
+type Signer struct {
+	key crypto.Signer
+}
+
+// LoadSigner reads the PEM encoded private key at keyPath. ECDSA, Ed25519 and RSA keys are supported, either in PKCS #8
+// or SEC 1 form, or encrypted by cosign, in which case password is used to decrypt them.
+func LoadSigner(keyPath string, password []byte) (*Signer, error) {
+	contents, err := os.ReadFile(filepath.Clean(keyPath))
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading signing key %s", style.Symbol(keyPath))
+	}
+
+	block, _ := pem.Decode(contents)
+	if block == nil {
+		return nil, errors.Errorf("signing key %s is not PEM encoded", style.Symbol(keyPath))
+	}
+
+	der := block.Bytes
+	switch block.Type {
+	case cosignPrivateKeyPEMType, sigstorePrivateKeyPEMType:
+		if der, err = decryptCosignKey(block.Bytes, password); err != nil {
+			return nil, errors.Wrapf(err, "decrypting signing key %s", style.Symbol(keyPath))
+		}
+	case "EC PRIVATE KEY":
+		key, err := x509.ParseECPrivateKey(der)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing signing key %s", style.Symbol(keyPath))
+		}
+		return &Signer{key: key}, nil
+	case "PRIVATE KEY":
+	default:
+		return nil, errors.Errorf("unsupported PEM block type %s in signing key %s", style.Symbol(block.Type), style.Symbol(keyPath))
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing signing key %s", style.Symbol(keyPath))
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, errors.Errorf("signing key %s cannot be used for signing", style.Symbol(keyPath))
+	}
+	return &Signer{key: signer}, nil
+}
+
+// NewSigner creates a Signer from a private key.
+func NewSigner(key crypto.Signer) *Signer {
+	return &Signer{key: key}
+}
+
+// Sign signs payload. ECDSA and RSA keys sign the SHA-256 digest of the payload, Ed25519 keys sign the payload itself.
+func (s *Signer) Sign(payload []byte) ([]byte, error) {
+	switch s.key.(type) {
+	case ed25519.PrivateKey:
+		return s.key.Sign(rand.Reader, payload, crypto.Hash(0))
+	case *ecdsa.PrivateKey, *rsa.PrivateKey:
+		digest := sha256.Sum256(payload)
+		return s.key.Sign(rand.Reader, digest[:], crypto.SHA256)
+	default:
+		return nil, errors.Errorf("unsupported signing key type %T", s.key)
+	}
+}
+
+// PublicKey returns the public key matching the private key of the signer.
+func (s *Signer) PublicKey() crypto.PublicKey {
+	return s.key.Public()
+}
+
+// encryptedKey is the format of the private keys generated by cosign
+type encryptedKey struct {
+	KDF struct {
+		Name   string `json:"name"`
+		Params struct {
+			N int `json:"N"`
+			R int `json:"r"`
+			P int `json:"p"`
+		} `json:"params"`
+		Salt string `json:"salt"`
+	} `json:"kdf"`
+	Cipher struct {
+		Name  string `json:"name"`
+		Nonce string `json:"nonce"`
+	} `json:"cipher"`
+	Ciphertext string `json:"ciphertext"`
+}
+
+func decryptCosignKey(contents, password []byte) ([]byte, error) {
+	var key encryptedKey
+	if err := json.Unmarshal(contents, &key); err != nil {
+		return nil, err
+	}
+	if key.KDF.Name != "scrypt" || key.Cipher.Name != "nacl/secretbox" {
+		return nil, errors.Errorf("unsupported key encryption %s with %s", style.Symbol(key.Cipher.Name), style.Symbol(key.KDF.Name))
+	}
+
+	salt, err := base64.StdEncoding.DecodeString(key.KDF.Salt)
+	if err != nil {
+		return nil, errors.Wrap(err, "decoding salt")
+	}
+	nonce, err := base64.StdEncoding.DecodeString(key.Cipher.Nonce)
+	if err != nil || len(nonce) != 24 {
+		return nil, errors.New("invalid nonce")
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(key.Ciphertext)
+	if err != nil {
+		return nil, errors.Wrap(err, "decoding ciphertext")
+	}
+
+	secret, err := scrypt.Key(password, salt, key.KDF.Params.N, key.KDF.Params.R, key.KDF.Params.P, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		nonceArray  [24]byte
+		secretArray [32]byte
+	)
+	copy(nonceArray[:], nonce)
+	copy(secretArray[:], secret)
+	plaintext, ok := secretbox.Open(nil, ciphertext, &nonceArray, &secretArray)
+	if !ok {
+		return nil, errors.New("invalid password")
+	}
+	return plaintext, nil
+}
@@ -0,0 +1,167 @@
+package attest_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/buildpacks/pack/internal/attest"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestSigner(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Signer", testSigner, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testSigner(t *testing.T, when spec.G, it spec.S) {
+	var (
+		tmpDir  string
+		ecKey   *ecdsa.PrivateKey
+		payload = []byte("some-payload")
+	)
+
+	it.Before(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "attest-signer")
+		h.AssertNil(t, err)
+
+		ecKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		h.AssertNil(t, err)
+	})
+
+	it.After(func() {
+		h.AssertNil(t, os.RemoveAll(tmpDir))
+	})
+
+	writeKey := func(blockType string, der []byte) string {
+		keyPath := filepath.Join(tmpDir, "key.pem")
+		h.AssertNil(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600))
+		return keyPath
+	}
+
+	assertSignedBy := func(signer *attest.Signer, key *ecdsa.PrivateKey) {
+		t.Helper()
+		signature, err := signer.Sign(payload)
+		h.AssertNil(t, err)
+
+		digest := sha256.Sum256(payload)
+		h.AssertTrue(t, ecdsa.VerifyASN1(&key.PublicKey, digest[:], signature))
+	}
+
+	when("#LoadSigner", func() {
+		it("loads PKCS #8 keys", func() {
+			der, err := x509.MarshalPKCS8PrivateKey(ecKey)
+			h.AssertNil(t, err)
+
+			signer, err := attest.LoadSigner(writeKey("PRIVATE KEY", der), nil)
+			h.AssertNil(t, err)
+			assertSignedBy(signer, ecKey)
+		})
+
+		it("loads SEC 1 EC keys", func() {
+			der, err := x509.MarshalECPrivateKey(ecKey)
+			h.AssertNil(t, err)
+
+			signer, err := attest.LoadSigner(writeKey("EC PRIVATE KEY", der), nil)
+			h.AssertNil(t, err)
+			assertSignedBy(signer, ecKey)
+		})
+
+		it("loads Ed25519 keys", func() {
+			pub, key, err := ed25519.GenerateKey(rand.Reader)
+			h.AssertNil(t, err)
+			der, err := x509.MarshalPKCS8PrivateKey(key)
+			h.AssertNil(t, err)
+
+			signer, err := attest.LoadSigner(writeKey("PRIVATE KEY", der), nil)
+			h.AssertNil(t, err)
+
+			signature, err := signer.Sign(payload)
+			h.AssertNil(t, err)
+			h.AssertTrue(t, ed25519.Verify(pub, payload, signature))
+		})
+
+		when("the key is encrypted by cosign", func() {
+			var keyPath string
+
+			it.Before(func() {
+				der, err := x509.MarshalPKCS8PrivateKey(ecKey)
+				h.AssertNil(t, err)
+				keyPath = writeKey("ENCRYPTED SIGSTORE PRIVATE KEY", encryptKey(t, der, []byte("some-password")))
+			})
+
+			it("decrypts the key with the password", func() {
+				signer, err := attest.LoadSigner(keyPath, []byte("some-password"))
+				h.AssertNil(t, err)
+				assertSignedBy(signer, ecKey)
+			})
+
+			it("errors with the wrong password", func() {
+				_, err := attest.LoadSigner(keyPath, []byte("other-password"))
+				h.AssertError(t, err, "invalid password")
+			})
+		})
+
+		it("errors when the key is not PEM encoded", func() {
+			keyPath := filepath.Join(tmpDir, "key.pem")
+			h.AssertNil(t, os.WriteFile(keyPath, []byte("some-key"), 0600))
+
+			_, err := attest.LoadSigner(keyPath, nil)
+			h.AssertError(t, err, "is not PEM encoded")
+		})
+
+		it("errors when the key type is not supported", func() {
+			_, err := attest.LoadSigner(writeKey("PUBLIC KEY", []byte("some-key")), nil)
+			h.AssertError(t, err, "unsupported PEM block type 'PUBLIC KEY'")
+		})
+	})
+}
+
+// encryptKey encrypts der the way cosign encrypts the private keys it generates, with cheap scrypt parameters
+func encryptKey(t *testing.T, der, password []byte) []byte {
+	t.Helper()
+
+	salt := make([]byte, 32)
+	_, err := rand.Read(salt)
+	h.AssertNil(t, err)
+	var nonce [24]byte
+	_, err = rand.Read(nonce[:])
+	h.AssertNil(t, err)
+
+	secret, err := scrypt.Key(password, salt, 1024, 8, 1, 32)
+	h.AssertNil(t, err)
+	var secretArray [32]byte
+	copy(secretArray[:], secret)
+
+	contents, err := json.Marshal(map[string]interface{}{
+		"kdf": map[string]interface{}{
+			"name":   "scrypt",
+			"params": map[string]int{"N": 1024, "r": 8, "p": 1},
+			"salt":   base64.StdEncoding.EncodeToString(salt),
+		},
+		"cipher": map[string]interface{}{
+			"name":  "nacl/secretbox",
+			"nonce": base64.StdEncoding.EncodeToString(nonce[:]),
+		},
+		"ciphertext": base64.StdEncoding.EncodeToString(secretbox.Seal(nil, der, &nonce, &secretArray)),
+	})
+	h.AssertNil(t, err)
+	return contents
+}
@@ -0,0 +1,104 @@
+package build
+
+import (
+	"bytes"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// the lines may start with the prefix of the phase, see WithLogPrefix
+var (
+	colorCodes               = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+	buildpackOutputStartLine = regexp.MustCompile(`^(?:\[\S+\] )?(?:Running build for buildpack (\S+)|======== (?:Output|Error): (\S+) ========)$`)
+	buildpackOutputEndLine   = regexp.MustCompile(`^(?:\[\S+\] )?(?:Finished running build for buildpack \S+|======== Results ========)$`)
+)
+
+// buildpackLogWriter logs each line of the output of a phase with the ID of the buildpack that produced it, for loggers
+// that support fields. The buildpack is known from the lines the lifecycle logs around its output at debug level.
+type buildpackLogWriter struct {
+	logger logging.Logger
+	level  logging.Level
+
+	mu        sync.Mutex
+	buf       []byte
+	buildpack string
+	writers   map[string]io.Writer
+}
+
+func newBuildpackLogWriter(logger logging.Logger, level logging.Level) *buildpackLogWriter {
+	return &buildpackLogWriter{logger: logger, level: level, writers: map[string]io.Writer{}}
+}
+
+func (w *buildpackLogWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		line := w.buf[:i+1]
+		w.buf = w.buf[i+1:]
+		if err := w.writeLine(line); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// Close logs the last line when it was not terminated
+func (w *buildpackLogWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.buf) > 0 {
+		line := w.buf
+		w.buf = nil
+		if err := w.writeLine(line); err != nil {
+			return err
+		}
+	}
+	for _, writer := range w.writers {
+		if closer, ok := writer.(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (w *buildpackLogWriter) writeLine(line []byte) error {
+	text := strings.TrimRight(colorCodes.ReplaceAllString(string(line), ""), "\r\n")
+	if match := buildpackOutputStartLine.FindStringSubmatch(text); match != nil {
+		w.buildpack = match[1] + match[2]
+	}
+
+	_, err := w.writer().Write(line)
+
+	if buildpackOutputEndLine.MatchString(text) {
+		w.buildpack = ""
+	}
+	return err
+}
+
+func (w *buildpackLogWriter) writer() io.Writer {
+	if writer, ok := w.writers[w.buildpack]; ok {
+		return writer
+	}
+
+	logger := w.logger
+	if w.buildpack != "" {
+		id, _, _ := strings.Cut(w.buildpack, "@")
+		logger = logging.WithFields(w.logger, logging.FieldBuildpackID, id)
+	}
+	writer := logging.GetWriterForLevel(logger, w.level)
+	w.writers[w.buildpack] = writer
+	return writer
+}
@@ -0,0 +1,43 @@
+package build
+
+import (
+	"context"
+	"time"
+
+	"github.com/buildpacks/pack/internal/events"
+)
+
+// eventPhaseFactory records the start, end and duration of every phase it creates
+type eventPhaseFactory struct {
+	PhaseFactory
+	recorder *events.Recorder
+}
+
+func (f *eventPhaseFactory) New(provider *PhaseConfigProvider) RunnerCleaner {
+	return &eventPhase{
+		RunnerCleaner: f.PhaseFactory.New(provider),
+		name:          provider.Name(),
+		output:        provider.eventOutput,
+		recorder:      f.recorder,
+	}
+}
+
+type eventPhase struct {
+	RunnerCleaner
+	name     string
+	output   *events.LifecycleOutput
+	recorder *events.Recorder
+}
+
+func (p *eventPhase) Run(ctx context.Context) error {
+	p.recorder.PhaseStarted(p.name)
+	start := time.Now()
+
+	err := p.RunnerCleaner.Run(ctx)
+
+	if p.output != nil {
+		p.output.Finish(err)
+	}
+	p.recorder.PhaseFinished(p.name, time.Since(start), err)
+	return err
+}
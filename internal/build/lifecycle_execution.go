@@ -20,6 +20,7 @@ import (
 	"github.com/buildpacks/pack/internal/builder"
 	"github.com/buildpacks/pack/internal/paths"
 	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/internal/timing"
 	"github.com/buildpacks/pack/pkg/cache"
 	"github.com/buildpacks/pack/pkg/logging"
 )
@@ -56,6 +57,10 @@ func NewLifecycleExecution(logger logging.Logger, docker DockerClient, tmpDir st
 		return nil, err
 	}
 
+	if opts.Image != nil {
+		logger = logging.WithFields(logger, logging.FieldImage, opts.Image.Name())
+	}
+
 	exec := &LifecycleExecution{
 		logger:       logger,
 		docker:       docker,
@@ -165,6 +170,14 @@ func (l *LifecycleExecution) PrevImageName() string {
 
 func (l *LifecycleExecution) Run(ctx context.Context, phaseFactoryCreator PhaseFactoryCreator) error {
 	phaseFactory := phaseFactoryCreator(l)
+	if l.opts.Events != nil {
+		phaseFactory = &eventPhaseFactory{PhaseFactory: phaseFactory, recorder: l.opts.Events}
+	}
+	if l.opts.Timings != nil {
+		phaseFactory = &timedPhaseFactory{PhaseFactory: phaseFactory, recorder: l.opts.Timings}
+	}
+
+	doneCacheSetup := l.opts.Timings.Track(timing.CategoryPack, "set up caches")
 	var buildCache Cache
 	if l.opts.CacheImage != "" || (l.opts.Cache.Build.Format == cache.CacheImage) {
 		cacheImageName := l.opts.CacheImage
@@ -195,6 +208,7 @@ func (l *LifecycleExecution) Run(ctx context.Context, phaseFactoryCreator PhaseF
 	}
 
 	launchCache := cache.NewVolumeCache(l.opts.Image, l.opts.Cache.Launch, "launch", l.docker)
+	doneCacheSetup()
 
 	if !l.opts.UseCreator {
 		if l.platformAPI.LessThan("0.7") {
@@ -203,6 +217,10 @@ func (l *LifecycleExecution) Run(ctx context.Context, phaseFactoryCreator PhaseF
 				return err
 			}
 
+			if l.opts.DetectOnly {
+				return nil
+			}
+
 			l.logger.Info(style.Step("ANALYZING"))
 			if err := l.Analyze(ctx, buildCache, launchCache, phaseFactory); err != nil {
 				return err
@@ -217,6 +235,10 @@ func (l *LifecycleExecution) Run(ctx context.Context, phaseFactoryCreator PhaseF
 			if err := l.Detect(ctx, phaseFactory); err != nil {
 				return err
 			}
+
+			if l.opts.DetectOnly {
+				return nil
+			}
 		}
 
 		var kanikoCache Cache
@@ -362,7 +384,7 @@ func (l *LifecycleExecution) Create(ctx context.Context, buildCache, launchCache
 	}
 
 	opts := []PhaseConfigProviderOperation{
-		WithFlags(l.withLogLevel(flags...)...),
+		WithFlags(l.withResultsLogLevel(flags...)...),
 		WithArgs(l.opts.Image.String()),
 		WithNetwork(l.opts.Network),
 		cacheBindOp,
@@ -374,6 +396,9 @@ func (l *LifecycleExecution) Create(ctx context.Context, buildCache, launchCache
 		If(l.opts.ReportDestinationDir != "", WithPostContainerRunOperations(
 			EnsureVolumeAccess(l.opts.Builder.UID(), l.opts.Builder.GID(), l.os, l.layersVolume, l.appVolume),
 			CopyOutTo(l.mountPaths.reportPath(), l.opts.ReportDestinationDir))),
+		If(l.opts.GroupDestinationDir != "", WithPostContainerRunOperations(
+			EnsureVolumeAccess(l.opts.Builder.UID(), l.opts.Builder.GID(), l.os, l.layersVolume, l.appVolume),
+			CopyOutTo(filepath.Join(l.mountPaths.layersDir(), "group.toml"), l.opts.GroupDestinationDir))),
 		If(l.opts.Interactive, WithPostContainerRunOperations(
 			EnsureVolumeAccess(l.opts.Builder.UID(), l.opts.Builder.GID(), l.os, l.layersVolume, l.appVolume),
 			CopyOut(l.opts.Termui.ReadLayers, l.mountPaths.layersDir(), l.mountPaths.appDir()))),
@@ -396,10 +421,16 @@ func (l *LifecycleExecution) Create(ctx context.Context, buildCache, launchCache
 
 		opts = append(opts, WithRoot(), WithRegistryAccess(authConfig))
 	} else {
+		cacheRegistryOp, err := l.withCacheRegistryAccess(buildCache)
+		if err != nil {
+			return err
+		}
+
 		opts = append(opts,
 			WithDaemonAccess(l.opts.DockerHost),
 			WithFlags("-daemon", "-launch-cache", l.mountPaths.launchCacheDir()),
 			WithBinds(fmt.Sprintf("%s:%s", launchCache.Name(), l.mountPaths.launchCacheDir())),
+			cacheRegistryOp,
 		)
 	}
 
@@ -416,12 +447,20 @@ func (l *LifecycleExecution) Detect(ctx context.Context, phaseFactory PhaseFacto
 		envOp = WithEnv("CNB_EXPERIMENTAL_MODE=warn")
 	}
 
+	args := l.withResultsLogLevel()
+	if l.opts.DetectOnly {
+		// the debug output of the detector is the only record of why optional buildpacks were skipped
+		args = []string{"-log-level", "debug"}
+	}
+
 	configProvider := NewPhaseConfigProvider(
 		"detector",
 		l,
 		WithLogPrefix("detector"),
+		If(l.opts.DetectOnly && l.opts.DetectLog != nil, WithLogCapture(l.opts.DetectLog)),
+		If(l.opts.DetectOnly && l.opts.DetectLog != nil && !l.logger.IsVerbose(), WithLogsCapturedOnly()),
 		WithArgs(
-			l.withLogLevel()...,
+			args...,
 		),
 		WithNetwork(l.opts.Network),
 		WithBinds(l.opts.Volumes...),
@@ -434,6 +473,9 @@ func (l *LifecycleExecution) Detect(ctx context.Context, phaseFactory PhaseFacto
 			CopyOutToMaybe(filepath.Join(l.mountPaths.layersDir(), "analyzed.toml"), l.tmpDir))),
 		If(l.hasExtensions(), WithPostContainerRunOperations(
 			CopyOutToMaybe(filepath.Join(l.mountPaths.layersDir(), "generated", "build"), l.tmpDir))),
+		If(l.opts.DetectOnly, WithPostContainerRunOperations(
+			CopyOutTo(filepath.Join(l.mountPaths.layersDir(), "group.toml"), l.opts.DetectOutputDir),
+			CopyOutTo(filepath.Join(l.mountPaths.layersDir(), "plan.toml"), l.opts.DetectOutputDir))),
 		envOp,
 	)
 
@@ -625,6 +667,11 @@ func (l *LifecycleExecution) Analyze(ctx context.Context, buildCache, launchCach
 
 		analyze = phaseFactory.New(configProvider)
 	} else {
+		cacheRegistryOp, err := l.withCacheRegistryAccess(buildCache)
+		if err != nil {
+			return err
+		}
+
 		configProvider := NewPhaseConfigProvider(
 			"analyzer",
 			l,
@@ -641,6 +688,7 @@ func (l *LifecycleExecution) Analyze(ctx context.Context, buildCache, launchCach
 			flagsOp,
 			WithNetwork(l.opts.Network),
 			cacheBindOp,
+			cacheRegistryOp,
 			stackOp,
 			runOp,
 		)
@@ -658,7 +706,7 @@ func (l *LifecycleExecution) Build(ctx context.Context, phaseFactory PhaseFactor
 		"builder",
 		l,
 		WithLogPrefix("builder"),
-		WithArgs(l.withLogLevel()...),
+		WithArgs(l.withResultsLogLevel()...),
 		WithNetwork(l.opts.Network),
 		WithBinds(l.opts.Volumes...),
 		WithFlags(flags...),
@@ -789,6 +837,9 @@ func (l *LifecycleExecution) Export(ctx context.Context, buildCache, launchCache
 		If(l.opts.ReportDestinationDir != "", WithPostContainerRunOperations(
 			EnsureVolumeAccess(l.opts.Builder.UID(), l.opts.Builder.GID(), l.os, l.layersVolume, l.appVolume),
 			CopyOutTo(l.mountPaths.reportPath(), l.opts.ReportDestinationDir))),
+		If(l.opts.GroupDestinationDir != "", WithPostContainerRunOperations(
+			EnsureVolumeAccess(l.opts.Builder.UID(), l.opts.Builder.GID(), l.os, l.layersVolume, l.appVolume),
+			CopyOutTo(filepath.Join(l.mountPaths.layersDir(), "group.toml"), l.opts.GroupDestinationDir))),
 		If(l.opts.Interactive, WithPostContainerRunOperations(
 			EnsureVolumeAccess(l.opts.Builder.UID(), l.opts.Builder.GID(), l.os, l.layersVolume, l.appVolume),
 			CopyOut(l.opts.Termui.ReadLayers, l.mountPaths.layersDir(), l.mountPaths.appDir()))),
@@ -810,11 +861,17 @@ func (l *LifecycleExecution) Export(ctx context.Context, buildCache, launchCache
 		)
 		export = phaseFactory.New(NewPhaseConfigProvider("exporter", l, opts...))
 	} else {
+		cacheRegistryOp, err := l.withCacheRegistryAccess(buildCache)
+		if err != nil {
+			return err
+		}
+
 		opts = append(
 			opts,
 			WithDaemonAccess(l.opts.DockerHost),
 			WithFlags("-daemon", "-launch-cache", l.mountPaths.launchCacheDir()),
 			WithBinds(fmt.Sprintf("%s:%s", launchCache.Name(), l.mountPaths.launchCacheDir())),
+			cacheRegistryOp,
 		)
 		export = phaseFactory.New(NewPhaseConfigProvider("exporter", l, opts...))
 	}
@@ -823,6 +880,20 @@ func (l *LifecycleExecution) Export(ctx context.Context, buildCache, launchCache
 	return export.Run(ctx)
 }
 
+// withCacheRegistryAccess provides the credentials of an image build cache to phases that otherwise only access the
+// daemon, so that the cache image can be pulled and pushed when the app image is not published.
+func (l *LifecycleExecution) withCacheRegistryAccess(buildCache Cache) (PhaseConfigProviderOperation, error) {
+	if buildCache.Type() != cache.Image {
+		return NullOp(), nil
+	}
+
+	authConfig, err := auth.BuildEnvVar(authn.DefaultKeychain, buildCache.Name())
+	if err != nil {
+		return nil, err
+	}
+	return WithRegistryAccess(authConfig), nil
+}
+
 func (l *LifecycleExecution) withLogLevel(args ...string) []string {
 	if l.logger.IsVerbose() {
 		return append([]string{"-log-level", "debug"}, args...)
@@ -830,6 +901,15 @@ func (l *LifecycleExecution) withLogLevel(args ...string) []string {
 	return args
 }
 
+// withResultsLogLevel is withLogLevel for the phases that only log the result of each buildpack at debug level,
+// which is needed when build events are recorded
+func (l *LifecycleExecution) withResultsLogLevel(args ...string) []string {
+	if l.opts.Events != nil && !l.logger.IsVerbose() {
+		return append([]string{"-log-level", "debug"}, args...)
+	}
+	return l.withLogLevel(args...)
+}
+
 func (l *LifecycleExecution) hasExtensions() bool {
 	return len(l.opts.Builder.OrderExtensions()) > 0
 }
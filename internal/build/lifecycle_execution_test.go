@@ -3,6 +3,7 @@ package build_test
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -25,7 +26,9 @@ import (
 
 	"github.com/buildpacks/pack/internal/build"
 	"github.com/buildpacks/pack/internal/build/fakes"
+	"github.com/buildpacks/pack/internal/events"
 	"github.com/buildpacks/pack/internal/paths"
+	"github.com/buildpacks/pack/internal/timing"
 	"github.com/buildpacks/pack/pkg/cache"
 	"github.com/buildpacks/pack/pkg/dist"
 	"github.com/buildpacks/pack/pkg/logging"
@@ -448,6 +451,104 @@ func testLifecycleExecution(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 
+			when("detect only", func() {
+				it("stops after detection", func() {
+					fakeBuilder, err := fakes.NewFakeBuilder(fakes.WithSupportedPlatformAPIs([]*api.Version{api.MustParse("0.7")}))
+					h.AssertNil(t, err)
+
+					opts := build.LifecycleOptions{
+						RunImage:        "test",
+						Image:           imageName,
+						Builder:         fakeBuilder,
+						Termui:          fakeTermui,
+						DetectOnly:      true,
+						DetectOutputDir: "some-output-dir",
+					}
+
+					lifecycle, err := build.NewLifecycleExecution(logger, docker, "some-temp-dir", opts)
+					h.AssertNil(t, err)
+
+					err = lifecycle.Run(context.Background(), func(execution *build.LifecycleExecution) build.PhaseFactory {
+						return fakePhaseFactory
+					})
+					h.AssertNil(t, err)
+
+					h.AssertEq(t, len(fakePhaseFactory.NewCalledWithProvider), 2)
+					h.AssertEq(t, fakePhaseFactory.NewCalledWithProvider[0].Name(), "analyzer")
+					h.AssertEq(t, fakePhaseFactory.NewCalledWithProvider[1].Name(), "detector")
+				})
+			})
+
+			when("events are recorded", func() {
+				it("records the start and end of each phase", func() {
+					var eventsBuf bytes.Buffer
+					opts := build.LifecycleOptions{
+						RunImage: "test",
+						Image:    imageName,
+						Builder:  fakeBuilder,
+						Termui:   fakeTermui,
+						Events:   events.NewRecorder(&eventsBuf),
+					}
+
+					lifecycle, err := build.NewLifecycleExecution(logger, docker, "some-temp-dir", opts)
+					h.AssertNil(t, err)
+
+					err = lifecycle.Run(context.Background(), func(execution *build.LifecycleExecution) build.PhaseFactory {
+						return fakePhaseFactory
+					})
+					h.AssertNil(t, err)
+
+					var recorded []string
+					decoder := json.NewDecoder(&eventsBuf)
+					for decoder.More() {
+						var event events.Event
+						h.AssertNil(t, decoder.Decode(&event))
+						recorded = append(recorded, fmt.Sprintf("%s %s", event.Type, event.Phase))
+					}
+					h.AssertEq(t, recorded, []string{
+						"phase_started detector", "phase_finished detector",
+						"phase_started analyzer", "phase_finished analyzer",
+						"phase_started restorer", "phase_finished restorer",
+						"phase_started builder", "phase_finished builder",
+						"phase_started exporter", "phase_finished exporter",
+					})
+				})
+			})
+
+			when("timings are recorded", func() {
+				it("records the duration of each phase", func() {
+					recorder := timing.NewRecorder()
+					opts := build.LifecycleOptions{
+						RunImage: "test",
+						Image:    imageName,
+						Builder:  fakeBuilder,
+						Termui:   fakeTermui,
+						Timings:  recorder,
+					}
+
+					lifecycle, err := build.NewLifecycleExecution(logger, docker, "some-temp-dir", opts)
+					h.AssertNil(t, err)
+
+					err = lifecycle.Run(context.Background(), func(execution *build.LifecycleExecution) build.PhaseFactory {
+						return fakePhaseFactory
+					})
+					h.AssertNil(t, err)
+
+					var recorded []string
+					for _, step := range recorder.Steps() {
+						recorded = append(recorded, fmt.Sprintf("%s %s", step.Category, step.Name))
+					}
+					h.AssertEq(t, recorded, []string{
+						"pack set up caches",
+						"phase detector",
+						"phase analyzer",
+						"phase restorer",
+						"phase builder",
+						"phase exporter",
+					})
+				})
+			})
+
 			it("succeeds", func() {
 				opts := build.LifecycleOptions{
 					Publish:      false,
@@ -839,6 +940,10 @@ func testLifecycleExecution(t *testing.T, when spec.G, it spec.S) {
 				)
 				h.AssertSliceNotContains(t, configProvider.HostConfig().Binds, ":/cache")
 			})
+
+			it("configures the phase with registry access for the cache image", func() {
+				h.AssertSliceContains(t, configProvider.ContainerConfig().Env, "CNB_REGISTRY_AUTH={}")
+			})
 		})
 
 		when("additional tags are specified", func() {
@@ -1180,6 +1285,18 @@ func testLifecycleExecution(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("group destination directory is provided", func() {
+			lifecycleOps = append(lifecycleOps, func(opts *build.LifecycleOptions) {
+				opts.GroupDestinationDir = "a-destination-dir"
+			})
+
+			it("copies the group out after the container runs", func() {
+				h.AssertEq(t, len(configProvider.PostContainerRunOps()), 2)
+				h.AssertFunctionName(t, configProvider.PostContainerRunOps()[0], "EnsureVolumeAccess")
+				h.AssertFunctionName(t, configProvider.PostContainerRunOps()[1], "CopyOut")
+			})
+		})
+
 		when("--creation-time", func() {
 			when("platform < 0.9", func() {
 				platformAPI = api.MustParse("0.8")
@@ -1272,6 +1389,58 @@ func testLifecycleExecution(t *testing.T, when spec.G, it spec.S) {
 			h.AssertFunctionName(t, configProvider.ContainerOps()[1], "CopyDir")
 		})
 
+		it("does not copy out the detection output", func() {
+			h.AssertEq(t, len(configProvider.PostContainerRunOps()), 0)
+		})
+
+		when("detect only", func() {
+			lifecycleOps = append(lifecycleOps, func(opts *build.LifecycleOptions) {
+				opts.DetectOnly = true
+				opts.DetectOutputDir = "some-output-dir"
+			})
+
+			it("copies out the group and plan", func() {
+				h.AssertEq(t, len(configProvider.PostContainerRunOps()), 2)
+				h.AssertFunctionName(t, configProvider.PostContainerRunOps()[0], "CopyOut")
+				h.AssertFunctionName(t, configProvider.PostContainerRunOps()[1], "CopyOut")
+			})
+
+			it("runs the detector with debug logging", func() {
+				h.AssertIncludeAllExpectedPatterns(t, configProvider.ContainerConfig().Cmd, []string{"-log-level", "debug"})
+			})
+
+			when("the detector logs are captured", func() {
+				var detectLog bytes.Buffer
+
+				lifecycleOps = append(lifecycleOps, func(opts *build.LifecycleOptions) {
+					detectLog.Reset()
+					opts.DetectLog = &detectLog
+				})
+
+				it("writes the detector logs to the capture as well when verbose", func() {
+					_, err := configProvider.InfoWriter().Write([]byte("pass: some/bp@1.0\n"))
+					h.AssertNil(t, err)
+					h.AssertEq(t, detectLog.String(), "pass: some/bp@1.0\n")
+					h.AssertNotEq(t, configProvider.InfoWriter(), io.Writer(&detectLog))
+				})
+			})
+		})
+
+		when("events are recorded", func() {
+			it("runs the detector with debug logging when not verbose", func() {
+				lifecycle := newTestLifecycleExec(t, false, "some-temp-dir", func(opts *build.LifecycleOptions) {
+					opts.Events = events.NewRecorder(io.Discard)
+				})
+				fakePhaseFactory := fakes.NewFakePhaseFactory()
+
+				err := lifecycle.Detect(context.Background(), fakePhaseFactory)
+				h.AssertNil(t, err)
+
+				configProvider := fakePhaseFactory.NewCalledWithProvider[0]
+				h.AssertIncludeAllExpectedPatterns(t, configProvider.ContainerConfig().Cmd, []string{"-log-level", "debug"})
+			})
+		})
+
 		when("extensions", func() {
 			platformAPI = api.MustParse("0.10")
 
@@ -1341,6 +1510,10 @@ func testLifecycleExecution(t *testing.T, when spec.G, it spec.S) {
 					)
 				})
 
+				it("configures the phase with registry access for the cache image", func() {
+					h.AssertSliceContains(t, configProvider.ContainerConfig().Env, "CNB_REGISTRY_AUTH={}")
+				})
+
 				when("clear-cache", func() {
 					providedClearCache = true
 
@@ -2064,6 +2237,10 @@ func testLifecycleExecution(t *testing.T, when spec.G, it spec.S) {
 					[]string{"-cache-image", "some-cache-image"},
 				)
 			})
+
+			it("configures the phase with registry access for the cache image", func() {
+				h.AssertSliceContains(t, configProvider.ContainerConfig().Env, "CNB_REGISTRY_AUTH={}")
+			})
 		})
 
 		when("publish", func() {
@@ -2324,6 +2501,18 @@ func testLifecycleExecution(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("group destination directory is provided", func() {
+			lifecycleOps = append(lifecycleOps, func(opts *build.LifecycleOptions) {
+				opts.GroupDestinationDir = "a-destination-dir"
+			})
+
+			it("copies the group out after the container runs", func() {
+				h.AssertEq(t, len(configProvider.PostContainerRunOps()), 2)
+				h.AssertFunctionName(t, configProvider.PostContainerRunOps()[0], "EnsureVolumeAccess")
+				h.AssertFunctionName(t, configProvider.PostContainerRunOps()[1], "CopyOut")
+			})
+		})
+
 		when("--creation-time", func() {
 			when("platform < 0.9", func() {
 				platformAPI = api.MustParse("0.8")
@@ -13,6 +13,8 @@ import (
 
 	"github.com/buildpacks/pack/internal/builder"
 	"github.com/buildpacks/pack/internal/container"
+	"github.com/buildpacks/pack/internal/events"
+	"github.com/buildpacks/pack/internal/timing"
 	"github.com/buildpacks/pack/pkg/cache"
 	"github.com/buildpacks/pack/pkg/dist"
 	"github.com/buildpacks/pack/pkg/logging"
@@ -97,7 +99,13 @@ type LifecycleOptions struct {
 	PreviousImage        string
 	ReportDestinationDir string
 	SBOMDestinationDir   string
+	GroupDestinationDir  string // directory that group.toml is copied to once the app image is exported, when set
 	CreationTime         *time.Time
+	DetectOnly           bool             // only analyze and detect are run; requires UseCreator to be false
+	DetectOutputDir      string           // directory that group.toml and plan.toml are copied to when DetectOnly is set
+	DetectLog            io.Writer        // receives the debug output of the detector when DetectOnly is set
+	Events               *events.Recorder // receives structured events about the progress of the build, when set
+	Timings              *timing.Recorder // receives the duration of each phase, when set
 }
 
 func NewLifecycleExecutor(logger logging.Logger, docker DockerClient) *LifecycleExecutor {
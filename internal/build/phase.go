@@ -15,6 +15,7 @@ type Phase struct {
 	name                string
 	infoWriter          io.Writer
 	errorWriter         io.Writer
+	closeLogs           func() error
 	docker              DockerClient
 	handler             container.Handler
 	ctrConf             *dcontainer.Config
@@ -27,8 +28,15 @@ type Phase struct {
 	fileFilter          func(string) bool
 }
 
-func (p *Phase) Run(ctx context.Context) error {
-	var err error
+func (p *Phase) Run(ctx context.Context) (err error) {
+	if p.closeLogs != nil {
+		defer func() {
+			if closeErr := p.closeLogs(); err == nil {
+				err = closeErr
+			}
+		}()
+	}
+
 	p.ctr, err = p.docker.ContainerCreate(ctx, p.ctrConf, p.hostConf, nil, nil, "")
 	if err != nil {
 		return errors.Wrapf(err, "failed to create '%s' container", p.name)
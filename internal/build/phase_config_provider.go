@@ -9,6 +9,7 @@ import (
 	"github.com/docker/docker/api/types/container"
 
 	pcontainer "github.com/buildpacks/pack/internal/container"
+	"github.com/buildpacks/pack/internal/events"
 	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/pkg/logging"
 )
@@ -31,16 +32,30 @@ type PhaseConfigProvider struct {
 	infoWriter          io.Writer
 	errorWriter         io.Writer
 	handler             pcontainer.Handler
+	eventOutput         *events.LifecycleOutput
+	logCaptures         []io.Writer
+	captureLogsOnly     bool
+	logClosers          []io.Closer
 }
 
 func NewPhaseConfigProvider(name string, lifecycleExec *LifecycleExecution, ops ...PhaseConfigProviderOperation) *PhaseConfigProvider {
+	phaseLogger := logging.WithFields(lifecycleExec.logger, logging.FieldPhase, name)
 	provider := &PhaseConfigProvider{
 		ctrConf:     new(container.Config),
 		hostConf:    new(container.HostConfig),
 		name:        name,
 		os:          lifecycleExec.os,
-		infoWriter:  logging.GetWriterForLevel(lifecycleExec.logger, logging.InfoLevel),
-		errorWriter: logging.GetWriterForLevel(lifecycleExec.logger, logging.ErrorLevel),
+		infoWriter:  logging.GetWriterForLevel(phaseLogger, logging.InfoLevel),
+		errorWriter: logging.GetWriterForLevel(phaseLogger, logging.ErrorLevel),
+	}
+	// loggers without fields return themselves, their output has no buildpack ID to carry
+	if logging.WithFields(phaseLogger, logging.FieldPhase, name) != phaseLogger && provider.infoWriter != io.Discard {
+		provider.infoWriter = newBuildpackLogWriter(phaseLogger, logging.InfoLevel)
+	}
+	for _, w := range []io.Writer{provider.infoWriter, provider.errorWriter} {
+		if closer, ok := w.(io.Closer); ok {
+			provider.logClosers = append(provider.logClosers, closer)
+		}
 	}
 
 	provider.ctrConf.Image = lifecycleExec.opts.Builder.Name()
@@ -63,6 +78,19 @@ func NewPhaseConfigProvider(name string, lifecycleExec *LifecycleExecution, ops
 		op(provider)
 	}
 
+	if lifecycleExec.opts.Events != nil {
+		provider.eventOutput = events.NewLifecycleOutput(lifecycleExec.opts.Events, name)
+		provider.logCaptures = append(provider.logCaptures, provider.eventOutput)
+		provider.errorWriter = io.MultiWriter(provider.errorWriter, provider.eventOutput.Stderr())
+	}
+
+	switch {
+	case provider.captureLogsOnly:
+		provider.infoWriter = io.MultiWriter(provider.logCaptures...)
+	case len(provider.logCaptures) > 0:
+		provider.infoWriter = io.MultiWriter(append([]io.Writer{provider.infoWriter}, provider.logCaptures...)...)
+	}
+
 	provider.ctrConf.Entrypoint = []string{""} // override entrypoint in case it is set
 	provider.ctrConf.Cmd = append([]string{"/cnb/lifecycle/" + name}, provider.ctrConf.Cmd...)
 
@@ -125,6 +153,16 @@ func (p *PhaseConfigProvider) ErrorWriter() io.Writer {
 	return p.errorWriter
 }
 
+// CloseLogs writes the last line of the logs of the phase when it was not terminated
+func (p *PhaseConfigProvider) CloseLogs() error {
+	for _, closer := range p.logClosers {
+		if err := closer.Close(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (p *PhaseConfigProvider) InfoWriter() io.Writer {
 	return p.infoWriter
 }
@@ -200,12 +238,30 @@ func WithImage(image string) PhaseConfigProviderOperation {
 func WithLogPrefix(prefix string) PhaseConfigProviderOperation {
 	return func(provider *PhaseConfigProvider) {
 		if prefix != "" {
-			provider.infoWriter = logging.NewPrefixWriter(provider.infoWriter, prefix)
-			provider.errorWriter = logging.NewPrefixWriter(provider.errorWriter, prefix)
+			infoWriter := logging.NewPrefixWriter(provider.infoWriter, prefix)
+			errorWriter := logging.NewPrefixWriter(provider.errorWriter, prefix)
+			provider.infoWriter, provider.errorWriter = infoWriter, errorWriter
+			// the prefix writers are closed first, so that their last lines reach the writers they wrap
+			provider.logClosers = append([]io.Closer{infoWriter, errorWriter}, provider.logClosers...)
 		}
 	}
 }
 
+// WithLogCapture copies the logs produced by this phase to w, without any prefix
+func WithLogCapture(w io.Writer) PhaseConfigProviderOperation {
+	return func(provider *PhaseConfigProvider) {
+		provider.logCaptures = append(provider.logCaptures, w)
+	}
+}
+
+// WithLogsCapturedOnly writes the logs produced by this phase to its log captures only, for phases that run at debug
+// level for the sake of a log capture. Errors are still logged.
+func WithLogsCapturedOnly() PhaseConfigProviderOperation {
+	return func(provider *PhaseConfigProvider) {
+		provider.captureLogsOnly = true
+	}
+}
+
 func WithLifecycleProxy(lifecycleExec *LifecycleExecution) PhaseConfigProviderOperation {
 	return func(provider *PhaseConfigProvider) {
 		if lifecycleExec.opts.HTTPProxy != "" {
@@ -3,12 +3,14 @@ package build_test
 import (
 	"bytes"
 	"io"
+	"log/slog"
 	"testing"
 
 	ifakes "github.com/buildpacks/imgutil/fakes"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/strslice"
 	"github.com/docker/docker/client"
+	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/heroku/color"
 	"github.com/pkg/errors"
 	"github.com/sclevine/spec"
@@ -16,6 +18,7 @@ import (
 
 	"github.com/buildpacks/pack/internal/build"
 	"github.com/buildpacks/pack/internal/build/fakes"
+	"github.com/buildpacks/pack/internal/events"
 	"github.com/buildpacks/pack/pkg/logging"
 	h "github.com/buildpacks/pack/testhelpers"
 )
@@ -273,6 +276,54 @@ func testPhaseConfigProvider(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("the logger supports fields", func() {
+			it("attaches the phase and the image to the logs of the phase", func() {
+				var out bytes.Buffer
+				logger := logging.NewSlogLogger(slog.New(slog.NewJSONHandler(&out, nil)))
+
+				docker, err := client.NewClientWithOpts(client.FromEnv, client.WithVersion("1.38"))
+				h.AssertNil(t, err)
+				fakeBuilder, err := fakes.NewFakeBuilder()
+				h.AssertNil(t, err)
+				imageRef, err := name.ParseReference("some-org/some-image")
+				h.AssertNil(t, err)
+
+				lifecycle, err := build.NewLifecycleExecution(logger, docker, "some-temp-dir", build.LifecycleOptions{
+					Builder: fakeBuilder,
+					Image:   imageRef,
+				})
+				h.AssertNil(t, err)
+
+				phaseConfigProvider := build.NewPhaseConfigProvider("detector", lifecycle)
+				_, err = phaseConfigProvider.InfoWriter().Write([]byte("some output\n"))
+				h.AssertNil(t, err)
+
+				h.AssertContains(t, out.String(), `"msg":"some output"`)
+				h.AssertContains(t, out.String(), `"image":"index.docker.io/some-org/some-image:latest"`)
+				h.AssertContains(t, out.String(), `"phase":"detector"`)
+			})
+
+			it("attaches the buildpack ID to the output of each buildpack", func() {
+				var out bytes.Buffer
+				logger := logging.NewSlogLogger(slog.New(slog.NewJSONHandler(&out, nil)))
+				lifecycle, err := build.NewLifecycleExecution(logger, nil, "some-temp-dir", build.LifecycleOptions{Builder: fakeBuilder(t)})
+				h.AssertNil(t, err)
+
+				phaseConfigProvider := build.NewPhaseConfigProvider("builder", lifecycle, build.WithLogPrefix("builder"))
+				_, err = phaseConfigProvider.InfoWriter().Write([]byte("Running build for buildpack some/bp@1.0\n" +
+					"some build output\n" +
+					"Finished running build for buildpack some/bp@1.0\n" +
+					"some other output\n" +
+					"some unterminated output"))
+				h.AssertNil(t, err)
+				h.AssertNil(t, phaseConfigProvider.CloseLogs())
+
+				h.AssertContains(t, out.String(), `"msg":"[builder] some build output","phase":"builder","buildpack_id":"some/bp"}`)
+				h.AssertContains(t, out.String(), `"msg":"[builder] some other output","phase":"builder"}`)
+				h.AssertContains(t, out.String(), `"msg":"[builder] some unterminated output","phase":"builder"}`)
+			})
+		})
+
 		when("called with WithLogPrefix", func() {
 			it("sets prefix writers", func() {
 				lifecycle := newTestLifecycleExec(t, false, "some-temp-dir")
@@ -291,6 +342,103 @@ func testPhaseConfigProvider(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("called with WithLogCapture", func() {
+			it("copies logs to the provided writer", func() {
+				var captured bytes.Buffer
+				lifecycle := newTestLifecycleExec(t, false, "some-temp-dir")
+
+				phaseConfigProvider := build.NewPhaseConfigProvider(
+					"some-name",
+					lifecycle,
+					build.WithLogPrefix("some-prefix"),
+					build.WithLogCapture(&captured),
+				)
+
+				_, err := phaseConfigProvider.InfoWriter().Write([]byte("some-log\n"))
+				h.AssertNil(t, err)
+				h.AssertEq(t, captured.String(), "some-log\n")
+			})
+		})
+
+		when("called with WithLogsCapturedOnly", func() {
+			it("only writes logs to the log captures", func() {
+				var outBuf, captured bytes.Buffer
+				docker, err := client.NewClientWithOpts(client.FromEnv, client.WithVersion("1.38"))
+				h.AssertNil(t, err)
+				defaultBuilder, err := fakes.NewFakeBuilder()
+				h.AssertNil(t, err)
+
+				lifecycleExec, err := build.NewLifecycleExecution(logging.NewLogWithWriters(&outBuf, &outBuf), docker, "some-temp-dir", build.LifecycleOptions{
+					AppPath: "some-app-path",
+					Builder: defaultBuilder,
+				})
+				h.AssertNil(t, err)
+
+				phaseConfigProvider := build.NewPhaseConfigProvider(
+					"detector",
+					lifecycleExec,
+					build.WithLogPrefix("detector"),
+					build.WithLogCapture(&captured),
+					build.WithLogsCapturedOnly(),
+				)
+
+				_, err = phaseConfigProvider.InfoWriter().Write([]byte("pass: some/bp@1.0\n"))
+				h.AssertNil(t, err)
+				_, err = phaseConfigProvider.ErrorWriter().Write([]byte("some-error\n"))
+				h.AssertNil(t, err)
+
+				h.AssertEq(t, captured.String(), "pass: some/bp@1.0\n")
+				h.AssertContains(t, outBuf.String(), "some-error")
+				h.AssertNotContains(t, outBuf.String(), "pass: some/bp@1.0")
+			})
+		})
+
+		when("events are recorded", func() {
+			it("records events from the phase logs", func() {
+				var eventsBuf bytes.Buffer
+				lifecycle := newTestLifecycleExec(t, false, "some-temp-dir", func(opts *build.LifecycleOptions) {
+					opts.Events = events.NewRecorder(&eventsBuf)
+				})
+
+				phaseConfigProvider := build.NewPhaseConfigProvider(
+					"some-name",
+					lifecycle,
+					build.WithLogPrefix("some-prefix"),
+				)
+
+				_, err := phaseConfigProvider.InfoWriter().Write([]byte("Warning: some-warning\n"))
+				h.AssertNil(t, err)
+				h.AssertContains(t, eventsBuf.String(), `"type":"warning","phase":"some-name","message":"some-warning"`)
+			})
+
+			it("writes every line to the logs as well as to the events", func() {
+				var outBuf, eventsBuf bytes.Buffer
+				docker, err := client.NewClientWithOpts(client.FromEnv, client.WithVersion("1.38"))
+				h.AssertNil(t, err)
+				defaultBuilder, err := fakes.NewFakeBuilder()
+				h.AssertNil(t, err)
+
+				lifecycleExec, err := build.NewLifecycleExecution(logging.NewLogWithWriters(&outBuf, &outBuf), docker, "some-temp-dir", build.LifecycleOptions{
+					AppPath: "some-app-path",
+					Builder: defaultBuilder,
+					Events:  events.NewRecorder(&eventsBuf),
+				})
+				h.AssertNil(t, err)
+
+				phaseConfigProvider := build.NewPhaseConfigProvider("builder", lifecycleExec)
+
+				_, err = phaseConfigProvider.InfoWriter().Write([]byte("Running build for buildpack some/bp@1.0\n" +
+					"some build output\n" +
+					"Finished running build for buildpack some/bp@1.0\n"))
+				h.AssertNil(t, err)
+
+				h.AssertContains(t, eventsBuf.String(), `"type":"build_result","phase":"builder","buildpack":"some/bp@1.0","result":"success"`)
+				h.AssertEq(t, outBuf.String(), "Running build for buildpack some/bp@1.0\n"+
+					"some build output\n"+
+					"Finished running build for buildpack some/bp@1.0\n")
+			})
+		})
+
 		when("verbose", func() {
 			it("prints debug information about the phase", func() {
 				var outBuf bytes.Buffer
@@ -359,3 +507,11 @@ func testPhaseConfigProvider(t *testing.T, when spec.G, it spec.S) {
 		})
 	})
 }
+
+func fakeBuilder(t *testing.T) *fakes.FakeBuilder {
+	t.Helper()
+
+	builder, err := fakes.NewFakeBuilder()
+	h.AssertNil(t, err)
+	return builder
+}
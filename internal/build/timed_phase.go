@@ -0,0 +1,32 @@
+package build
+
+import (
+	"context"
+
+	"github.com/buildpacks/pack/internal/timing"
+)
+
+// timedPhaseFactory records the duration of every phase it creates
+type timedPhaseFactory struct {
+	PhaseFactory
+	recorder *timing.Recorder
+}
+
+func (f *timedPhaseFactory) New(provider *PhaseConfigProvider) RunnerCleaner {
+	return &timedPhase{
+		RunnerCleaner: f.PhaseFactory.New(provider),
+		name:          provider.Name(),
+		recorder:      f.recorder,
+	}
+}
+
+type timedPhase struct {
+	RunnerCleaner
+	name     string
+	recorder *timing.Recorder
+}
+
+func (p *timedPhase) Run(ctx context.Context) error {
+	defer p.recorder.Track(timing.CategoryPhase, p.name)()
+	return p.RunnerCleaner.Run(ctx)
+}
@@ -1,6 +1,8 @@
 package commands
 
 import (
+	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -14,14 +16,19 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/buildpacks/pack/internal/config"
+	detectwriter "github.com/buildpacks/pack/internal/detect/writer"
 	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/dist"
 	"github.com/buildpacks/pack/pkg/image"
 	"github.com/buildpacks/pack/pkg/logging"
 	"github.com/buildpacks/pack/pkg/project"
 	projectTypes "github.com/buildpacks/pack/pkg/project/types"
 )
 
+// signingKeyPasswordEnv holds the password of encrypted signing keys, as it does for cosign
+const signingKeyPasswordEnv = "COSIGN_PASSWORD"
+
 type BuildFlags struct {
 	Publish              bool
 	ClearCache           bool
@@ -54,6 +61,17 @@ type BuildFlags struct {
 	DateTime             string
 	PreBuildpacks        []string
 	PostBuildpacks       []string
+	Platforms            []string
+	DetectOnly           bool
+	PrepareOnly          bool
+	VendorDir            string
+	OutputFormat         string
+	Events               string
+	Timings              bool
+	SigningKey           string
+	InsecureRegistries   []string
+	Attest               bool
+	Provenance           bool
 }
 
 // Build an image from source code
@@ -72,11 +90,6 @@ func Build(logger logging.Logger, cfg config.Config, packClient PackClient) *cob
 			"on how to use `pack build`, see: https://buildpacks.io/docs/app-developer-guide/build-an-app/.",
 		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
 			inputImageName := client.ParseInputImageReference(args[0])
-			if err := validateBuildFlags(&flags, cfg, inputImageName, logger); err != nil {
-				return err
-			}
-
-			inputPreviousImage := client.ParseInputImageReference(flags.PreviousImage)
 
 			descriptor, actualDescriptorPath, err := parseProjectToml(flags.AppPath, flags.DescriptorPath)
 			if err != nil {
@@ -85,8 +98,22 @@ func Build(logger logging.Logger, cfg config.Config, packClient PackClient) *cob
 
 			if actualDescriptorPath != "" {
 				logger.Debugf("Using project descriptor located at %s", style.Symbol(actualDescriptorPath))
+				if err := applyProjectDescriptor(cmd, &flags, descriptor, filepath.Dir(actualDescriptorPath)); err != nil {
+					return err
+				}
+			}
+
+			if err := validateBuildFlags(&flags, cfg, inputImageName, logger); err != nil {
+				return err
+			}
+
+			// the exporter pushes the app image to its registry, which is not reachable when running offline
+			if flags.Publish && isOffline(cmd, cfg) {
+				return errors.New("the app image cannot be published when running offline, build it without the publish flag or run with --offline=false")
 			}
 
+			inputPreviousImage := client.ParseInputImageReference(flags.PreviousImage)
+
 			builder := flags.Builder
 			// We only override the builder to the one in the project descriptor
 			// if it was not explicitly set by the user
@@ -94,7 +121,13 @@ func Build(logger logging.Logger, cfg config.Config, packClient PackClient) *cob
 				builder = descriptor.Build.Builder
 			}
 
-			if builder == "" {
+			// builds from a vendor directory use the builder saved to it, unless another one is set explicitly
+			fromVendorDir := flags.VendorDir != "" && !flags.PrepareOnly
+			if fromVendorDir && !cmd.Flags().Changed("builder") && descriptor.Build.Builder == "" {
+				builder = ""
+			}
+
+			if builder == "" && !fromVendorDir {
 				suggestSettingBuilder(logger, packClient)
 				return client.NewSoftError()
 			}
@@ -132,7 +165,7 @@ func Build(logger logging.Logger, cfg config.Config, packClient PackClient) *cob
 				return errors.Wrapf(err, "parsing pull policy %s", flags.Policy)
 			}
 			var lifecycleImage string
-			if flags.LifecycleImage != "" {
+			if flags.LifecycleImage != "" && !(fromVendorDir && !cmd.Flags().Changed("lifecycle-image")) {
 				ref, err := name.ParseReference(flags.LifecycleImage)
 				if err != nil {
 					return errors.Wrapf(err, "parsing lifecycle image %s", flags.LifecycleImage)
@@ -147,7 +180,11 @@ func Build(logger logging.Logger, cfg config.Config, packClient PackClient) *cob
 			if err != nil {
 				return errors.Wrapf(err, "parsing creation time %s", flags.DateTime)
 			}
-			if err := packClient.Build(cmd.Context(), client.BuildOptions{
+			targets, err := parseTargets(flags.Platforms)
+			if err != nil {
+				return err
+			}
+			buildOpts := client.BuildOptions{
 				AppPath:           flags.AppPath,
 				Builder:           builder,
 				Registry:          flags.Registry,
@@ -184,13 +221,65 @@ func Build(logger logging.Logger, cfg config.Config, packClient PackClient) *cob
 				CreationTime:             dateTime,
 				PreBuildpacks:            flags.PreBuildpacks,
 				PostBuildpacks:           flags.PostBuildpacks,
+				Timings:                  flags.Timings,
+				SigningKey:               flags.SigningKey,
+				InsecureRegistries:       flags.InsecureRegistries,
+				Attest:                   flags.Attest,
+				Provenance:               flags.Provenance,
 				LayoutConfig: &client.LayoutConfig{
 					Sparse:             flags.Sparse,
 					InputImage:         inputImageName,
 					PreviousInputImage: inputPreviousImage,
 					LayoutRepoDir:      cfg.LayoutRepositoryDir,
 				},
-			}); err != nil {
+				Targets:   targets,
+				VendorDir: flags.VendorDir,
+			}
+
+			if flags.SigningKey != "" {
+				buildOpts.SigningKeyPassword = []byte(os.Getenv(signingKeyPasswordEnv))
+			}
+
+			if flags.Events != "" {
+				eventsOutput, closeEvents, err := openEventsOutput(flags.Events)
+				if err != nil {
+					return err
+				}
+				defer closeEvents()
+				buildOpts.Events = eventsOutput
+			}
+
+			if flags.PrepareOnly {
+				vendored, err := packClient.PrepareBuild(cmd.Context(), buildOpts)
+				if err != nil {
+					return errors.Wrap(err, "failed to prepare build")
+				}
+
+				logger.Infof("Saved the artifacts of the build to %s", style.Symbol(flags.VendorDir))
+				for _, img := range vendored.Images {
+					logger.Infof("  image %s", style.Symbol(img))
+				}
+				for _, uri := range vendored.Downloads {
+					logger.Infof("  download %s", style.Symbol(uri))
+				}
+				logger.Infof("To build without network access, run %s", style.Symbol(fmt.Sprintf("pack build %s --vendor-dir %s", inputImageName.Name(), flags.VendorDir)))
+				return nil
+			}
+
+			if flags.DetectOnly {
+				w, err := detectwriter.NewFactory().Writer(flags.OutputFormat)
+				if err != nil {
+					return err
+				}
+
+				result, err := packClient.Detect(cmd.Context(), buildOpts)
+				if err != nil {
+					return errors.Wrap(err, "failed to detect")
+				}
+				return w.Print(logger, result)
+			}
+
+			if err := packClient.Build(cmd.Context(), buildOpts); err != nil {
 				return errors.Wrap(err, "failed to build")
 			}
 			logger.Infof("Successfully built image %s", style.Symbol(inputImageName.Name()))
@@ -228,6 +317,7 @@ func buildCommandFlags(cmd *cobra.Command, buildFlags *BuildFlags, cfg config.Co
 		`Cache options used to define cache techniques for build process.
 - Cache as bind: type=<build/launch>;format=bind;source=<path to directory>;
 - Cache as image: type=<build/launch>;format=image;name=<registry image name>;
+    - The cache image is pulled from and pushed to the registry, also when the app image is saved to the daemon.
 - Cache as volume: type=<build/launch>;format=volume;[name=<volume name>;]
     - If no name is provided, a random name will be generated.
 `)
@@ -235,12 +325,16 @@ func buildCommandFlags(cmd *cobra.Command, buildFlags *BuildFlags, cfg config.Co
 	cmd.Flags().BoolVar(&buildFlags.ClearCache, "clear-cache", false, "Clear image's associated cache before building")
 	cmd.Flags().StringVar(&buildFlags.DateTime, "creation-time", "", "Desired create time in the output image config. Accepted values are Unix timestamps (e.g., '1641013200'), or 'now'. Platform API version must be at least 0.9 to use this feature.")
 	cmd.Flags().StringVarP(&buildFlags.DescriptorPath, "descriptor", "d", "", "Path to the project descriptor file")
+	cmd.Flags().BoolVar(&buildFlags.DetectOnly, "detect-only", false, "Only run detection, then print the buildpacks that would participate in the build along with the build plan.\nNo app image is created.")
 	cmd.Flags().StringVarP(&buildFlags.DefaultProcessType, "default-process", "D", "", `Set the default process type. (default "web")`)
+	cmd.Flags().StringVar(&buildFlags.Events, "events", "", "Write structured build events as newline-delimited JSON to a file, or to an open file descriptor in the form 'fd:<number>'.\nThe lifecycle logs at debug level so that the result of each buildpack can be reported.")
 	cmd.Flags().StringArrayVarP(&buildFlags.Env, "env", "e", []string{}, "Build-time environment variable, in the form 'VAR=VALUE' or 'VAR'.\nWhen using latter value-less form, value will be taken from current\n  environment at the time this command is executed.\nThis flag may be specified multiple times and will override\n  individual values defined by --env-file."+stringArrayHelp("env")+"\nNOTE: These are NOT available at image runtime.")
 	cmd.Flags().StringArrayVar(&buildFlags.EnvFiles, "env-file", []string{}, "Build-time environment variables file\nOne variable per line, of the form 'VAR=VALUE' or 'VAR'\nWhen using latter value-less form, value will be taken from current\n  environment at the time this command is executed\nNOTE: These are NOT available at image runtime.\"")
 	cmd.Flags().StringVar(&buildFlags.Network, "network", "", "Connect detect and build containers to network")
+	cmd.Flags().StringSliceVar(&buildFlags.Platforms, "platform", nil, "Platform to build the app image for, in the form '<os>/<arch>[/<variant>]'.\nWhen more than one platform is provided, an image index referencing each platform-specific image is published. Requires --publish."+stringSliceHelp("platform"))
 	cmd.Flags().StringArrayVar(&buildFlags.PreBuildpacks, "pre-buildpack", []string{}, "Buildpacks to prepend to the groups in the builder's order")
 	cmd.Flags().StringArrayVar(&buildFlags.PostBuildpacks, "post-buildpack", []string{}, "Buildpacks to append to the groups in the builder's order")
+	cmd.Flags().BoolVar(&buildFlags.PrepareOnly, "prepare-only", false, "Only save the builder, run image, lifecycle image and buildpacks the build would use to --vendor-dir.\nImages are saved as OCI layouts, and the directory can be copied to a host without network access.\nNo app image is created.")
 	cmd.Flags().BoolVar(&buildFlags.Publish, "publish", false, "Publish to registry")
 	cmd.Flags().StringVar(&buildFlags.DockerHost, "docker-host", "",
 		`Address to docker daemon that will be exposed to the build container.
@@ -249,17 +343,24 @@ Special value 'inherit' may be used in which case DOCKER_HOST environment variab
 This option may set DOCKER_HOST environment variable for the build container if needed.
 `)
 	cmd.Flags().StringVar(&buildFlags.LifecycleImage, "lifecycle-image", cfg.LifecycleImage, `Custom lifecycle image to use for analysis, restore, and export when builder is untrusted.`)
+	cmd.Flags().StringVarP(&buildFlags.OutputFormat, "output", "o", "human-readable", "Output format of --detect-only (json, yaml, human-readable).\nOmission of this flag will display as human-readable.")
 	cmd.Flags().StringVar(&buildFlags.Policy, "pull-policy", "", `Pull policy to use. Accepted values are always, never, and if-not-present. (default "always")`)
 	cmd.Flags().StringVarP(&buildFlags.Registry, "buildpack-registry", "r", cfg.DefaultRegistryName, "Buildpack Registry by name")
 	cmd.Flags().StringVar(&buildFlags.RunImage, "run-image", "", "Run image (defaults to default stack's run image)")
 	cmd.Flags().StringSliceVarP(&buildFlags.AdditionalTags, "tag", "t", nil, "Additional tags to push the output image to.\nTags should be in the format 'image:tag' or 'repository/image:tag'."+stringSliceHelp("tag"))
 	cmd.Flags().BoolVar(&buildFlags.TrustBuilder, "trust-builder", false, "Trust the provided builder.\nAll lifecycle phases will be run in a single container.\nFor more on trusted builders, and when to trust or untrust a builder, check out our docs here: https://buildpacks.io/docs/tools/pack/concepts/trusted_builders")
+	cmd.Flags().StringVar(&buildFlags.VendorDir, "vendor-dir", "", "Directory the artifacts of the build are saved to with --prepare-only.\nWithout --prepare-only, the builder, run image, lifecycle image and buildpacks are read from this directory\n  instead of being pulled or downloaded, and the builder saved to it is used when --builder is not set.")
 	cmd.Flags().StringArrayVar(&buildFlags.Volumes, "volume", nil, "Mount host volume into the build container, in the form '<host path>:<target path>[:<options>]'.\n- 'host path': Name of the volume or absolute directory path to mount.\n- 'target path': The path where the file or directory is available in the container.\n- 'options' (default \"ro\"): An optional comma separated list of mount options.\n    - \"ro\", volume contents are read-only.\n    - \"rw\", volume contents are readable and writeable.\n    - \"volume-opt=<key>=<value>\", can be specified more than once, takes a key-value pair consisting of the option name and its value."+stringArrayHelp("volume"))
 	cmd.Flags().StringVar(&buildFlags.Workspace, "workspace", "", "Location at which to mount the app dir in the build image")
 	cmd.Flags().IntVar(&buildFlags.GID, "gid", 0, `Override GID of user's group in the stack's build and run images. The provided value must be a positive number`)
 	cmd.Flags().StringVar(&buildFlags.PreviousImage, "previous-image", "", "Set previous image to a particular tag reference, digest reference, or (when performing a daemon build) image ID")
 	cmd.Flags().StringVar(&buildFlags.SBOMDestinationDir, "sbom-output-dir", "", "Path to export SBoM contents.\nOmitting the flag will yield no SBoM content.")
 	cmd.Flags().StringVar(&buildFlags.ReportDestinationDir, "report-output-dir", "", "Path to export build report.toml.\nOmitting the flag yield no report file.")
+	cmd.Flags().BoolVar(&buildFlags.Timings, "timings", false, "Print how long each step of the build took, including image fetches and lifecycle phases.\nWhen --report-output-dir is set, a JSON timing report is also written to timings.json in that directory.")
+	cmd.Flags().StringVar(&buildFlags.SigningKey, "signing-key", "", "Path of a PEM encoded private key to sign the published image digest with, for the image and each additional tag.\nSignatures are attached to the image as OCI referrers which can be verified with cosign.\nKeys encrypted by cosign are decrypted with the password in the "+signingKeyPasswordEnv+" environment variable.")
+	cmd.Flags().StringSliceVar(&buildFlags.InsecureRegistries, "insecure-registry", nil, "Registry that the signature and attestations of the published image are pushed to over HTTP."+stringSliceHelp("insecure-registry"))
+	cmd.Flags().BoolVar(&buildFlags.Attest, "attest", false, "Attach the SBOM of the published image and a SLSA provenance statement describing its builder, buildpacks and source to the image as OCI referrers.\nWith --signing-key, the provenance statement is signed.")
+	cmd.Flags().BoolVar(&buildFlags.Provenance, "provenance", false, "Generate a SLSA provenance statement describing the builder, run image, lifecycle, buildpacks and project of the app image.\nIt is written to provenance.json in --report-output-dir, and attached to the image as an OCI referrer with --publish.")
 	cmd.Flags().BoolVar(&buildFlags.Interactive, "interactive", false, "Launch a terminal UI to depict the build process")
 	cmd.Flags().BoolVar(&buildFlags.Sparse, "sparse", false, "Use this flag to avoid saving on disk the run-image layers when the application image is exported to OCI layout format")
 	if !cfg.Experimental {
@@ -281,14 +382,50 @@ func validateBuildFlags(flags *BuildFlags, cfg config.Config, inputImageRef clie
 		return errors.New("'cache' flag with 'image' format cannot be used with 'cache-image' flag.")
 	}
 
-	if flags.Cache.Build.Format == cache.CacheImage && !flags.Publish {
-		return errors.New("image cache format requires the 'publish' flag")
-	}
-
 	if flags.CacheImage != "" && !flags.Publish {
 		return errors.New("cache-image flag requires the publish flag")
 	}
 
+	if flags.SigningKey != "" && !flags.Publish {
+		return errors.New("signing-key flag requires the publish flag")
+	}
+
+	if flags.Attest && !flags.Publish {
+		return errors.New("attest flag requires the publish flag")
+	}
+
+	if flags.Provenance && !flags.Publish && flags.ReportDestinationDir == "" {
+		return errors.New("provenance flag requires the publish flag or the report-output-dir flag")
+	}
+
+	if flags.DetectOnly && len(flags.Platforms) > 1 {
+		return errors.New("detect-only flag cannot be used when building for multiple platforms")
+	}
+
+	if flags.DetectOnly && flags.Interactive {
+		return errors.New("detect-only flag cannot be used with the interactive flag")
+	}
+
+	if flags.PrepareOnly && flags.VendorDir == "" {
+		return errors.New("prepare-only flag requires the vendor-dir flag")
+	}
+
+	if flags.PrepareOnly && flags.DetectOnly {
+		return errors.New("prepare-only flag cannot be used with the detect-only flag")
+	}
+
+	if flags.VendorDir != "" && len(flags.Platforms) > 1 {
+		return errors.New("vendor-dir flag cannot be used when building for multiple platforms")
+	}
+
+	if flags.VendorDir != "" && !flags.PrepareOnly && flags.Publish {
+		return errors.New("vendor-dir flag cannot be used with the publish flag")
+	}
+
+	if len(flags.Platforms) > 1 && !flags.Publish {
+		return errors.New("building for multiple platforms requires the publish flag")
+	}
+
 	if flags.GID < 0 {
 		return errors.New("gid flag must be in the range of 0-2147483647")
 	}
@@ -304,6 +441,77 @@ func validateBuildFlags(flags *BuildFlags, cfg config.Config, inputImageRef clie
 	return nil
 }
 
+// applyProjectDescriptor uses the build preferences declared in the project descriptor for any flags that were not
+// explicitly set by the user
+func applyProjectDescriptor(cmd *cobra.Command, flags *BuildFlags, descriptor projectTypes.Descriptor, descriptorDir string) error {
+	if !cmd.Flags().Changed("run-image") && descriptor.Build.RunImage != "" {
+		flags.RunImage = descriptor.Build.RunImage
+	}
+
+	if !cmd.Flags().Changed("default-process") && descriptor.Launch.DefaultProcess != "" {
+		flags.DefaultProcessType = descriptor.Launch.DefaultProcess
+	}
+
+	// an image index can only be published, so several targets are only used when publishing and builds to the daemon
+	// are done for the platform of the daemon
+	if !cmd.Flags().Changed("platform") && (flags.Publish || len(descriptor.Build.Targets) == 1) {
+		for _, target := range descriptor.Build.Targets {
+			flags.Platforms = append(flags.Platforms, target.Platform())
+		}
+	}
+
+	if !cmd.Flags().Changed("cache") {
+		for _, c := range descriptor.Build.Cache {
+			cacheOpt := fmt.Sprintf("type=%s;format=%s", c.Type, c.Format)
+			switch {
+			case c.Source != "":
+				source := c.Source
+				if !filepath.IsAbs(source) {
+					source = filepath.Join(descriptorDir, source)
+				}
+				cacheOpt += ";source=" + source
+			case c.Name != "":
+				cacheOpt += ";name=" + c.Name
+			}
+			if err := flags.Cache.Set(cacheOpt); err != nil {
+				return errors.Wrap(err, "parsing cache from project descriptor")
+			}
+		}
+	}
+
+	return nil
+}
+
+func parseTargets(platforms []string) ([]dist.Target, error) {
+	var targets []dist.Target
+	for _, platform := range platforms {
+		target, err := dist.ParseTarget(platform)
+		if err != nil {
+			return nil, errors.Wrap(err, "parsing platform")
+		}
+		targets = append(targets, target)
+	}
+	return targets, nil
+}
+
+// openEventsOutput opens the file or file descriptor build events are written to
+func openEventsOutput(target string) (io.Writer, func(), error) {
+	if fd, ok := strings.CutPrefix(target, "fd:"); ok {
+		n, err := strconv.ParseUint(fd, 10, 32)
+		if err != nil {
+			return nil, nil, errors.Errorf("invalid events file descriptor %s", style.Symbol(target))
+		}
+		// the descriptor is owned by the caller, so it is not closed
+		return os.NewFile(uintptr(n), target), func() {}, nil
+	}
+
+	file, err := os.Create(filepath.Clean(target))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "creating events file")
+	}
+	return file, func() { file.Close() }, nil
+}
+
 func parseEnv(envFiles []string, envVars []string) (map[string]string, error) {
 	env := map[string]string{}
 
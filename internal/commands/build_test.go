@@ -23,6 +23,7 @@ import (
 	"github.com/buildpacks/pack/internal/commands/testmocks"
 	"github.com/buildpacks/pack/internal/config"
 	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/dist"
 	"github.com/buildpacks/pack/pkg/image"
 	"github.com/buildpacks/pack/pkg/logging"
 	projectTypes "github.com/buildpacks/pack/pkg/project/types"
@@ -336,6 +337,36 @@ func testBuildCommand(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("running offline", func() {
+			it("errors when publishing", func() {
+				cfg.Offline = true
+				command = commands.Build(logger, cfg, mockClient)
+
+				command.SetArgs([]string{"--builder", "my-builder", "image", "--publish"})
+				h.AssertError(t, command.Execute(), "the app image cannot be published when running offline")
+			})
+
+			it("errors when publishing with the offline flag", func() {
+				rootCmd := &cobra.Command{Use: "pack"}
+				rootCmd.PersistentFlags().Bool("offline", false, "")
+				rootCmd.AddCommand(command)
+
+				rootCmd.SetArgs([]string{"build", "--builder", "my-builder", "image", "--publish", "--offline"})
+				h.AssertError(t, rootCmd.Execute(), "the app image cannot be published when running offline")
+			})
+
+			it("builds to the daemon", func() {
+				cfg.Offline = true
+				command = commands.Build(logger, cfg, mockClient)
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithImage("my-builder", "image")).
+					Return(nil)
+
+				command.SetArgs([]string{"--builder", "my-builder", "image"})
+				h.AssertNil(t, command.Execute())
+			})
+		})
+
 		when("a cache-image passed", func() {
 			when("--publish is not used", func() {
 				it("errors", func() {
@@ -358,10 +389,13 @@ func testBuildCommand(t *testing.T, when spec.G, it spec.S) {
 
 		when("cache flag with 'format=image' is passed", func() {
 			when("--publish is not used", func() {
-				it("errors", func() {
+				it("succeeds", func() {
+					mockClient.EXPECT().
+						Build(gomock.Any(), EqBuildOptionsWithCacheFlags("type=build;format=image;name=myorg/myimage:cache;type=launch;format=volume;")).
+						Return(nil)
+
 					command.SetArgs([]string{"--builder", "my-builder", "image", "--cache", "type=build;format=image;name=myorg/myimage:cache"})
-					err := command.Execute()
-					h.AssertError(t, err, "image cache format requires the 'publish' flag")
+					h.AssertNil(t, command.Execute())
 				})
 			})
 			when("--publish is used", func() {
@@ -608,6 +642,127 @@ builder = "my-builder"
 					})
 				})
 			})
+			when("file has build preferences specified", func() {
+				var projectTomlPath string
+
+				it.Before(func() {
+					projectToml, err := os.CreateTemp("", "project.toml")
+					h.AssertNil(t, err)
+					defer projectToml.Close()
+
+					projectToml.WriteString(`
+[_]
+schema-version = "0.3"
+
+[io.buildpacks]
+run-image = "some/run-image"
+
+[io.buildpacks.launch]
+default-process = "worker"
+
+[[io.buildpacks.targets]]
+os = "linux"
+arch = "arm64"
+
+[[io.buildpacks.cache]]
+type = "build"
+format = "volume"
+name = "some-volume"
+`)
+					projectTomlPath = projectToml.Name()
+				})
+
+				it.After(func() {
+					h.AssertNil(t, os.RemoveAll(projectTomlPath))
+				})
+
+				when("no flags are explicitly passed by the user", func() {
+					it("should build an image with the preferences in the descriptor", func() {
+						mockClient.EXPECT().
+							Build(gomock.Any(), gomock.All(
+								EqBuildOptionsWithRunImage("some/run-image"),
+								EqBuildOptionsDefaultProcess("worker"),
+								EqBuildOptionsWithTargets([]dist.Target{{OS: "linux", Arch: "arm64"}}),
+								EqBuildOptionsWithCacheFlags("type=build;format=volume;name=some-volume;type=launch;format=volume;"),
+							)).
+							Return(nil)
+
+						command.SetArgs([]string{"--builder", "my-builder", "--descriptor", projectTomlPath, "image"})
+						h.AssertNil(t, command.Execute())
+					})
+				})
+
+				when("flags are explicitly passed by the user", func() {
+					it("should build an image with the passed flags", func() {
+						mockClient.EXPECT().
+							Build(gomock.Any(), gomock.All(
+								EqBuildOptionsWithRunImage("flag/run-image"),
+								EqBuildOptionsDefaultProcess("web"),
+								EqBuildOptionsWithTargets([]dist.Target{{OS: "linux", Arch: "amd64"}}),
+								EqBuildOptionsWithCacheFlags("type=build;format=volume;name=flag-volume;type=launch;format=volume;"),
+							)).
+							Return(nil)
+
+						command.SetArgs([]string{
+							"--builder", "my-builder",
+							"--descriptor", projectTomlPath,
+							"--run-image", "flag/run-image",
+							"--default-process", "web",
+							"--platform", "linux/amd64",
+							"--cache", "type=build;format=volume;name=flag-volume",
+							"image",
+						})
+						h.AssertNil(t, command.Execute())
+					})
+				})
+			})
+
+			when("file has several targets", func() {
+				var projectTomlPath string
+
+				it.Before(func() {
+					projectToml, err := os.CreateTemp("", "project.toml")
+					h.AssertNil(t, err)
+					defer projectToml.Close()
+
+					projectToml.WriteString(`
+[_]
+schema-version = "0.3"
+
+[[io.buildpacks.targets]]
+os = "linux"
+arch = "amd64"
+
+[[io.buildpacks.targets]]
+os = "linux"
+arch = "arm64"
+`)
+					projectTomlPath = projectToml.Name()
+				})
+
+				it.After(func() {
+					h.AssertNil(t, os.RemoveAll(projectTomlPath))
+				})
+
+				it("builds for the platform of the daemon when not publishing", func() {
+					mockClient.EXPECT().
+						Build(gomock.Any(), EqBuildOptionsWithTargets(nil)).
+						Return(nil)
+
+					command.SetArgs([]string{"--builder", "my-builder", "--descriptor", projectTomlPath, "image"})
+					h.AssertNil(t, command.Execute())
+				})
+
+				it("builds for every target when publishing", func() {
+					mockClient.EXPECT().
+						Build(gomock.Any(), EqBuildOptionsWithTargets([]dist.Target{{OS: "linux", Arch: "amd64"}, {OS: "linux", Arch: "arm64"}})).
+						Return(nil)
+
+					command.SetArgs([]string{"--builder", "my-builder", "--descriptor", projectTomlPath, "--publish", "image"})
+					h.AssertNil(t, command.Execute())
+				})
+			})
+
 			when("file is invalid", func() {
 				var projectTomlPath string
 
@@ -869,6 +1024,300 @@ builder = "my-builder"
 			})
 		})
 
+		when("--platform", func() {
+			it("passes the parsed targets to the client", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithTargets([]dist.Target{
+						{OS: "linux", Arch: "amd64"},
+						{OS: "linux", Arch: "arm64", ArchVariant: "v8"},
+					})).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--publish", "--platform", "linux/amd64,linux/arm64/v8"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			when("multiple platforms are provided without --publish", func() {
+				it("errors", func() {
+					command.SetArgs([]string{"image", "--builder", "my-builder", "--platform", "linux/amd64", "--platform", "linux/arm64"})
+					h.AssertError(t, command.Execute(), "building for multiple platforms requires the publish flag")
+				})
+			})
+
+			when("the platform is malformed", func() {
+				it("errors", func() {
+					command.SetArgs([]string{"image", "--builder", "my-builder", "--platform", "linux/"})
+					h.AssertError(t, command.Execute(), "invalid platform")
+				})
+			})
+		})
+
+		when("--detect-only", func() {
+			var detectResult client.DetectResult
+
+			it.Before(func() {
+				detectResult = client.DetectResult{
+					Group: []dist.ModuleInfo{{ID: "some/buildpack", Version: "1.2.3"}},
+					SkippedBuildpacks: []client.SkippedBuildpack{
+						{ModuleInfo: dist.ModuleInfo{ID: "optional/buildpack", Version: "0.1.0"}, Reason: "failed detection"},
+					},
+				}
+			})
+
+			it("runs detection instead of a build and prints the result", func() {
+				mockClient.EXPECT().
+					Detect(gomock.Any(), EqBuildOptionsWithImage("my-builder", "image")).
+					Return(detectResult, nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--detect-only"})
+				h.AssertNil(t, command.Execute())
+				h.AssertContains(t, outBuf.String(), "Detected Buildpacks:")
+				h.AssertContains(t, outBuf.String(), "some/buildpack")
+				h.AssertContains(t, outBuf.String(), "Skipped Optional Buildpacks:")
+				h.AssertContains(t, outBuf.String(), "failed detection")
+				h.AssertNotContains(t, outBuf.String(), "Successfully built image")
+			})
+
+			it("prints the result in the requested format", func() {
+				mockClient.EXPECT().
+					Detect(gomock.Any(), gomock.Any()).
+					Return(detectResult, nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--detect-only", "--output", "json"})
+				h.AssertNil(t, command.Execute())
+				h.AssertContains(t, outBuf.String(), `"skipped_buildpacks": [`)
+			})
+
+			when("the output format is not supported", func() {
+				it("errors", func() {
+					command.SetArgs([]string{"image", "--builder", "my-builder", "--detect-only", "--output", "toml"})
+					h.AssertError(t, command.Execute(), "output format 'toml' is not supported")
+				})
+			})
+
+			when("detection fails", func() {
+				it("errors", func() {
+					mockClient.EXPECT().
+						Detect(gomock.Any(), gomock.Any()).
+						Return(client.DetectResult{}, errors.New("no buildpacks participating"))
+
+					command.SetArgs([]string{"image", "--builder", "my-builder", "--detect-only"})
+					h.AssertError(t, command.Execute(), "failed to detect: no buildpacks participating")
+				})
+			})
+
+			when("multiple platforms are provided", func() {
+				it("errors", func() {
+					command.SetArgs([]string{"image", "--builder", "my-builder", "--detect-only", "--publish", "--platform", "linux/amd64,linux/arm64"})
+					h.AssertError(t, command.Execute(), "detect-only flag cannot be used when building for multiple platforms")
+				})
+			})
+		})
+
+		when("--prepare-only", func() {
+			it("saves the artifacts of the build to the vendor directory instead of building", func() {
+				mockClient.EXPECT().
+					PrepareBuild(gomock.Any(), EqBuildOptionsWithVendorDir("my-builder", "some-vendor-dir")).
+					Return(&client.VendoredBuild{
+						Images:    []string{"some/builder", "some/run"},
+						Downloads: []string{"https://example.com/some-buildpack.tgz"},
+					}, nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--prepare-only", "--vendor-dir", "some-vendor-dir"})
+				h.AssertNil(t, command.Execute())
+				h.AssertContains(t, outBuf.String(), "Saved the artifacts of the build to 'some-vendor-dir'")
+				h.AssertContains(t, outBuf.String(), "image 'some/run'")
+				h.AssertContains(t, outBuf.String(), "download 'https://example.com/some-buildpack.tgz'")
+				h.AssertContains(t, outBuf.String(), "To build without network access, run 'pack build image --vendor-dir some-vendor-dir'")
+				h.AssertNotContains(t, outBuf.String(), "Successfully built image")
+			})
+
+			when("preparing the build fails", func() {
+				it("errors", func() {
+					mockClient.EXPECT().
+						PrepareBuild(gomock.Any(), gomock.Any()).
+						Return(nil, errors.New("some-error"))
+
+					command.SetArgs([]string{"image", "--builder", "my-builder", "--prepare-only", "--vendor-dir", "some-vendor-dir"})
+					h.AssertError(t, command.Execute(), "failed to prepare build: some-error")
+				})
+			})
+
+			when("the vendor directory is not provided", func() {
+				it("errors", func() {
+					command.SetArgs([]string{"image", "--builder", "my-builder", "--prepare-only"})
+					h.AssertError(t, command.Execute(), "prepare-only flag requires the vendor-dir flag")
+				})
+			})
+
+			when("detect-only is provided", func() {
+				it("errors", func() {
+					command.SetArgs([]string{"image", "--builder", "my-builder", "--prepare-only", "--vendor-dir", "some-vendor-dir", "--detect-only"})
+					h.AssertError(t, command.Execute(), "prepare-only flag cannot be used with the detect-only flag")
+				})
+			})
+		})
+
+		when("--vendor-dir", func() {
+			it("builds with the builder of the vendor directory instead of the default builder", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), gomock.All(EqBuildOptionsWithVendorDir("", "some-vendor-dir"), EqBuildOptionsWithLifecycleImage(""))).
+					Return(nil)
+
+				command = commands.Build(logger, config.Config{DefaultBuilder: "default-builder", LifecycleImage: "some-lifecycle-image"}, mockClient)
+				command.SetArgs([]string{"image", "--vendor-dir", "some-vendor-dir"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("builds with the builder provided explicitly", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithVendorDir("my-builder", "some-vendor-dir")).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--vendor-dir", "some-vendor-dir"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			when("publish is provided", func() {
+				it("errors", func() {
+					command.SetArgs([]string{"image", "--builder", "my-builder", "--vendor-dir", "some-vendor-dir", "--publish"})
+					h.AssertError(t, command.Execute(), "vendor-dir flag cannot be used with the publish flag")
+				})
+			})
+		})
+
+		when("--events", func() {
+			var tmpDir string
+
+			it.Before(func() {
+				var err error
+				tmpDir, err = os.MkdirTemp("", "build-events")
+				h.AssertNil(t, err)
+			})
+
+			it.After(func() {
+				h.AssertNil(t, os.RemoveAll(tmpDir))
+			})
+
+			it("writes events to the file", func() {
+				eventsFile := filepath.Join(tmpDir, "events.ndjson")
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithEvents()).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--events", eventsFile})
+				h.AssertNil(t, command.Execute())
+
+				_, err := os.Stat(eventsFile)
+				h.AssertNil(t, err)
+			})
+
+			when("the file descriptor is invalid", func() {
+				it("errors", func() {
+					command.SetArgs([]string{"image", "--builder", "my-builder", "--events", "fd:abc"})
+					h.AssertError(t, command.Execute(), "invalid events file descriptor 'fd:abc'")
+				})
+			})
+
+			when("the file cannot be created", func() {
+				it("errors", func() {
+					command.SetArgs([]string{"image", "--builder", "my-builder", "--events", filepath.Join(tmpDir, "missing", "events.ndjson")})
+					h.AssertError(t, command.Execute(), "creating events file")
+				})
+			})
+		})
+
+		when("--timings", func() {
+			it("measures the duration of the build steps", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithTimings(true)).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--timings"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("is disabled by default", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithTimings(false)).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder"})
+				h.AssertNil(t, command.Execute())
+			})
+		})
+
+		when("--signing-key", func() {
+			it("passes the key and the password from the environment", func() {
+				h.AssertNil(t, os.Setenv("COSIGN_PASSWORD", "some-password"))
+				defer os.Unsetenv("COSIGN_PASSWORD")
+
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithSigningKey("some-key.pem", "some-password")).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--publish", "--signing-key", "some-key.pem"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("requires publishing", func() {
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--signing-key", "some-key.pem"})
+				h.AssertError(t, command.Execute(), "signing-key flag requires the publish flag")
+			})
+		})
+
+		when("--insecure-registry", func() {
+			it("passes the registries reached over HTTP", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithInsecureRegistries([]string{"registry.local:5000", "other.local"})).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--publish", "--signing-key", "some-key.pem", "--insecure-registry", "registry.local:5000", "--insecure-registry", "other.local"})
+				h.AssertNil(t, command.Execute())
+			})
+		})
+
+		when("--attest", func() {
+			it("attaches attestations to the image", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithAttest(true)).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--publish", "--attest"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("requires publishing", func() {
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--attest"})
+				h.AssertError(t, command.Execute(), "attest flag requires the publish flag")
+			})
+		})
+
+		when("--provenance", func() {
+			it("generates provenance for published images", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithProvenance(true)).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--publish", "--provenance"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("generates provenance into the report directory", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithProvenance(true)).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--report-output-dir", "some-dir", "--provenance"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("requires publishing or a report directory", func() {
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--provenance"})
+				h.AssertError(t, command.Execute(), "provenance flag requires the publish flag or the report-output-dir flag")
+			})
+		})
+
 		when("export to OCI layout is expected but experimental isn't set in the config", func() {
 			it("errors with a descriptive message", func() {
 				command.SetArgs([]string{"oci:image", "--builder", "my-builder"})
@@ -947,6 +1396,69 @@ func EqBuildOptionsWithImage(builder, image string) gomock.Matcher {
 	}
 }
 
+func EqBuildOptionsWithEvents() gomock.Matcher {
+	return buildOptionsMatcher{
+		description: "Events is set",
+		equals: func(o client.BuildOptions) bool {
+			return o.Events != nil
+		},
+	}
+}
+
+func EqBuildOptionsWithTimings(timings bool) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("Timings=%t", timings),
+		equals: func(o client.BuildOptions) bool {
+			return o.Timings == timings
+		},
+	}
+}
+
+func EqBuildOptionsWithSigningKey(key, password string) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("SigningKey=%s", key),
+		equals: func(o client.BuildOptions) bool {
+			return o.SigningKey == key && string(o.SigningKeyPassword) == password
+		},
+	}
+}
+
+func EqBuildOptionsWithInsecureRegistries(registries []string) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("InsecureRegistries=%s", registries),
+		equals: func(o client.BuildOptions) bool {
+			return reflect.DeepEqual(o.InsecureRegistries, registries)
+		},
+	}
+}
+
+func EqBuildOptionsWithAttest(attest bool) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("Attest=%t", attest),
+		equals: func(o client.BuildOptions) bool {
+			return o.Attest == attest
+		},
+	}
+}
+
+func EqBuildOptionsWithProvenance(provenance bool) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("Provenance=%t", provenance),
+		equals: func(o client.BuildOptions) bool {
+			return o.Provenance == provenance
+		},
+	}
+}
+
+func EqBuildOptionsWithRunImage(runImage string) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("RunImage=%s", runImage),
+		equals: func(o client.BuildOptions) bool {
+			return o.RunImage == runImage
+		},
+	}
+}
+
 func EqBuildOptionsDefaultProcess(defaultProc string) gomock.Matcher {
 	return buildOptionsMatcher{
 		description: fmt.Sprintf("Default Process Type=%s", defaultProc),
@@ -1120,6 +1632,24 @@ func EqBuildOptionsWithLayoutConfig(image, previousImage string, sparse bool, la
 	}
 }
 
+func EqBuildOptionsWithTargets(targets []dist.Target) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("Targets=%v", targets),
+		equals: func(o client.BuildOptions) bool {
+			return reflect.DeepEqual(o.Targets, targets)
+		},
+	}
+}
+
+func EqBuildOptionsWithVendorDir(builder, vendorDir string) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("Builder=%s VendorDir=%s", builder, vendorDir),
+		equals: func(o client.BuildOptions) bool {
+			return o.Builder == builder && o.VendorDir == vendorDir
+		},
+	}
+}
+
 type buildOptionsMatcher struct {
 	equals      func(client.BuildOptions) bool
 	description string
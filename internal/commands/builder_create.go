@@ -64,6 +64,10 @@ Creating a custom builder allows you to control what buildpacks are used and wha
 				logger.Warnf("builder configuration: %s", w)
 			}
 
+			if len(builderConfig.Targets) > 1 && !flags.Publish {
+				return errors.New("builder config declares multiple targets; creating a multi-platform builder requires the publish flag")
+			}
+
 			if hasExtensions(builderConfig) {
 				if !cfg.Experimental {
 					return errors.New("builder config contains image extensions; support for image extensions is currently experimental")
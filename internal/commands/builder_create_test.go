@@ -2,6 +2,7 @@ package commands_test
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -16,6 +17,8 @@ import (
 	"github.com/buildpacks/pack/internal/commands"
 	"github.com/buildpacks/pack/internal/commands/testmocks"
 	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/dist"
 	"github.com/buildpacks/pack/pkg/logging"
 	h "github.com/buildpacks/pack/testhelpers"
 )
@@ -47,6 +50,24 @@ const validConfigWithExtensions = `
 
 `
 
+const validConfigWithTargets = `
+[[buildpacks]]
+  id = "some.buildpack"
+
+[[order]]
+	[[order.group]]
+		id = "some.buildpack"
+
+[[targets]]
+  os = "linux"
+  arch = "amd64"
+
+[[targets]]
+  os = "linux"
+  arch = "arm64"
+
+`
+
 func TestCreateCommand(t *testing.T) {
 	color.Disable(true)
 	defer color.Disable(false)
@@ -194,6 +215,39 @@ func testCreateCommand(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("builder config has multiple targets", func() {
+			it.Before(func() {
+				h.AssertNil(t, os.WriteFile(builderConfigPath, []byte(validConfigWithTargets), 0666))
+			})
+
+			it("errors when --publish isn't set", func() {
+				command.SetArgs([]string{
+					"some/builder",
+					"--config", builderConfigPath,
+				})
+				h.AssertError(t, command.Execute(), "creating a multi-platform builder requires the publish flag")
+			})
+
+			it("passes the targets to the client", func() {
+				mockClient.EXPECT().
+					CreateBuilder(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, opts client.CreateBuilderOptions) error {
+						h.AssertEq(t, opts.Config.Targets, []dist.Target{
+							{OS: "linux", Arch: "amd64"},
+							{OS: "linux", Arch: "arm64"},
+						})
+						return nil
+					})
+
+				command.SetArgs([]string{
+					"some/builder",
+					"--config", builderConfigPath,
+					"--publish",
+				})
+				h.AssertNil(t, command.Execute())
+			})
+		})
+
 		when("flatten is set to true", func() {
 			it.Before(func() {
 				h.AssertNil(t, os.WriteFile(builderConfigPath, []byte(validConfig), 0666))
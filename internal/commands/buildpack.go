@@ -21,6 +21,8 @@ func NewBuildpackCommand(logger logging.Logger, cfg config.Config, client PackCl
 	cmd.AddCommand(BuildpackPull(logger, cfg, client))
 	cmd.AddCommand(BuildpackRegister(logger, cfg, client))
 	cmd.AddCommand(BuildpackYank(logger, cfg, client))
+	cmd.AddCommand(BuildpackSearch(logger, cfg, client))
+	cmd.AddCommand(BuildpackVersions(logger, cfg, client))
 
 	AddHelpFlag(cmd, "buildpack")
 	return cmd
@@ -24,6 +24,7 @@ type BuildpackPackageFlags struct {
 	BuildpackRegistry string
 	Path              string
 	FlattenExclude    []string
+	Targets           []string
 	Publish           bool
 	Flatten           bool
 	Depth             int
@@ -96,6 +97,11 @@ func BuildpackPackage(logger logging.Logger, cfg config.Config, packager Buildpa
 					logger.Warnf("%s is not a valid extension for a packaged buildpack. Packaged buildpacks must have a %s extension", style.Symbol(ext), style.Symbol(client.CNBExtension))
 				}
 			}
+			targets, err := parseTargets(flags.Targets)
+			if err != nil {
+				return err
+			}
+
 			if flags.Flatten {
 				logger.Warn("Flattening a buildpack package could break the distribution specification. Please use it with caution.")
 			}
@@ -111,6 +117,7 @@ func BuildpackPackage(logger logging.Logger, cfg config.Config, packager Buildpa
 				Flatten:         flags.Flatten,
 				FlattenExclude:  flags.FlattenExclude,
 				Depth:           flags.Depth,
+				Targets:         targets,
 			}); err != nil {
 				return err
 			}
@@ -137,6 +144,7 @@ func BuildpackPackage(logger logging.Logger, cfg config.Config, packager Buildpa
 	cmd.Flags().StringVarP(&flags.BuildpackRegistry, "buildpack-registry", "r", "", "Buildpack Registry name")
 	cmd.Flags().BoolVar(&flags.Flatten, "flatten", false, "Flatten the buildpack into a single layer")
 	cmd.Flags().StringSliceVarP(&flags.FlattenExclude, "flatten-exclude", "e", nil, "Buildpacks to exclude from flattening, in the form of '<buildpack-id>@<buildpack-version>'")
+	cmd.Flags().StringSliceVar(&flags.Targets, "target", nil, "Target platform to package the buildpack for, in the form '<os>/<arch>[/<variant>]'.\nPlatform specific files are taken from the '<os>/<arch>[/<variant>]' directory of the buildpack when present. When more than one target is provided, images are published as an image index (requires --publish) or saved as a single multi-platform file."+stringSliceHelp("target"))
 	cmd.Flags().IntVar(&flags.Depth, "depth", -1, "Max depth to flatten.\nOmission of this flag or values < 0 will flatten the entire tree.")
 	if !cfg.Experimental {
 		cmd.Flags().MarkHidden("flatten")
@@ -151,6 +159,9 @@ func validateBuildpackPackageFlags(cfg config.Config, p *BuildpackPackageFlags)
 	if p.Publish && p.Policy == image.PullNever.String() {
 		return errors.Errorf("--publish and --pull-policy never cannot be used together. The --publish flag requires the use of remote images.")
 	}
+	if len(p.Targets) > 1 && p.Format != client.FormatFile && !p.Publish {
+		return errors.Errorf("packaging for multiple targets requires either --publish or --format file")
+	}
 	if p.PackageTomlPath != "" && p.Path != "" {
 		return errors.Errorf("--config and --path cannot be used together. Please specify the relative path to the Buildpack directory in the package config file.")
 	}
@@ -162,6 +162,32 @@ func testPackageCommand(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 
+			when("--target", func() {
+				it("passes the targets to the packager", func() {
+					cmd := packageCommand(withBuildpackPackager(fakeBuildpackPackager))
+					cmd.SetArgs([]string{"some-image-name", "--target", "linux/amd64", "--target", "linux/arm/v7", "--publish"})
+					h.AssertNil(t, cmd.Execute())
+
+					receivedOptions := fakeBuildpackPackager.CreateCalledWithOptions
+					h.AssertEq(t, receivedOptions.Targets, []dist.Target{
+						{OS: "linux", Arch: "amd64"},
+						{OS: "linux", Arch: "arm", ArchVariant: "v7"},
+					})
+				})
+
+				it("errors for multiple targets without --publish or file format", func() {
+					cmd := packageCommand(withBuildpackPackager(fakeBuildpackPackager))
+					cmd.SetArgs([]string{"some-image-name", "--target", "linux/amd64", "--target", "linux/arm64"})
+					h.AssertError(t, cmd.Execute(), "packaging for multiple targets requires either --publish or --format file")
+				})
+
+				it("errors for an invalid target", func() {
+					cmd := packageCommand(withBuildpackPackager(fakeBuildpackPackager))
+					cmd.SetArgs([]string{"some-image-name", "--target", "linux//arm64"})
+					h.AssertError(t, cmd.Execute(), "invalid platform")
+				})
+			})
+
 			when("there is a path flag", func() {
 				it("returns an error saying that it cannot be used with the config flag", func() {
 					myConfig := pubbldpkg.Config{
@@ -1,6 +1,8 @@
 package commands
 
 import (
+	"os"
+
 	"github.com/spf13/cobra"
 
 	"github.com/buildpacks/pack/internal/config"
@@ -9,6 +11,9 @@ import (
 	"github.com/buildpacks/pack/pkg/logging"
 )
 
+// registryTokenEnv holds the token used to open issues in github registries through their REST API
+const registryTokenEnv = "PACK_REGISTRY_TOKEN"
+
 type BuildpackRegisterFlags struct {
 	BuildpackRegistry string
 }
@@ -18,9 +23,11 @@ func BuildpackRegister(logger logging.Logger, cfg config.Config, pack PackClient
 	var flags BuildpackRegisterFlags
 
 	cmd := &cobra.Command{
-		Use:     "register <image>",
-		Args:    cobra.ExactArgs(1),
-		Short:   "Register a buildpack to a registry",
+		Use:   "register <image>",
+		Args:  cobra.ExactArgs(1),
+		Short: "Register a buildpack to a registry",
+		Long: "Register a buildpack to a registry.\n\nFor github registries, the registration issue is opened in a browser, unless a token is set in the " +
+			registryTokenEnv + " environment variable, in which case the issue is created through the REST API of the registry.",
 		Example: "pack buildpack register my-buildpack",
 		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
 			registry, err := config.GetRegistry(cfg, flags.BuildpackRegistry)
@@ -31,6 +38,8 @@ func BuildpackRegister(logger logging.Logger, cfg config.Config, pack PackClient
 			opts.Type = registry.Type
 			opts.URL = registry.URL
 			opts.Name = registry.Name
+			opts.APIURL = registry.APIURL
+			opts.Token = os.Getenv(registryTokenEnv)
 
 			if err := pack.RegisterBuildpack(cmd.Context(), opts); err != nil {
 				return err
@@ -141,6 +141,34 @@ func testRegisterCommand(t *testing.T, when spec.G, it spec.S) {
 				cmd.SetArgs([]string{buildpackImage, "--buildpack-registry", buildpackRegistry})
 				h.AssertNil(t, cmd.Execute())
 			})
+
+			it("should pass the api url of the registry", func() {
+				cfg = config.Config{
+					DefaultRegistryName: "enterprise",
+					Registries: []config.Registry{
+						{
+							Name:   "enterprise",
+							Type:   "github",
+							URL:    "https://git.example.com/platform/buildpack-registry",
+							APIURL: "https://git.example.com/api/v3",
+						},
+					},
+				}
+				opts := client.RegisterBuildpackOptions{
+					ImageName: buildpackImage,
+					Type:      "github",
+					URL:       "https://git.example.com/platform/buildpack-registry",
+					Name:      "enterprise",
+					APIURL:    "https://git.example.com/api/v3",
+				}
+				mockClient.EXPECT().
+					RegisterBuildpack(gomock.Any(), opts).
+					Return(nil)
+
+				cmd = commands.BuildpackRegister(logger, cfg, mockClient)
+				cmd.SetArgs([]string{buildpackImage})
+				h.AssertNil(t, cmd.Execute())
+			})
 		})
 	})
 }
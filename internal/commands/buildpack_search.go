@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// BuildpackSearchFlags consist of flags applicable to the `buildpack search` command
+type BuildpackSearchFlags struct {
+	// BuildpackRegistry is the name of the buildpack registry to search
+	BuildpackRegistry string
+	OutputFormat      string
+}
+
+// BuildpackSearch searches the buildpacks of a registry
+func BuildpackSearch(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
+	var flags BuildpackSearchFlags
+
+	cmd := &cobra.Command{
+		Use:   "search [<query>]",
+		Args:  cobra.MaximumNArgs(1),
+		Short: "Search the buildpacks of a registry",
+		Long: "Search the buildpacks of a registry whose ID (<namespace>/<name>) contains the query, and show their latest version.\n\n" +
+			"Omitting the query lists every buildpack of the registry.",
+		Example: "pack buildpack search java",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if err := validateOutputFormat(flags.OutputFormat); err != nil {
+				return err
+			}
+
+			registry, err := config.GetRegistry(cfg, flags.BuildpackRegistry)
+			if err != nil {
+				return err
+			}
+
+			opts := client.SearchBuildpacksOptions{Registry: registry.Name}
+			if len(args) > 0 {
+				opts.Query = args[0]
+			}
+			buildpacks, err := pack.SearchBuildpacks(opts)
+			if err != nil {
+				return err
+			}
+
+			if flags.OutputFormat != "human-readable" {
+				return writeStructuredOutput(logger, flags.OutputFormat, buildpacks)
+			}
+
+			if len(buildpacks) == 0 {
+				logger.Info("No buildpacks found")
+				return nil
+			}
+			tw := tabwriter.NewWriter(logger.Writer(), writerMinWidth, writerTabWidth, defaultTabWidth, writerPadChar, writerFlags)
+			fmt.Fprint(tw, "ID\tLATEST VERSION\tVERSIONS\tADDRESS\n")
+			for _, bp := range buildpacks {
+				fmt.Fprintf(tw, "%s\t%s\t%d\t%s\n", bp.ID, bp.LatestVersion, bp.Versions, orNone(bp.Address))
+			}
+			return tw.Flush()
+		}),
+	}
+	cmd.Flags().StringVarP(&flags.BuildpackRegistry, "buildpack-registry", "r", "", "Buildpack Registry name")
+	cmd.Flags().StringVarP(&flags.OutputFormat, "output", "o", "human-readable", "Output format to display the buildpacks (json, yaml, human-readable).\nOmission of this flag will display as human-readable.")
+	AddHelpFlag(cmd, "search")
+	return cmd
+}
@@ -0,0 +1,121 @@
+package commands_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestBuildpackSearchCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "BuildpackSearchCommand", testBuildpackSearchCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testBuildpackSearchCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		logger         logging.Logger
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+		cfg            config.Config
+		buildpacks     []client.RegistryBuildpack
+	)
+
+	it.Before(func() {
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		cfg = config.Config{
+			Registries: []config.Registry{{Name: "some-registry", Type: "http", URL: "https://registry.example.com"}},
+		}
+
+		command = commands.BuildpackSearch(logger, cfg, mockClient)
+
+		buildpacks = []client.RegistryBuildpack{
+			{ID: "example/java", LatestVersion: "1.2.0", Address: "example.com/java@sha256:abc", Versions: 3},
+			{ID: "example/javascript", LatestVersion: "0.1.0", Address: "example.com/javascript@sha256:def", Versions: 1},
+		}
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#BuildpackSearch", func() {
+		it("shows the matching buildpacks", func() {
+			mockClient.EXPECT().
+				SearchBuildpacks(client.SearchBuildpacksOptions{Query: "java", Registry: "official"}).
+				Return(buildpacks, nil)
+
+			command.SetArgs([]string{"java"})
+			h.AssertNil(t, command.Execute())
+
+			output := outBuf.String()
+			h.AssertContainsMatch(t, output, `ID\s+LATEST VERSION\s+VERSIONS\s+ADDRESS`)
+			h.AssertContainsMatch(t, output, `example/java\s+1.2.0\s+3\s+example.com/java@sha256:abc`)
+			h.AssertContainsMatch(t, output, `example/javascript\s+0.1.0\s+1\s+example.com/javascript@sha256:def`)
+		})
+
+		it("searches the given registry", func() {
+			mockClient.EXPECT().
+				SearchBuildpacks(client.SearchBuildpacksOptions{Registry: "some-registry"}).
+				Return([]client.RegistryBuildpack{}, nil)
+
+			command.SetArgs([]string{"--buildpack-registry", "some-registry"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "No buildpacks found")
+		})
+
+		it("outputs the buildpacks as json", func() {
+			mockClient.EXPECT().SearchBuildpacks(gomock.Any()).Return(buildpacks, nil)
+
+			command.SetArgs([]string{"java", "-o", "json"})
+			h.AssertNil(t, command.Execute())
+
+			var decoded []client.RegistryBuildpack
+			h.AssertNil(t, json.Unmarshal(outBuf.Bytes(), &decoded))
+			h.AssertEq(t, decoded, buildpacks)
+		})
+
+		it("outputs the buildpacks as yaml", func() {
+			mockClient.EXPECT().SearchBuildpacks(gomock.Any()).Return(buildpacks, nil)
+
+			command.SetArgs([]string{"java", "-o", "yaml"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "- id: example/java\n  latestVersion: 1.2.0")
+		})
+
+		it("errors on unsupported output formats", func() {
+			command.SetArgs([]string{"java", "-o", "toml"})
+			h.AssertError(t, command.Execute(), "output format 'toml' is not supported")
+		})
+
+		it("errors when the registry is not defined", func() {
+			command.SetArgs([]string{"java", "--buildpack-registry", "other-registry"})
+			h.AssertError(t, command.Execute(), "registry 'other-registry' is not defined in your config file")
+		})
+
+		it("errors when the search fails", func() {
+			mockClient.EXPECT().SearchBuildpacks(gomock.Any()).Return(nil, errors.New("refreshing cache"))
+
+			command.SetArgs([]string{"java"})
+			h.AssertError(t, command.Execute(), "refreshing cache")
+		})
+	})
+}
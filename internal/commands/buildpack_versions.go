@@ -0,0 +1,62 @@
+package commands
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// BuildpackVersionsFlags consist of flags applicable to the `buildpack versions` command
+type BuildpackVersionsFlags struct {
+	// BuildpackRegistry is the name of the buildpack registry to read
+	BuildpackRegistry string
+	OutputFormat      string
+}
+
+// BuildpackVersions lists the versions of a buildpack in a registry
+func BuildpackVersions(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
+	var flags BuildpackVersionsFlags
+
+	cmd := &cobra.Command{
+		Use:     "versions <id>",
+		Args:    cobra.ExactArgs(1),
+		Short:   "List the versions of a buildpack in a registry",
+		Long:    "List every version of a buildpack in a registry, from newest to oldest, with its yanked status and address.",
+		Example: "pack buildpack versions example/my-buildpack",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if err := validateOutputFormat(flags.OutputFormat); err != nil {
+				return err
+			}
+
+			registry, err := config.GetRegistry(cfg, flags.BuildpackRegistry)
+			if err != nil {
+				return err
+			}
+
+			versions, err := pack.ListBuildpackVersions(client.ListBuildpackVersionsOptions{ID: args[0], Registry: registry.Name})
+			if err != nil {
+				return err
+			}
+
+			if flags.OutputFormat != "human-readable" {
+				return writeStructuredOutput(logger, flags.OutputFormat, versions)
+			}
+
+			tw := tabwriter.NewWriter(logger.Writer(), writerMinWidth, writerTabWidth, defaultTabWidth, writerPadChar, writerFlags)
+			fmt.Fprint(tw, "VERSION\tYANKED\tADDRESS\n")
+			for _, version := range versions {
+				fmt.Fprintf(tw, "%s\t%t\t%s\n", version.Version, version.Yanked, orNone(version.Address))
+			}
+			return tw.Flush()
+		}),
+	}
+	cmd.Flags().StringVarP(&flags.BuildpackRegistry, "buildpack-registry", "r", "", "Buildpack Registry name")
+	cmd.Flags().StringVarP(&flags.OutputFormat, "output", "o", "human-readable", "Output format to display the versions (json, yaml, human-readable).\nOmission of this flag will display as human-readable.")
+	AddHelpFlag(cmd, "versions")
+	return cmd
+}
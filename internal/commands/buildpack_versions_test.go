@@ -0,0 +1,90 @@
+package commands_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestBuildpackVersionsCommand(t *testing.T) {
+	spec.Run(t, "BuildpackVersionsCommand", testBuildpackVersionsCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testBuildpackVersionsCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		logger         logging.Logger
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+		versions       []client.RegistryBuildpackVersion
+	)
+
+	it.Before(func() {
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+
+		command = commands.BuildpackVersions(logger, config.Config{}, mockClient)
+
+		versions = []client.RegistryBuildpackVersion{
+			{ID: "example/java", Version: "1.3.0", Yanked: true},
+			{ID: "example/java", Version: "1.2.0", Address: "example.com/java@sha256:abc"},
+		}
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#BuildpackVersions", func() {
+		it("fails without a buildpack id", func() {
+			h.AssertError(t, command.Execute(), "accepts 1 arg")
+		})
+
+		it("shows the versions with their yanked status", func() {
+			mockClient.EXPECT().
+				ListBuildpackVersions(client.ListBuildpackVersionsOptions{ID: "example/java", Registry: "official"}).
+				Return(versions, nil)
+
+			command.SetArgs([]string{"example/java"})
+			h.AssertNil(t, command.Execute())
+
+			output := outBuf.String()
+			h.AssertContainsMatch(t, output, `VERSION\s+YANKED\s+ADDRESS`)
+			h.AssertContainsMatch(t, output, `1.3.0\s+true\s+-`)
+			h.AssertContainsMatch(t, output, `1.2.0\s+false\s+example.com/java@sha256:abc`)
+		})
+
+		it("outputs the versions as json", func() {
+			mockClient.EXPECT().ListBuildpackVersions(gomock.Any()).Return(versions, nil)
+
+			command.SetArgs([]string{"example/java", "-o", "json"})
+			h.AssertNil(t, command.Execute())
+
+			var decoded []client.RegistryBuildpackVersion
+			h.AssertNil(t, json.Unmarshal(outBuf.Bytes(), &decoded))
+			h.AssertEq(t, decoded, versions)
+		})
+
+		it("errors when the versions cannot be listed", func() {
+			mockClient.EXPECT().ListBuildpackVersions(gomock.Any()).Return(nil, errors.New("listing versions of 'example/java'"))
+
+			command.SetArgs([]string{"example/java"})
+			h.AssertError(t, command.Execute(), "listing versions of 'example/java'")
+		})
+	})
+}
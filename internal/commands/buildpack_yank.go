@@ -2,6 +2,7 @@ package commands
 
 import (
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -21,9 +22,11 @@ func BuildpackYank(logger logging.Logger, cfg config.Config, pack PackClient) *c
 	var flags BuildpackYankFlags
 
 	cmd := &cobra.Command{
-		Use:     "yank <buildpack-id-and-version>",
-		Args:    cobra.ExactArgs(1),
-		Short:   "Yank a buildpack from a registry",
+		Use:   "yank <buildpack-id-and-version>",
+		Args:  cobra.ExactArgs(1),
+		Short: "Yank a buildpack from a registry",
+		Long: "Yank a buildpack from a registry.\n\nThe yank issue is opened in a browser, unless a token is set in the " +
+			registryTokenEnv + " environment variable, in which case the issue is created through the REST API of the registry.",
 		Example: "pack yank my-buildpack@0.0.1",
 		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
 			buildpackIDVersion := args[0]
@@ -40,9 +43,11 @@ func BuildpackYank(logger logging.Logger, cfg config.Config, pack PackClient) *c
 			opts := client.YankBuildpackOptions{
 				ID:      id,
 				Version: version,
-				Type:    "github",
+				Type:    registry.Type,
 				URL:     registry.URL,
 				Yank:    !flags.Undo,
+				Token:   os.Getenv(registryTokenEnv),
+				APIURL:  registry.APIURL,
 			}
 
 			if err := pack.YankBuildpack(opts); err != nil {
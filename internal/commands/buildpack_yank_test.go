@@ -162,6 +162,32 @@ func testYankCommand(t *testing.T, when spec.G, it spec.S) {
 					h.AssertNil(t, cmd.Execute())
 				})
 
+				it("should pass the type of the buildpack registry", func() {
+					cfg = config.Config{
+						Registries: []config.Registry{
+							{
+								Name: "internal",
+								Type: "http",
+								URL:  "https://registry.example.com/index",
+							},
+						},
+					}
+					opts := client.YankBuildpackOptions{
+						ID:      "heroku/rust",
+						Version: "0.0.1",
+						Type:    "http",
+						URL:     "https://registry.example.com/index",
+						Yank:    true,
+					}
+					mockClient.EXPECT().
+						YankBuildpack(opts).
+						Return(nil)
+
+					cmd = commands.BuildpackYank(logger, cfg, mockClient)
+					cmd.SetArgs([]string{buildpackIDVersion, "--buildpack-registry", "internal"})
+					h.AssertNil(t, cmd.Execute())
+				})
+
 				it("should handle config errors", func() {
 					cfg = config.Config{
 						DefaultRegistryName: "missing registry",
@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+func NewCacheCommand(logger logging.Logger, cfg config.Config, client PackClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "cache",
+		Aliases: []string{"caches"},
+		Short:   "Interact with build caches",
+		Long: "Build caches keep the layers of previous builds to speed up later builds.\n\n" +
+			"pack keeps track of the volume, bind and image caches used by builds, so that they can be listed with `pack cache ls` and cleaned up with `pack cache rm` or `pack cache prune`.\n\n" +
+			"Volume and bind caches can be moved between machines with `pack cache export` and `pack cache import`.",
+		RunE: nil,
+	}
+
+	cmd.AddCommand(CacheList(logger, client))
+	cmd.AddCommand(CacheInspect(logger, client))
+	cmd.AddCommand(CacheRemove(logger, client))
+	cmd.AddCommand(CachePrune(logger, client))
+	cmd.AddCommand(CacheExport(logger, cfg, client))
+	cmd.AddCommand(CacheImport(logger, cfg, client))
+
+	AddHelpFlag(cmd, "cache")
+	return cmd
+}
+
+func formatCacheSize(buildCache client.BuildCache) string {
+	if buildCache.Size < 0 {
+		return "N/A"
+	}
+	return humanize.Bytes(uint64(buildCache.Size))
+}
+
+func formatCacheLastUsed(buildCache client.BuildCache) string {
+	if buildCache.LastUsed.IsZero() {
+		return "N/A"
+	}
+	return humanize.Time(buildCache.LastUsed)
+}
+
+func formatCacheAppImage(buildCache client.BuildCache) string {
+	if buildCache.AppImage == "" {
+		return "<unknown>"
+	}
+	return buildCache.AppImage
+}
@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+func CacheExport(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
+	var policy string
+
+	cmd := &cobra.Command{
+		Use:     "export <cache-name> <archive-path>",
+		Args:    cobra.ExactArgs(2),
+		Short:   "Export a build cache to an OCI layout archive",
+		Example: "pack cache export pack-cache-library_my-app_latest-6c5a8a4d4b7e.build my-app-cache.tar",
+		Long: "Export the contents of a volume or bind cache to an OCI layout archive, which can be restored on another machine with `pack cache import`.\n\n" +
+			"Image caches are already stored in a registry and cannot be exported.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			pullPolicy, err := parseCachePullPolicy(policy, cfg)
+			if err != nil {
+				return err
+			}
+
+			if err := pack.ExportCache(cmd.Context(), client.ExportCacheOptions{
+				Name:        args[0],
+				Path:        args[1],
+				HelperImage: cfg.LifecycleImage,
+				PullPolicy:  pullPolicy,
+			}); err != nil {
+				return err
+			}
+
+			logger.Infof("Exported cache %s to %s", style.Symbol(args[0]), style.Symbol(args[1]))
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVar(&policy, "pull-policy", "", "Pull policy of the image used to read volume caches. Accepted values are always, never, and if-not-present. The default is if-not-present")
+	AddHelpFlag(cmd, "export")
+	return cmd
+}
+
+// parseCachePullPolicy parses the pull policy of the helper image used to access volume caches, which is only
+// pulled when missing by default
+func parseCachePullPolicy(policy string, cfg config.Config) (image.PullPolicy, error) {
+	if policy == "" {
+		policy = cfg.PullPolicy
+	}
+	if policy == "" {
+		policy = "if-not-present"
+	}
+
+	pullPolicy, err := image.ParsePullPolicy(policy)
+	if err != nil {
+		return pullPolicy, errors.Wrapf(err, "parsing pull policy %s", policy)
+	}
+	return pullPolicy, nil
+}
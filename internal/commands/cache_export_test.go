@@ -0,0 +1,79 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestCacheExportCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "CacheExportCommand", testCacheExportCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testCacheExportCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		logger         logging.Logger
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#CacheExport", func() {
+		it("exports the cache", func() {
+			mockClient.EXPECT().ExportCache(gomock.Any(), client.ExportCacheOptions{
+				Name:       "some-cache",
+				Path:       "some-cache.tar",
+				PullPolicy: image.PullIfNotPresent,
+			}).Return(nil)
+
+			command := commands.CacheExport(logger, config.Config{}, mockClient)
+			command.SetArgs([]string{"some-cache", "some-cache.tar"})
+			h.AssertNil(t, command.Execute())
+
+			h.AssertContains(t, outBuf.String(), "Exported cache 'some-cache' to 'some-cache.tar'")
+		})
+
+		it("uses the configured lifecycle image and pull policy", func() {
+			mockClient.EXPECT().ExportCache(gomock.Any(), client.ExportCacheOptions{
+				Name:        "some-cache",
+				Path:        "some-cache.tar",
+				HelperImage: "some/lifecycle",
+				PullPolicy:  image.PullNever,
+			}).Return(nil)
+
+			command := commands.CacheExport(logger, config.Config{LifecycleImage: "some/lifecycle", PullPolicy: "never"}, mockClient)
+			command.SetArgs([]string{"some-cache", "some-cache.tar"})
+			h.AssertNil(t, command.Execute())
+		})
+
+		it("errors on an invalid pull policy", func() {
+			command := commands.CacheExport(logger, config.Config{}, mockClient)
+			command.SetArgs([]string{"some-cache", "some-cache.tar", "--pull-policy", "sometimes"})
+			h.AssertError(t, command.Execute(), "parsing pull policy sometimes")
+		})
+	})
+}
@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/cache"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type CacheImportFlags struct {
+	Volume     string
+	Bind       string
+	PullPolicy string
+}
+
+func CacheImport(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
+	var flags CacheImportFlags
+
+	cmd := &cobra.Command{
+		Use:     "import <archive-path>",
+		Args:    cobra.ExactArgs(1),
+		Short:   "Restore a build cache from an OCI layout archive",
+		Example: "pack cache import my-app-cache.tar",
+		Long: "Restore a build cache exported with `pack cache export`.\n\n" +
+			"By default, the cache is restored to the volume used by builds of the app image it was exported from, so that the next `pack build` of that image starts from the restored cache. " +
+			"A cache restored with --volume or --bind is used by passing it to `pack build` with the --cache flag, for example --cache 'type=build;format=volume;source=<volume>'.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if flags.Volume != "" && flags.Bind != "" {
+				return errors.New("--volume and --bind cannot be used together")
+			}
+
+			pullPolicy, err := parseCachePullPolicy(flags.PullPolicy, cfg)
+			if err != nil {
+				return err
+			}
+
+			buildCache, err := pack.ImportCache(cmd.Context(), client.ImportCacheOptions{
+				Path:        args[0],
+				Volume:      flags.Volume,
+				Bind:        flags.Bind,
+				HelperImage: cfg.LifecycleImage,
+				PullPolicy:  pullPolicy,
+			})
+			if err != nil {
+				return err
+			}
+
+			logger.Infof("Restored %s cache %s", buildCache.Format, style.Symbol(buildCache.Name))
+			if buildCache.Format == cache.CacheVolume && flags.Volume == "" {
+				logger.Infof("It will be used by the next build of %s", style.Symbol(buildCache.AppImage))
+			} else {
+				logger.Infof("Use it with --cache 'type=%s;format=%s;source=%s'", buildCache.Type, buildCache.Format, buildCache.Name)
+			}
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVar(&flags.Volume, "volume", "", "Name of the volume to restore the cache to\nDefaults to the volume used by builds of the app image the cache was exported from")
+	cmd.Flags().StringVar(&flags.Bind, "bind", "", "Path of an empty directory to restore the cache to, instead of a volume")
+	cmd.Flags().StringVar(&flags.PullPolicy, "pull-policy", "", "Pull policy of the image used to write volume caches. Accepted values are always, never, and if-not-present. The default is if-not-present")
+	AddHelpFlag(cmd, "import")
+	return cmd
+}
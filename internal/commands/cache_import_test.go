@@ -0,0 +1,98 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/cache"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestCacheImportCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "CacheImportCommand", testCacheImportCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testCacheImportCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+		command = commands.CacheImport(logger, config.Config{}, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#CacheImport", func() {
+		it("restores the cache for the app image", func() {
+			mockClient.EXPECT().ImportCache(gomock.Any(), client.ImportCacheOptions{
+				Path:       "some-cache.tar",
+				PullPolicy: image.PullIfNotPresent,
+			}).Return(client.BuildCache{
+				Name:     "pack-cache-some_app_latest-123.build",
+				Format:   cache.CacheVolume,
+				Type:     "build",
+				AppImage: "index.docker.io/some/app:latest",
+			}, nil)
+
+			command.SetArgs([]string{"some-cache.tar"})
+			h.AssertNil(t, command.Execute())
+
+			h.AssertContains(t, outBuf.String(), "Restored volume cache 'pack-cache-some_app_latest-123.build'")
+			h.AssertContains(t, outBuf.String(), "It will be used by the next build of 'index.docker.io/some/app:latest'")
+		})
+
+		it("restores the cache to the given volume", func() {
+			mockClient.EXPECT().ImportCache(gomock.Any(), client.ImportCacheOptions{
+				Path:       "some-cache.tar",
+				Volume:     "some-volume",
+				PullPolicy: image.PullIfNotPresent,
+			}).Return(client.BuildCache{Name: "some-volume", Format: cache.CacheVolume, Type: "build"}, nil)
+
+			command.SetArgs([]string{"some-cache.tar", "--volume", "some-volume"})
+			h.AssertNil(t, command.Execute())
+
+			h.AssertContains(t, outBuf.String(), "Use it with --cache 'type=build;format=volume;source=some-volume'")
+		})
+
+		it("restores the cache to the given directory", func() {
+			mockClient.EXPECT().ImportCache(gomock.Any(), client.ImportCacheOptions{
+				Path:       "some-cache.tar",
+				Bind:       "/some/dir",
+				PullPolicy: image.PullIfNotPresent,
+			}).Return(client.BuildCache{Name: "/some/dir", Format: cache.CacheBind, Type: "build"}, nil)
+
+			command.SetArgs([]string{"some-cache.tar", "--bind", "/some/dir"})
+			h.AssertNil(t, command.Execute())
+
+			h.AssertContains(t, outBuf.String(), "Use it with --cache 'type=build;format=bind;source=/some/dir'")
+		})
+
+		it("errors when both a volume and a directory are given", func() {
+			command.SetArgs([]string{"some-cache.tar", "--volume", "some-volume", "--bind", "/some/dir"})
+			h.AssertError(t, command.Execute(), "--volume and --bind cannot be used together")
+		})
+	})
+}
@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+func CacheInspect(logger logging.Logger, pack PackClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "inspect <cache-name>",
+		Args:    cobra.ExactArgs(1),
+		Short:   "Show information about a build cache",
+		Example: "pack cache inspect pack-cache-library_my-app_latest-6c5a8a4d4b7e.build",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			buildCache, err := pack.InspectCache(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+
+			lastUsed := formatCacheLastUsed(buildCache)
+			if !buildCache.LastUsed.IsZero() {
+				lastUsed = fmt.Sprintf("%s (%s)", buildCache.LastUsed.Local().Format(time.RFC3339), lastUsed)
+			}
+
+			tw := tabwriter.NewWriter(logger.Writer(), writerMinWidth, writerTabWidth, defaultTabWidth, writerPadChar, writerFlags)
+			fmt.Fprintf(tw, "Name:\t%s\n", buildCache.Name)
+			fmt.Fprintf(tw, "Format:\t%s\n", buildCache.Format)
+			fmt.Fprintf(tw, "Type:\t%s\n", buildCache.Type)
+			fmt.Fprintf(tw, "App Image:\t%s\n", formatCacheAppImage(buildCache))
+			fmt.Fprintf(tw, "Size:\t%s\n", formatCacheSize(buildCache))
+			fmt.Fprintf(tw, "Last Used:\t%s\n", lastUsed)
+			return tw.Flush()
+		}),
+	}
+
+	AddHelpFlag(cmd, "inspect")
+	return cmd
+}
@@ -0,0 +1,79 @@
+package commands_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/pkg/cache"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestCacheInspectCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "CacheInspectCommand", testCacheInspectCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testCacheInspectCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+		command = commands.CacheInspect(logger, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#CacheInspect", func() {
+		it("shows the cache", func() {
+			lastUsed := time.Now().Add(-time.Hour)
+			mockClient.EXPECT().InspectCache(gomock.Any(), "pack-cache-some_app_latest-123.build").Return(client.BuildCache{
+				Name:     "pack-cache-some_app_latest-123.build",
+				Format:   cache.CacheVolume,
+				Type:     "build",
+				AppImage: "index.docker.io/some/app:latest",
+				Size:     1500,
+				LastUsed: lastUsed,
+			}, nil)
+
+			command.SetArgs([]string{"pack-cache-some_app_latest-123.build"})
+			h.AssertNil(t, command.Execute())
+
+			output := outBuf.String()
+			h.AssertContainsMatch(t, output, `Name:\s+pack-cache-some_app_latest-123.build`)
+			h.AssertContainsMatch(t, output, `Format:\s+volume`)
+			h.AssertContainsMatch(t, output, `Type:\s+build`)
+			h.AssertContainsMatch(t, output, `App Image:\s+index.docker.io/some/app:latest`)
+			h.AssertContainsMatch(t, output, `Size:\s+1.5 kB`)
+			h.AssertContains(t, output, lastUsed.Local().Format(time.RFC3339)+" (1 hour ago)")
+		})
+
+		it("errors when the cache cannot be found", func() {
+			mockClient.EXPECT().InspectCache(gomock.Any(), "some-cache").Return(client.BuildCache{}, errors.New("cache 'some-cache' not found"))
+
+			command.SetArgs([]string{"some-cache"})
+			h.AssertError(t, command.Execute(), "cache 'some-cache' not found")
+		})
+	})
+}
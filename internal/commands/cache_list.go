@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+func CacheList(logger logging.Logger, pack PackClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "ls",
+		Aliases: []string{"list"},
+		Args:    cobra.NoArgs,
+		Short:   "List build caches",
+		Example: "pack cache ls",
+		Long: "List the volume caches created by pack, along with the bind and image caches used by builds.\n\n" +
+			"The size of image caches is not shown, as they are stored in a registry.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			caches, err := pack.ListCaches(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			if len(caches) == 0 {
+				logger.Info("No build caches found")
+				return nil
+			}
+
+			tw := tabwriter.NewWriter(logger.Writer(), writerMinWidth, writerTabWidth, defaultTabWidth, writerPadChar, writerFlags)
+			fmt.Fprintln(tw, "NAME\tFORMAT\tTYPE\tAPP IMAGE\tSIZE\tLAST USED")
+			for _, buildCache := range caches {
+				fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+					buildCache.Name,
+					buildCache.Format,
+					buildCache.Type,
+					formatCacheAppImage(buildCache),
+					formatCacheSize(buildCache),
+					formatCacheLastUsed(buildCache),
+				)
+			}
+			return tw.Flush()
+		}),
+	}
+
+	AddHelpFlag(cmd, "ls")
+	return cmd
+}
@@ -0,0 +1,74 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/pkg/cache"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestCacheListCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "CacheListCommand", testCacheListCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testCacheListCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+		command = commands.CacheList(logger, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#CacheList", func() {
+		it("lists the caches", func() {
+			mockClient.EXPECT().ListCaches(gomock.Any()).Return([]client.BuildCache{
+				{Name: "/some/bind", Format: cache.CacheBind, Type: "build", AppImage: "index.docker.io/some/app:latest", Size: 2 * 1000 * 1000, LastUsed: time.Now().Add(-49 * time.Hour)},
+				{Name: "pack-cache-some_app_latest-123.launch", Format: cache.CacheVolume, Type: "launch", Size: -1},
+				{Name: "registry.example.com/some/cache", Format: cache.CacheImage, Type: "build", AppImage: "registry.example.com/some/app:latest", Size: -1, LastUsed: time.Now().Add(-3 * time.Hour)},
+			}, nil)
+
+			command.SetArgs([]string{})
+			h.AssertNil(t, command.Execute())
+
+			output := outBuf.String()
+			h.AssertContainsMatch(t, output, `NAME\s+FORMAT\s+TYPE\s+APP IMAGE\s+SIZE\s+LAST USED`)
+			h.AssertContainsMatch(t, output, `/some/bind\s+bind\s+build\s+index.docker.io/some/app:latest\s+2.0 MB\s+2 days ago`)
+			h.AssertContainsMatch(t, output, `pack-cache-some_app_latest-123.launch\s+volume\s+launch\s+<unknown>\s+N/A\s+N/A`)
+			h.AssertContainsMatch(t, output, `registry.example.com/some/cache\s+image\s+build\s+registry.example.com/some/app:latest\s+N/A\s+3 hours ago`)
+		})
+
+		it("reports when there are no caches", func() {
+			mockClient.EXPECT().ListCaches(gomock.Any()).Return(nil, nil)
+
+			command.SetArgs([]string{})
+			h.AssertNil(t, command.Execute())
+
+			h.AssertContains(t, outBuf.String(), "No build caches found")
+		})
+	})
+}
@@ -0,0 +1,120 @@
+package commands
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type CachePruneFlags struct {
+	OlderThan   string
+	MaxSize     string
+	DryRun      bool
+	IncludeBind bool
+}
+
+func CachePrune(logger logging.Logger, pack PackClient) *cobra.Command {
+	var flags CachePruneFlags
+
+	cmd := &cobra.Command{
+		Use:     "prune",
+		Args:    cobra.NoArgs,
+		Short:   "Remove build caches by age or total size",
+		Example: "pack cache prune --older-than 30d --max-size 10GB",
+		Long: "Remove the volume caches that were not used recently, or the least recently used caches until the total size of the remaining caches fits the given budget.\n\n" +
+			"Bind caches are only pruned with --include-bind, in which case the contents of their directories are removed but not the directories themselves. " +
+			"Image caches are never pruned, remove them with `pack cache rm`.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			opts, err := parseCachePruneFlags(flags)
+			if err != nil {
+				return err
+			}
+
+			pruned, err := pack.PruneCaches(cmd.Context(), opts)
+			if err != nil {
+				return err
+			}
+
+			if len(pruned) == 0 {
+				logger.Info("No build caches to remove")
+				return nil
+			}
+
+			var reclaimed int64
+			verb := "Removed"
+			if flags.DryRun {
+				verb = "Would remove"
+			}
+			for _, buildCache := range pruned {
+				logger.Infof("%s cache %s (%s, last used %s)", verb, style.Symbol(buildCache.Name), formatCacheSize(buildCache), formatCacheLastUsed(buildCache))
+				if buildCache.Size > 0 {
+					reclaimed += buildCache.Size
+				}
+			}
+			logger.Infof("Total reclaimed space: %s", humanize.Bytes(uint64(reclaimed)))
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVar(&flags.OlderThan, "older-than", "", "Remove caches last used longer ago than this duration, such as 36h or 30d")
+	cmd.Flags().StringVar(&flags.MaxSize, "max-size", "", "Remove the least recently used caches until the total size of the remaining caches is at most this size, such as 500MB or 10GB")
+	cmd.Flags().BoolVar(&flags.DryRun, "dry-run", false, "Show the caches that would be removed without removing them")
+	cmd.Flags().BoolVar(&flags.IncludeBind, "include-bind", false, "Also prune bind caches, removing the contents of their directories")
+	AddHelpFlag(cmd, "prune")
+	return cmd
+}
+
+func parseCachePruneFlags(flags CachePruneFlags) (client.PruneCachesOptions, error) {
+	opts := client.PruneCachesOptions{DryRun: flags.DryRun, IncludeBind: flags.IncludeBind}
+	if flags.OlderThan == "" && flags.MaxSize == "" {
+		return opts, errors.New("at least one of --older-than or --max-size must be set")
+	}
+
+	if flags.OlderThan != "" {
+		olderThan, err := parseCacheAge(flags.OlderThan)
+		if err != nil {
+			return opts, errors.Errorf("invalid --older-than value %s", style.Symbol(flags.OlderThan))
+		}
+		opts.OlderThan = olderThan
+	}
+
+	if flags.MaxSize != "" {
+		maxSize, err := humanize.ParseBytes(flags.MaxSize)
+		if err != nil || maxSize == 0 {
+			return opts, errors.Errorf("invalid --max-size value %s", style.Symbol(flags.MaxSize))
+		}
+		opts.MaxSize = int64(maxSize)
+	}
+
+	return opts, nil
+}
+
+// parseCacheAge parses a duration, which may also be given in days with a 'd' suffix
+func parseCacheAge(value string) (time.Duration, error) {
+	var (
+		age time.Duration
+		err error
+	)
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		var n int
+		n, err = strconv.Atoi(days)
+		age = time.Duration(n) * 24 * time.Hour
+	} else {
+		age, err = time.ParseDuration(value)
+	}
+	if err != nil {
+		return 0, err
+	}
+	if age <= 0 {
+		return 0, errors.New("age must be positive")
+	}
+	return age, nil
+}
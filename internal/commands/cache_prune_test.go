@@ -0,0 +1,97 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/pkg/cache"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestCachePruneCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "CachePruneCommand", testCachePruneCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testCachePruneCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+		pruned         []client.BuildCache
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+		command = commands.CachePrune(logger, mockClient)
+		pruned = []client.BuildCache{
+			{Name: "pack-cache-some_app_latest-123.build", Format: cache.CacheVolume, Type: "build", Size: 3000, LastUsed: time.Now().Add(-50 * 24 * time.Hour)},
+			{Name: "/some/bind", Format: cache.CacheBind, Type: "build", Size: 2000, LastUsed: time.Now().Add(-40 * 24 * time.Hour)},
+		}
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#CachePrune", func() {
+		it("removes caches older than the given number of days", func() {
+			mockClient.EXPECT().PruneCaches(gomock.Any(), client.PruneCachesOptions{OlderThan: 30 * 24 * time.Hour, IncludeBind: true}).Return(pruned, nil)
+
+			command.SetArgs([]string{"--older-than", "30d", "--include-bind"})
+			h.AssertNil(t, command.Execute())
+
+			h.AssertContains(t, outBuf.String(), "Removed cache 'pack-cache-some_app_latest-123.build' (3.0 kB, last used 1 month ago)")
+			h.AssertContains(t, outBuf.String(), "Removed cache '/some/bind' (2.0 kB")
+			h.AssertContains(t, outBuf.String(), "Total reclaimed space: 5.0 kB")
+		})
+
+		it("removes caches to fit a size budget", func() {
+			mockClient.EXPECT().PruneCaches(gomock.Any(), client.PruneCachesOptions{OlderThan: 36 * time.Hour, MaxSize: 10 * 1000 * 1000 * 1000}).Return(nil, nil)
+
+			command.SetArgs([]string{"--older-than", "36h", "--max-size", "10GB"})
+			h.AssertNil(t, command.Execute())
+
+			h.AssertContains(t, outBuf.String(), "No build caches to remove")
+		})
+
+		it("reports the caches that would be removed on a dry run", func() {
+			mockClient.EXPECT().PruneCaches(gomock.Any(), client.PruneCachesOptions{MaxSize: 500 * 1000 * 1000, DryRun: true}).Return(pruned, nil)
+
+			command.SetArgs([]string{"--max-size", "500MB", "--dry-run"})
+			h.AssertNil(t, command.Execute())
+
+			h.AssertContains(t, outBuf.String(), "Would remove cache 'pack-cache-some_app_latest-123.build'")
+		})
+
+		it("requires an age or a size budget", func() {
+			command.SetArgs([]string{})
+			h.AssertError(t, command.Execute(), "at least one of --older-than or --max-size must be set")
+		})
+
+		it("errors on an invalid age", func() {
+			command.SetArgs([]string{"--older-than", "some-age"})
+			h.AssertError(t, command.Execute(), "invalid --older-than value 'some-age'")
+		})
+
+		it("errors on an invalid size", func() {
+			command.SetArgs([]string{"--max-size", "some-size"})
+			h.AssertError(t, command.Execute(), "invalid --max-size value 'some-size'")
+		})
+	})
+}
@@ -0,0 +1,40 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+func CacheRemove(logger logging.Logger, pack PackClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "rm <cache-name> [<cache-name>...]",
+		Aliases: []string{"remove"},
+		Args:    cobra.MinimumNArgs(1),
+		Short:   "Remove build caches",
+		Example: "pack cache rm pack-cache-library_my-app_latest-6c5a8a4d4b7e.build",
+		Long: "Remove build caches by name. Volume caches are removed from the daemon, bind caches from the file system, " +
+			"and image caches are deleted from their registry.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			failed := false
+			for _, name := range args {
+				if err := pack.RemoveCache(cmd.Context(), name); err != nil {
+					logger.Error(err.Error())
+					failed = true
+					continue
+				}
+				logger.Infof("Removed cache %s", style.Symbol(name))
+			}
+
+			if failed {
+				return client.NewSoftError()
+			}
+			return nil
+		}),
+	}
+
+	AddHelpFlag(cmd, "rm")
+	return cmd
+}
@@ -0,0 +1,73 @@
+package commands_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestCacheRemoveCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "CacheRemoveCommand", testCacheRemoveCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testCacheRemoveCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+		command = commands.CacheRemove(logger, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#CacheRemove", func() {
+		it("removes each cache", func() {
+			mockClient.EXPECT().RemoveCache(gomock.Any(), "some-cache").Return(nil)
+			mockClient.EXPECT().RemoveCache(gomock.Any(), "other-cache").Return(nil)
+
+			command.SetArgs([]string{"some-cache", "other-cache"})
+			h.AssertNil(t, command.Execute())
+
+			h.AssertContains(t, outBuf.String(), "Removed cache 'some-cache'")
+			h.AssertContains(t, outBuf.String(), "Removed cache 'other-cache'")
+		})
+
+		it("removes the other caches when one cannot be removed", func() {
+			mockClient.EXPECT().RemoveCache(gomock.Any(), "some-cache").Return(errors.New("cache 'some-cache' not found"))
+			mockClient.EXPECT().RemoveCache(gomock.Any(), "other-cache").Return(nil)
+
+			command.SetArgs([]string{"some-cache", "other-cache"})
+			h.AssertNotNil(t, command.Execute())
+
+			h.AssertContains(t, outBuf.String(), "ERROR: cache 'some-cache' not found")
+			h.AssertContains(t, outBuf.String(), "Removed cache 'other-cache'")
+		})
+
+		it("requires a cache name", func() {
+			command.SetArgs([]string{})
+			h.AssertError(t, command.Execute(), "requires at least 1 arg(s), only received 0")
+		})
+	})
+}
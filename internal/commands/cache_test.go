@@ -0,0 +1,50 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestCacheCommand(t *testing.T) {
+	spec.Run(t, "CacheCommand", testCacheCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testCacheCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		cmd        *cobra.Command
+		logger     logging.Logger
+		outBuf     bytes.Buffer
+		mockClient *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController := gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		cmd = commands.NewCacheCommand(logger, config.Config{}, mockClient)
+		cmd.SetOut(logging.GetWriterForLevel(logger, logging.InfoLevel))
+	})
+
+	when("cache", func() {
+		it("prints help text", func() {
+			cmd.SetArgs([]string{})
+			h.AssertNil(t, cmd.Execute())
+			output := outBuf.String()
+			h.AssertContains(t, output, "Build caches keep the layers of previous builds")
+			for _, command := range []string{"Usage", "ls", "inspect", "rm", "prune", "export", "import"} {
+				h.AssertContains(t, output, command)
+			}
+		})
+	})
+}
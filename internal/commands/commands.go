@@ -1,14 +1,18 @@
 package commands
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/signal"
 	"syscall"
 
+	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 
 	"github.com/buildpacks/pack/internal/config"
 	"github.com/buildpacks/pack/internal/style"
@@ -20,18 +24,40 @@ import (
 type PackClient interface {
 	InspectBuilder(string, bool, ...client.BuilderInspectionModifier) (*client.BuilderInfo, error)
 	InspectImage(string, bool) (*client.ImageInfo, error)
+	DiffImage(context.Context, string, string, client.DiffImageOptions) (*client.ImageDiff, error)
 	Rebase(context.Context, client.RebaseOptions) error
+	PlanRebase(context.Context, client.RebaseOptions) (*client.RebasePlan, error)
+	RebaseBatch(context.Context, client.RebaseBatchOptions) []client.RebaseResult
 	CreateBuilder(context.Context, client.CreateBuilderOptions) error
 	NewBuildpack(context.Context, client.NewBuildpackOptions) error
 	PackageBuildpack(ctx context.Context, opts client.PackageBuildpackOptions) error
 	PackageExtension(ctx context.Context, opts client.PackageBuildpackOptions) error
 	Build(context.Context, client.BuildOptions) error
+	Detect(context.Context, client.BuildOptions) (client.DetectResult, error)
+	PrepareBuild(context.Context, client.BuildOptions) (*client.VendoredBuild, error)
+	ValidateProjectDescriptor(context.Context, client.ValidateProjectDescriptorOptions) (client.ProjectDescriptorValidation, error)
 	RegisterBuildpack(context.Context, client.RegisterBuildpackOptions) error
 	YankBuildpack(client.YankBuildpackOptions) error
 	InspectBuildpack(client.InspectBuildpackOptions) (*client.BuildpackInfo, error)
 	InspectExtension(client.InspectExtensionOptions) (*client.ExtensionInfo, error)
 	PullBuildpack(context.Context, client.PullBuildpackOptions) error
+	SearchBuildpacks(client.SearchBuildpacksOptions) ([]client.RegistryBuildpack, error)
+	ListBuildpackVersions(client.ListBuildpackVersionsOptions) ([]client.RegistryBuildpackVersion, error)
 	DownloadSBOM(name string, options client.DownloadSBOMOptions) error
+	InspectSBOM(name string, options client.InspectSBOMOptions) (*client.SBOMInfo, error)
+	CreateManifest(context.Context, client.CreateManifestOptions) error
+	AddManifest(context.Context, client.AddManifestOptions) error
+	AnnotateManifest(context.Context, client.AnnotateManifestOptions) error
+	RemoveManifest(context.Context, client.RemoveManifestOptions) error
+	InspectManifest(context.Context, string) (*v1.IndexManifest, error)
+	PushManifest(context.Context, client.PushManifestOptions) error
+	DeleteManifest(context.Context, []string) error
+	ListCaches(context.Context) ([]client.BuildCache, error)
+	InspectCache(context.Context, string) (client.BuildCache, error)
+	RemoveCache(context.Context, string) error
+	PruneCaches(context.Context, client.PruneCachesOptions) ([]client.BuildCache, error)
+	ExportCache(context.Context, client.ExportCacheOptions) error
+	ImportCache(context.Context, client.ImportCacheOptions) (client.BuildCache, error)
 }
 
 func AddHelpFlag(cmd *cobra.Command, commandName string) {
@@ -86,6 +112,40 @@ func stringSliceHelp(name string) string {
 	return fmt.Sprintf("\nRepeat for each %s in order, or supply once by comma-separated list", name)
 }
 
+// validateOutputFormat ensures format is one of the output formats of the commands with structured output
+func validateOutputFormat(format string) error {
+	switch format {
+	case "human-readable", "json", "yaml":
+		return nil
+	default:
+		return fmt.Errorf("output format %s is not supported", style.Symbol(format))
+	}
+}
+
+// writeStructuredOutput writes a value as json or yaml
+func writeStructuredOutput(logger logging.Logger, format string, value interface{}) error {
+	buf := bytes.NewBuffer(nil)
+	if format == "json" {
+		encoder := json.NewEncoder(buf)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(value); err != nil {
+			return err
+		}
+	} else if err := yaml.NewEncoder(buf).Encode(value); err != nil {
+		return err
+	}
+	_, err := logger.Writer().Write(buf.Bytes())
+	return err
+}
+
+// isOffline reports whether pack runs offline, as set by the --offline flag of the root command or by the config
+func isOffline(cmd *cobra.Command, cfg config.Config) bool {
+	if flag, err := cmd.Flags().GetBool("offline"); err == nil && cmd.Flags().Changed("offline") {
+		return flag
+	}
+	return cfg.Offline
+}
+
 func getMirrors(config config.Config) map[string][]string {
 	mirrors := map[string][]string{}
 	for _, ri := range config.RunImages {
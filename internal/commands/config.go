@@ -24,6 +24,8 @@ func NewConfigCommand(logger logging.Logger, cfg config.Config, cfgPath string,
 	cmd.AddCommand(ConfigTrustedBuilder(logger, cfg, cfgPath))
 	cmd.AddCommand(ConfigLifecycleImage(logger, cfg, cfgPath))
 	cmd.AddCommand(ConfigRegistryMirrors(logger, cfg, cfgPath))
+	cmd.AddCommand(ConfigRequireDigests(logger, cfg, cfgPath))
+	cmd.AddCommand(ConfigOffline(logger, cfg, cfgPath))
 
 	AddHelpFlag(cmd, "config")
 	return cmd
@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+func ConfigOffline(logger logging.Logger, cfg config.Config, cfgPath string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "offline [<true | false>]",
+		Args:  cobra.MaximumNArgs(1),
+		Short: "List and set the current 'offline' value from the config",
+		Long: "When `offline` is enabled, pack runs without network access: images are never pulled to the daemon, remote URIs are only read from the download cache, " +
+			"and buildpack registries are used as they were last cached. Artifacts which are not available locally are listed instead of failing with network errors. " +
+			"App images cannot be published to a registry.\n\n" +
+			"The `--offline` flag of every command overrides this value.\n\n" +
+			"* Running `pack config offline` prints whether pack runs offline.\n" +
+			"* Running `pack config offline <true | false>` makes pack run offline or online.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			switch {
+			case len(args) == 0:
+				if cfg.Offline {
+					logger.Info("Pack runs offline. To run it online, run `pack config offline false`")
+				} else {
+					logger.Info("Pack runs online. To run it offline, run `pack config offline true`")
+				}
+			default:
+				val, err := strconv.ParseBool(args[0])
+				if err != nil {
+					return errors.Wrapf(err, "invalid value %s provided", style.Symbol(args[0]))
+				}
+				cfg.Offline = val
+
+				if err = config.Write(cfg, cfgPath); err != nil {
+					return errors.Wrap(err, "writing to config")
+				}
+
+				if cfg.Offline {
+					logger.Info("Pack now runs offline")
+				} else {
+					logger.Info("Pack now runs online")
+				}
+			}
+
+			return nil
+		}),
+	}
+
+	AddHelpFlag(cmd, "offline")
+	return cmd
+}
@@ -20,8 +20,9 @@ var (
 )
 
 var (
-	setDefault   bool
-	registryType string
+	setDefault     bool
+	registryType   string
+	registryAPIURL string
 )
 
 func ConfigRegistries(logger logging.Logger, cfg config.Config, cfgPath string) *cobra.Command {
@@ -46,7 +47,8 @@ func ConfigRegistries(logger logging.Logger, cfg config.Config, cfgPath string)
 	addCmd.Example = "pack config registries add my-registry https://github.com/buildpacks/my-registry"
 	addCmd.Long = bpRegistryExplanation + "Users can add registries from the config by using registries remove, and publish/yank buildpacks from it, as well as use those buildpacks when building applications."
 	addCmd.Flags().BoolVar(&setDefault, "default", false, "Set this buildpack registry as the default")
-	addCmd.Flags().StringVar(&registryType, "type", "github", "Type of buildpack registry [git|github]")
+	addCmd.Flags().StringVar(&registryType, "type", "github", "Type of buildpack registry [git|github|http|oci]")
+	addCmd.Flags().StringVar(&registryAPIURL, "api-url", "", "REST API endpoint of a github buildpack registry, when it is not derived from the registry URL")
 	cmd.AddCommand(addCmd)
 
 	rmCmd := generateRemove("registries", logger, cfg, cfgPath, removeRegistry)
@@ -62,9 +64,10 @@ func ConfigRegistries(logger logging.Logger, cfg config.Config, cfgPath string)
 
 func addRegistry(args []string, logger logging.Logger, cfg config.Config, cfgPath string) error {
 	newRegistry := config.Registry{
-		Name: args[0],
-		URL:  args[1],
-		Type: registryType,
+		Name:   args[0],
+		URL:    args[1],
+		Type:   registryType,
+		APIURL: registryAPIURL,
 	}
 
 	return addRegistryToConfig(logger, newRegistry, setDefault, cfg, cfgPath)
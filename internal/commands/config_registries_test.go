@@ -195,6 +195,18 @@ func testConfigRegistries(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("api-url is provided", func() {
+			it("saves the REST API endpoint of the registry", func() {
+				cmd.SetArgs(append(args, "--api-url", "https://git.example.com/api/v3"))
+				assert.Succeeds(cmd.Execute())
+
+				cfg, err := config.Read(configPath)
+				assert.Nil(err)
+				assert.Equal(len(cfg.Registries), 1)
+				assert.Equal(cfg.Registries[0].APIURL, "https://git.example.com/api/v3")
+			})
+		})
+
 		when("default is true", func() {
 			it("sets newly added registry as the default", func() {
 				cmd.SetArgs(append(args, "--default"))
@@ -221,7 +233,7 @@ func testConfigRegistries(t *testing.T, when spec.G, it spec.S) {
 				assert.Error(cmd.Execute())
 
 				output := outBuf.String()
-				assert.Contains(output, "'bogus' is not a valid type. Supported types are: 'git', 'github'.")
+				assert.Contains(output, "'bogus' is not a valid type. Supported types are: 'git', 'github', 'http', 'oci'.")
 			})
 
 			it("should throw error when registry already exists", func() {
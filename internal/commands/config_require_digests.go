@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+func ConfigRequireDigests(logger logging.Logger, cfg config.Config, cfgPath string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "require-digests [<true | false>]",
+		Args:  cobra.MaximumNArgs(1),
+		Short: "List and set the current 'require-digests' value from the config",
+		Long: "Buildpack and lifecycle URIs in builder.toml, package.toml and project.toml can be pinned to the sha256 digest of their content by appending `#sha256=<digest>`, " +
+			"for example `uri = \"https://example.com/buildpack.tgz#sha256=<digest>\"`. Downloads of pinned URIs fail when the content does not match the digest.\n\n" +
+			"When `require-digests` is enabled, remote URIs which are not pinned are rejected. " +
+			"Builders must then declare a pinned `lifecycle.uri`, as a lifecycle `version` cannot be pinned.\n\n" +
+			"* Running `pack config require-digests` prints whether digests are currently required.\n" +
+			"* Running `pack config require-digests <true | false>` requires digests or stops requiring them.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			switch {
+			case len(args) == 0:
+				if cfg.RequireDigests {
+					logger.Info("Remote URIs must be pinned to a digest. To allow unpinned URIs, run `pack config require-digests false`")
+				} else {
+					logger.Info("Remote URIs are not required to be pinned to a digest. To require it, run `pack config require-digests true`")
+				}
+			default:
+				val, err := strconv.ParseBool(args[0])
+				if err != nil {
+					return errors.Wrapf(err, "invalid value %s provided", style.Symbol(args[0]))
+				}
+				cfg.RequireDigests = val
+
+				if err = config.Write(cfg, cfgPath); err != nil {
+					return errors.Wrap(err, "writing to config")
+				}
+
+				if cfg.RequireDigests {
+					logger.Info("Remote URIs must now be pinned to a digest")
+				} else {
+					logger.Info("Remote URIs are no longer required to be pinned to a digest")
+				}
+			}
+
+			return nil
+		}),
+	}
+
+	AddHelpFlag(cmd, "require-digests")
+	return cmd
+}
@@ -0,0 +1,93 @@
+package commands_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestConfigRequireDigests(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ConfigRequireDigestsCommand", testConfigRequireDigests, spec.Random(), spec.Report(report.Terminal{}))
+}
+
+func testConfigRequireDigests(t *testing.T, when spec.G, it spec.S) {
+	var (
+		cmd          *cobra.Command
+		logger       logging.Logger
+		outBuf       bytes.Buffer
+		tempPackHome string
+		configPath   string
+	)
+
+	it.Before(func() {
+		var err error
+
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		tempPackHome, err = os.MkdirTemp("", "pack-home")
+		h.AssertNil(t, err)
+		configPath = filepath.Join(tempPackHome, "config.toml")
+
+		cmd = commands.ConfigRequireDigests(logger, config.Config{}, configPath)
+		cmd.SetOut(logging.GetWriterForLevel(logger, logging.InfoLevel))
+	})
+
+	it.After(func() {
+		h.AssertNil(t, os.RemoveAll(tempPackHome))
+	})
+
+	when("#ConfigRequireDigests", func() {
+		when("list values", func() {
+			it("prints a clear message if false", func() {
+				cmd.SetArgs([]string{})
+				h.AssertNil(t, cmd.Execute())
+				h.AssertContains(t, outBuf.String(), "Remote URIs are not required to be pinned to a digest")
+			})
+
+			it("prints a clear message if true", func() {
+				cmd = commands.ConfigRequireDigests(logger, config.Config{RequireDigests: true}, configPath)
+				cmd.SetArgs([]string{})
+				h.AssertNil(t, cmd.Execute())
+				h.AssertContains(t, outBuf.String(), "Remote URIs must be pinned to a digest")
+			})
+		})
+
+		when("set", func() {
+			it("sets true if provided", func() {
+				cmd.SetArgs([]string{"true"})
+				h.AssertNil(t, cmd.Execute())
+				h.AssertContains(t, outBuf.String(), "Remote URIs must now be pinned to a digest")
+				cfg, err := config.Read(configPath)
+				h.AssertNil(t, err)
+				h.AssertEq(t, cfg.RequireDigests, true)
+			})
+
+			it("sets false if provided", func() {
+				cmd = commands.ConfigRequireDigests(logger, config.Config{RequireDigests: true}, configPath)
+				cmd.SetArgs([]string{"false"})
+				h.AssertNil(t, cmd.Execute())
+				h.AssertContains(t, outBuf.String(), "Remote URIs are no longer required to be pinned to a digest")
+				cfg, err := config.Read(configPath)
+				h.AssertNil(t, err)
+				h.AssertEq(t, cfg.RequireDigests, false)
+			})
+
+			it("returns error if invalid value provided", func() {
+				cmd.SetArgs([]string{"disable"})
+				h.AssertError(t, cmd.Execute(), "invalid value 'disable' provided")
+			})
+		})
+	})
+}
@@ -0,0 +1,20 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+func NewImageCommand(logger logging.Logger, client PackClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "image",
+		Short: "Interact with app images",
+		RunE:  nil,
+	}
+
+	cmd.AddCommand(ImageDiff(logger, client))
+
+	AddHelpFlag(cmd, "image")
+	return cmd
+}
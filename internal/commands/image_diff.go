@@ -0,0 +1,99 @@
+package commands
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type ImageDiffFlags struct {
+	Remote       bool
+	RemoteBefore bool
+	RemoteAfter  bool
+	OutputFormat string
+}
+
+func ImageDiff(logger logging.Logger, pack PackClient) *cobra.Command {
+	var flags ImageDiffFlags
+	cmd := &cobra.Command{
+		Use:   "diff <image-name> <other-image-name>",
+		Args:  cobra.ExactArgs(2),
+		Short: "Show what changed between two app images",
+		Long: "Compare the run image, buildpack versions, BOM entries, SBOM documents, processes, labels and layers of two app images.\n\n" +
+			"Changes are relative to the first image: values only found in the second image are added, and values only found in the first image are removed.",
+		Example: "pack image diff my-app:v1 my-app:v2",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if err := validateOutputFormat(flags.OutputFormat); err != nil {
+				return err
+			}
+
+			diff, err := pack.DiffImage(cmd.Context(), args[0], args[1], client.DiffImageOptions{
+				BeforeDaemon: !flags.Remote && !flags.RemoteBefore,
+				AfterDaemon:  !flags.Remote && !flags.RemoteAfter,
+			})
+			if err != nil {
+				return err
+			}
+
+			if flags.OutputFormat == "human-readable" {
+				return writeHumanReadableImageDiff(logger, args[0], args[1], diff)
+			}
+			return writeStructuredOutput(logger, flags.OutputFormat, diff)
+		}),
+	}
+
+	AddHelpFlag(cmd, "diff")
+	cmd.Flags().BoolVar(&flags.Remote, "remote", false, "Compare images in their remote registry (without pulling them)")
+	cmd.Flags().BoolVar(&flags.RemoteBefore, "remote-before", false, "Read the first image from its remote registry (without pulling it)")
+	cmd.Flags().BoolVar(&flags.RemoteAfter, "remote-after", false, "Read the second image from its remote registry (without pulling it)")
+	cmd.Flags().StringVarP(&flags.OutputFormat, "output", "o", "human-readable", "Output format to display the differences (json, yaml, human-readable).\nOmission of this flag will display as human-readable.")
+	return cmd
+}
+
+func writeHumanReadableImageDiff(logger logging.Logger, before, after string, diff *client.ImageDiff) error {
+	if diff.Empty() {
+		logger.Infof("No differences between %s and %s", style.Symbol(before), style.Symbol(after))
+		return nil
+	}
+
+	logger.Infof("Differences between %s and %s:", style.Symbol(before), style.Symbol(after))
+	for _, section := range []struct {
+		title   string
+		changes []client.Change
+	}{
+		{"Run Image", diff.RunImage},
+		{"Buildpacks", diff.Buildpacks},
+		{"BOM", diff.BOM},
+		{"SBOM", diff.SBOM},
+		{"Processes", diff.Processes},
+		{"Labels", diff.Labels},
+		{"Layers", diff.Layers},
+	} {
+		if len(section.changes) == 0 {
+			continue
+		}
+
+		logger.Info("")
+		logger.Infof("%s:", section.title)
+		tw := tabwriter.NewWriter(logger.Writer(), writerMinWidth, writerTabWidth, defaultTabWidth, writerPadChar, writerFlags)
+		for _, change := range section.changes {
+			switch change.Status {
+			case client.ChangeAdded:
+				fmt.Fprintf(tw, "  + %s\t%s\n", change.Name, change.After)
+			case client.ChangeRemoved:
+				fmt.Fprintf(tw, "  - %s\t%s\n", change.Name, change.Before)
+			default:
+				fmt.Fprintf(tw, "  ~ %s\t%s -> %s\n", change.Name, change.Before, change.After)
+			}
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package commands_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestImageDiffCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ImageDiffCommand", testImageDiffCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testImageDiffCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+		diff           *client.ImageDiff
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+		command = commands.ImageDiff(logger, mockClient)
+
+		diff = &client.ImageDiff{
+			Buildpacks: []client.Change{
+				{Name: "some/buildpack", Status: client.ChangeModified, Before: "1.0.0", After: "2.0.0"},
+				{Name: "other/buildpack", Status: client.ChangeAdded, After: "0.1.0"},
+			},
+			Labels: []client.Change{
+				{Name: "some-label", Status: client.ChangeRemoved, Before: "some-value"},
+			},
+		}
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#ImageDiff", func() {
+		it("shows the differences between daemon images", func() {
+			mockClient.EXPECT().DiffImage(gomock.Any(), "some/app:v1", "some/app:v2", client.DiffImageOptions{BeforeDaemon: true, AfterDaemon: true}).Return(diff, nil)
+
+			command.SetArgs([]string{"some/app:v1", "some/app:v2"})
+			h.AssertNil(t, command.Execute())
+
+			output := outBuf.String()
+			h.AssertContains(t, output, "Differences between 'some/app:v1' and 'some/app:v2':")
+			h.AssertContains(t, output, "Buildpacks:")
+			h.AssertContainsMatch(t, output, `~ some/buildpack\s+1.0.0 -> 2.0.0`)
+			h.AssertContainsMatch(t, output, `\+ other/buildpack\s+0.1.0`)
+			h.AssertContainsMatch(t, output, `- some-label\s+some-value`)
+			h.AssertNotContains(t, output, "Layers:")
+		})
+
+		it("compares remote images with --remote", func() {
+			mockClient.EXPECT().DiffImage(gomock.Any(), "some/app:v1", "some/app:v2", client.DiffImageOptions{BeforeDaemon: false, AfterDaemon: false}).Return(&client.ImageDiff{}, nil)
+
+			command.SetArgs([]string{"some/app:v1", "some/app:v2", "--remote"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "No differences between 'some/app:v1' and 'some/app:v2'")
+		})
+
+		it("compares a daemon image with a remote image", func() {
+			mockClient.EXPECT().DiffImage(gomock.Any(), "some/app:v1", "some/app:v2", client.DiffImageOptions{BeforeDaemon: true, AfterDaemon: false}).Return(&client.ImageDiff{}, nil)
+
+			command.SetArgs([]string{"some/app:v1", "some/app:v2", "--remote-after"})
+			h.AssertNil(t, command.Execute())
+		})
+
+		it("compares a remote image with a daemon image", func() {
+			mockClient.EXPECT().DiffImage(gomock.Any(), "some/app:v1", "some/app:v2", client.DiffImageOptions{BeforeDaemon: false, AfterDaemon: true}).Return(&client.ImageDiff{}, nil)
+
+			command.SetArgs([]string{"some/app:v1", "some/app:v2", "--remote-before"})
+			h.AssertNil(t, command.Execute())
+		})
+
+		it("outputs json", func() {
+			mockClient.EXPECT().DiffImage(gomock.Any(), "some/app:v1", "some/app:v2", gomock.Any()).Return(diff, nil)
+
+			command.SetArgs([]string{"some/app:v1", "some/app:v2", "--output", "json"})
+			h.AssertNil(t, command.Execute())
+
+			var output client.ImageDiff
+			h.AssertNil(t, json.Unmarshal(outBuf.Bytes(), &output))
+			h.AssertEq(t, output.Buildpacks, diff.Buildpacks)
+			h.AssertEq(t, output.Labels, diff.Labels)
+		})
+
+		it("outputs yaml", func() {
+			mockClient.EXPECT().DiffImage(gomock.Any(), "some/app:v1", "some/app:v2", gomock.Any()).Return(diff, nil)
+
+			command.SetArgs([]string{"some/app:v1", "some/app:v2", "-o", "yaml"})
+			h.AssertNil(t, command.Execute())
+
+			var output client.ImageDiff
+			h.AssertNil(t, yaml.Unmarshal(outBuf.Bytes(), &output))
+			h.AssertEq(t, output.Buildpacks, diff.Buildpacks)
+		})
+
+		it("errors on unsupported output formats", func() {
+			command.SetArgs([]string{"some/app:v1", "some/app:v2", "-o", "toml"})
+			h.AssertError(t, command.Execute(), "output format 'toml' is not supported")
+		})
+
+		it("errors when the images cannot be compared", func() {
+			mockClient.EXPECT().DiffImage(gomock.Any(), "some/app:v1", "some/app:v2", gomock.Any()).Return(nil, errors.New("image 'some/app:v2' cannot be found"))
+
+			command.SetArgs([]string{"some/app:v1", "some/app:v2"})
+			h.AssertError(t, command.Execute(), "image 'some/app:v2' cannot be found")
+		})
+	})
+}
@@ -0,0 +1,49 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestImageCommand(t *testing.T) {
+	spec.Run(t, "ImageCommand", testImageCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testImageCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		cmd        *cobra.Command
+		logger     logging.Logger
+		outBuf     bytes.Buffer
+		mockClient *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController := gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		cmd = commands.NewImageCommand(logger, mockClient)
+		cmd.SetOut(logging.GetWriterForLevel(logger, logging.InfoLevel))
+	})
+
+	when("image", func() {
+		it("prints help text", func() {
+			cmd.SetArgs([]string{})
+			h.AssertNil(t, cmd.Execute())
+			output := outBuf.String()
+			h.AssertContains(t, output, "Interact with app images")
+			for _, command := range []string{"Usage", "diff"} {
+				h.AssertContains(t, output, command)
+			}
+		})
+	})
+}
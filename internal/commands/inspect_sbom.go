@@ -0,0 +1,124 @@
+package commands
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/sbom"
+	"github.com/buildpacks/pack/internal/style"
+	cpkg "github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type InspectSBOMFlags struct {
+	Remote       bool
+	Package      string
+	Version      string
+	OutputFormat string
+}
+
+func InspectSBOM(
+	logger logging.Logger,
+	client PackClient,
+) *cobra.Command {
+	var flags InspectSBOMFlags
+	cmd := &cobra.Command{
+		Use:   "inspect <image-name>...",
+		Args:  cobra.MinimumNArgs(1),
+		Short: "Show the packages listed in the SBoM of images",
+		Long: "Show the packages listed by the CycloneDX, SPDX and Syft documents in the SBoM layer of images, merged per buildpack and per layer.\n\n" +
+			"With --package or --version, only the packages matching them are shown. Package names match when they contain the given name, ignoring case, " +
+			"and versions match when they start with the given version, so that 2.14 matches 2.14.1.",
+		Example: "pack sbom inspect my-app-a my-app-b --package log4j --version 2.14",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if err := validateOutputFormat(flags.OutputFormat); err != nil {
+				return err
+			}
+
+			var infos []*cpkg.SBOMInfo
+			for _, img := range args {
+				info, err := client.InspectSBOM(img, cpkg.InspectSBOMOptions{Daemon: !flags.Remote})
+				if err != nil {
+					return err
+				}
+				infos = append(infos, info)
+			}
+
+			search := flags.Package != "" || flags.Version != ""
+			if !search {
+				if flags.OutputFormat == "human-readable" {
+					return writeHumanReadableSBOM(logger, infos)
+				}
+				return writeStructuredOutput(logger, flags.OutputFormat, infos)
+			}
+
+			matches := []cpkg.SBOMMatch{}
+			for _, info := range infos {
+				matches = append(matches, info.Search(flags.Package, flags.Version)...)
+			}
+			if flags.OutputFormat == "human-readable" {
+				return writeHumanReadableSBOMMatches(logger, matches)
+			}
+			return writeStructuredOutput(logger, flags.OutputFormat, matches)
+		}),
+	}
+	AddHelpFlag(cmd, "inspect")
+	cmd.Flags().BoolVar(&flags.Remote, "remote", false, "Inspect SBoM of images in remote registry (without pulling them)")
+	cmd.Flags().StringVar(&flags.Package, "package", "", "Only show packages whose name contains this name")
+	cmd.Flags().StringVar(&flags.Version, "version", "", "Only show packages whose version starts with this version")
+	cmd.Flags().StringVarP(&flags.OutputFormat, "output", "o", "human-readable", "Output format to display the packages (json, yaml, human-readable).\nOmission of this flag will display as human-readable.")
+	return cmd
+}
+
+func writeHumanReadableSBOM(logger logging.Logger, infos []*cpkg.SBOMInfo) error {
+	for i, info := range infos {
+		if i > 0 {
+			logger.Info("")
+		}
+		logger.Infof("Image: %s", style.Symbol(info.Image))
+		if len(info.Buildpacks) == 0 {
+			logger.Info("  (no packages)")
+			continue
+		}
+
+		tw := tabwriter.NewWriter(logger.Writer(), writerMinWidth, writerTabWidth, defaultTabWidth, writerPadChar, writerFlags)
+		for _, bp := range info.Buildpacks {
+			fmt.Fprintf(tw, "  Buildpack: %s (%s)\n", bp.ID, bp.Scope)
+			writeSBOMPackages(tw, "    ", bp.Packages)
+			for _, layer := range bp.Layers {
+				fmt.Fprintf(tw, "    Layer: %s\n", layer.Name)
+				writeSBOMPackages(tw, "      ", layer.Packages)
+			}
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeSBOMPackages(tw *tabwriter.Writer, indent string, packages []sbom.Package) {
+	for _, pkg := range packages {
+		fmt.Fprintf(tw, "%s%s\t%s\t%s\n", indent, pkg.Name, pkg.Version, pkg.PURL)
+	}
+}
+
+func writeHumanReadableSBOMMatches(logger logging.Logger, matches []cpkg.SBOMMatch) error {
+	if len(matches) == 0 {
+		logger.Info("No matching packages found")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(logger.Writer(), writerMinWidth, writerTabWidth, defaultTabWidth, writerPadChar, writerFlags)
+	fmt.Fprint(tw, "IMAGE\tBUILDPACK\tSCOPE\tLAYER\tPACKAGE\tVERSION\n")
+	for _, match := range matches {
+		layer := match.Layer
+		if layer == "" {
+			layer = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", match.Image, match.Buildpack, match.Scope, layer, match.Package.Name, match.Package.Version)
+	}
+	return tw.Flush()
+}
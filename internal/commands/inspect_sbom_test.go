@@ -0,0 +1,150 @@
+package commands_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/sbom"
+	cpkg "github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestInspectSBOMCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "InspectSBOMCommand", testInspectSBOMCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testInspectSBOMCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		logger         logging.Logger
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+		someInfo       *cpkg.SBOMInfo
+		otherInfo      *cpkg.SBOMInfo
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		command = commands.InspectSBOM(logger, mockClient)
+
+		someInfo = &cpkg.SBOMInfo{
+			Image: "some/image",
+			Buildpacks: []cpkg.BuildpackSBOM{{
+				ID:       "some/java",
+				Scope:    "launch",
+				Packages: []sbom.Package{{Name: "maven", Version: "3.9.0"}},
+				Layers: []cpkg.LayerSBOM{{
+					Name:     "app",
+					Packages: []sbom.Package{{Name: "log4j-core", Version: "2.14.1", PURL: "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1"}},
+				}},
+			}},
+		}
+		otherInfo = &cpkg.SBOMInfo{
+			Image: "other/image",
+			Buildpacks: []cpkg.BuildpackSBOM{{
+				ID:    "some/java",
+				Scope: "launch",
+				Layers: []cpkg.LayerSBOM{{
+					Name:     "app",
+					Packages: []sbom.Package{{Name: "log4j-core", Version: "2.17.1"}},
+				}},
+			}},
+		}
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#InspectSBOM", func() {
+		it("shows the packages of each buildpack and layer", func() {
+			mockClient.EXPECT().InspectSBOM("some/image", cpkg.InspectSBOMOptions{Daemon: true}).Return(someInfo, nil)
+
+			command.SetArgs([]string{"some/image"})
+			h.AssertNil(t, command.Execute())
+
+			output := outBuf.String()
+			h.AssertContains(t, output, "Image: 'some/image'")
+			h.AssertContains(t, output, "Buildpack: some/java (launch)")
+			h.AssertContainsMatch(t, output, `maven\s+3.9.0`)
+			h.AssertContains(t, output, "Layer: app")
+			h.AssertContainsMatch(t, output, `log4j-core\s+2.14.1\s+pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1`)
+		})
+
+		it("searches packages across images", func() {
+			mockClient.EXPECT().InspectSBOM("some/image", cpkg.InspectSBOMOptions{Daemon: false}).Return(someInfo, nil)
+			mockClient.EXPECT().InspectSBOM("other/image", cpkg.InspectSBOMOptions{Daemon: false}).Return(otherInfo, nil)
+
+			command.SetArgs([]string{"some/image", "other/image", "--remote", "--package", "log4j", "--version", "2.14"})
+			h.AssertNil(t, command.Execute())
+
+			output := outBuf.String()
+			h.AssertContainsMatch(t, output, `IMAGE\s+BUILDPACK\s+SCOPE\s+LAYER\s+PACKAGE\s+VERSION`)
+			h.AssertContainsMatch(t, output, `some/image\s+some/java\s+launch\s+app\s+log4j-core\s+2.14.1`)
+			h.AssertNotContains(t, output, "other/image")
+		})
+
+		it("reports when no packages match", func() {
+			mockClient.EXPECT().InspectSBOM("other/image", gomock.Any()).Return(otherInfo, nil)
+
+			command.SetArgs([]string{"other/image", "--package", "log4j", "--version", "2.14"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "No matching packages found")
+		})
+
+		it("outputs the matches as json", func() {
+			mockClient.EXPECT().InspectSBOM("some/image", gomock.Any()).Return(someInfo, nil)
+
+			command.SetArgs([]string{"some/image", "--package", "maven", "-o", "json"})
+			h.AssertNil(t, command.Execute())
+
+			var matches []cpkg.SBOMMatch
+			h.AssertNil(t, json.Unmarshal(outBuf.Bytes(), &matches))
+			h.AssertEq(t, matches, []cpkg.SBOMMatch{{
+				Image:     "some/image",
+				Buildpack: "some/java",
+				Scope:     "launch",
+				Package:   sbom.Package{Name: "maven", Version: "3.9.0"},
+			}})
+		})
+
+		it("outputs the packages as yaml", func() {
+			mockClient.EXPECT().InspectSBOM("some/image", gomock.Any()).Return(someInfo, nil)
+
+			command.SetArgs([]string{"some/image", "-o", "yaml"})
+			h.AssertNil(t, command.Execute())
+
+			output := outBuf.String()
+			h.AssertContains(t, output, "- image: some/image")
+			h.AssertContains(t, output, "name: log4j-core")
+		})
+
+		it("errors on unsupported output formats", func() {
+			command.SetArgs([]string{"some/image", "-o", "toml"})
+			h.AssertError(t, command.Execute(), "output format 'toml' is not supported")
+		})
+
+		it("errors when the SBOM cannot be read", func() {
+			mockClient.EXPECT().InspectSBOM("some/image", gomock.Any()).Return(nil, errors.New("could not find SBoM information on 'some/image'"))
+
+			command.SetArgs([]string{"some/image"})
+			h.AssertError(t, command.Execute(), "could not find SBoM information on 'some/image'")
+		})
+	})
+}
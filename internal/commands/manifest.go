@@ -0,0 +1,28 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+func NewManifestCommand(logger logging.Logger, client PackClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "manifest",
+		Aliases: []string{"manifests"},
+		Short:   "Interact with image indexes",
+		Long:    "An image index (or manifest list) references images of the same application built for different platforms.\n\nImage indexes are assembled locally with `pack manifest create`, `add`, `annotate` and `remove`, then published with `pack manifest push`.",
+		RunE:    nil,
+	}
+
+	cmd.AddCommand(ManifestCreate(logger, client))
+	cmd.AddCommand(ManifestAdd(logger, client))
+	cmd.AddCommand(ManifestAnnotate(logger, client))
+	cmd.AddCommand(ManifestRemove(logger, client))
+	cmd.AddCommand(ManifestInspect(logger, client))
+	cmd.AddCommand(ManifestPush(logger, client))
+	cmd.AddCommand(ManifestDelete(logger, client))
+
+	AddHelpFlag(cmd, "manifest")
+	return cmd
+}
@@ -0,0 +1,37 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type ManifestAddFlags struct {
+	All      bool
+	Insecure bool
+}
+
+func ManifestAdd(logger logging.Logger, pack PackClient) *cobra.Command {
+	var flags ManifestAddFlags
+
+	cmd := &cobra.Command{
+		Use:     "add <index-name> <image>",
+		Args:    cobra.ExactArgs(2),
+		Short:   "Add an image to a local image index",
+		Example: "pack manifest add cnbs/sample-app:latest cnbs/sample-app:latest-linux-arm64",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			return pack.AddManifest(cmd.Context(), client.AddManifestOptions{
+				IndexRepoName: args[0],
+				RepoName:      args[1],
+				All:           flags.All,
+				Insecure:      flags.Insecure,
+			})
+		}),
+	}
+
+	cmd.Flags().BoolVar(&flags.All, "all", false, "When the image is itself an image index, add every image it references instead of only the image for the current platform")
+	cmd.Flags().BoolVar(&flags.Insecure, "insecure", false, "Allow communicating with registries over HTTP")
+	AddHelpFlag(cmd, "add")
+	return cmd
+}
@@ -0,0 +1,60 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestManifestAddCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ManifestAddCommand", testManifestAddCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testManifestAddCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		cmd        *cobra.Command
+		outBuf     bytes.Buffer
+		mockClient *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController := gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		cmd = commands.ManifestAdd(logger, mockClient)
+	})
+
+	when("#ManifestAdd", func() {
+		it("adds the image to the index", func() {
+			mockClient.EXPECT().
+				AddManifest(gomock.Any(), client.AddManifestOptions{
+					IndexRepoName: "some/index",
+					RepoName:      "some/image",
+					All:           true,
+					Insecure:      true,
+				}).
+				Return(nil)
+
+			cmd.SetArgs([]string{"some/index", "some/image", "--all", "--insecure"})
+			h.AssertNil(t, cmd.Execute())
+		})
+
+		it("fails when the image is missing", func() {
+			cmd.SetArgs([]string{"some/index"})
+			h.AssertError(t, cmd.Execute(), "accepts 2 arg(s)")
+		})
+	})
+}
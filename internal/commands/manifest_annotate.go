@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type ManifestAnnotateFlags struct {
+	OS          string
+	Arch        string
+	Variant     string
+	OSVersion   string
+	OSFeatures  []string
+	Features    []string
+	Annotations map[string]string
+	Insecure    bool
+}
+
+func ManifestAnnotate(logger logging.Logger, pack PackClient) *cobra.Command {
+	var flags ManifestAnnotateFlags
+
+	cmd := &cobra.Command{
+		Use:     "annotate <index-name> <image>",
+		Args:    cobra.ExactArgs(2),
+		Short:   "Set the platform and annotations of an image in a local image index",
+		Example: "pack manifest annotate cnbs/sample-app:latest cnbs/sample-app:latest-linux-arm --arch arm --variant v7",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			return pack.AnnotateManifest(cmd.Context(), client.AnnotateManifestOptions{
+				IndexRepoName: args[0],
+				RepoName:      args[1],
+				OS:            flags.OS,
+				OSArch:        flags.Arch,
+				OSVariant:     flags.Variant,
+				OSVersion:     flags.OSVersion,
+				OSFeatures:    flags.OSFeatures,
+				Features:      flags.Features,
+				Annotations:   flags.Annotations,
+				Insecure:      flags.Insecure,
+			})
+		}),
+	}
+
+	cmd.Flags().StringVar(&flags.OS, "os", "", "Operating system of the image")
+	cmd.Flags().StringVar(&flags.Arch, "arch", "", "Architecture of the image")
+	cmd.Flags().StringVar(&flags.Variant, "variant", "", "Architecture variant of the image")
+	cmd.Flags().StringVar(&flags.OSVersion, "os-version", "", "Operating system version of the image")
+	cmd.Flags().StringSliceVar(&flags.OSFeatures, "os-features", nil, "Operating system features required by the image"+stringSliceHelp("os feature"))
+	cmd.Flags().StringSliceVar(&flags.Features, "features", nil, "Features required by the image"+stringSliceHelp("feature"))
+	cmd.Flags().StringToStringVar(&flags.Annotations, "annotations", nil, "Annotations to set on the image entry, in the form 'key=value'"+stringSliceHelp("annotation"))
+	cmd.Flags().BoolVar(&flags.Insecure, "insecure", false, "Allow communicating with registries over HTTP")
+	AddHelpFlag(cmd, "annotate")
+	return cmd
+}
@@ -0,0 +1,74 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestManifestAnnotateCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ManifestAnnotateCommand", testManifestAnnotateCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testManifestAnnotateCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		cmd        *cobra.Command
+		outBuf     bytes.Buffer
+		mockClient *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController := gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		cmd = commands.ManifestAnnotate(logger, mockClient)
+	})
+
+	when("#ManifestAnnotate", func() {
+		it("passes the platform and annotations", func() {
+			mockClient.EXPECT().
+				AnnotateManifest(gomock.Any(), client.AnnotateManifestOptions{
+					IndexRepoName: "some/index",
+					RepoName:      "some/image",
+					OS:            "linux",
+					OSArch:        "arm",
+					OSVariant:     "v7",
+					OSVersion:     "1.0",
+					OSFeatures:    []string{"some-os-feature"},
+					Features:      []string{"some-feature", "other-feature"},
+					Annotations:   map[string]string{"some-key": "some-value"},
+				}).
+				Return(nil)
+
+			cmd.SetArgs([]string{
+				"some/index", "some/image",
+				"--os", "linux",
+				"--arch", "arm",
+				"--variant", "v7",
+				"--os-version", "1.0",
+				"--os-features", "some-os-feature",
+				"--features", "some-feature,other-feature",
+				"--annotations", "some-key=some-value",
+			})
+			h.AssertNil(t, cmd.Execute())
+		})
+
+		it("fails when the annotation is malformed", func() {
+			cmd.SetArgs([]string{"some/index", "some/image", "--annotations", "some-key"})
+			h.AssertError(t, cmd.Execute(), "must be formatted as key=value")
+		})
+	})
+}
@@ -0,0 +1,44 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type ManifestCreateFlags struct {
+	Format   string
+	All      bool
+	Insecure bool
+	Publish  bool
+}
+
+func ManifestCreate(logger logging.Logger, pack PackClient) *cobra.Command {
+	var flags ManifestCreateFlags
+
+	cmd := &cobra.Command{
+		Use:     "create <index-name> <image> [<image>...]",
+		Args:    cobra.MinimumNArgs(1),
+		Short:   "Create a local image index",
+		Example: "pack manifest create cnbs/sample-app:latest cnbs/sample-app:latest-linux-amd64 cnbs/sample-app:latest-linux-arm64",
+		Long:    "Create a local image index referencing the provided images, which must exist in a registry.\n\nThe image index is kept locally until it is published with `pack manifest push`.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			return pack.CreateManifest(cmd.Context(), client.CreateManifestOptions{
+				IndexRepoName: args[0],
+				RepoNames:     args[1:],
+				Format:        flags.Format,
+				All:           flags.All,
+				Insecure:      flags.Insecure,
+				Publish:       flags.Publish,
+			})
+		}),
+	}
+
+	cmd.Flags().StringVarP(&flags.Format, "format", "f", client.ManifestFormatOCI, "Media type of the image index, either 'oci' or 'docker'")
+	cmd.Flags().BoolVar(&flags.All, "all", false, "When an image is itself an image index, add every image it references instead of only the image for the current platform")
+	cmd.Flags().BoolVar(&flags.Insecure, "insecure", false, "Allow communicating with registries over HTTP")
+	cmd.Flags().BoolVar(&flags.Publish, "publish", false, "Push the image index to the registry once created")
+	AddHelpFlag(cmd, "create")
+	return cmd
+}
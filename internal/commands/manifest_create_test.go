@@ -0,0 +1,86 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestManifestCreateCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ManifestCreateCommand", testManifestCreateCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testManifestCreateCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		cmd        *cobra.Command
+		outBuf     bytes.Buffer
+		mockClient *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController := gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		cmd = commands.ManifestCreate(logger, mockClient)
+	})
+
+	when("#ManifestCreate", func() {
+		it("creates an oci image index by default", func() {
+			mockClient.EXPECT().
+				CreateManifest(gomock.Any(), client.CreateManifestOptions{
+					IndexRepoName: "some/index",
+					RepoNames:     []string{"some/image:amd64", "some/image:arm64"},
+					Format:        client.ManifestFormatOCI,
+				}).
+				Return(nil)
+
+			cmd.SetArgs([]string{"some/index", "some/image:amd64", "some/image:arm64"})
+			h.AssertNil(t, cmd.Execute())
+		})
+
+		it("passes the provided flags", func() {
+			mockClient.EXPECT().
+				CreateManifest(gomock.Any(), client.CreateManifestOptions{
+					IndexRepoName: "some/index",
+					RepoNames:     []string{"some/image"},
+					Format:        client.ManifestFormatDocker,
+					All:           true,
+					Insecure:      true,
+					Publish:       true,
+				}).
+				Return(nil)
+
+			cmd.SetArgs([]string{"some/index", "some/image", "--format", "docker", "--all", "--insecure", "--publish"})
+			h.AssertNil(t, cmd.Execute())
+		})
+
+		it("fails when no index name is provided", func() {
+			cmd.SetArgs([]string{})
+			h.AssertError(t, cmd.Execute(), "requires at least 1 arg")
+		})
+
+		it("reports client errors", func() {
+			mockClient.EXPECT().
+				CreateManifest(gomock.Any(), gomock.Any()).
+				Return(errors.New("some error"))
+
+			cmd.SetArgs([]string{"some/index"})
+			h.AssertError(t, cmd.Execute(), "some error")
+			h.AssertContains(t, outBuf.String(), "ERROR: some error")
+		})
+	})
+}
@@ -0,0 +1,36 @@
+package commands
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+func ManifestInspect(logger logging.Logger, pack PackClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "inspect <index-name>",
+		Args:    cobra.ExactArgs(1),
+		Short:   "Display the contents of an image index",
+		Long:    "Display the contents of the local image index, or of the image index in its registry when it does not exist locally.",
+		Example: "pack manifest inspect cnbs/sample-app:latest",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			manifest, err := pack.InspectManifest(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+
+			out, err := json.MarshalIndent(manifest, "", "  ")
+			if err != nil {
+				return errors.Wrap(err, "marshalling image index")
+			}
+			logger.Info(string(out))
+			return nil
+		}),
+	}
+
+	AddHelpFlag(cmd, "inspect")
+	return cmd
+}
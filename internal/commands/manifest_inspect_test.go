@@ -0,0 +1,70 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestManifestInspectCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ManifestInspectCommand", testManifestInspectCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testManifestInspectCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		cmd        *cobra.Command
+		outBuf     bytes.Buffer
+		mockClient *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController := gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		cmd = commands.ManifestInspect(logger, mockClient)
+	})
+
+	when("#ManifestInspect", func() {
+		it("prints the image index", func() {
+			mockClient.EXPECT().
+				InspectManifest(gomock.Any(), "some/index").
+				Return(&v1.IndexManifest{
+					SchemaVersion: 2,
+					MediaType:     types.OCIImageIndex,
+					Manifests: []v1.Descriptor{{
+						MediaType: types.OCIManifestSchema1,
+						Platform:  &v1.Platform{OS: "linux", Architecture: "arm64"},
+					}},
+				}, nil)
+
+			cmd.SetArgs([]string{"some/index"})
+			h.AssertNil(t, cmd.Execute())
+			h.AssertContains(t, outBuf.String(), `"mediaType": "application/vnd.oci.image.index.v1+json"`)
+			h.AssertContains(t, outBuf.String(), `"architecture": "arm64"`)
+		})
+
+		it("reports client errors", func() {
+			mockClient.EXPECT().
+				InspectManifest(gomock.Any(), "some/index").
+				Return(nil, errors.New("some error"))
+
+			cmd.SetArgs([]string{"some/index"})
+			h.AssertError(t, cmd.Execute(), "some error")
+		})
+	})
+}
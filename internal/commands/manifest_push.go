@@ -0,0 +1,40 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type ManifestPushFlags struct {
+	Format   string
+	Insecure bool
+	Purge    bool
+}
+
+func ManifestPush(logger logging.Logger, pack PackClient) *cobra.Command {
+	var flags ManifestPushFlags
+
+	cmd := &cobra.Command{
+		Use:     "push <index-name>",
+		Args:    cobra.ExactArgs(1),
+		Short:   "Push a local image index to its registry",
+		Example: "pack manifest push cnbs/sample-app:latest",
+		Long:    "Push a local image index to its registry. Images referenced by the index are copied to the repository of the index when missing from it.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			return pack.PushManifest(cmd.Context(), client.PushManifestOptions{
+				IndexRepoName: args[0],
+				Format:        flags.Format,
+				Insecure:      flags.Insecure,
+				Purge:         flags.Purge,
+			})
+		}),
+	}
+
+	cmd.Flags().StringVarP(&flags.Format, "format", "f", "", "Media type of the pushed image index, either 'oci' or 'docker' (defaults to the format the index was created with)")
+	cmd.Flags().BoolVar(&flags.Insecure, "insecure", false, "Allow communicating with registries over HTTP")
+	cmd.Flags().BoolVar(&flags.Purge, "purge", false, "Delete the local image index once pushed")
+	AddHelpFlag(cmd, "push")
+	return cmd
+}
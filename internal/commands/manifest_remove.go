@@ -0,0 +1,34 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type ManifestRemoveFlags struct {
+	Insecure bool
+}
+
+func ManifestRemove(logger logging.Logger, pack PackClient) *cobra.Command {
+	var flags ManifestRemoveFlags
+
+	cmd := &cobra.Command{
+		Use:     "remove <index-name> <image> [<image>...]",
+		Args:    cobra.MinimumNArgs(2),
+		Short:   "Remove images from a local image index",
+		Example: "pack manifest remove cnbs/sample-app:latest cnbs/sample-app:latest-linux-arm64",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			return pack.RemoveManifest(cmd.Context(), client.RemoveManifestOptions{
+				IndexRepoName: args[0],
+				RepoNames:     args[1:],
+				Insecure:      flags.Insecure,
+			})
+		}),
+	}
+
+	cmd.Flags().BoolVar(&flags.Insecure, "insecure", false, "Allow communicating with registries over HTTP")
+	AddHelpFlag(cmd, "remove")
+	return cmd
+}
@@ -0,0 +1,58 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestManifestRemoveCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ManifestRemoveCommand", testManifestRemoveCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testManifestRemoveCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		cmd        *cobra.Command
+		outBuf     bytes.Buffer
+		mockClient *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController := gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		cmd = commands.ManifestRemove(logger, mockClient)
+	})
+
+	when("#ManifestRemove", func() {
+		it("removes the images from the index", func() {
+			mockClient.EXPECT().
+				RemoveManifest(gomock.Any(), client.RemoveManifestOptions{
+					IndexRepoName: "some/index",
+					RepoNames:     []string{"some/image:amd64", "some/image:arm64"},
+				}).
+				Return(nil)
+
+			cmd.SetArgs([]string{"some/index", "some/image:amd64", "some/image:arm64"})
+			h.AssertNil(t, cmd.Execute())
+		})
+
+		it("fails when no image is provided", func() {
+			cmd.SetArgs([]string{"some/index"})
+			h.AssertError(t, cmd.Execute(), "requires at least 2 arg(s)")
+		})
+	})
+}
@@ -0,0 +1,23 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+func ManifestDelete(logger logging.Logger, pack PackClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "rm <index-name> [<index-name>...]",
+		Args:    cobra.MinimumNArgs(1),
+		Short:   "Delete local image indexes",
+		Long:    "Delete local image indexes. Image indexes already pushed to a registry are not affected.",
+		Example: "pack manifest rm cnbs/sample-app:latest",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			return pack.DeleteManifest(cmd.Context(), args)
+		}),
+	}
+
+	AddHelpFlag(cmd, "rm")
+	return cmd
+}
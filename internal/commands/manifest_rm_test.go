@@ -0,0 +1,54 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestManifestDeleteCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ManifestDeleteCommand", testManifestDeleteCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testManifestDeleteCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		cmd        *cobra.Command
+		outBuf     bytes.Buffer
+		mockClient *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController := gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		cmd = commands.ManifestDelete(logger, mockClient)
+	})
+
+	when("#ManifestDelete", func() {
+		it("deletes the local indexes", func() {
+			mockClient.EXPECT().
+				DeleteManifest(gomock.Any(), []string{"some/index", "other/index"}).
+				Return(nil)
+
+			cmd.SetArgs([]string{"some/index", "other/index"})
+			h.AssertNil(t, cmd.Execute())
+		})
+
+		it("fails when no index is provided", func() {
+			cmd.SetArgs([]string{})
+			h.AssertError(t, cmd.Execute(), "requires at least 1 arg")
+		})
+	})
+}
@@ -0,0 +1,24 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+func NewProjectCommand(logger logging.Logger, cfg config.Config, client PackClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "project",
+		Aliases: []string{"projects"},
+		Short:   "Interact with project descriptors",
+		Long:    "A project descriptor (project.toml) configures how an app is built.\n\nStarter descriptors can be generated with `pack project init`, and existing descriptors checked with `pack project validate`.",
+		RunE:    nil,
+	}
+
+	cmd.AddCommand(ProjectValidate(logger, cfg, client))
+	cmd.AddCommand(ProjectInit(logger, cfg, client))
+
+	AddHelpFlag(cmd, "project")
+	return cmd
+}
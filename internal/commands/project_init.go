@@ -0,0 +1,109 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/project"
+	projectTypes "github.com/buildpacks/pack/pkg/project/types"
+)
+
+type ProjectInitFlags struct {
+	AppPath      string
+	Builder      string
+	Policy       string
+	TrustBuilder bool
+	Force        bool
+}
+
+func ProjectInit(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
+	var flags ProjectInitFlags
+
+	cmd := &cobra.Command{
+		Use:     "init",
+		Args:    cobra.NoArgs,
+		Short:   "Generate a starter project descriptor",
+		Example: "pack project init --path apps/test-app --builder cnbs/sample-builder:bionic",
+		Long: "Generate a starter project descriptor for an app.\n\n" +
+			"Detection is run against the app directory using the provided builder, and the buildpacks that pass " +
+			"detection are recorded in a new project.toml alongside the builder.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			descriptorPath := filepath.Join(flags.AppPath, "project.toml")
+			if _, err := os.Stat(descriptorPath); err == nil && !flags.Force {
+				return errors.Errorf("project descriptor %s already exists, use --force to overwrite it", style.Symbol(descriptorPath))
+			}
+
+			if flags.Builder == "" {
+				suggestSettingBuilder(logger, pack)
+				return client.NewSoftError()
+			}
+
+			stringPolicy := flags.Policy
+			if stringPolicy == "" {
+				stringPolicy = cfg.PullPolicy
+			}
+			pullPolicy, err := image.ParsePullPolicy(stringPolicy)
+			if err != nil {
+				return errors.Wrapf(err, "parsing pull policy %s", flags.Policy)
+			}
+
+			trustBuilder := isTrustedBuilder(cfg, flags.Builder) || flags.TrustBuilder
+			result, err := pack.Detect(cmd.Context(), client.BuildOptions{
+				AppPath:           flags.AppPath,
+				Builder:           flags.Builder,
+				AdditionalMirrors: getMirrors(cfg),
+				PullPolicy:        pullPolicy,
+				TrustBuilder: func(string) bool {
+					return trustBuilder
+				},
+				GroupID: -1,
+			})
+			if err != nil {
+				return errors.Wrap(err, "detecting buildpacks")
+			}
+
+			appPath, err := filepath.Abs(flags.AppPath)
+			if err != nil {
+				return err
+			}
+
+			descriptor := projectTypes.Descriptor{
+				Project: projectTypes.Project{Name: filepath.Base(appPath)},
+				Build:   projectTypes.Build{Builder: flags.Builder},
+			}
+			for _, bp := range result.Group {
+				descriptor.Build.Buildpacks = append(descriptor.Build.Buildpacks, projectTypes.Buildpack{
+					ID:      bp.ID,
+					Version: bp.Version,
+				})
+			}
+
+			contents, err := project.EncodeProjectDescriptor(descriptor)
+			if err != nil {
+				return err
+			}
+			if err := os.WriteFile(descriptorPath, contents, 0644); err != nil {
+				return errors.Wrap(err, "writing project descriptor")
+			}
+
+			logger.Infof("Successfully created project descriptor %s", style.Symbol(descriptorPath))
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVarP(&flags.AppPath, "path", "p", ".", "Path to app dir")
+	cmd.Flags().StringVarP(&flags.Builder, "builder", "B", cfg.DefaultBuilder, "Builder image used to detect buildpacks")
+	cmd.Flags().StringVar(&flags.Policy, "pull-policy", "", `Pull policy to use. Accepted values are always, never, and if-not-present. (default "always")`)
+	cmd.Flags().BoolVar(&flags.TrustBuilder, "trust-builder", false, "Trust the provided builder.\nAll lifecycle phases will be run in a single container.")
+	cmd.Flags().BoolVar(&flags.Force, "force", false, "Overwrite an existing project descriptor")
+	AddHelpFlag(cmd, "init")
+	return cmd
+}
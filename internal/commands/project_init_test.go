@@ -0,0 +1,143 @@
+package commands_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/project"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestProjectInitCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ProjectInitCommand", testProjectInitCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testProjectInitCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command    *cobra.Command
+		outBuf     bytes.Buffer
+		mockClient *testmocks.MockPackClient
+		appDir     string
+	)
+
+	it.Before(func() {
+		logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController := gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+
+		var err error
+		appDir, err = os.MkdirTemp("", "project-init-test")
+		h.AssertNil(t, err)
+		appDir = filepath.Join(appDir, "my-app")
+		h.AssertNil(t, os.Mkdir(appDir, 0755))
+
+		command = commands.ProjectInit(logger, config.Config{DefaultBuilder: "default/builder"}, mockClient)
+	})
+
+	it.After(func() {
+		h.AssertNil(t, os.RemoveAll(filepath.Dir(appDir)))
+	})
+
+	when("#ProjectInit", func() {
+		it("writes a project descriptor with the detected buildpacks", func() {
+			mockClient.EXPECT().
+				Detect(gomock.Any(), EqBuildOptionsForDetect(appDir, "default/builder", image.PullAlways)).
+				Return(client.DetectResult{Group: []dist.ModuleInfo{
+					{ID: "some/buildpack", Version: "1.2.3"},
+					{ID: "other/buildpack", Version: "4.5.6"},
+				}}, nil)
+
+			command.SetArgs([]string{"--path", appDir})
+			h.AssertNil(t, command.Execute())
+
+			descriptorPath := filepath.Join(appDir, "project.toml")
+			h.AssertContains(t, outBuf.String(), "Successfully created project descriptor")
+
+			descriptor, err := project.ReadProjectDescriptor(descriptorPath)
+			h.AssertNil(t, err)
+			h.AssertEq(t, descriptor.SchemaVersion.String(), project.LatestSchemaVersion)
+			h.AssertEq(t, descriptor.Project.Name, "my-app")
+			h.AssertEq(t, descriptor.Build.Builder, "default/builder")
+			h.AssertEq(t, len(descriptor.Build.Buildpacks), 2)
+			h.AssertEq(t, descriptor.Build.Buildpacks[0].ID, "some/buildpack")
+			h.AssertEq(t, descriptor.Build.Buildpacks[0].Version, "1.2.3")
+			h.AssertEq(t, descriptor.Build.Buildpacks[1].ID, "other/buildpack")
+			h.AssertEq(t, descriptor.Build.Buildpacks[1].Version, "4.5.6")
+		})
+
+		it("uses the provided builder and pull policy", func() {
+			mockClient.EXPECT().
+				Detect(gomock.Any(), EqBuildOptionsForDetect(appDir, "other/builder", image.PullNever)).
+				Return(client.DetectResult{}, nil)
+
+			command.SetArgs([]string{"--path", appDir, "--builder", "other/builder", "--pull-policy", "never"})
+			h.AssertNil(t, command.Execute())
+		})
+
+		when("a project descriptor already exists", func() {
+			it.Before(func() {
+				h.AssertNil(t, os.WriteFile(filepath.Join(appDir, "project.toml"), []byte("existing"), 0600))
+			})
+
+			it("fails", func() {
+				command.SetArgs([]string{"--path", appDir})
+				h.AssertError(t, command.Execute(), "already exists, use --force to overwrite it")
+
+				contents, err := os.ReadFile(filepath.Join(appDir, "project.toml"))
+				h.AssertNil(t, err)
+				h.AssertEq(t, string(contents), "existing")
+			})
+
+			it("overwrites it with --force", func() {
+				mockClient.EXPECT().
+					Detect(gomock.Any(), gomock.Any()).
+					Return(client.DetectResult{}, nil)
+
+				command.SetArgs([]string{"--path", appDir, "--force"})
+				h.AssertNil(t, command.Execute())
+
+				_, err := project.ReadProjectDescriptor(filepath.Join(appDir, "project.toml"))
+				h.AssertNil(t, err)
+			})
+		})
+
+		it("fails when detection fails", func() {
+			mockClient.EXPECT().
+				Detect(gomock.Any(), gomock.Any()).
+				Return(client.DetectResult{}, errors.New("no buildpacks participating"))
+
+			command.SetArgs([]string{"--path", appDir})
+			h.AssertError(t, command.Execute(), "detecting buildpacks: no buildpacks participating")
+			_, err := os.Stat(filepath.Join(appDir, "project.toml"))
+			h.AssertTrue(t, os.IsNotExist(err))
+		})
+	})
+}
+
+func EqBuildOptionsForDetect(appPath, builder string, pullPolicy image.PullPolicy) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("AppPath=%s, Builder=%s and PullPolicy=%s", appPath, builder, pullPolicy),
+		equals: func(o client.BuildOptions) bool {
+			return o.AppPath == appPath && o.Builder == builder && o.PullPolicy == pullPolicy && o.GroupID == -1
+		},
+	}
+}
@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type ProjectValidateFlags struct {
+	DescriptorPath string
+	Registry       string
+}
+
+func ProjectValidate(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
+	var flags ProjectValidateFlags
+
+	cmd := &cobra.Command{
+		Use:     "validate [<app-dir>]",
+		Args:    cobra.MaximumNArgs(1),
+		Short:   "Validate a project descriptor",
+		Example: "pack project validate apps/test-app",
+		Long: "Validate the project descriptor of an app against its schema version.\n\n" +
+			"Keys that are not part of the schema are reported, and buildpack references are checked against the file system and the buildpack registry.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			descriptorPath := flags.DescriptorPath
+			if descriptorPath == "" {
+				appDir := "."
+				if len(args) > 0 {
+					appDir = args[0]
+				}
+				descriptorPath = filepath.Join(appDir, "project.toml")
+			}
+
+			result, err := pack.ValidateProjectDescriptor(cmd.Context(), client.ValidateProjectDescriptorOptions{
+				ProjectDescriptorPath: descriptorPath,
+				Registry:              flags.Registry,
+			})
+			if err != nil {
+				return err
+			}
+
+			for _, warning := range result.Warnings {
+				logger.Warn(warning)
+			}
+
+			if !result.Valid() {
+				for _, validationErr := range result.Errors {
+					logger.Error(validationErr.Error())
+				}
+				return client.NewSoftError()
+			}
+
+			logger.Infof("Project descriptor %s is valid", style.Symbol(descriptorPath))
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVarP(&flags.DescriptorPath, "descriptor", "d", "", "Path to the project descriptor file\nDefaults to project.toml in the app directory")
+	cmd.Flags().StringVarP(&flags.Registry, "buildpack-registry", "r", cfg.DefaultRegistryName, "Buildpack Registry name used to resolve buildpack references")
+	AddHelpFlag(cmd, "validate")
+	return cmd
+}
@@ -0,0 +1,117 @@
+package commands_test
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestProjectValidateCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ProjectValidateCommand", testProjectValidateCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testProjectValidateCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command    *cobra.Command
+		outBuf     bytes.Buffer
+		mockClient *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController := gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+
+		command = commands.ProjectValidate(logger, config.Config{DefaultRegistryName: "some-registry"}, mockClient)
+	})
+
+	when("#ProjectValidate", func() {
+		it("validates project.toml in the current directory by default", func() {
+			mockClient.EXPECT().
+				ValidateProjectDescriptor(gomock.Any(), client.ValidateProjectDescriptorOptions{
+					ProjectDescriptorPath: "project.toml",
+					Registry:              "some-registry",
+				}).
+				Return(client.ProjectDescriptorValidation{}, nil)
+
+			command.SetArgs([]string{})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "Project descriptor 'project.toml' is valid")
+		})
+
+		it("validates project.toml in the provided app directory", func() {
+			mockClient.EXPECT().
+				ValidateProjectDescriptor(gomock.Any(), client.ValidateProjectDescriptorOptions{
+					ProjectDescriptorPath: filepath.Join("some", "app", "project.toml"),
+					Registry:              "other-registry",
+				}).
+				Return(client.ProjectDescriptorValidation{}, nil)
+
+			command.SetArgs([]string{filepath.Join("some", "app"), "--buildpack-registry", "other-registry"})
+			h.AssertNil(t, command.Execute())
+		})
+
+		it("validates the provided descriptor", func() {
+			mockClient.EXPECT().
+				ValidateProjectDescriptor(gomock.Any(), client.ValidateProjectDescriptorOptions{
+					ProjectDescriptorPath: "other.toml",
+					Registry:              "some-registry",
+				}).
+				Return(client.ProjectDescriptorValidation{}, nil)
+
+			command.SetArgs([]string{"--descriptor", "other.toml"})
+			h.AssertNil(t, command.Execute())
+		})
+
+		it("logs warnings", func() {
+			mockClient.EXPECT().
+				ValidateProjectDescriptor(gomock.Any(), gomock.Any()).
+				Return(client.ProjectDescriptorValidation{Warnings: []string{"some warning"}}, nil)
+
+			command.SetArgs([]string{})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "Warning: some warning")
+		})
+
+		it("logs each error and fails", func() {
+			mockClient.EXPECT().
+				ValidateProjectDescriptor(gomock.Any(), gomock.Any()).
+				Return(client.ProjectDescriptorValidation{Errors: []error{
+					errors.New("first problem"),
+					errors.New("second problem"),
+				}}, nil)
+
+			command.SetArgs([]string{})
+			err := command.Execute()
+			h.AssertError(t, err, client.NewSoftError().Error())
+			h.AssertContains(t, outBuf.String(), "ERROR: first problem")
+			h.AssertContains(t, outBuf.String(), "ERROR: second problem")
+			h.AssertNotContains(t, outBuf.String(), "is valid")
+		})
+
+		it("fails when the descriptor cannot be read", func() {
+			mockClient.EXPECT().
+				ValidateProjectDescriptor(gomock.Any(), gomock.Any()).
+				Return(client.ProjectDescriptorValidation{}, errors.New("reading project descriptor"))
+
+			command.SetArgs([]string{})
+			h.AssertError(t, command.Execute(), "reading project descriptor")
+		})
+	})
+}
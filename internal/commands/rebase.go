@@ -1,6 +1,13 @@
 package commands
 
 import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
 	"github.com/pkg/errors"
 
 	"github.com/spf13/cobra"
@@ -13,19 +20,41 @@ import (
 	"github.com/buildpacks/pack/pkg/logging"
 )
 
+type RebaseFlags struct {
+	ImagesFile  string
+	Concurrency int
+	DryRun      bool
+}
+
 func Rebase(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
 	var opts client.RebaseOptions
+	var flags RebaseFlags
 	var policy string
 
 	cmd := &cobra.Command{
-		Use:     "rebase <image-name>",
-		Args:    cobra.ExactArgs(1),
+		Use:     "rebase <image-name>...",
+		Args:    cobra.ArbitraryArgs,
 		Short:   "Rebase app image with latest run image",
 		Example: "pack rebase buildpacksio/pack",
 		Long: "Rebase allows you to quickly swap out the underlying OS layers (run image) of an app image generated by `pack build` " +
-			"with a newer version of the run image, without re-building the application.",
+			"with a newer version of the run image, without re-building the application.\n\n" +
+			"When more than one image is provided, as arguments or with --images-file, the images are rebased in parallel. " +
+			"Images already based on the latest run image are skipped, and a summary of the rebase of each image is printed.\n\n" +
+			"With --dry-run, the run image is resolved and checked for compatibility with each image, and the layers that would be " +
+			"swapped are reported, without rebasing or writing anything. The command fails when a rebase would not be safe.",
 		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
-			opts.RepoName = args[0]
+			images := args
+			if flags.ImagesFile != "" {
+				fileImages, err := readImagesFile(flags.ImagesFile)
+				if err != nil {
+					return err
+				}
+				images = append(images, fileImages...)
+			}
+			if len(images) == 0 {
+				return errors.New("an image name or the images-file flag is required")
+			}
+
 			opts.AdditionalMirrors = getMirrors(cfg)
 
 			var err error
@@ -38,6 +67,28 @@ func Rebase(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Co
 				return errors.Wrapf(err, "parsing pull policy %s", stringPolicy)
 			}
 
+			if flags.DryRun {
+				if opts.ReportDestinationDir != "" {
+					return errors.New("report-output-dir flag is not supported with the dry-run flag")
+				}
+				return rebaseDryRun(cmd, logger, pack, opts, images)
+			}
+
+			if len(images) > 1 || flags.ImagesFile != "" {
+				if opts.ReportDestinationDir != "" {
+					return errors.New("report-output-dir flag is not supported when rebasing more than one image")
+				}
+				if flags.Concurrency < 1 {
+					return errors.New("concurrency must be at least 1")
+				}
+				return rebaseBatch(cmd, logger, pack, client.RebaseBatchOptions{
+					RebaseOptions: opts,
+					Images:        images,
+					Concurrency:   flags.Concurrency,
+				})
+			}
+
+			opts.RepoName = images[0]
 			if err := pack.Rebase(cmd.Context(), opts); err != nil {
 				return err
 			}
@@ -51,7 +102,131 @@ func Rebase(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Co
 	cmd.Flags().StringVar(&policy, "pull-policy", "", "Pull policy to use. Accepted values are always, never, and if-not-present. The default is always")
 	cmd.Flags().StringVar(&opts.ReportDestinationDir, "report-output-dir", "", "Path to export build report.toml.\nOmitting the flag yield no report file.")
 	cmd.Flags().BoolVar(&opts.Force, "force", false, "Perform rebase operation without target validation (only available for API >= 0.12)")
+	cmd.Flags().StringVar(&flags.ImagesFile, "images-file", "", "Path to a file listing images to rebase, one per line.\nEmpty lines and lines starting with # are ignored.")
+	cmd.Flags().IntVar(&flags.Concurrency, "concurrency", 4, "Maximum number of images rebased at once when rebasing more than one image")
+	cmd.Flags().BoolVar(&flags.DryRun, "dry-run", false, "Report the run image and layers each image would be rebased on, and whether the rebase is safe, without rebasing")
 
 	AddHelpFlag(cmd, "rebase")
 	return cmd
 }
+
+func rebaseBatch(cmd *cobra.Command, logger logging.Logger, pack PackClient, opts client.RebaseBatchOptions) error {
+	results := pack.RebaseBatch(cmd.Context(), opts)
+
+	var rebased, skipped, failed int
+	logger.Info("")
+	logger.Info(style.Step("SUMMARY"))
+	tw := tabwriter.NewWriter(logger.Writer(), writerMinWidth, writerTabWidth, defaultTabWidth, writerPadChar, writerFlags)
+	fmt.Fprint(tw, "IMAGE\tSTATUS\tPREVIOUS RUN IMAGE\tRUN IMAGE\n")
+	for _, result := range results {
+		var status string
+		switch {
+		case result.Err != nil:
+			status = "failed"
+			failed++
+		case result.Skipped:
+			status = "up to date"
+			skipped++
+		default:
+			status = "rebased"
+			rebased++
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", result.Image, status, orNone(result.PreviousRunImage), orNone(result.RunImage))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		if result.Err != nil {
+			logger.Errorf("Failed to rebase %s: %s", style.Symbol(result.Image), result.Err)
+		}
+	}
+	if failed > 0 {
+		return errors.Errorf("failed to rebase %d of %d images", failed, len(results))
+	}
+
+	logger.Infof("Successfully rebased %d image(s), %d already up to date", rebased, skipped)
+	return nil
+}
+
+func rebaseDryRun(cmd *cobra.Command, logger logging.Logger, pack PackClient, opts client.RebaseOptions, images []string) error {
+	var unsafe int
+	for i, imageName := range images {
+		if i > 0 {
+			logger.Info("")
+		}
+
+		opts.RepoName = imageName
+		plan, err := pack.PlanRebase(cmd.Context(), opts)
+		if err != nil {
+			return err
+		}
+
+		logger.Infof("Image %s would be rebased on run image %s", style.Symbol(plan.Image), style.Symbol(plan.RunImage))
+		if plan.UpToDate {
+			logger.Infof("  Already based on the run image, no layers would be swapped")
+		} else {
+			logger.Infof("  Run image layers up to %s (%s) would be swapped", orNone(plan.PreviousTopLayer), orNone(plan.PreviousRunImage))
+			logger.Infof("  for run image layers up to %s (%s)", orNone(plan.TopLayer), orNone(plan.RunImageReference))
+		}
+
+		logger.Info(style.Step("Checks"))
+		tw := tabwriter.NewWriter(logger.Writer(), writerMinWidth, writerTabWidth, defaultTabWidth, writerPadChar, writerFlags)
+		for _, check := range plan.Checks {
+			status := "passed"
+			switch {
+			case check.Forced:
+				status = "forced"
+			case !check.Passed:
+				status = "failed"
+			}
+			fmt.Fprintf(tw, "  %s\t%s\t%s\n", check.Name, status, check.Message)
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+
+		if plan.Safe() {
+			logger.Infof("Rebase of %s is safe", style.Symbol(plan.Image))
+		} else {
+			logger.Infof("Rebase of %s is not safe", style.Symbol(plan.Image))
+			unsafe++
+		}
+	}
+
+	if unsafe > 0 {
+		return errors.Errorf("rebase of %d of %d images is not safe", unsafe, len(images))
+	}
+	return nil
+}
+
+// readImagesFile returns the images listed in a file, one per line
+func readImagesFile(path string) ([]string, error) {
+	file, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, errors.Wrap(err, "reading images file")
+	}
+	defer file.Close()
+
+	var images []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		images = append(images, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "reading images file")
+	}
+	return images, nil
+}
+
+func orNone(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -2,6 +2,9 @@ package commands_test
 
 import (
 	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/heroku/color"
@@ -51,7 +54,7 @@ func testRebaseCommand(t *testing.T, when spec.G, it spec.S) {
 		when("no image is provided", func() {
 			it("fails to run", func() {
 				err := command.Execute()
-				h.AssertError(t, err, "accepts 1 arg")
+				h.AssertError(t, err, "an image name or the images-file flag is required")
 			})
 		})
 
@@ -159,5 +162,145 @@ func testRebaseCommand(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 		})
+
+		when("several images are provided", func() {
+			var batchOpts client.RebaseBatchOptions
+
+			it.Before(func() {
+				batchOpts = client.RebaseBatchOptions{
+					RebaseOptions: client.RebaseOptions{
+						PullPolicy:        image.PullAlways,
+						AdditionalMirrors: map[string][]string{},
+					},
+					Images:      []string{"some/app", "other/app"},
+					Concurrency: 4,
+				}
+			})
+
+			it("rebases them in a batch and prints a summary", func() {
+				mockClient.EXPECT().RebaseBatch(gomock.Any(), batchOpts).Return([]client.RebaseResult{
+					{Image: "some/app", PreviousRunImage: "some/run@sha256:old", RunImage: "some/run@sha256:new"},
+					{Image: "other/app", PreviousRunImage: "some/run@sha256:new", RunImage: "some/run@sha256:new", Skipped: true},
+				})
+
+				command.SetArgs([]string{"some/app", "other/app"})
+				h.AssertNil(t, command.Execute())
+
+				output := outBuf.String()
+				h.AssertContainsMatch(t, output, `IMAGE\s+STATUS\s+PREVIOUS RUN IMAGE\s+RUN IMAGE`)
+				h.AssertContainsMatch(t, output, `some/app\s+rebased\s+some/run@sha256:old\s+some/run@sha256:new`)
+				h.AssertContainsMatch(t, output, `other/app\s+up to date\s+some/run@sha256:new\s+some/run@sha256:new`)
+				h.AssertContains(t, output, "Successfully rebased 1 image(s), 1 already up to date")
+			})
+
+			it("reads images from a file", func() {
+				imagesFile := filepath.Join(t.TempDir(), "images.txt")
+				h.AssertNil(t, os.WriteFile(imagesFile, []byte("# apps\nsome/app\n\nother/app\n"), 0600))
+				batchOpts.Concurrency = 2
+				mockClient.EXPECT().RebaseBatch(gomock.Any(), batchOpts).Return([]client.RebaseResult{
+					{Image: "some/app"},
+					{Image: "other/app"},
+				})
+
+				command.SetArgs([]string{"--images-file", imagesFile, "--concurrency", "2"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("reports failures", func() {
+				mockClient.EXPECT().RebaseBatch(gomock.Any(), batchOpts).Return([]client.RebaseResult{
+					{Image: "some/app", Err: errors.New("some-error")},
+					{Image: "other/app", PreviousRunImage: "some/run@sha256:old", RunImage: "some/run@sha256:new"},
+				})
+
+				command.SetArgs([]string{"some/app", "other/app"})
+				h.AssertError(t, command.Execute(), "failed to rebase 1 of 2 images")
+
+				output := outBuf.String()
+				h.AssertContainsMatch(t, output, `some/app\s+failed\s+-\s+-`)
+				h.AssertContains(t, output, "Failed to rebase 'some/app': some-error")
+			})
+
+			it("errors when the images file cannot be read", func() {
+				command.SetArgs([]string{"--images-file", filepath.Join(t.TempDir(), "missing.txt")})
+				h.AssertError(t, command.Execute(), "reading images file")
+			})
+
+			it("does not support report directories", func() {
+				command.SetArgs([]string{"some/app", "other/app", "--report-output-dir", t.TempDir()})
+				h.AssertError(t, command.Execute(), "report-output-dir flag is not supported when rebasing more than one image")
+			})
+
+			it("requires a positive concurrency", func() {
+				command.SetArgs([]string{"some/app", "other/app", "--concurrency", "0"})
+				h.AssertError(t, command.Execute(), "concurrency must be at least 1")
+			})
+		})
+
+		when("--dry-run", func() {
+			var planOpts client.RebaseOptions
+
+			it.Before(func() {
+				planOpts = client.RebaseOptions{
+					RepoName:          "some/app",
+					PullPolicy:        image.PullAlways,
+					AdditionalMirrors: map[string][]string{},
+				}
+			})
+
+			it("reports the plan and does not rebase", func() {
+				mockClient.EXPECT().PlanRebase(gomock.Any(), planOpts).Return(&client.RebasePlan{
+					Image:             "some/app",
+					RunImage:          "some/run",
+					PreviousRunImage:  "some/run@sha256:old",
+					PreviousTopLayer:  "sha256:old-top",
+					RunImageReference: "some/run@sha256:new",
+					TopLayer:          "sha256:new-top",
+					Checks: []client.RebaseCheck{
+						{Name: client.RebaseCheckStackID, Passed: true},
+						{Name: client.RebaseCheckRunImage, Forced: true, Message: "run image 'some/run' is not found"},
+					},
+				}, nil)
+
+				command.SetArgs([]string{"some/app", "--dry-run"})
+				h.AssertNil(t, command.Execute())
+
+				output := outBuf.String()
+				h.AssertContains(t, output, "Image 'some/app' would be rebased on run image 'some/run'")
+				h.AssertContains(t, output, "Run image layers up to sha256:old-top (some/run@sha256:old) would be swapped")
+				h.AssertContains(t, output, "for run image layers up to sha256:new-top (some/run@sha256:new)")
+				h.AssertContainsMatch(t, output, `stack-id\s+passed`)
+				h.AssertContainsMatch(t, output, `run-image\s+forced\s+run image 'some/run' is not found`)
+				h.AssertContains(t, output, "Rebase of 'some/app' is safe")
+			})
+
+			it("fails when a rebase is not safe", func() {
+				mockClient.EXPECT().PlanRebase(gomock.Any(), planOpts).Return(&client.RebasePlan{
+					Image:    "some/app",
+					RunImage: "some/run",
+					UpToDate: true,
+					Checks:   []client.RebaseCheck{{Name: client.RebaseCheckStackID, Message: "stack not defined on app image"}},
+				}, nil)
+				otherOpts := planOpts
+				otherOpts.RepoName = "other/app"
+				mockClient.EXPECT().PlanRebase(gomock.Any(), otherOpts).Return(&client.RebasePlan{
+					Image:    "other/app",
+					RunImage: "some/run",
+				}, nil)
+
+				command.SetArgs([]string{"some/app", "other/app", "--dry-run"})
+				h.AssertError(t, command.Execute(), "rebase of 1 of 2 images is not safe")
+
+				output := outBuf.String()
+				h.AssertContains(t, output, "Already based on the run image, no layers would be swapped")
+				h.AssertContainsMatch(t, output, `stack-id\s+failed\s+stack not defined on app image`)
+				h.AssertContains(t, output, "Rebase of 'some/app' is not safe")
+				h.AssertContains(t, output, "Rebase of 'other/app' is safe")
+			})
+
+			it("does not support report directories", func() {
+				command.SetArgs([]string{"some/app", "--dry-run", "--report-output-dir", t.TempDir()})
+				h.AssertError(t, command.Execute(), "report-output-dir flag is not supported with the dry-run flag")
+			})
+		})
 	})
 }
@@ -15,6 +15,7 @@ func NewSBOMCommand(logger logging.Logger, cfg config.Config, client PackClient)
 	}
 
 	cmd.AddCommand(DownloadSBOM(logger, client))
+	cmd.AddCommand(InspectSBOM(logger, client))
 	AddHelpFlag(cmd, "sbom")
 	return cmd
 }
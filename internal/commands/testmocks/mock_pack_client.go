@@ -9,6 +9,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
 
 	client "github.com/buildpacks/pack/pkg/client"
 )
@@ -36,6 +37,34 @@ func (m *MockPackClient) EXPECT() *MockPackClientMockRecorder {
 	return m.recorder
 }
 
+// AddManifest mocks base method.
+func (m *MockPackClient) AddManifest(arg0 context.Context, arg1 client.AddManifestOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddManifest", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddManifest indicates an expected call of AddManifest.
+func (mr *MockPackClientMockRecorder) AddManifest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddManifest", reflect.TypeOf((*MockPackClient)(nil).AddManifest), arg0, arg1)
+}
+
+// AnnotateManifest mocks base method.
+func (m *MockPackClient) AnnotateManifest(arg0 context.Context, arg1 client.AnnotateManifestOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnnotateManifest", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AnnotateManifest indicates an expected call of AnnotateManifest.
+func (mr *MockPackClientMockRecorder) AnnotateManifest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnnotateManifest", reflect.TypeOf((*MockPackClient)(nil).AnnotateManifest), arg0, arg1)
+}
+
 // Build mocks base method.
 func (m *MockPackClient) Build(arg0 context.Context, arg1 client.BuildOptions) error {
 	m.ctrl.T.Helper()
@@ -64,6 +93,64 @@ func (mr *MockPackClientMockRecorder) CreateBuilder(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBuilder", reflect.TypeOf((*MockPackClient)(nil).CreateBuilder), arg0, arg1)
 }
 
+// CreateManifest mocks base method.
+func (m *MockPackClient) CreateManifest(arg0 context.Context, arg1 client.CreateManifestOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateManifest", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateManifest indicates an expected call of CreateManifest.
+func (mr *MockPackClientMockRecorder) CreateManifest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateManifest", reflect.TypeOf((*MockPackClient)(nil).CreateManifest), arg0, arg1)
+}
+
+// DeleteManifest mocks base method.
+func (m *MockPackClient) DeleteManifest(arg0 context.Context, arg1 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteManifest", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteManifest indicates an expected call of DeleteManifest.
+func (mr *MockPackClientMockRecorder) DeleteManifest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteManifest", reflect.TypeOf((*MockPackClient)(nil).DeleteManifest), arg0, arg1)
+}
+
+// Detect mocks base method.
+func (m *MockPackClient) Detect(arg0 context.Context, arg1 client.BuildOptions) (client.DetectResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Detect", arg0, arg1)
+	ret0, _ := ret[0].(client.DetectResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Detect indicates an expected call of Detect.
+func (mr *MockPackClientMockRecorder) Detect(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Detect", reflect.TypeOf((*MockPackClient)(nil).Detect), arg0, arg1)
+}
+
+// DiffImage mocks base method.
+func (m *MockPackClient) DiffImage(arg0 context.Context, arg1, arg2 string, arg3 client.DiffImageOptions) (*client.ImageDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffImage", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*client.ImageDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffImage indicates an expected call of DiffImage.
+func (mr *MockPackClientMockRecorder) DiffImage(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffImage", reflect.TypeOf((*MockPackClient)(nil).DiffImage), arg0, arg1, arg2, arg3)
+}
+
 // DownloadSBOM mocks base method.
 func (m *MockPackClient) DownloadSBOM(arg0 string, arg1 client.DownloadSBOMOptions) error {
 	m.ctrl.T.Helper()
@@ -78,6 +165,35 @@ func (mr *MockPackClientMockRecorder) DownloadSBOM(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadSBOM", reflect.TypeOf((*MockPackClient)(nil).DownloadSBOM), arg0, arg1)
 }
 
+// ExportCache mocks base method.
+func (m *MockPackClient) ExportCache(arg0 context.Context, arg1 client.ExportCacheOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportCache", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportCache indicates an expected call of ExportCache.
+func (mr *MockPackClientMockRecorder) ExportCache(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportCache", reflect.TypeOf((*MockPackClient)(nil).ExportCache), arg0, arg1)
+}
+
+// ImportCache mocks base method.
+func (m *MockPackClient) ImportCache(arg0 context.Context, arg1 client.ImportCacheOptions) (client.BuildCache, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportCache", arg0, arg1)
+	ret0, _ := ret[0].(client.BuildCache)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportCache indicates an expected call of ImportCache.
+func (mr *MockPackClientMockRecorder) ImportCache(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportCache", reflect.TypeOf((*MockPackClient)(nil).ImportCache), arg0, arg1)
+}
+
 // InspectBuilder mocks base method.
 func (m *MockPackClient) InspectBuilder(arg0 string, arg1 bool, arg2 ...client.BuilderInspectionModifier) (*client.BuilderInfo, error) {
 	m.ctrl.T.Helper()
@@ -113,6 +229,21 @@ func (mr *MockPackClientMockRecorder) InspectBuildpack(arg0 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InspectBuildpack", reflect.TypeOf((*MockPackClient)(nil).InspectBuildpack), arg0)
 }
 
+// InspectCache mocks base method.
+func (m *MockPackClient) InspectCache(arg0 context.Context, arg1 string) (client.BuildCache, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InspectCache", arg0, arg1)
+	ret0, _ := ret[0].(client.BuildCache)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InspectCache indicates an expected call of InspectCache.
+func (mr *MockPackClientMockRecorder) InspectCache(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InspectCache", reflect.TypeOf((*MockPackClient)(nil).InspectCache), arg0, arg1)
+}
+
 // InspectExtension mocks base method.
 func (m *MockPackClient) InspectExtension(arg0 client.InspectExtensionOptions) (*client.ExtensionInfo, error) {
 	m.ctrl.T.Helper()
@@ -143,6 +274,66 @@ func (mr *MockPackClientMockRecorder) InspectImage(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InspectImage", reflect.TypeOf((*MockPackClient)(nil).InspectImage), arg0, arg1)
 }
 
+// InspectManifest mocks base method.
+func (m *MockPackClient) InspectManifest(arg0 context.Context, arg1 string) (*v1.IndexManifest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InspectManifest", arg0, arg1)
+	ret0, _ := ret[0].(*v1.IndexManifest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InspectManifest indicates an expected call of InspectManifest.
+func (mr *MockPackClientMockRecorder) InspectManifest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InspectManifest", reflect.TypeOf((*MockPackClient)(nil).InspectManifest), arg0, arg1)
+}
+
+// InspectSBOM mocks base method.
+func (m *MockPackClient) InspectSBOM(arg0 string, arg1 client.InspectSBOMOptions) (*client.SBOMInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InspectSBOM", arg0, arg1)
+	ret0, _ := ret[0].(*client.SBOMInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InspectSBOM indicates an expected call of InspectSBOM.
+func (mr *MockPackClientMockRecorder) InspectSBOM(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InspectSBOM", reflect.TypeOf((*MockPackClient)(nil).InspectSBOM), arg0, arg1)
+}
+
+// ListBuildpackVersions mocks base method.
+func (m *MockPackClient) ListBuildpackVersions(arg0 client.ListBuildpackVersionsOptions) ([]client.RegistryBuildpackVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBuildpackVersions", arg0)
+	ret0, _ := ret[0].([]client.RegistryBuildpackVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBuildpackVersions indicates an expected call of ListBuildpackVersions.
+func (mr *MockPackClientMockRecorder) ListBuildpackVersions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBuildpackVersions", reflect.TypeOf((*MockPackClient)(nil).ListBuildpackVersions), arg0)
+}
+
+// ListCaches mocks base method.
+func (m *MockPackClient) ListCaches(arg0 context.Context) ([]client.BuildCache, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCaches", arg0)
+	ret0, _ := ret[0].([]client.BuildCache)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCaches indicates an expected call of ListCaches.
+func (mr *MockPackClientMockRecorder) ListCaches(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCaches", reflect.TypeOf((*MockPackClient)(nil).ListCaches), arg0)
+}
+
 // NewBuildpack mocks base method.
 func (m *MockPackClient) NewBuildpack(arg0 context.Context, arg1 client.NewBuildpackOptions) error {
 	m.ctrl.T.Helper()
@@ -185,6 +376,51 @@ func (mr *MockPackClientMockRecorder) PackageExtension(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PackageExtension", reflect.TypeOf((*MockPackClient)(nil).PackageExtension), arg0, arg1)
 }
 
+// PlanRebase mocks base method.
+func (m *MockPackClient) PlanRebase(arg0 context.Context, arg1 client.RebaseOptions) (*client.RebasePlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PlanRebase", arg0, arg1)
+	ret0, _ := ret[0].(*client.RebasePlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PlanRebase indicates an expected call of PlanRebase.
+func (mr *MockPackClientMockRecorder) PlanRebase(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlanRebase", reflect.TypeOf((*MockPackClient)(nil).PlanRebase), arg0, arg1)
+}
+
+// PrepareBuild mocks base method.
+func (m *MockPackClient) PrepareBuild(arg0 context.Context, arg1 client.BuildOptions) (*client.VendoredBuild, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PrepareBuild", arg0, arg1)
+	ret0, _ := ret[0].(*client.VendoredBuild)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PrepareBuild indicates an expected call of PrepareBuild.
+func (mr *MockPackClientMockRecorder) PrepareBuild(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrepareBuild", reflect.TypeOf((*MockPackClient)(nil).PrepareBuild), arg0, arg1)
+}
+
+// PruneCaches mocks base method.
+func (m *MockPackClient) PruneCaches(arg0 context.Context, arg1 client.PruneCachesOptions) ([]client.BuildCache, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PruneCaches", arg0, arg1)
+	ret0, _ := ret[0].([]client.BuildCache)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PruneCaches indicates an expected call of PruneCaches.
+func (mr *MockPackClientMockRecorder) PruneCaches(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneCaches", reflect.TypeOf((*MockPackClient)(nil).PruneCaches), arg0, arg1)
+}
+
 // PullBuildpack mocks base method.
 func (m *MockPackClient) PullBuildpack(arg0 context.Context, arg1 client.PullBuildpackOptions) error {
 	m.ctrl.T.Helper()
@@ -199,6 +435,20 @@ func (mr *MockPackClientMockRecorder) PullBuildpack(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PullBuildpack", reflect.TypeOf((*MockPackClient)(nil).PullBuildpack), arg0, arg1)
 }
 
+// PushManifest mocks base method.
+func (m *MockPackClient) PushManifest(arg0 context.Context, arg1 client.PushManifestOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PushManifest", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PushManifest indicates an expected call of PushManifest.
+func (mr *MockPackClientMockRecorder) PushManifest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PushManifest", reflect.TypeOf((*MockPackClient)(nil).PushManifest), arg0, arg1)
+}
+
 // Rebase mocks base method.
 func (m *MockPackClient) Rebase(arg0 context.Context, arg1 client.RebaseOptions) error {
 	m.ctrl.T.Helper()
@@ -213,6 +463,20 @@ func (mr *MockPackClientMockRecorder) Rebase(arg0, arg1 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rebase", reflect.TypeOf((*MockPackClient)(nil).Rebase), arg0, arg1)
 }
 
+// RebaseBatch mocks base method.
+func (m *MockPackClient) RebaseBatch(arg0 context.Context, arg1 client.RebaseBatchOptions) []client.RebaseResult {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RebaseBatch", arg0, arg1)
+	ret0, _ := ret[0].([]client.RebaseResult)
+	return ret0
+}
+
+// RebaseBatch indicates an expected call of RebaseBatch.
+func (mr *MockPackClientMockRecorder) RebaseBatch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RebaseBatch", reflect.TypeOf((*MockPackClient)(nil).RebaseBatch), arg0, arg1)
+}
+
 // RegisterBuildpack mocks base method.
 func (m *MockPackClient) RegisterBuildpack(arg0 context.Context, arg1 client.RegisterBuildpackOptions) error {
 	m.ctrl.T.Helper()
@@ -227,6 +491,64 @@ func (mr *MockPackClientMockRecorder) RegisterBuildpack(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterBuildpack", reflect.TypeOf((*MockPackClient)(nil).RegisterBuildpack), arg0, arg1)
 }
 
+// RemoveCache mocks base method.
+func (m *MockPackClient) RemoveCache(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveCache", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveCache indicates an expected call of RemoveCache.
+func (mr *MockPackClientMockRecorder) RemoveCache(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveCache", reflect.TypeOf((*MockPackClient)(nil).RemoveCache), arg0, arg1)
+}
+
+// RemoveManifest mocks base method.
+func (m *MockPackClient) RemoveManifest(arg0 context.Context, arg1 client.RemoveManifestOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveManifest", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveManifest indicates an expected call of RemoveManifest.
+func (mr *MockPackClientMockRecorder) RemoveManifest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveManifest", reflect.TypeOf((*MockPackClient)(nil).RemoveManifest), arg0, arg1)
+}
+
+// SearchBuildpacks mocks base method.
+func (m *MockPackClient) SearchBuildpacks(arg0 client.SearchBuildpacksOptions) ([]client.RegistryBuildpack, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchBuildpacks", arg0)
+	ret0, _ := ret[0].([]client.RegistryBuildpack)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchBuildpacks indicates an expected call of SearchBuildpacks.
+func (mr *MockPackClientMockRecorder) SearchBuildpacks(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchBuildpacks", reflect.TypeOf((*MockPackClient)(nil).SearchBuildpacks), arg0)
+}
+
+// ValidateProjectDescriptor mocks base method.
+func (m *MockPackClient) ValidateProjectDescriptor(arg0 context.Context, arg1 client.ValidateProjectDescriptorOptions) (client.ProjectDescriptorValidation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateProjectDescriptor", arg0, arg1)
+	ret0, _ := ret[0].(client.ProjectDescriptorValidation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateProjectDescriptor indicates an expected call of ValidateProjectDescriptor.
+func (mr *MockPackClientMockRecorder) ValidateProjectDescriptor(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateProjectDescriptor", reflect.TypeOf((*MockPackClient)(nil).ValidateProjectDescriptor), arg0, arg1)
+}
+
 // YankBuildpack mocks base method.
 func (m *MockPackClient) YankBuildpack(arg0 client.YankBuildpackOptions) error {
 	m.ctrl.T.Helper()
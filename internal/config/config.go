@@ -23,12 +23,17 @@ type Config struct {
 	LifecycleImage      string            `toml:"lifecycle-image,omitempty"`
 	RegistryMirrors     map[string]string `toml:"registry-mirrors,omitempty"`
 	LayoutRepositoryDir string            `toml:"layout-repo-dir,omitempty"`
+	RequireDigests      bool              `toml:"require-digests,omitempty"`
+	Offline             bool              `toml:"offline,omitempty"`
 }
 
 type Registry struct {
 	Name string `toml:"name"`
 	Type string `toml:"type"`
 	URL  string `toml:"url"`
+	// APIURL is the REST API endpoint used to publish to a github registry without a browser.
+	// When empty, it is derived from URL.
+	APIURL string `toml:"api-url,omitempty"`
 }
 
 type RunImage struct {
@@ -44,9 +49,9 @@ const OfficialRegistryName = "official"
 
 func DefaultRegistry() Registry {
 	return Registry{
-		OfficialRegistryName,
-		"github",
-		"https://github.com/buildpacks/registry-index",
+		Name: OfficialRegistryName,
+		Type: "github",
+		URL:  "https://github.com/buildpacks/registry-index",
 	}
 }
 
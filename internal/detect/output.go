@@ -0,0 +1,75 @@
+package detect
+
+import (
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/dist"
+)
+
+type RequireDisplay struct {
+	Name     string                 `json:"name" yaml:"name" toml:"name"`
+	Version  string                 `json:"version,omitempty" yaml:"version,omitempty" toml:"version,omitempty"`
+	Metadata map[string]interface{} `json:"metadata,omitempty" yaml:"metadata,omitempty" toml:"metadata,omitempty"`
+}
+
+type PlanEntryDisplay struct {
+	Providers []dist.ModuleInfo `json:"providers" yaml:"providers" toml:"providers"`
+	Requires  []RequireDisplay  `json:"requires" yaml:"requires" toml:"requires"`
+}
+
+type SkippedBuildpackDisplay struct {
+	ID      string `json:"id" yaml:"id" toml:"id"`
+	Version string `json:"version,omitempty" yaml:"version,omitempty" toml:"version,omitempty"`
+	Reason  string `json:"reason" yaml:"reason" toml:"reason"`
+}
+
+type Output struct {
+	Group             []dist.ModuleInfo         `json:"group" yaml:"group" toml:"group"`
+	GroupExtensions   []dist.ModuleInfo         `json:"group_extensions,omitempty" yaml:"group_extensions,omitempty" toml:"group_extensions,omitempty"`
+	Plan              []PlanEntryDisplay        `json:"plan" yaml:"plan" toml:"plan"`
+	SkippedBuildpacks []SkippedBuildpackDisplay `json:"skipped_buildpacks" yaml:"skipped_buildpacks" toml:"skipped_buildpacks"`
+}
+
+func NewOutput(result client.DetectResult) Output {
+	output := Output{
+		Group:             result.Group,
+		GroupExtensions:   result.GroupExtensions,
+		Plan:              []PlanEntryDisplay{},
+		SkippedBuildpacks: []SkippedBuildpackDisplay{},
+	}
+	if output.Group == nil {
+		output.Group = []dist.ModuleInfo{}
+	}
+
+	for _, entry := range result.Plan {
+		display := PlanEntryDisplay{Providers: entry.Providers}
+		for _, require := range entry.Requires {
+			display.Requires = append(display.Requires, RequireDisplay{
+				Name:     require.Name,
+				Version:  require.Version,
+				Metadata: require.Metadata,
+			})
+		}
+		output.Plan = append(output.Plan, display)
+	}
+
+	for _, skipped := range result.SkippedBuildpacks {
+		output.SkippedBuildpacks = append(output.SkippedBuildpacks, SkippedBuildpackDisplay{
+			ID:      skipped.ID,
+			Version: skipped.Version,
+			Reason:  skipped.Reason,
+		})
+	}
+
+	return output
+}
+
+// RequiredVersion returns the version of a requirement, which may be provided as metadata.
+func (r RequireDisplay) RequiredVersion() string {
+	if r.Version != "" {
+		return r.Version
+	}
+	if version, ok := r.Metadata["version"].(string); ok {
+		return version
+	}
+	return ""
+}
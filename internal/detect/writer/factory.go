@@ -0,0 +1,32 @@
+package writer
+
+import (
+	"fmt"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type Factory struct{}
+
+type DetectWriter interface {
+	Print(logger logging.Logger, result client.DetectResult) error
+}
+
+func NewFactory() *Factory {
+	return &Factory{}
+}
+
+func (f *Factory) Writer(kind string) (DetectWriter, error) {
+	switch kind {
+	case "human-readable":
+		return NewHumanReadable(), nil
+	case "json":
+		return NewJSON(), nil
+	case "yaml":
+		return NewYAML(), nil
+	}
+
+	return nil, fmt.Errorf("output format %s is not supported", style.Symbol(kind))
+}
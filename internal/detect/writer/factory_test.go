@@ -0,0 +1,68 @@
+package writer_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/detect/writer"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestFactory(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Detect Writer Factory", testFactory, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testFactory(t *testing.T, when spec.G, it spec.S) {
+	var assert = h.NewAssertionManager(t)
+
+	when("Writer", func() {
+		when("output format is human-readable", func() {
+			it("returns a HumanReadable writer", func() {
+				returnedWriter, err := writer.NewFactory().Writer("human-readable")
+				assert.Nil(err)
+				_, ok := returnedWriter.(*writer.HumanReadable)
+				assert.TrueWithMessage(
+					ok,
+					fmt.Sprintf("expected %T to be assignable to type `*writer.HumanReadable`", returnedWriter),
+				)
+			})
+		})
+
+		when("output format is json", func() {
+			it("returns a JSON writer", func() {
+				returnedWriter, err := writer.NewFactory().Writer("json")
+				assert.Nil(err)
+				_, ok := returnedWriter.(*writer.JSON)
+				assert.TrueWithMessage(
+					ok,
+					fmt.Sprintf("expected %T to be assignable to type `*writer.JSON`", returnedWriter),
+				)
+			})
+		})
+
+		when("output format is yaml", func() {
+			it("returns a YAML writer", func() {
+				returnedWriter, err := writer.NewFactory().Writer("yaml")
+				assert.Nil(err)
+				_, ok := returnedWriter.(*writer.YAML)
+				assert.TrueWithMessage(
+					ok,
+					fmt.Sprintf("expected %T to be assignable to type `*writer.YAML`", returnedWriter),
+				)
+			})
+		})
+
+		when("output format is not supported", func() {
+			it("returns an error", func() {
+				_, err := writer.NewFactory().Writer("mind-beam")
+				assert.ErrorWithMessage(err, "output format 'mind-beam' is not supported")
+			})
+		})
+	})
+}
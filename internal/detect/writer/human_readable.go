@@ -0,0 +1,90 @@
+package writer
+
+import (
+	"bytes"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	"github.com/buildpacks/pack/internal/detect"
+	strs "github.com/buildpacks/pack/internal/strings"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type HumanReadable struct{}
+
+func NewHumanReadable() *HumanReadable {
+	return &HumanReadable{}
+}
+
+func (h *HumanReadable) Print(logger logging.Logger, result client.DetectResult) error {
+	tpl := template.Must(template.New("detect").
+		Funcs(template.FuncMap{
+			"StringsValueOrDefault": strs.ValueOrDefault,
+			"Providers":             providers,
+		}).
+		Parse(detectTemplate))
+
+	buf := bytes.NewBuffer(nil)
+	tw := tabwriter.NewWriter(buf, 0, 0, 8, ' ', 0)
+	if err := tpl.Execute(tw, detect.NewOutput(result)); err != nil {
+		return err
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	logger.Info(buf.String())
+	return nil
+}
+
+func providers(entry detect.PlanEntryDisplay) string {
+	var names []string
+	for _, provider := range entry.Providers {
+		names = append(names, provider.FullName())
+	}
+	return strings.Join(names, ", ")
+}
+
+var detectTemplate = `
+Detected Buildpacks:
+{{- if .Group }}
+  ID	VERSION
+{{- range $_, $b := .Group }}
+  {{ $b.ID }}	{{ $b.Version }}
+{{- end }}
+{{- else }}
+  (none)
+{{- end }}
+{{- if .GroupExtensions }}
+
+Detected Extensions:
+  ID	VERSION
+{{- range $_, $e := .GroupExtensions }}
+  {{ $e.ID }}	{{ $e.Version }}
+{{- end }}
+{{- end }}
+
+Build Plan:
+{{- if .Plan }}
+  NAME	VERSION	PROVIDED BY
+{{- range $_, $entry := .Plan }}
+{{- range $_, $r := $entry.Requires }}
+  {{ $r.Name }}	{{ StringsValueOrDefault $r.RequiredVersion "-" }}	{{ Providers $entry }}
+{{- end }}
+{{- end }}
+{{- else }}
+  (none)
+{{- end }}
+
+Skipped Optional Buildpacks:
+{{- if .SkippedBuildpacks }}
+  ID	VERSION	REASON
+{{- range $_, $s := .SkippedBuildpacks }}
+  {{ $s.ID }}	{{ $s.Version }}	{{ $s.Reason }}
+{{- end }}
+{{- else }}
+  (none)
+{{- end }}
+`
@@ -0,0 +1,82 @@
+package writer_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/detect/writer"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestHumanReadable(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Human Readable Writer", testHumanReadable, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testHumanReadable(t *testing.T, when spec.G, it spec.S) {
+	var (
+		assert = h.NewAssertionManager(t)
+		outBuf bytes.Buffer
+	)
+
+	when("Print", func() {
+		it("prints the group, build plan and skipped buildpacks", func() {
+			logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+			assert.Nil(writer.NewHumanReadable().Print(logger, detectResult))
+
+			assert.Contains(outBuf.String(), `
+Detected Buildpacks:
+  ID                     VERSION
+  some/buildpack         1.2.3
+  other/buildpack        4.5.6
+
+Build Plan:
+  NAME                    VERSION        PROVIDED BY
+  some-dependency         7.8.9          some/buildpack@1.2.3
+  other-dependency        -              some/buildpack@1.2.3, other/buildpack@4.5.6
+
+Skipped Optional Buildpacks:
+  ID                        VERSION        REASON
+  optional/buildpack        0.1.0          failed detection
+`)
+			assert.NotContains(outBuf.String(), "Detected Extensions:")
+		})
+
+		it("prints detected extensions", func() {
+			logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+			assert.Nil(writer.NewHumanReadable().Print(logger, client.DetectResult{
+				GroupExtensions: []dist.ModuleInfo{{ID: "some/extension", Version: "0.0.1"}},
+			}))
+
+			assert.Contains(outBuf.String(), `
+Detected Extensions:
+  ID                    VERSION
+  some/extension        0.0.1
+`)
+		})
+
+		it("prints placeholders when nothing was detected", func() {
+			logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+			assert.Nil(writer.NewHumanReadable().Print(logger, client.DetectResult{}))
+
+			assert.Contains(outBuf.String(), `
+Detected Buildpacks:
+  (none)
+
+Build Plan:
+  (none)
+
+Skipped Optional Buildpacks:
+  (none)
+`)
+		})
+	})
+}
@@ -0,0 +1,31 @@
+package writer_test
+
+import (
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/dist"
+)
+
+var detectResult = client.DetectResult{
+	Group: []dist.ModuleInfo{
+		{ID: "some/buildpack", Version: "1.2.3"},
+		{ID: "other/buildpack", Version: "4.5.6"},
+	},
+	Plan: []client.DetectPlanEntry{
+		{
+			Providers: []dist.ModuleInfo{{ID: "some/buildpack", Version: "1.2.3"}},
+			Requires: []client.DetectRequire{
+				{Name: "some-dependency", Metadata: map[string]interface{}{"version": "7.8.9"}},
+			},
+		},
+		{
+			Providers: []dist.ModuleInfo{
+				{ID: "some/buildpack", Version: "1.2.3"},
+				{ID: "other/buildpack", Version: "4.5.6"},
+			},
+			Requires: []client.DetectRequire{{Name: "other-dependency"}},
+		},
+	},
+	SkippedBuildpacks: []client.SkippedBuildpack{
+		{ModuleInfo: dist.ModuleInfo{ID: "optional/buildpack", Version: "0.1.0"}, Reason: "failed detection"},
+	},
+}
@@ -0,0 +1,21 @@
+package writer
+
+import (
+	"github.com/buildpacks/pack/internal/detect"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type StructuredFormat struct {
+	MarshalFunc func(interface{}) ([]byte, error)
+}
+
+func (w *StructuredFormat) Print(logger logging.Logger, result client.DetectResult) error {
+	out, err := w.MarshalFunc(detect.NewOutput(result))
+	if err != nil {
+		return err
+	}
+
+	_, err = logger.Writer().Write(out)
+	return err
+}
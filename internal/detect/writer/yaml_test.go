@@ -0,0 +1,61 @@
+package writer_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/detect/writer"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestYAML(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "YAML Writer", testYAML, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testYAML(t *testing.T, when spec.G, it spec.S) {
+	var (
+		assert = h.NewAssertionManager(t)
+		outBuf bytes.Buffer
+	)
+
+	when("Print", func() {
+		it("prints the detect result in YAML format", func() {
+			logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+			assert.Nil(writer.NewYAML().Print(logger, detectResult))
+
+			assert.EqualYAML(outBuf.String(), `---
+group:
+- id: some/buildpack
+  version: 1.2.3
+- id: other/buildpack
+  version: 4.5.6
+plan:
+- providers:
+  - id: some/buildpack
+    version: 1.2.3
+  requires:
+  - name: some-dependency
+    metadata:
+      version: 7.8.9
+- providers:
+  - id: some/buildpack
+    version: 1.2.3
+  - id: other/buildpack
+    version: 4.5.6
+  requires:
+  - name: other-dependency
+skipped_buildpacks:
+- id: optional/buildpack
+  version: 0.1.0
+  reason: failed detection
+`)
+		})
+	})
+}
@@ -0,0 +1,104 @@
+// Package events records machine-readable build events as newline-delimited JSON.
+package events
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+type Type string
+
+const (
+	PhaseStarted  Type = "phase_started"
+	PhaseFinished Type = "phase_finished"
+	DetectResult  Type = "detect_result"
+	BuildResult   Type = "build_result"
+	CacheHit      Type = "cache_hit"
+	CacheMiss     Type = "cache_miss"
+	ImageExported Type = "image_exported"
+	Warning       Type = "warning"
+)
+
+// Results reported by DetectResult and BuildResult events
+const (
+	ResultPass    = "pass"
+	ResultSkip    = "skip"
+	ResultFail    = "fail"
+	ResultError   = "error"
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+)
+
+type Event struct {
+	Time       time.Time `json:"time"`
+	Type       Type      `json:"type"`
+	Phase      string    `json:"phase,omitempty"`
+	DurationMS int64     `json:"duration_ms,omitempty"`
+	Buildpack  string    `json:"buildpack,omitempty"`
+	Result     string    `json:"result,omitempty"`
+	Layer      string    `json:"layer,omitempty"`
+	Image      string    `json:"image,omitempty"`
+	Digest     string    `json:"digest,omitempty"`
+	Message    string    `json:"message,omitempty"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// Recorder writes events to a stream, one JSON object per line. It is safe for concurrent use, and a nil
+// Recorder discards all events.
+type Recorder struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+	now func() time.Time
+}
+
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{
+		enc: json.NewEncoder(w),
+		now: time.Now,
+	}
+}
+
+// WithClock sets the function used to timestamp events.
+func (r *Recorder) WithClock(now func() time.Time) *Recorder {
+	r.now = now
+	return r
+}
+
+// Record writes e to the stream, setting its time if it is not set. Failures to write are ignored so that the
+// event stream never causes a build to fail.
+func (r *Recorder) Record(e Event) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if e.Time.IsZero() {
+		e.Time = r.now().UTC()
+	}
+	_ = r.enc.Encode(e)
+}
+
+func (r *Recorder) PhaseStarted(phase string) {
+	r.Record(Event{Type: PhaseStarted, Phase: phase})
+}
+
+func (r *Recorder) PhaseFinished(phase string, duration time.Duration, err error) {
+	e := Event{Type: PhaseFinished, Phase: phase, DurationMS: duration.Milliseconds(), Result: ResultSuccess}
+	if err != nil {
+		e.Result = ResultFailure
+		e.Error = err.Error()
+	}
+	r.Record(e)
+}
+
+func (r *Recorder) ImageExported(image, digest string) {
+	r.Record(Event{Type: ImageExported, Image: image, Digest: digest})
+}
+
+func (r *Recorder) Warning(phase, message string) {
+	r.Record(Event{Type: Warning, Phase: phase, Message: message})
+}
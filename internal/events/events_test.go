@@ -0,0 +1,93 @@
+package events_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/events"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestEvents(t *testing.T) {
+	spec.Run(t, "Events", testEvents, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func readEvents(t *testing.T, buf *bytes.Buffer) []events.Event {
+	t.Helper()
+
+	var recorded []events.Event
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var e events.Event
+		h.AssertNil(t, json.Unmarshal([]byte(line), &e))
+		recorded = append(recorded, e)
+	}
+	return recorded
+}
+
+func testEvents(t *testing.T, when spec.G, it spec.S) {
+	var (
+		outBuf   bytes.Buffer
+		recorder *events.Recorder
+		now      = time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	)
+
+	it.Before(func() {
+		outBuf.Reset()
+		recorder = events.NewRecorder(&outBuf).WithClock(func() time.Time { return now })
+	})
+
+	when("#Record", func() {
+		it("writes one JSON object per line", func() {
+			recorder.PhaseStarted("detector")
+			recorder.Warning("detector", "some warning")
+
+			h.AssertEq(t, outBuf.String(),
+				`{"time":"2023-01-02T03:04:05Z","type":"phase_started","phase":"detector"}`+"\n"+
+					`{"time":"2023-01-02T03:04:05Z","type":"warning","phase":"detector","message":"some warning"}`+"\n")
+		})
+
+		it("keeps the provided time", func() {
+			other := now.Add(time.Hour)
+			recorder.Record(events.Event{Time: other, Type: events.Warning})
+
+			h.AssertEq(t, readEvents(t, &outBuf)[0].Time, other)
+		})
+
+		it("ignores a nil recorder", func() {
+			var nilRecorder *events.Recorder
+			nilRecorder.PhaseStarted("detector")
+		})
+	})
+
+	when("#PhaseFinished", func() {
+		it("records the duration and result", func() {
+			recorder.PhaseFinished("builder", 1500*time.Millisecond, nil)
+			recorder.PhaseFinished("exporter", time.Second, errors.New("some error"))
+
+			h.AssertEq(t, readEvents(t, &outBuf), []events.Event{
+				{Time: now, Type: events.PhaseFinished, Phase: "builder", DurationMS: 1500, Result: events.ResultSuccess},
+				{Time: now, Type: events.PhaseFinished, Phase: "exporter", DurationMS: 1000, Result: events.ResultFailure, Error: "some error"},
+			})
+		})
+	})
+
+	when("#ImageExported", func() {
+		it("records the image and digest", func() {
+			recorder.ImageExported("index.docker.io/some/app:latest", "sha256:abc")
+
+			h.AssertEq(t, readEvents(t, &outBuf), []events.Event{
+				{Time: now, Type: events.ImageExported, Image: "index.docker.io/some/app:latest", Digest: "sha256:abc"},
+			})
+		})
+	})
+}
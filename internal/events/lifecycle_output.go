@@ -0,0 +1,150 @@
+package events
+
+import (
+	"bytes"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+var (
+	colorCodes        = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+	detectResultLine  = regexp.MustCompile(`^(pass|skip|fail): (\S+@\S+)(?: (requires|provides unused) (.+))?$`)
+	detectErrorLine   = regexp.MustCompile(`^err:\s+(\S+@\S+)(?: \((-?\d+)\))?$`)
+	buildStartedLine  = regexp.MustCompile(`^Running build for buildpack (\S+)$`)
+	buildFinishedLine = regexp.MustCompile(`^Finished running build for buildpack (\S+)$`)
+	cacheHitLines     = []*regexp.Regexp{
+		regexp.MustCompile(`^Restoring data for "(.+)" from cache$`),
+		regexp.MustCompile(`^Reusing cache layer '(.+)'$`),
+	}
+	cacheMissLines = []*regexp.Regexp{
+		regexp.MustCompile(`^Removing "(.+)", (?:not in cache|wrong sha)$`),
+		regexp.MustCompile(`^Adding cache layer '(.+)'$`),
+	}
+)
+
+const warningPrefix = "Warning: "
+
+// LifecycleOutput parses the output of a lifecycle phase and records the events it describes.
+// Per-buildpack results are only logged by the lifecycle at debug level.
+// It is an io.Writer for the standard output of the phase, see Stderr for its standard error.
+type LifecycleOutput struct {
+	recorder *Recorder
+	phase    string
+
+	mu       sync.Mutex
+	stdout   *outputStream
+	stderr   *outputStream
+	building string
+}
+
+func NewLifecycleOutput(recorder *Recorder, phase string) *LifecycleOutput {
+	o := &LifecycleOutput{recorder: recorder, phase: phase}
+	o.stdout = &outputStream{output: o}
+	o.stderr = &outputStream{output: o}
+	return o
+}
+
+func (o *LifecycleOutput) Write(p []byte) (int, error) {
+	return o.stdout.Write(p)
+}
+
+// Stderr returns the io.Writer for the standard error of the phase. Lines are buffered per stream, so that partial
+// lines of one stream are not mixed with the other.
+func (o *LifecycleOutput) Stderr() io.Writer {
+	return o.stderr
+}
+
+// Finish parses any remaining output and, when the phase failed, records a failure for the buildpack that was
+// building.
+func (o *LifecycleOutput) Finish(err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for _, stream := range []*outputStream{o.stdout, o.stderr} {
+		if len(stream.buf) > 0 {
+			o.parse(string(stream.buf))
+			stream.buf = nil
+		}
+	}
+
+	if err != nil && o.building != "" {
+		o.recorder.Record(Event{Type: BuildResult, Phase: o.phase, Buildpack: o.building, Result: ResultFailure, Error: err.Error()})
+	}
+	o.building = ""
+}
+
+// outputStream buffers the output of one stream of a phase until a line is complete
+type outputStream struct {
+	output *LifecycleOutput
+	buf    []byte
+}
+
+func (s *outputStream) Write(p []byte) (int, error) {
+	s.output.mu.Lock()
+	defer s.output.mu.Unlock()
+
+	s.buf = append(s.buf, p...)
+	for {
+		i := bytes.IndexByte(s.buf, '\n')
+		if i < 0 {
+			break
+		}
+		s.output.parse(string(s.buf[:i]))
+		s.buf = s.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+func (o *LifecycleOutput) parse(line string) {
+	line = strings.TrimSpace(colorCodes.ReplaceAllString(line, ""))
+
+	if strings.HasPrefix(line, warningPrefix) {
+		o.recorder.Warning(o.phase, strings.TrimPrefix(line, warningPrefix))
+		return
+	}
+
+	if match := detectResultLine.FindStringSubmatch(line); match != nil {
+		e := Event{Type: DetectResult, Phase: o.phase, Buildpack: match[2], Result: match[1]}
+		if match[3] != "" {
+			e.Message = match[3] + " " + match[4]
+		}
+		o.recorder.Record(e)
+		return
+	}
+
+	if match := detectErrorLine.FindStringSubmatch(line); match != nil {
+		e := Event{Type: DetectResult, Phase: o.phase, Buildpack: match[1], Result: ResultError}
+		if match[2] != "" {
+			e.Message = "exit code " + match[2]
+		}
+		o.recorder.Record(e)
+		return
+	}
+
+	if match := buildStartedLine.FindStringSubmatch(line); match != nil {
+		o.building = match[1]
+		return
+	}
+
+	if match := buildFinishedLine.FindStringSubmatch(line); match != nil {
+		o.recorder.Record(Event{Type: BuildResult, Phase: o.phase, Buildpack: match[1], Result: ResultSuccess})
+		o.building = ""
+		return
+	}
+
+	for _, re := range cacheHitLines {
+		if match := re.FindStringSubmatch(line); match != nil {
+			o.recorder.Record(Event{Type: CacheHit, Phase: o.phase, Layer: match[1]})
+			return
+		}
+	}
+
+	for _, re := range cacheMissLines {
+		if match := re.FindStringSubmatch(line); match != nil {
+			o.recorder.Record(Event{Type: CacheMiss, Phase: o.phase, Layer: match[1]})
+			return
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package events_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/events"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestLifecycleOutput(t *testing.T) {
+	spec.Run(t, "LifecycleOutput", testLifecycleOutput, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testLifecycleOutput(t *testing.T, when spec.G, it spec.S) {
+	var (
+		outBuf   bytes.Buffer
+		recorder *events.Recorder
+		now      = time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	)
+
+	it.Before(func() {
+		outBuf.Reset()
+		recorder = events.NewRecorder(&outBuf).WithClock(func() time.Time { return now })
+	})
+
+	write := func(output *events.LifecycleOutput, s string) {
+		_, err := fmt.Fprint(output, s)
+		h.AssertNil(t, err)
+	}
+
+	it("records detect results", func() {
+		output := events.NewLifecycleOutput(recorder, "detector")
+		write(output, `======== Results ========
+pass: some/buildpack@1.0.0
+skip: optional/buildpack@2.0.0
+fail: required/buildpack@3.0.0
+err:  broken/buildpack@4.0.0 (1)
+fail: no viable buildpacks in group
+Resolving plan... (try #1)
+skip: requiring/buildpack@5.0.0 requires some-dependency
+`)
+		output.Finish(nil)
+
+		h.AssertEq(t, readEvents(t, &outBuf), []events.Event{
+			{Time: now, Type: events.DetectResult, Phase: "detector", Buildpack: "some/buildpack@1.0.0", Result: events.ResultPass},
+			{Time: now, Type: events.DetectResult, Phase: "detector", Buildpack: "optional/buildpack@2.0.0", Result: events.ResultSkip},
+			{Time: now, Type: events.DetectResult, Phase: "detector", Buildpack: "required/buildpack@3.0.0", Result: events.ResultFail},
+			{Time: now, Type: events.DetectResult, Phase: "detector", Buildpack: "broken/buildpack@4.0.0", Result: events.ResultError, Message: "exit code 1"},
+			{Time: now, Type: events.DetectResult, Phase: "detector", Buildpack: "requiring/buildpack@5.0.0", Result: events.ResultSkip, Message: "requires some-dependency"},
+		})
+	})
+
+	it("records build results", func() {
+		output := events.NewLifecycleOutput(recorder, "builder")
+		write(output, "Running build for buildpack some/buildpack@1.0.0\n")
+		write(output, "some build output\n")
+		write(output, "Finished running build for buildpack some/buildpack@1.0.0\n")
+		write(output, "Running build for buildpack other/buildpack@2.0.0\n")
+		output.Finish(errors.New("failed with status code: 51"))
+
+		h.AssertEq(t, readEvents(t, &outBuf), []events.Event{
+			{Time: now, Type: events.BuildResult, Phase: "builder", Buildpack: "some/buildpack@1.0.0", Result: events.ResultSuccess},
+			{Time: now, Type: events.BuildResult, Phase: "builder", Buildpack: "other/buildpack@2.0.0", Result: events.ResultFailure, Error: "failed with status code: 51"},
+		})
+	})
+
+	it("records cache hits and misses", func() {
+		output := events.NewLifecycleOutput(recorder, "restorer")
+		write(output, `Restoring data for "some/buildpack:hit" from cache
+Removing "some/buildpack:gone", not in cache
+Removing "some/buildpack:stale", wrong sha
+`)
+		output.Finish(nil)
+
+		h.AssertEq(t, readEvents(t, &outBuf), []events.Event{
+			{Time: now, Type: events.CacheHit, Phase: "restorer", Layer: "some/buildpack:hit"},
+			{Time: now, Type: events.CacheMiss, Phase: "restorer", Layer: "some/buildpack:gone"},
+			{Time: now, Type: events.CacheMiss, Phase: "restorer", Layer: "some/buildpack:stale"},
+		})
+	})
+
+	it("records warnings without color codes", func() {
+		output := events.NewLifecycleOutput(recorder, "exporter")
+		write(output, "\x1b[33mWarning: \x1b[0msome warning\nReusing cache layer 'some/buildpack:layer'\nAdding cache layer 'some/buildpack:new'")
+		output.Finish(nil)
+
+		h.AssertEq(t, readEvents(t, &outBuf), []events.Event{
+			{Time: now, Type: events.Warning, Phase: "exporter", Message: "some warning"},
+			{Time: now, Type: events.CacheHit, Phase: "exporter", Layer: "some/buildpack:layer"},
+			{Time: now, Type: events.CacheMiss, Phase: "exporter", Layer: "some/buildpack:new"},
+		})
+	})
+
+	it("handles lines split across writes", func() {
+		output := events.NewLifecycleOutput(recorder, "detector")
+		write(output, "pass: some/bu")
+		write(output, "ildpack@1.0.0\n")
+
+		h.AssertEq(t, len(readEvents(t, &outBuf)), 1)
+	})
+
+	it("keeps the lines of stdout and stderr apart", func() {
+		output := events.NewLifecycleOutput(recorder, "detector")
+		write(output, "pass: some/bu")
+		_, err := fmt.Fprint(output.Stderr(), "Warning: some")
+		h.AssertNil(t, err)
+		write(output, "ildpack@1.0.0\n")
+		_, err = fmt.Fprint(output.Stderr(), " warning\n")
+		h.AssertNil(t, err)
+
+		h.AssertEq(t, readEvents(t, &outBuf), []events.Event{
+			{Time: now, Type: events.DetectResult, Phase: "detector", Buildpack: "some/buildpack@1.0.0", Result: events.ResultPass},
+			{Time: now, Type: events.Warning, Phase: "detector", Message: "some warning"},
+		})
+	})
+}
@@ -0,0 +1,93 @@
+package fakes
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+)
+
+// FakeIssue is an issue created through a FakeGithubAPI
+type FakeIssue struct {
+	Repository string
+	Title      string
+	Body       string
+}
+
+// FakeGithubAPI serves the issue creation endpoint of the GitHub REST API, and records the issues it creates
+type FakeGithubAPI struct {
+	Server *httptest.Server
+
+	// Token the requests must be authenticated with
+	Token string
+
+	mu     sync.Mutex
+	issues []FakeIssue
+}
+
+// NewFakeGithubAPI starts a FakeGithubAPI accepting token. It must be closed with Close.
+func NewFakeGithubAPI(token string) *FakeGithubAPI {
+	api := &FakeGithubAPI{Token: token}
+	api.Server = httptest.NewServer(http.HandlerFunc(api.handle))
+	return api
+}
+
+// URL returns the REST API endpoint of the fake
+func (a *FakeGithubAPI) URL() string {
+	return a.Server.URL
+}
+
+// Issues returns the issues created so far
+func (a *FakeGithubAPI) Issues() []FakeIssue {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]FakeIssue{}, a.issues...)
+}
+
+func (a *FakeGithubAPI) Close() {
+	a.Server.Close()
+}
+
+func (a *FakeGithubAPI) handle(w http.ResponseWriter, req *http.Request) {
+	// issues are created at /repos/<owner>/<repository>/issues
+	parts := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	if req.Method != http.MethodPost || len(parts) != 4 || parts[0] != "repos" || parts[3] != "issues" {
+		writeAPIError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	if req.Header.Get("Authorization") != "Bearer "+a.Token {
+		writeAPIError(w, http.StatusUnauthorized, "Bad credentials")
+		return
+	}
+
+	var payload struct {
+		Title string `json:"title"`
+		Body  string `json:"body"`
+	}
+	if err := json.NewDecoder(req.Body).Decode(&payload); err != nil || payload.Title == "" {
+		writeAPIError(w, http.StatusUnprocessableEntity, "Validation Failed")
+		return
+	}
+
+	a.mu.Lock()
+	repository := parts[1] + "/" + parts[2]
+	a.issues = append(a.issues, FakeIssue{Repository: repository, Title: payload.Title, Body: payload.Body})
+	number := len(a.issues)
+	a.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"number":   number,
+		"html_url": fmt.Sprintf("%s/%s/issues/%d", a.Server.URL, repository, number),
+		"state":    "open",
+	})
+}
+
+func writeAPIError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"message": message})
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/offline"
 )
 
 type FetchArgs struct {
@@ -20,6 +21,10 @@ type FakeImageFetcher struct {
 	LocalImages  map[string]imgutil.Image
 	RemoteImages map[string]imgutil.Image
 	FetchCalls   map[string]*FetchArgs
+
+	// Offline mimics an offline fetcher: daemon images which are not local are reported as missing, unless their pull
+	// policy is PullNever
+	Offline bool
 }
 
 func NewFakeImageFetcher() *FakeImageFetcher {
@@ -38,6 +43,13 @@ func (f *FakeImageFetcher) Fetch(ctx context.Context, name string, options image
 	if options.Daemon {
 		li, localFound := f.LocalImages[name]
 
+		if f.Offline && options.PullPolicy != image.PullNever {
+			if !localFound {
+				return nil, offline.NewMissingArtifactError(offline.KindImage, name)
+			}
+			return li, nil
+		}
+
 		if shouldPull(localFound, remoteFound, options.PullPolicy) {
 			f.LocalImages[name] = ri
 			li = ri
@@ -2,15 +2,26 @@ package fakes
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 
 	"github.com/buildpacks/pack/internal/build"
 )
 
 type FakeLifecycle struct {
 	Opts build.LifecycleOptions
+
+	// Group is written to group.toml in the group destination directory, when set
+	Group string
 }
 
 func (f *FakeLifecycle) Execute(ctx context.Context, opts build.LifecycleOptions) error {
 	f.Opts = opts
-	return nil
+	if f.Group == "" || opts.GroupDestinationDir == "" {
+		return nil
+	}
+	if err := os.MkdirAll(opts.GroupDestinationDir, 0750); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(opts.GroupDestinationDir, "group.toml"), []byte(f.Group), 0600)
 }
@@ -2,7 +2,9 @@ package registry
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"net/url"
 	"os/exec"
 	"strings"
@@ -71,3 +73,76 @@ func GetIssueURL(githubURL string) (*url.URL, error) {
 	}
 	return url.Parse(fmt.Sprintf("%s/issues/new", strings.TrimSuffix(githubURL, "/")))
 }
+
+// CreatedIssue is an issue created through the API of a GitHub-compatible service
+type CreatedIssue struct {
+	Number int    `json:"number"`
+	URL    string `json:"html_url"`
+	State  string `json:"state"`
+}
+
+// GetAPIURL returns the REST API endpoint of the GitHub-compatible service hosting a registry: api.github.com for
+// github.com, and the /api/v3 path of the host otherwise, as served by GitHub Enterprise.
+func GetAPIURL(githubURL string) (string, error) {
+	parsedURL, err := url.Parse(githubURL)
+	if err != nil {
+		return "", errors.Wrapf(err, "parsing registry url %s", style.Symbol(githubURL))
+	}
+	if parsedURL.Host == "" {
+		return "", errors.Errorf("invalid registry url %s", style.Symbol(githubURL))
+	}
+
+	if parsedURL.Host == "github.com" {
+		return "https://api.github.com", nil
+	}
+	return fmt.Sprintf("%s://%s/api/v3", parsedURL.Scheme, parsedURL.Host), nil
+}
+
+// CreateGithubIssueWithAPI opens an issue in the repository of a registry through the REST API of a GitHub-compatible
+// service, authenticated with token
+func CreateGithubIssueWithAPI(apiURL, githubURL, token string, issue GithubIssue) (CreatedIssue, error) {
+	repository, err := url.Parse(githubURL)
+	if err != nil {
+		return CreatedIssue{}, errors.Wrapf(err, "parsing registry url %s", style.Symbol(githubURL))
+	}
+	parts := strings.Split(strings.Trim(repository.Path, "/"), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return CreatedIssue{}, errors.Errorf("invalid registry url %s: expected <host>/<owner>/<repository>", style.Symbol(githubURL))
+	}
+
+	payload, err := json.Marshal(map[string]string{"title": issue.Title, "body": issue.Body})
+	if err != nil {
+		return CreatedIssue{}, err
+	}
+
+	issuesURL := fmt.Sprintf("%s/repos/%s/%s/issues", strings.TrimSuffix(apiURL, "/"), parts[0], strings.TrimSuffix(parts[1], ".git"))
+	req, err := http.NewRequest(http.MethodPost, issuesURL, bytes.NewReader(payload))
+	if err != nil {
+		return CreatedIssue{}, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return CreatedIssue{}, errors.Wrapf(err, "creating issue at %s", style.Symbol(issuesURL))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		var apiError struct {
+			Message string `json:"message"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&apiError); err != nil || apiError.Message == "" {
+			apiError.Message = resp.Status
+		}
+		return CreatedIssue{}, errors.Errorf("creating issue at %s: %s", style.Symbol(issuesURL), apiError.Message)
+	}
+
+	var created CreatedIssue
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		return CreatedIssue{}, errors.Wrap(err, "parsing created issue")
+	}
+	return created, nil
+}
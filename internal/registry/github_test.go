@@ -7,6 +7,7 @@ import (
 	"github.com/sclevine/spec"
 	"github.com/sclevine/spec/report"
 
+	ifakes "github.com/buildpacks/pack/internal/fakes"
 	"github.com/buildpacks/pack/internal/registry"
 	h "github.com/buildpacks/pack/testhelpers"
 )
@@ -69,4 +70,63 @@ func testGitHub(t *testing.T, when spec.G, it spec.S) {
 			h.AssertError(t, err, "missing github URL")
 		})
 	})
+
+	when("#GetAPIURL", func() {
+		it("should return the github.com API for github.com registries", func() {
+			apiURL, err := registry.GetAPIURL("https://github.com/buildpacks/registry-index")
+
+			h.AssertNil(t, err)
+			h.AssertEq(t, apiURL, "https://api.github.com")
+		})
+
+		it("should return the enterprise API for other hosts", func() {
+			apiURL, err := registry.GetAPIURL("https://git.example.com/platform/registry-index")
+
+			h.AssertNil(t, err)
+			h.AssertEq(t, apiURL, "https://git.example.com/api/v3")
+		})
+
+		it("should fail when url has no host", func() {
+			_, err := registry.GetAPIURL("registry-index")
+
+			h.AssertError(t, err, "invalid registry url 'registry-index'")
+		})
+	})
+
+	when("#CreateGithubIssueWithAPI", func() {
+		var api *ifakes.FakeGithubAPI
+
+		it.Before(func() {
+			api = ifakes.NewFakeGithubAPI("some-token")
+		})
+
+		it.After(func() {
+			api.Close()
+		})
+
+		it("should create the issue in the registry repository", func() {
+			created, err := registry.CreateGithubIssueWithAPI(api.URL(), "https://github.com/buildpacks/registry-index", "some-token",
+				registry.GithubIssue{Title: "ADD example/java@1.0.0", Body: "some-body"})
+
+			h.AssertNil(t, err)
+			h.AssertEq(t, created.Number, 1)
+			h.AssertEq(t, created.State, "open")
+			h.AssertEq(t, created.URL, api.URL()+"/buildpacks/registry-index/issues/1")
+			h.AssertEq(t, api.Issues(), []ifakes.FakeIssue{{Repository: "buildpacks/registry-index", Title: "ADD example/java@1.0.0", Body: "some-body"}})
+		})
+
+		it("should report API errors", func() {
+			_, err := registry.CreateGithubIssueWithAPI(api.URL(), "https://github.com/buildpacks/registry-index", "other-token",
+				registry.GithubIssue{Title: "ADD example/java@1.0.0"})
+
+			h.AssertError(t, err, "Bad credentials")
+		})
+
+		it("should fail when url is not a repository", func() {
+			_, err := registry.CreateGithubIssueWithAPI(api.URL(), "https://github.com/buildpacks", "some-token",
+				registry.GithubIssue{Title: "ADD example/java@1.0.0"})
+
+			h.AssertError(t, err, "expected <host>/<owner>/<repository>")
+		})
+	})
 }
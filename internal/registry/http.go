@@ -0,0 +1,223 @@
+package registry
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// IndexManifestFile lists the index files served by an HTTP registry, along with their digests
+const IndexManifestFile = "index.json"
+
+// indexStateFile records, in the root of the cache of an HTTP or OCI registry, what was last downloaded
+const indexStateFile = ".pack-index.json"
+
+// IndexManifest is served by an HTTP registry at <url>/index.json. Files maps the path of each index file, relative to
+// the registry URL, to its sha256 digest.
+type IndexManifest struct {
+	Files map[string]string `json:"files"`
+}
+
+// indexState is what was last downloaded from an HTTP or OCI registry
+type indexState struct {
+	// ETag of the index manifest of an HTTP registry
+	ETag string `json:"etag,omitempty"`
+
+	// Files downloaded from an HTTP registry, with their digests
+	Files map[string]string `json:"files,omitempty"`
+
+	// Digest of the index artifact of an OCI registry
+	Digest string `json:"digest,omitempty"`
+}
+
+// refreshHTTP downloads the index files of an HTTP registry that changed since the last refresh, and removes the ones
+// that are no longer listed in the index manifest
+func (r *Cache) refreshHTTP() error {
+	if err := os.MkdirAll(r.Root, 0750); err != nil {
+		return errors.Wrapf(err, "creating registry cache %s", style.Symbol(r.Root))
+	}
+
+	state, err := r.readIndexState()
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, r.indexFileURL(IndexManifestFile), nil)
+	if err != nil {
+		return err
+	}
+	if state.ETag != "" && r.hasIndexFiles(state) {
+		req.Header.Set("If-None-Match", state.ETag)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "downloading index manifest of %s", style.Symbol(r.url.String()))
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNotModified:
+		r.logger.Debugf("Registry cache for %s is up to date", r.url.String())
+		return nil
+	case http.StatusOK:
+	default:
+		return errors.Errorf("downloading index manifest of %s: %s", style.Symbol(r.url.String()), resp.Status)
+	}
+
+	var manifest IndexManifest
+	if err := json.NewDecoder(resp.Body).Decode(&manifest); err != nil {
+		return errors.Wrapf(err, "parsing index manifest of %s", style.Symbol(r.url.String()))
+	}
+
+	for indexFile, digest := range manifest.Files {
+		localPath, err := r.indexFilePath(indexFile)
+		if err != nil {
+			return err
+		}
+		if state.Files[indexFile] == digest {
+			if _, err := os.Stat(localPath); err == nil {
+				continue
+			}
+		}
+
+		r.logger.Debugf("Downloading index file %s", style.Symbol(indexFile))
+		if err := r.downloadIndexFile(indexFile, digest, localPath); err != nil {
+			return err
+		}
+	}
+
+	for indexFile := range state.Files {
+		if _, ok := manifest.Files[indexFile]; ok {
+			continue
+		}
+		localPath, err := r.indexFilePath(indexFile)
+		if err != nil {
+			return err
+		}
+		r.logger.Debugf("Removing index file %s", style.Symbol(indexFile))
+		if err := os.Remove(localPath); err != nil && !os.IsNotExist(err) {
+			return errors.Wrapf(err, "removing index file %s", style.Symbol(indexFile))
+		}
+	}
+
+	return r.writeIndexState(indexState{ETag: resp.Header.Get("ETag"), Files: manifest.Files})
+}
+
+func (r *Cache) downloadIndexFile(indexFile, digest, localPath string) error {
+	resp, err := http.Get(r.indexFileURL(indexFile))
+	if err != nil {
+		return errors.Wrapf(err, "downloading index file %s", style.Symbol(indexFile))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("downloading index file %s: %s", style.Symbol(indexFile), resp.Status)
+	}
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrapf(err, "downloading index file %s", style.Symbol(indexFile))
+	}
+
+	sum := sha256.Sum256(content)
+	if actual := "sha256:" + hex.EncodeToString(sum[:]); actual != digest {
+		return errors.Errorf("index file %s has digest %s, expected %s", style.Symbol(indexFile), actual, digest)
+	}
+
+	return writeFileAtomically(localPath, content)
+}
+
+// hasIndexFiles returns true when every file downloaded from an HTTP registry is still in the cache
+func (r *Cache) hasIndexFiles(state indexState) bool {
+	for indexFile := range state.Files {
+		localPath, err := r.indexFilePath(indexFile)
+		if err != nil {
+			return false
+		}
+		if _, err := os.Stat(localPath); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// indexFileURL returns the URL of a file served by an HTTP registry
+func (r *Cache) indexFileURL(indexFile string) string {
+	fileURL := *r.url
+	fileURL.Path = path.Join(fileURL.Path, indexFile)
+	return fileURL.String()
+}
+
+// indexFilePath returns the path in the cache of an index file downloaded from an HTTP or OCI registry
+func (r *Cache) indexFilePath(indexFile string) (string, error) {
+	cleaned := path.Clean(indexFile)
+	if path.IsAbs(cleaned) || cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, "../") || strings.HasPrefix(path.Base(cleaned), ".") {
+		return "", errors.Errorf("invalid index file path %s", style.Symbol(indexFile))
+	}
+	return filepath.Join(r.Root, filepath.FromSlash(cleaned)), nil
+}
+
+func (r *Cache) readIndexState() (indexState, error) {
+	var state indexState
+	content, err := os.ReadFile(filepath.Join(r.Root, indexStateFile))
+	if os.IsNotExist(err) {
+		return state, nil
+	} else if err != nil {
+		return state, errors.Wrap(err, "reading registry cache state")
+	}
+
+	if err := json.Unmarshal(content, &state); err != nil {
+		// a corrupted state only costs a full download
+		r.logger.Debugf("Ignoring invalid registry cache state: %s", err)
+		return indexState{}, nil
+	}
+	return state, nil
+}
+
+func (r *Cache) writeIndexState(state indexState) error {
+	content, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomically(filepath.Join(r.Root, indexStateFile), content); err != nil {
+		return errors.Wrap(err, "writing registry cache state")
+	}
+	return nil
+}
+
+// writeFileAtomically writes a file through a temporary file, so that concurrent readers never see a partial file
+func writeFileAtomically(path string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return err
+	}
+
+	tmpFile, err := os.CreateTemp(filepath.Dir(path), fmt.Sprintf(".%s.", filepath.Base(path)))
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := tmpFile.Write(content); err != nil {
+		tmpFile.Close()
+		return err
+	}
+	if err := tmpFile.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpFile.Name(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile.Name(), path)
+}
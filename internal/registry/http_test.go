@@ -0,0 +1,184 @@
+package registry
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestHTTPRegistry(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "HTTPRegistry", testHTTPRegistry, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testHTTPRegistry(t *testing.T, when spec.G, it spec.S) {
+	var (
+		server        *httptest.Server
+		files         map[string]string
+		requests      []string
+		mu            sync.Mutex
+		registryCache Cache
+		outBuf        bytes.Buffer
+	)
+
+	// manifest returns the index manifest of files
+	manifest := func() []byte {
+		m := IndexManifest{Files: map[string]string{}}
+		for path, content := range files {
+			sum := sha256.Sum256([]byte(content))
+			m.Files[path] = "sha256:" + hex.EncodeToString(sum[:])
+		}
+		content, err := json.Marshal(m)
+		h.AssertNil(t, err)
+		return content
+	}
+
+	it.Before(func() {
+		files = map[string]string{}
+		for _, path := range []string{"3/fo/example_foo", "ja/va/example_java"} {
+			content, err := os.ReadFile(filepath.Join("..", "..", "testdata", "registry", filepath.FromSlash(path)))
+			h.AssertNil(t, err)
+			files[path] = string(content)
+		}
+
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			requests = append(requests, req.URL.Path)
+
+			path := strings.TrimPrefix(req.URL.Path, "/index/")
+			if path == IndexManifestFile {
+				content := manifest()
+				sum := sha256.Sum256(content)
+				etag := `"` + hex.EncodeToString(sum[:]) + `"`
+				if req.Header.Get("If-None-Match") == etag {
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+				w.Header().Set("ETag", etag)
+				_, _ = w.Write(content)
+				return
+			}
+			content, ok := files[path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write([]byte(content))
+		}))
+
+		var err error
+		registryCache, err = NewRegistryCacheWithType(logging.NewLogWithWriters(&outBuf, &outBuf), t.TempDir(), server.URL+"/index", "http")
+		h.AssertNil(t, err)
+	})
+
+	it.After(func() {
+		server.Close()
+	})
+
+	// resetRequests returns the requests made to the server and forgets them
+	resetRequests := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		made := requests
+		requests = nil
+		return made
+	}
+
+	when("#LocateBuildpack", func() {
+		it("locates buildpacks from the downloaded index", func() {
+			bp, err := registryCache.LocateBuildpack("example/java")
+			h.AssertNil(t, err)
+			h.AssertEq(t, bp.Namespace, "example")
+			h.AssertEq(t, bp.Name, "java")
+			h.AssertEq(t, bp.Version, "1.0.0")
+
+			_, err = registryCache.LocateBuildpack("example/foo@1.1.0")
+			h.AssertNil(t, err)
+		})
+	})
+
+	when("#Refresh", func() {
+		it("only downloads what changed", func() {
+			h.AssertNil(t, registryCache.Refresh())
+			h.AssertEq(t, len(resetRequests()), 3)
+
+			h.AssertNil(t, registryCache.Refresh())
+			h.AssertEq(t, resetRequests(), []string{"/index/index.json"})
+
+			mu.Lock()
+			files["ja/va/example_java"] += `{"ns":"example","name":"java","version":"1.1.0","yanked":false,"addr":"example.com/some/package@sha256:8c27fe111c11b722081701dfed3bd55e039b9ce92865473cf4cdfa918071c566"}` + "\n"
+			delete(files, "3/fo/example_foo")
+			mu.Unlock()
+
+			h.AssertNil(t, registryCache.Refresh())
+			h.AssertEq(t, resetRequests(), []string{"/index/index.json", "/index/ja/va/example_java"})
+
+			bp, err := registryCache.LocateBuildpack("example/java")
+			h.AssertNil(t, err)
+			h.AssertEq(t, bp.Version, "1.1.0")
+
+			_, err = os.Stat(filepath.Join(registryCache.Root, "3", "fo", "example_foo"))
+			h.AssertTrue(t, os.IsNotExist(err))
+		})
+
+		it("downloads index files that are missing from the cache", func() {
+			h.AssertNil(t, registryCache.Refresh())
+			h.AssertNil(t, os.Remove(filepath.Join(registryCache.Root, "ja", "va", "example_java")))
+			resetRequests()
+
+			h.AssertNil(t, registryCache.Refresh())
+			_, err := registryCache.LocateBuildpack("example/java")
+			h.AssertNil(t, err)
+		})
+
+		it("fails when an index file does not match its digest", func() {
+			server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				if strings.HasSuffix(req.URL.Path, IndexManifestFile) {
+					_, _ = w.Write([]byte(`{"files": {"ja/va/example_java": "sha256:0000"}}`))
+					return
+				}
+				_, _ = w.Write([]byte(files["ja/va/example_java"]))
+			})
+
+			h.AssertError(t, registryCache.Refresh(), "index file 'ja/va/example_java' has digest")
+		})
+
+		it("fails on index files outside of the cache", func() {
+			server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				_, _ = w.Write([]byte(`{"files": {"../outside": "sha256:0000"}}`))
+			})
+
+			h.AssertError(t, registryCache.Refresh(), "invalid index file path '../outside'")
+		})
+
+		it("fails when the index manifest cannot be downloaded", func() {
+			server.Config.Handler = http.NotFoundHandler()
+
+			h.AssertError(t, registryCache.Refresh(), "downloading index manifest")
+		})
+	})
+
+	when("#Commit", func() {
+		it("is not supported", func() {
+			err := registryCache.Commit(Buildpack{Namespace: "example", Name: "java", Version: "1.1.0"}, "user", "ADD example/java@1.1.0")
+			h.AssertError(t, err, "committing is not supported for http registries")
+		})
+	})
+}
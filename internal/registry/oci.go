@@ -0,0 +1,122 @@
+package registry
+
+import (
+	"archive/tar"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+const ociScheme = "oci://"
+
+// ociReference returns the reference to the index artifact of an OCI registry
+func (r *Cache) ociReference() (name.Reference, error) {
+	ref, err := name.ParseReference(strings.TrimPrefix(r.url.String(), ociScheme), name.WeakValidation)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing registry reference %s", style.Symbol(r.url.String()))
+	}
+	return ref, nil
+}
+
+// refreshOCI downloads the index artifact of an OCI registry when its digest changed since the last refresh. The
+// layers of the artifact are tarballs of index files, laid out like in a git registry.
+func (r *Cache) refreshOCI() error {
+	ref, err := r.ociReference()
+	if err != nil {
+		return err
+	}
+
+	state, err := r.readIndexState()
+	if err != nil {
+		return err
+	}
+
+	desc, err := remote.Head(ref, remote.WithAuthFromKeychain(authn.DefaultKeychain))
+	if err != nil {
+		return errors.Wrapf(err, "reading index artifact %s", style.Symbol(ref.Name()))
+	}
+	if state.Digest == desc.Digest.String() {
+		if _, err := os.Stat(r.Root); err == nil {
+			r.logger.Debugf("Registry cache for %s is up to date", ref.Name())
+			return nil
+		}
+	}
+
+	img, err := remote.Image(ref.Context().Digest(desc.Digest.String()), remote.WithAuthFromKeychain(authn.DefaultKeychain))
+	if err != nil {
+		return errors.Wrapf(err, "downloading index artifact %s", style.Symbol(ref.Name()))
+	}
+	layers, err := img.Layers()
+	if err != nil {
+		return errors.Wrapf(err, "reading layers of index artifact %s", style.Symbol(ref.Name()))
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.Root), 0750); err != nil {
+		return err
+	}
+	registryDir, err := os.MkdirTemp(filepath.Dir(r.Root), "registry")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(registryDir)
+
+	extracted := Cache{Root: registryDir}
+	for _, layer := range layers {
+		if err := extracted.extractIndexLayer(layer); err != nil {
+			return errors.Wrapf(err, "extracting index artifact %s", style.Symbol(ref.Name()))
+		}
+	}
+	if err := extracted.writeIndexState(indexState{Digest: desc.Digest.String()}); err != nil {
+		return err
+	}
+
+	if err := os.RemoveAll(r.Root); err != nil {
+		return errors.Wrap(err, "resetting registry cache")
+	}
+	return os.Rename(registryDir, r.Root)
+}
+
+// extractIndexLayer writes the index files of a layer of an index artifact to the cache
+func (r *Cache) extractIndexLayer(layer v1.Layer) error {
+	rc, err := layer.Uncompressed()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	tr := tar.NewReader(rc)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if header.Typeflag != tar.TypeReg || strings.HasPrefix(path.Base(header.Name), ".") {
+			continue
+		}
+
+		localPath, err := r.indexFilePath(header.Name)
+		if err != nil {
+			return err
+		}
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			return err
+		}
+		if err := writeFileAtomically(localPath, content); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package registry
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"log"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	ggcrregistry "github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestOCIRegistry(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "OCIRegistry", testOCIRegistry, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testOCIRegistry(t *testing.T, when spec.G, it spec.S) {
+	var (
+		server        *httptest.Server
+		indexRef      name.Reference
+		registryCache Cache
+		outBuf        bytes.Buffer
+	)
+
+	// pushIndex pushes an index artifact with a layer holding files
+	pushIndex := func(files map[string]string) {
+		var buf bytes.Buffer
+		tw := tar.NewWriter(&buf)
+		for path, content := range files {
+			h.AssertNil(t, tw.WriteHeader(&tar.Header{Name: path, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+			_, err := tw.Write([]byte(content))
+			h.AssertNil(t, err)
+		}
+		h.AssertNil(t, tw.Close())
+
+		layer, err := tarball.LayerFromOpener(func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(buf.Bytes())), nil
+		})
+		h.AssertNil(t, err)
+		img, err := mutate.AppendLayers(empty.Image, layer)
+		h.AssertNil(t, err)
+		h.AssertNil(t, remote.Write(indexRef, img))
+	}
+
+	// fixture returns the content of an index file of the registry fixture
+	fixture := func(path string) string {
+		content, err := os.ReadFile(filepath.Join("..", "..", "testdata", "registry", filepath.FromSlash(path)))
+		h.AssertNil(t, err)
+		return string(content)
+	}
+
+	it.Before(func() {
+		server = httptest.NewServer(ggcrregistry.New(ggcrregistry.Logger(log.New(io.Discard, "", 0))))
+		serverURL, err := url.Parse(server.URL)
+		h.AssertNil(t, err)
+
+		indexRef, err = name.ParseReference(serverURL.Host + "/buildpacks/registry-index:latest")
+		h.AssertNil(t, err)
+
+		registryCache, err = NewRegistryCacheWithType(logging.NewLogWithWriters(&outBuf, &outBuf, logging.WithVerbose()), t.TempDir(), indexRef.Name(), "oci")
+		h.AssertNil(t, err)
+	})
+
+	it.After(func() {
+		server.Close()
+	})
+
+	when("#LocateBuildpack", func() {
+		it("locates buildpacks from the index artifact", func() {
+			pushIndex(map[string]string{
+				"3/fo/example_foo":   fixture("3/fo/example_foo"),
+				"ja/va/example_java": fixture("ja/va/example_java"),
+				".gitkeep":           "",
+			})
+
+			bp, err := registryCache.LocateBuildpack("example/foo")
+			h.AssertNil(t, err)
+			h.AssertEq(t, bp.Version, "1.2.0")
+		})
+	})
+
+	when("#Refresh", func() {
+		it("downloads the index artifact again when it changed", func() {
+			pushIndex(map[string]string{"3/fo/example_foo": fixture("3/fo/example_foo")})
+			h.AssertNil(t, registryCache.Refresh())
+
+			outBuf.Reset()
+			h.AssertNil(t, registryCache.Refresh())
+			h.AssertContains(t, outBuf.String(), "is up to date")
+
+			pushIndex(map[string]string{"ja/va/example_java": fixture("ja/va/example_java")})
+			h.AssertNil(t, registryCache.Refresh())
+
+			_, err := registryCache.LocateBuildpack("example/java")
+			h.AssertNil(t, err)
+			_, err = registryCache.LocateBuildpack("example/foo")
+			h.AssertError(t, err, "finding buildpack: example/foo")
+		})
+
+		it("fails when the index artifact does not exist", func() {
+			h.AssertError(t, registryCache.Refresh(), "reading index artifact")
+		})
+	})
+
+	when("#NewRegistryCacheWithType", func() {
+		it("accepts references with the oci scheme", func() {
+			withScheme, err := NewRegistryCacheWithType(logging.NewLogWithWriters(&outBuf, &outBuf), t.TempDir(), "oci://"+indexRef.Name(), "oci")
+			h.AssertNil(t, err)
+			h.AssertEq(t, withScheme.url, registryCache.url)
+		})
+	})
+}
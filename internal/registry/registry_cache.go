@@ -6,11 +6,14 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io/fs"
 	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/go-git/go-git/v5"
@@ -21,6 +24,8 @@ import (
 	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/pkg/buildpack"
 	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/offline"
+	registryTypes "github.com/buildpacks/pack/registry"
 )
 
 const DefaultRegistryURL = "https://github.com/buildpacks/registry-index"
@@ -33,6 +38,14 @@ type Cache struct {
 	url         *url.URL
 	Root        string
 	RegistryDir string
+
+	// Type of the registry, one of registry.Types. Git and GitHub registries are cloned, HTTP and OCI registries are
+	// downloaded.
+	Type string
+
+	// Offline uses the cache as it is, without refreshing it. Refreshing a registry which was never cached fails with
+	// an offline.MissingArtifactsError.
+	Offline bool
 }
 
 const GithubIssueTitleTemplate = "{{ if .Yanked }}YANK{{ else }}ADD{{ end }} {{.Namespace}}/{{.Name}}@{{.Version}}"
@@ -53,12 +66,20 @@ func NewDefaultRegistryCache(logger logging.Logger, home string) (Cache, error)
 	return NewRegistryCache(logger, home, DefaultRegistryURL)
 }
 
-// NewRegistryCache creates a new registry cache
+// NewRegistryCache creates a new registry cache for a git registry
 func NewRegistryCache(logger logging.Logger, home, registryURL string) (Cache, error) {
+	return NewRegistryCacheWithType(logger, home, registryURL, registryTypes.TypeGit)
+}
+
+// NewRegistryCacheWithType creates a new registry cache for a registry of the given type
+func NewRegistryCacheWithType(logger logging.Logger, home, registryURL, registryType string) (Cache, error) {
 	if _, err := os.Stat(home); err != nil {
 		return Cache{}, errors.Wrapf(err, "finding home %s", home)
 	}
 
+	if registryType == registryTypes.TypeOCI && !strings.HasPrefix(registryURL, ociScheme) {
+		registryURL = ociScheme + registryURL
+	}
 	normalizedURL, err := url.Parse(registryURL)
 	if err != nil {
 		return Cache{}, errors.Wrapf(err, "parsing registry url %s", registryURL)
@@ -66,12 +87,16 @@ func NewRegistryCache(logger logging.Logger, home, registryURL string) (Cache, e
 
 	key := sha256.New()
 	key.Write([]byte(normalizedURL.String()))
+	if registryType == registryTypes.TypeHTTP || registryType == registryTypes.TypeOCI {
+		key.Write([]byte(registryType))
+	}
 	cacheDir := fmt.Sprintf("%s-%s", defaultRegistryDir, hex.EncodeToString(key.Sum(nil)))
 
 	return Cache{
 		url:    normalizedURL,
 		logger: logger,
 		Root:   filepath.Join(home, cacheDir),
+		Type:   registryType,
 	}, nil
 }
 
@@ -116,10 +141,110 @@ func (r *Cache) LocateBuildpack(bp string) (Buildpack, error) {
 	return Buildpack{}, fmt.Errorf("no entries for buildpack: %s", bp)
 }
 
+// SearchBuildpacks returns the entries of the buildpacks whose ID contains query, sorted by ID, with their versions
+// sorted from newest to oldest. An empty query returns every buildpack in the registry.
+func (r *Cache) SearchBuildpacks(query string) ([]Entry, error) {
+	if err := r.Refresh(); err != nil {
+		return nil, errors.Wrap(err, "refreshing cache")
+	}
+
+	query = strings.ToLower(query)
+	var entries []Entry
+	err := filepath.WalkDir(r.Root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if strings.HasPrefix(d.Name(), ".") && path != r.Root {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		// index files are named <namespace>_<name>, at the path resolved by IndexPath
+		ns, name, ok := strings.Cut(d.Name(), "_")
+		if !ok {
+			return nil
+		}
+		if index, err := IndexPath(r.Root, ns, name); err != nil || index != path {
+			return nil
+		}
+		if !strings.Contains(fmt.Sprintf("%s/%s", ns, name), query) {
+			return nil
+		}
+
+		entry, err := readEntryFile(path, ns, name)
+		if err != nil {
+			return err
+		}
+		if len(entry.Buildpacks) > 0 {
+			sortVersions(entry)
+			entries = append(entries, entry)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "searching registry cache")
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entryID(entries[i]) < entryID(entries[j])
+	})
+	return entries, nil
+}
+
+// ListVersions returns the entry of a buildpack, with its versions sorted from newest to oldest
+func (r *Cache) ListVersions(id string) (Entry, error) {
+	if err := r.Refresh(); err != nil {
+		return Entry{}, errors.Wrap(err, "refreshing cache")
+	}
+
+	ns, name, err := ParseNamespaceName(id)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	entry, err := r.readEntry(ns, name)
+	if err != nil {
+		return Entry{}, errors.Wrap(err, "reading entry")
+	}
+	if len(entry.Buildpacks) == 0 {
+		return Entry{}, fmt.Errorf("no entries for buildpack: %s", id)
+	}
+
+	sortVersions(entry)
+	return entry, nil
+}
+
+// sortVersions sorts the buildpacks of an entry from the newest version to the oldest
+func sortVersions(entry Entry) {
+	sort.SliceStable(entry.Buildpacks, func(i, j int) bool {
+		return semver.Compare(fmt.Sprintf("v%s", entry.Buildpacks[i].Version), fmt.Sprintf("v%s", entry.Buildpacks[j].Version)) > 0
+	})
+}
+
+func entryID(entry Entry) string {
+	return fmt.Sprintf("%s/%s", entry.Buildpacks[0].Namespace, entry.Buildpacks[0].Name)
+}
+
 // Refresh local Registry Cache
 func (r *Cache) Refresh() error {
+	if r.Offline {
+		return r.checkCached()
+	}
+
 	r.logger.Debugf("Refreshing registry cache for %s/%s", r.url.Host, r.url.Path)
 
+	switch r.Type {
+	case registryTypes.TypeHTTP:
+		return r.refreshHTTP()
+	case registryTypes.TypeOCI:
+		return r.refreshOCI()
+	}
+
 	if err := r.Initialize(); err != nil {
 		return errors.Wrapf(err, "initializing (%s)", r.Root)
 	}
@@ -143,6 +268,10 @@ func (r *Cache) Refresh() error {
 
 // Initialize a local Registry Cache
 func (r *Cache) Initialize() error {
+	if r.Type == registryTypes.TypeHTTP || r.Type == registryTypes.TypeOCI {
+		return r.Refresh()
+	}
+
 	_, err := os.Stat(r.Root)
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -214,6 +343,25 @@ func (r *Cache) CreateCache() error {
 	return nil
 }
 
+// checkCached returns an offline.MissingArtifactsError when the registry was never cached
+func (r *Cache) checkCached() error {
+	cached := false
+	switch r.Type {
+	case registryTypes.TypeHTTP, registryTypes.TypeOCI:
+		_, err := os.Stat(filepath.Join(r.Root, indexStateFile))
+		cached = err == nil
+	default:
+		_, err := git.PlainOpen(r.Root)
+		cached = err == nil
+	}
+	if !cached {
+		return offline.NewMissingArtifactError(offline.KindRegistry, r.url.String())
+	}
+
+	r.logger.Debugf("Using registry cache for %s/%s without refreshing it", r.url.Host, r.url.Path)
+	return nil
+}
+
 func (r *Cache) validateCache() error {
 	r.logger.Debugf("Validating registry cache for %s/%s", r.url.Host, r.url.Path)
 
@@ -239,6 +387,10 @@ func (r *Cache) validateCache() error {
 func (r *Cache) Commit(b Buildpack, username, msg string) error {
 	r.logger.Debugf("Creating commit in registry cache")
 
+	if r.Type == registryTypes.TypeHTTP || r.Type == registryTypes.TypeOCI {
+		return errors.Errorf("committing is not supported for %s registries", r.Type)
+	}
+
 	if msg == "" {
 		return errors.New("invalid commit message")
 	}
@@ -344,6 +496,10 @@ func (r *Cache) readEntry(ns, name string) (Entry, error) {
 		return Entry{}, errors.Wrapf(err, "finding buildpack: %s/%s", ns, name)
 	}
 
+	return readEntryFile(index, ns, name)
+}
+
+func readEntryFile(index, ns, name string) (Entry, error) {
 	file, err := os.Open(filepath.Clean(index))
 	if err != nil {
 		return Entry{}, errors.Wrapf(err, "opening index for buildpack: %s/%s", ns, name)
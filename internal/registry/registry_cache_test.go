@@ -2,6 +2,7 @@ package registry
 
 import (
 	"bytes"
+	"fmt"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -16,6 +17,7 @@ import (
 	"github.com/sclevine/spec/report"
 
 	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/offline"
 	h "github.com/buildpacks/pack/testhelpers"
 )
 
@@ -150,6 +152,74 @@ func testRegistryCache(t *testing.T, when spec.G, it spec.S) {
 		})
 	})
 
+	when("#SearchBuildpacks", func() {
+		var (
+			registryCache Cache
+		)
+
+		it.Before(func() {
+			registryCache, err = NewRegistryCache(logger, tmpDir, registryFixture)
+			h.AssertNil(t, err)
+		})
+
+		it("returns every buildpack for an empty query", func() {
+			entries, err := registryCache.SearchBuildpacks("")
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, len(entries), 2)
+			h.AssertEq(t, entries[0].Buildpacks[0].Name, "foo")
+			h.AssertEq(t, entries[0].Buildpacks[0].Version, "1.2.0")
+			h.AssertEq(t, len(entries[0].Buildpacks), 3)
+			h.AssertEq(t, entries[1].Buildpacks[0].Name, "java")
+		})
+
+		it("returns the buildpacks whose id contains the query", func() {
+			entries, err := registryCache.SearchBuildpacks("ample/JA")
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, len(entries), 1)
+			h.AssertEq(t, entries[0].Buildpacks[0].Name, "java")
+		})
+
+		it("returns nothing when no buildpack matches", func() {
+			entries, err := registryCache.SearchBuildpacks("ruby")
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(entries), 0)
+		})
+	})
+
+	when("#ListVersions", func() {
+		var (
+			registryCache Cache
+		)
+
+		it.Before(func() {
+			registryCache, err = NewRegistryCache(logger, tmpDir, registryFixture)
+			h.AssertNil(t, err)
+		})
+
+		it("lists the versions from newest to oldest", func() {
+			entry, err := registryCache.ListVersions("example/foo")
+			h.AssertNil(t, err)
+
+			var versions []string
+			for _, bp := range entry.Buildpacks {
+				versions = append(versions, bp.Version)
+			}
+			h.AssertEq(t, versions, []string{"1.2.0", "1.1.0", "1.0.0"})
+		})
+
+		it("returns error if can't parse buildpack id", func() {
+			_, err := registryCache.ListVersions("quack")
+			h.AssertError(t, err, "does not contain a namespace")
+		})
+
+		it("returns error if can't find buildpack with requested id", func() {
+			_, err := registryCache.ListVersions("example/qu")
+			h.AssertError(t, err, "reading entry")
+		})
+	})
+
 	when("#Refresh", func() {
 		var (
 			registryCache Cache
@@ -188,6 +258,36 @@ func testRegistryCache(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("offline", func() {
+			it("uses the cache without pulling", func() {
+				h.AssertNil(t, registryCache.Refresh())
+				head := gitHead(t, registryCache.Root)
+
+				r, err := git.PlainOpen(registryFixture)
+				h.AssertNil(t, err)
+				w, err := r.Worktree()
+				h.AssertNil(t, err)
+				_, err = w.Commit("second", &git.CommitOptions{
+					Author: &object.Signature{Name: "John Doe", Email: "john@doe.org", When: time.Now()},
+				})
+				h.AssertNil(t, err)
+
+				registryCache.Offline = true
+				h.AssertNil(t, registryCache.Refresh())
+				h.AssertEq(t, gitHead(t, registryCache.Root), head)
+
+				_, err = registryCache.LocateBuildpack("example/java")
+				h.AssertNil(t, err)
+			})
+
+			it("reports registries which were never cached as missing", func() {
+				registryCache.Offline = true
+				err := registryCache.Refresh()
+				h.AssertError(t, err, fmt.Sprintf("running offline, registry '%s' is not available locally", registryFixture))
+				h.AssertTrue(t, offline.IsMissingArtifact(err))
+			})
+		})
+
 		when("Root is an empty string", func() {
 			it("fails to refresh", func() {
 				registryCache.Root = ""
@@ -309,3 +409,12 @@ func testRegistryCache(t *testing.T, when spec.G, it spec.S) {
 		})
 	})
 }
+
+func gitHead(t *testing.T, path string) string {
+	t.Helper()
+	r, err := git.PlainOpen(path)
+	h.AssertNil(t, err)
+	ref, err := r.Head()
+	h.AssertNil(t, err)
+	return ref.Hash().String()
+}
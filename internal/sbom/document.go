@@ -0,0 +1,188 @@
+// Package sbom reads the packages listed by the CycloneDX, SPDX and Syft documents buildpacks write to the SBOM layer
+// of app images.
+package sbom
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	FormatCycloneDX = "cyclonedx"
+	FormatSPDX      = "spdx"
+	FormatSyft      = "syft"
+)
+
+// formatExtensions are the file extensions of the SBOM documents buildpacks write, by format
+var formatExtensions = map[string]string{
+	".cdx.json":  FormatCycloneDX,
+	".spdx.json": FormatSPDX,
+	".syft.json": FormatSyft,
+}
+
+// Package is a software package listed by an SBOM document.
+type Package struct {
+	Name    string `json:"name" yaml:"name"`
+	Version string `json:"version,omitempty" yaml:"version,omitempty"`
+	PURL    string `json:"purl,omitempty" yaml:"purl,omitempty"`
+}
+
+// Format returns the format of the SBOM document at path, based on its extension, or an empty string when it is not
+// an SBOM document.
+func Format(path string) string {
+	for extension, format := range formatExtensions {
+		if strings.HasSuffix(path, extension) {
+			return format
+		}
+	}
+	return ""
+}
+
+// ParsePackages returns the packages listed by an SBOM document of the given format.
+func ParsePackages(format string, content []byte) ([]Package, error) {
+	var (
+		packages []Package
+		err      error
+	)
+	switch format {
+	case FormatCycloneDX:
+		packages, err = parseCycloneDX(content)
+	case FormatSPDX:
+		packages, err = parseSPDX(content)
+	case FormatSyft:
+		packages, err = parseSyft(content)
+	default:
+		return nil, errors.Errorf("unsupported SBOM format '%s'", format)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing %s document", format)
+	}
+	return packages, nil
+}
+
+type cycloneDXComponent struct {
+	Name       string               `json:"name"`
+	Version    string               `json:"version"`
+	PURL       string               `json:"purl"`
+	Components []cycloneDXComponent `json:"components"`
+}
+
+func parseCycloneDX(content []byte) ([]Package, error) {
+	var document struct {
+		Components []cycloneDXComponent `json:"components"`
+	}
+	if err := json.Unmarshal(content, &document); err != nil {
+		return nil, err
+	}
+
+	var packages []Package
+	var collect func(components []cycloneDXComponent)
+	collect = func(components []cycloneDXComponent) {
+		for _, component := range components {
+			packages = append(packages, Package{Name: component.Name, Version: component.Version, PURL: component.PURL})
+			collect(component.Components)
+		}
+	}
+	collect(document.Components)
+	return packages, nil
+}
+
+func parseSPDX(content []byte) ([]Package, error) {
+	var document struct {
+		Packages []struct {
+			Name         string `json:"name"`
+			VersionInfo  string `json:"versionInfo"`
+			ExternalRefs []struct {
+				ReferenceType    string `json:"referenceType"`
+				ReferenceLocator string `json:"referenceLocator"`
+			} `json:"externalRefs"`
+		} `json:"packages"`
+	}
+	if err := json.Unmarshal(content, &document); err != nil {
+		return nil, err
+	}
+
+	var packages []Package
+	for _, pkg := range document.Packages {
+		p := Package{Name: pkg.Name, Version: pkg.VersionInfo}
+		for _, ref := range pkg.ExternalRefs {
+			if ref.ReferenceType == "purl" {
+				p.PURL = ref.ReferenceLocator
+				break
+			}
+		}
+		packages = append(packages, p)
+	}
+	return packages, nil
+}
+
+func parseSyft(content []byte) ([]Package, error) {
+	var document struct {
+		Artifacts []struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+			PURL    string `json:"purl"`
+		} `json:"artifacts"`
+	}
+	if err := json.Unmarshal(content, &document); err != nil {
+		return nil, err
+	}
+
+	var packages []Package
+	for _, artifact := range document.Artifacts {
+		packages = append(packages, Package{Name: artifact.Name, Version: artifact.Version, PURL: artifact.PURL})
+	}
+	return packages, nil
+}
+
+// Merge returns the packages of several documents without duplicates, sorted by name and version. Packages with the
+// same name and version are the same package, keeping the first package URL found.
+func Merge(packageLists ...[]Package) []Package {
+	type key struct{ name, version string }
+	byKey := map[key]Package{}
+	for _, packages := range packageLists {
+		for _, pkg := range packages {
+			if pkg.Name == "" {
+				continue
+			}
+			k := key{pkg.Name, pkg.Version}
+			if existing, ok := byKey[k]; !ok || existing.PURL == "" {
+				byKey[k] = pkg
+			}
+		}
+	}
+
+	merged := make([]Package, 0, len(byKey))
+	for _, pkg := range byKey {
+		merged = append(merged, pkg)
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		if merged[i].Name != merged[j].Name {
+			return merged[i].Name < merged[j].Name
+		}
+		return merged[i].Version < merged[j].Version
+	})
+	return merged
+}
+
+// Matches returns true when the package name contains name, ignoring case, and its version is version or starts with
+// version followed by a separator, so that 2.14 matches 2.14.1 but not 2.140. An empty name or version matches any.
+func (p Package) Matches(name, version string) bool {
+	if name != "" && !strings.Contains(strings.ToLower(p.Name), strings.ToLower(name)) {
+		return false
+	}
+	if version == "" || p.Version == version {
+		return true
+	}
+	if !strings.HasPrefix(p.Version, version) {
+		return false
+	}
+	switch p.Version[len(version)] {
+	case '.', '-', '+', '_':
+		return true
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+package sbom_test
+
+import (
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/sbom"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestDocument(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Document", testDocument, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testDocument(t *testing.T, when spec.G, it spec.S) {
+	when("#Format", func() {
+		it("detects the format from the extension", func() {
+			h.AssertEq(t, sbom.Format("launch/some_buildpack/sbom.cdx.json"), sbom.FormatCycloneDX)
+			h.AssertEq(t, sbom.Format("launch/some_buildpack/sbom.spdx.json"), sbom.FormatSPDX)
+			h.AssertEq(t, sbom.Format("launch/some_buildpack/sbom.syft.json"), sbom.FormatSyft)
+			h.AssertEq(t, sbom.Format("launch/some_buildpack/launch.toml"), "")
+		})
+	})
+
+	when("#ParsePackages", func() {
+		it("reads nested CycloneDX components", func() {
+			packages, err := sbom.ParsePackages(sbom.FormatCycloneDX, []byte(`{
+  "bomFormat": "CycloneDX",
+  "components": [
+    {"name": "log4j-core", "version": "2.14.1", "purl": "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1",
+     "components": [{"name": "log4j-api", "version": "2.14.1"}]}
+  ]
+}`))
+			h.AssertNil(t, err)
+			h.AssertEq(t, packages, []sbom.Package{
+				{Name: "log4j-core", Version: "2.14.1", PURL: "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1"},
+				{Name: "log4j-api", Version: "2.14.1"},
+			})
+		})
+
+		it("reads SPDX packages and their package URL", func() {
+			packages, err := sbom.ParsePackages(sbom.FormatSPDX, []byte(`{
+  "spdxVersion": "SPDX-2.2",
+  "packages": [
+    {"name": "node", "versionInfo": "18.0.0", "externalRefs": [
+      {"referenceType": "cpe23Type", "referenceLocator": "cpe:2.3:a:nodejs:node.js:18.0.0"},
+      {"referenceType": "purl", "referenceLocator": "pkg:generic/node@18.0.0"}
+    ]}
+  ]
+}`))
+			h.AssertNil(t, err)
+			h.AssertEq(t, packages, []sbom.Package{{Name: "node", Version: "18.0.0", PURL: "pkg:generic/node@18.0.0"}})
+		})
+
+		it("reads Syft artifacts", func() {
+			packages, err := sbom.ParsePackages(sbom.FormatSyft, []byte(`{"artifacts": [{"name": "express", "version": "4.18.2", "purl": "pkg:npm/express@4.18.2"}]}`))
+			h.AssertNil(t, err)
+			h.AssertEq(t, packages, []sbom.Package{{Name: "express", Version: "4.18.2", PURL: "pkg:npm/express@4.18.2"}})
+		})
+
+		it("errors on invalid documents", func() {
+			_, err := sbom.ParsePackages(sbom.FormatSyft, []byte(`not json`))
+			h.AssertError(t, err, "parsing syft document")
+		})
+
+		it("errors on unsupported formats", func() {
+			_, err := sbom.ParsePackages("other", []byte(`{}`))
+			h.AssertError(t, err, "unsupported SBOM format 'other'")
+		})
+	})
+
+	when("#Merge", func() {
+		it("removes duplicates and sorts the packages", func() {
+			merged := sbom.Merge(
+				[]sbom.Package{{Name: "node", Version: "18.0.0"}, {Name: "express", Version: "4.18.2"}},
+				[]sbom.Package{{Name: "node", Version: "18.0.0", PURL: "pkg:generic/node@18.0.0"}, {Name: ""}},
+			)
+			h.AssertEq(t, merged, []sbom.Package{
+				{Name: "express", Version: "4.18.2"},
+				{Name: "node", Version: "18.0.0", PURL: "pkg:generic/node@18.0.0"},
+			})
+		})
+	})
+
+	when("#Matches", func() {
+		pkg := sbom.Package{Name: "log4j-core", Version: "2.14.1"}
+
+		it("matches part of the name ignoring case", func() {
+			h.AssertTrue(t, pkg.Matches("Log4J", ""))
+			h.AssertEq(t, pkg.Matches("logback", ""), false)
+		})
+
+		it("matches versions by prefix", func() {
+			h.AssertTrue(t, pkg.Matches("log4j", "2.14.1"))
+			h.AssertTrue(t, pkg.Matches("log4j", "2.14"))
+			h.AssertEq(t, pkg.Matches("log4j", "2.1"), false)
+			h.AssertEq(t, pkg.Matches("log4j", "2.15"), false)
+		})
+	})
+}
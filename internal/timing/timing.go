@@ -0,0 +1,132 @@
+// Package timing measures how long the steps of a build take.
+package timing
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+type Category string
+
+const (
+	// CategoryPack is the category of steps performed by pack itself, such as fetching images.
+	CategoryPack Category = "pack"
+	// CategoryPhase is the category of the lifecycle phases.
+	CategoryPhase Category = "phase"
+)
+
+// Step is a timed step of a build.
+type Step struct {
+	Category Category
+	Name     string
+	Start    time.Time
+	Duration time.Duration
+}
+
+// Recorder collects the duration of build steps. It is safe for concurrent use, and a nil Recorder
+// discards all measurements.
+type Recorder struct {
+	mu    sync.Mutex
+	steps []Step
+	start time.Time
+	now   func() time.Time
+}
+
+func NewRecorder() *Recorder {
+	return &Recorder{
+		start: time.Now(),
+		now:   time.Now,
+	}
+}
+
+// WithClock sets the function used to measure time. The start of the recording is reset to the current time
+// of the clock.
+func (r *Recorder) WithClock(now func() time.Time) *Recorder {
+	r.now = now
+	r.start = now()
+	return r
+}
+
+// Track starts timing a step. The step is recorded when the returned function is called.
+func (r *Recorder) Track(category Category, name string) (done func()) {
+	if r == nil {
+		return func() {}
+	}
+
+	start := r.now()
+	return func() {
+		duration := r.now().Sub(start)
+
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.steps = append(r.steps, Step{Category: category, Name: name, Start: start, Duration: duration})
+	}
+}
+
+// Steps returns the recorded steps in the order they finished.
+func (r *Recorder) Steps() []Step {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Step(nil), r.steps...)
+}
+
+// Total returns the time elapsed since the recording started.
+func (r *Recorder) Total() time.Duration {
+	return r.now().Sub(r.start)
+}
+
+// Report is the JSON representation of the recorded steps.
+type Report struct {
+	TotalMS int64        `json:"total_ms"`
+	Steps   []ReportStep `json:"steps"`
+}
+
+type ReportStep struct {
+	Category   Category  `json:"category"`
+	Name       string    `json:"name"`
+	Start      time.Time `json:"start"`
+	DurationMS int64     `json:"duration_ms"`
+}
+
+func (r *Recorder) Report() Report {
+	report := Report{
+		TotalMS: r.Total().Milliseconds(),
+		Steps:   []ReportStep{},
+	}
+	for _, step := range r.Steps() {
+		report.Steps = append(report.Steps, ReportStep{
+			Category:   step.Category,
+			Name:       step.Name,
+			Start:      step.Start.UTC(),
+			DurationMS: step.Duration.Milliseconds(),
+		})
+	}
+	return report
+}
+
+// WriteJSON writes the report of the recorded steps to w.
+func (r *Recorder) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r.Report())
+}
+
+// WriteTable writes a summary table of the recorded steps to w.
+func (r *Recorder) WriteTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 4, ' ', 0)
+	fmt.Fprintln(tw, "CATEGORY\tSTEP\tDURATION")
+	for _, step := range r.Steps() {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", step.Category, step.Name, formatDuration(step.Duration))
+	}
+	fmt.Fprintf(tw, "total\t\t%s\n", formatDuration(r.Total()))
+	return tw.Flush()
+}
+
+func formatDuration(d time.Duration) string {
+	return d.Round(time.Millisecond).String()
+}
@@ -0,0 +1,95 @@
+package timing_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/timing"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestTiming(t *testing.T) {
+	spec.Run(t, "Timing", testTiming, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testTiming(t *testing.T, when spec.G, it spec.S) {
+	var (
+		recorder *timing.Recorder
+		now      time.Time
+	)
+
+	it.Before(func() {
+		now = time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+		recorder = timing.NewRecorder().WithClock(func() time.Time { return now })
+	})
+
+	when("#Track", func() {
+		it("records the duration of each step", func() {
+			done := recorder.Track(timing.CategoryPack, "fetch builder image")
+			now = now.Add(1500 * time.Millisecond)
+			done()
+
+			done = recorder.Track(timing.CategoryPhase, "detector")
+			now = now.Add(2 * time.Second)
+			done()
+
+			h.AssertEq(t, recorder.Steps(), []timing.Step{
+				{Category: timing.CategoryPack, Name: "fetch builder image", Start: time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC), Duration: 1500 * time.Millisecond},
+				{Category: timing.CategoryPhase, Name: "detector", Start: time.Date(2023, 1, 2, 3, 4, 6, 500000000, time.UTC), Duration: 2 * time.Second},
+			})
+			h.AssertEq(t, recorder.Total(), 3500*time.Millisecond)
+		})
+
+		it("does nothing for a nil recorder", func() {
+			var nilRecorder *timing.Recorder
+			nilRecorder.Track(timing.CategoryPack, "some-step")()
+		})
+	})
+
+	when("#WriteTable", func() {
+		it("writes a summary of the steps", func() {
+			done := recorder.Track(timing.CategoryPack, "fetch builder image")
+			now = now.Add(1500 * time.Millisecond)
+			done()
+
+			done = recorder.Track(timing.CategoryPhase, "detector")
+			now = now.Add(2 * time.Second)
+			done()
+
+			var buf bytes.Buffer
+			h.AssertNil(t, recorder.WriteTable(&buf))
+			h.AssertEq(t, buf.String(), `CATEGORY    STEP                   DURATION
+pack        fetch builder image    1.5s
+phase       detector               2s
+total                              3.5s
+`)
+		})
+	})
+
+	when("#WriteJSON", func() {
+		it("writes the report", func() {
+			done := recorder.Track(timing.CategoryPhase, "exporter")
+			now = now.Add(250 * time.Millisecond)
+			done()
+
+			var buf bytes.Buffer
+			h.AssertNil(t, recorder.WriteJSON(&buf))
+			h.NewAssertionManager(t).EqualJSON(buf.String(), `{
+  "total_ms": 250,
+  "steps": [
+    {"category": "phase", "name": "exporter", "start": "2023-01-02T03:04:05Z", "duration_ms": 250}
+  ]
+}`)
+		})
+
+		it("writes an empty list when there are no steps", func() {
+			var buf bytes.Buffer
+			h.AssertNil(t, recorder.WriteJSON(&buf))
+			h.NewAssertionManager(t).EqualJSON(buf.String(), `{"total_ms": 0, "steps": []}`)
+		})
+	})
+}
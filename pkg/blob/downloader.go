@@ -3,23 +3,30 @@ package blob
 import (
 	"context"
 	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/mitchellh/ioprogress"
 	"github.com/pkg/errors"
 
 	"github.com/buildpacks/pack/internal/paths"
 	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/offline"
 )
 
 const (
 	cacheDirPrefix = "c"
 	cacheVersion   = "2"
+
+	// digestFragmentPrefix starts the fragment of URIs pinned to a sha256 digest, such as
+	// https://example.com/buildpack.tgz#sha256=<hex-digest>
+	digestFragmentPrefix = "sha256="
 )
 
 type Logger interface {
@@ -33,17 +40,44 @@ type Downloader interface {
 }
 
 type downloader struct {
-	logger       Logger
-	baseCacheDir string
+	logger         Logger
+	baseCacheDir   string
+	requireDigests bool
+	offline        bool
+}
+
+type DownloaderOption func(d *downloader)
+
+// WithRequireDigests rejects remote URIs which are not pinned to a sha256 digest.
+func WithRequireDigests(require bool) DownloaderOption {
+	return func(d *downloader) {
+		d.requireDigests = require
+	}
 }
 
-func NewDownloader(logger Logger, baseCacheDir string) Downloader {
-	return &downloader{
+// WithOffline serves remote URIs from the download cache only. URIs which are not cached fail with an
+// offline.MissingArtifactsError.
+func WithOffline(offline bool) DownloaderOption {
+	return func(d *downloader) {
+		d.offline = offline
+	}
+}
+
+func NewDownloader(logger Logger, baseCacheDir string, opts ...DownloaderOption) Downloader {
+	d := &downloader{
 		logger:       logger,
 		baseCacheDir: baseCacheDir,
 	}
+
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	return d
 }
 
+// Download returns the blob at pathOrURI. URIs may be pinned to the sha256 digest of their content with a
+// '#sha256=<hex-digest>' fragment, in which case the download fails when the content does not match the digest.
 func (d *downloader) Download(ctx context.Context, pathOrURI string) (Blob, error) {
 	if paths.IsURI(pathOrURI) {
 		parsedURL, err := url.Parse(pathOrURI)
@@ -51,12 +85,31 @@ func (d *downloader) Download(ctx context.Context, pathOrURI string) (Blob, erro
 			return nil, errors.Wrapf(err, "parsing path/uri %s", style.Symbol(pathOrURI))
 		}
 
+		digest, err := parseDigest(parsedURL)
+		if err != nil {
+			return nil, err
+		}
+		if digest != "" {
+			parsedURL.Fragment = ""
+			pathOrURI = parsedURL.String()
+		}
+
 		var path string
 		switch parsedURL.Scheme {
 		case "file":
 			path, err = paths.URIToFilePath(pathOrURI)
+			if err == nil && digest != "" {
+				err = verifyFileDigest(path, pathOrURI, digest)
+			}
 		case "http", "https":
-			path, err = d.handleHTTP(ctx, pathOrURI)
+			switch {
+			case digest != "":
+				path, err = d.handlePinnedHTTP(ctx, pathOrURI, digest)
+			case d.requireDigests:
+				err = fmt.Errorf("URI %s is not pinned to a digest, add the sha256 digest of its content as in %s", style.Symbol(pathOrURI), style.Symbol(pathOrURI+"#"+digestFragmentPrefix+"<digest>"))
+			default:
+				path, err = d.handleHTTP(ctx, pathOrURI)
+			}
 		default:
 			err = fmt.Errorf("unsupported protocol %s in URI %s", style.Symbol(parsedURL.Scheme), style.Symbol(pathOrURI))
 		}
@@ -96,6 +149,10 @@ func (d *downloader) handleHTTP(ctx context.Context, uri string) (string, error)
 		return "", err
 	}
 
+	if d.offline {
+		return d.cachedPath(uri, cachePath)
+	}
+
 	etag := ""
 	if etagExists {
 		bytes, err := os.ReadFile(filepath.Clean(etagFile))
@@ -131,6 +188,73 @@ func (d *downloader) handleHTTP(ctx context.Context, uri string) (string, error)
 	return cachePath, nil
 }
 
+// handlePinnedHTTP downloads a URI pinned to a digest. The cache is keyed by the digest, so a cached blob is used
+// without contacting the server.
+func (d *downloader) handlePinnedHTTP(ctx context.Context, uri, digest string) (string, error) {
+	cacheDir := d.versionedCacheDir()
+
+	if err := os.MkdirAll(cacheDir, 0750); err != nil {
+		return "", err
+	}
+
+	cachePath := filepath.Join(cacheDir, "sha256-"+digest)
+	cached, err := fileExists(cachePath)
+	if err != nil {
+		return "", err
+	}
+	if cached {
+		d.logger.Debugf("Using cached version of %s", style.Symbol(uri))
+		return cachePath, nil
+	}
+	if d.offline {
+		return "", offline.NewMissingArtifactError(offline.KindDownload, uri)
+	}
+
+	reader, _, err := d.downloadAsStream(ctx, uri, "")
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+
+	fh, err := os.CreateTemp(cacheDir, "download-")
+	if err != nil {
+		return "", errors.Wrap(err, "creating download file")
+	}
+	defer os.Remove(fh.Name())
+	defer fh.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(fh, hash), reader); err != nil {
+		return "", errors.Wrap(err, "writing cache")
+	}
+	if err := checkDigest(uri, digest, hash.Sum(nil)); err != nil {
+		return "", err
+	}
+
+	if err := fh.Close(); err != nil {
+		return "", errors.Wrap(err, "writing cache")
+	}
+	if err := os.Rename(fh.Name(), cachePath); err != nil {
+		return "", errors.Wrap(err, "writing cache")
+	}
+
+	return cachePath, nil
+}
+
+// cachedPath returns the cached version of a URI which is not pinned to a digest, without checking whether it changed
+func (d *downloader) cachedPath(uri, cachePath string) (string, error) {
+	cached, err := fileExists(cachePath)
+	if err != nil {
+		return "", err
+	}
+	if !cached {
+		return "", offline.NewMissingArtifactError(offline.KindDownload, uri)
+	}
+
+	d.logger.Debugf("Using cached version of %s", style.Symbol(uri))
+	return cachePath, nil
+}
+
 func (d *downloader) downloadAsStream(ctx context.Context, uri string, etag string) (io.ReadCloser, string, error) {
 	req, err := http.NewRequest("GET", uri, nil)
 	if err != nil {
@@ -183,6 +307,43 @@ func (d *downloader) versionedCacheDir() string {
 	return filepath.Join(d.baseCacheDir, cacheDirPrefix+cacheVersion)
 }
 
+// parseDigest returns the sha256 digest a URI is pinned to, if any
+func parseDigest(uri *url.URL) (string, error) {
+	if !strings.HasPrefix(uri.Fragment, digestFragmentPrefix) {
+		return "", nil
+	}
+
+	digest := strings.TrimPrefix(uri.Fragment, digestFragmentPrefix)
+	if decoded, err := hex.DecodeString(digest); err != nil || len(decoded) != sha256.Size {
+		return "", fmt.Errorf("invalid sha256 digest %s in URI %s", style.Symbol(digest), style.Symbol(uri.String()))
+	}
+	return strings.ToLower(digest), nil
+}
+
+func verifyFileDigest(path, uri, digest string) error {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return errors.Wrapf(err, "opening %s", style.Symbol(path))
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return errors.Wrapf(err, "reading %s", style.Symbol(path))
+	}
+	return checkDigest(uri, digest, hash.Sum(nil))
+}
+
+func checkDigest(uri, expected string, actual []byte) error {
+	if actualHex := hex.EncodeToString(actual); actualHex != expected {
+		return fmt.Errorf(
+			"digest mismatch for %s: expected %s, got %s",
+			style.Symbol(uri), style.Symbol("sha256:"+expected), style.Symbol("sha256:"+actualHex),
+		)
+	}
+	return nil
+}
+
 func fileExists(file string) (bool, error) {
 	_, err := os.Stat(file)
 	if err != nil {
@@ -2,11 +2,14 @@ package blob_test
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/heroku/color"
@@ -17,6 +20,7 @@ import (
 	"github.com/buildpacks/pack/internal/paths"
 	"github.com/buildpacks/pack/pkg/archive"
 	"github.com/buildpacks/pack/pkg/blob"
+	"github.com/buildpacks/pack/pkg/offline"
 	h "github.com/buildpacks/pack/testhelpers"
 )
 
@@ -135,6 +139,130 @@ func testDownloader(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 
+			when("uri is pinned to a digest", func() {
+				var digest string
+
+				it.Before(func() {
+					digest = fileDigest(t, tgz)
+				})
+
+				it("downloads once and uses the cache keyed by the digest afterwards", func() {
+					server.AppendHandlers(func(w http.ResponseWriter, r *http.Request) {
+						http.ServeFile(w, r, tgz)
+					})
+
+					b, err := subject.Download(context.TODO(), uri+"#sha256="+digest)
+					h.AssertNil(t, err)
+					assertBlob(t, b)
+
+					b, err = subject.Download(context.TODO(), uri+"#sha256="+digest)
+					h.AssertNil(t, err)
+					assertBlob(t, b)
+					h.AssertEq(t, len(server.ReceivedRequests()), 1)
+				})
+
+				it("fails when the content does not match the digest", func() {
+					server.AppendHandlers(func(w http.ResponseWriter, r *http.Request) {
+						http.ServeFile(w, r, tgz)
+					})
+					otherDigest := strings.Repeat("0", 64)
+
+					_, err := subject.Download(context.TODO(), uri+"#sha256="+otherDigest)
+					h.AssertError(t, err, fmt.Sprintf("digest mismatch for '%s': expected 'sha256:%s', got 'sha256:%s'", uri, otherDigest, digest))
+				})
+
+				it("verifies 'file://' URIs", func() {
+					fileURI, err := paths.FilePathToURI(tgz, "")
+					h.AssertNil(t, err)
+
+					b, err := subject.Download(context.TODO(), fileURI+"#sha256="+digest)
+					h.AssertNil(t, err)
+					assertBlob(t, b)
+
+					_, err = subject.Download(context.TODO(), fileURI+"#sha256="+strings.Repeat("0", 64))
+					h.AssertError(t, err, "digest mismatch")
+				})
+
+				it("errors when the digest is invalid", func() {
+					_, err := subject.Download(context.TODO(), uri+"#sha256=not-a-digest")
+					h.AssertError(t, err, "invalid sha256 digest 'not-a-digest'")
+				})
+			})
+
+			when("offline", func() {
+				var offlineSubject blob.Downloader
+
+				it.Before(func() {
+					offlineSubject = blob.NewDownloader(&logger{io.Discard}, cacheDir, blob.WithOffline(true))
+				})
+
+				it("serves cached URIs without contacting the server", func() {
+					server.AppendHandlers(func(w http.ResponseWriter, r *http.Request) {
+						w.Header().Add("ETag", "A")
+						http.ServeFile(w, r, tgz)
+					})
+					_, err := subject.Download(context.TODO(), uri)
+					h.AssertNil(t, err)
+
+					b, err := offlineSubject.Download(context.TODO(), uri)
+					h.AssertNil(t, err)
+					assertBlob(t, b)
+					h.AssertEq(t, len(server.ReceivedRequests()), 1)
+				})
+
+				it("serves cached pinned URIs", func() {
+					server.AppendHandlers(func(w http.ResponseWriter, r *http.Request) {
+						http.ServeFile(w, r, tgz)
+					})
+					pinnedURI := uri + "#sha256=" + fileDigest(t, tgz)
+					_, err := subject.Download(context.TODO(), pinnedURI)
+					h.AssertNil(t, err)
+
+					b, err := offlineSubject.Download(context.TODO(), pinnedURI)
+					h.AssertNil(t, err)
+					assertBlob(t, b)
+					h.AssertEq(t, len(server.ReceivedRequests()), 1)
+				})
+
+				it("reports URIs which are not cached as missing", func() {
+					_, err := offlineSubject.Download(context.TODO(), uri)
+					h.AssertError(t, err, fmt.Sprintf("running offline, download '%s' is not available locally", uri))
+					h.AssertTrue(t, offline.IsMissingArtifact(err))
+
+					_, err = offlineSubject.Download(context.TODO(), uri+"#sha256="+fileDigest(t, tgz))
+					h.AssertTrue(t, offline.IsMissingArtifact(err))
+					h.AssertEq(t, len(server.ReceivedRequests()), 0)
+				})
+			})
+
+			when("digests are required", func() {
+				it.Before(func() {
+					subject = blob.NewDownloader(&logger{io.Discard}, cacheDir, blob.WithRequireDigests(true))
+				})
+
+				it("rejects URIs which are not pinned", func() {
+					_, err := subject.Download(context.TODO(), uri)
+					h.AssertError(t, err, fmt.Sprintf("URI '%s' is not pinned to a digest", uri))
+					h.AssertEq(t, len(server.ReceivedRequests()), 0)
+				})
+
+				it("downloads pinned URIs", func() {
+					server.AppendHandlers(func(w http.ResponseWriter, r *http.Request) {
+						http.ServeFile(w, r, tgz)
+					})
+
+					b, err := subject.Download(context.TODO(), uri+"#sha256="+fileDigest(t, tgz))
+					h.AssertNil(t, err)
+					assertBlob(t, b)
+				})
+
+				it("allows local paths", func() {
+					b, err := subject.Download(context.TODO(), filepath.Join("testdata", "blob"))
+					h.AssertNil(t, err)
+					assertBlob(t, b)
+				})
+			})
+
 			when("uri is invalid", func() {
 				when("uri file is not found", func() {
 					it.Before(func() {
@@ -173,6 +301,15 @@ func assertBlob(t *testing.T, b blob.Blob) {
 	h.AssertEq(t, string(bytes), "contents")
 }
 
+func fileDigest(t *testing.T, path string) string {
+	t.Helper()
+	contents, err := os.ReadFile(path)
+	h.AssertNil(t, err)
+
+	sum := sha256.Sum256(contents)
+	return hex.EncodeToString(sum[:])
+}
+
 type logger struct {
 	writer io.Writer
 }
@@ -338,21 +338,33 @@ func (b *PackageBuilder) resolvedStacks() []dist.Stack {
 	return stacks
 }
 
+// TargetBuilder pairs a PackageBuilder with the target platform it packages for
+type TargetBuilder struct {
+	Target  dist.Target
+	Builder *PackageBuilder
+}
+
 func (b *PackageBuilder) SaveAsFile(path, imageOS string) error {
-	if err := b.validate(); err != nil {
-		return err
-	}
+	return b.SaveAsFileForTarget(path, dist.Target{OS: imageOS})
+}
 
-	layoutImage, err := newLayoutImage(imageOS)
-	if err != nil {
-		return errors.Wrap(err, "creating layout image")
+// SaveAsFileForTarget writes an OCI layout archive to path containing the package image for target
+func (b *PackageBuilder) SaveAsFileForTarget(path string, target dist.Target) error {
+	return SaveAsMultiPlatformFile(path, []TargetBuilder{{Target: target, Builder: b}})
+}
+
+// SaveAsMultiPlatformFile writes an OCI layout archive to path containing a package image for each
+// target builder. Each image is referenced from the index of the layout along with its platform.
+func SaveAsMultiPlatformFile(path string, builders []TargetBuilder) error {
+	for _, tb := range builders {
+		if err := tb.Builder.validate(); err != nil {
+			return err
+		}
 	}
 
-	tempDirName := ""
-	if b.buildpack != nil {
-		tempDirName = "package-buildpack"
-	} else if b.extension != nil {
-		tempDirName = "extension-buildpack"
+	tempDirName := "package-buildpack"
+	if len(builders) > 0 {
+		tempDirName = builders[0].Builder.tempDirName()
 	}
 
 	tmpDir, err := os.MkdirTemp("", tempDirName)
@@ -361,15 +373,6 @@ func (b *PackageBuilder) SaveAsFile(path, imageOS string) error {
 	}
 	defer os.RemoveAll(tmpDir)
 
-	if b.buildpack != nil {
-		if err := b.finalizeImage(layoutImage, tmpDir); err != nil {
-			return err
-		}
-	} else if b.extension != nil {
-		if err := b.finalizeExtensionImage(layoutImage, tmpDir); err != nil {
-			return err
-		}
-	}
 	layoutDir, err := os.MkdirTemp(tmpDir, "oci-layout")
 	if err != nil {
 		return errors.Wrap(err, "creating oci-layout temp dir")
@@ -380,8 +383,31 @@ func (b *PackageBuilder) SaveAsFile(path, imageOS string) error {
 		return errors.Wrap(err, "writing index")
 	}
 
-	if err := p.AppendImage(layoutImage); err != nil {
-		return errors.Wrap(err, "writing layout")
+	for _, tb := range builders {
+		layoutImage, err := newLayoutImage(tb.Target)
+		if err != nil {
+			return errors.Wrap(err, "creating layout image")
+		}
+
+		layersDir, err := os.MkdirTemp(tmpDir, "layers")
+		if err != nil {
+			return err
+		}
+
+		if tb.Builder.buildpack != nil {
+			if err := tb.Builder.finalizeImage(layoutImage, layersDir); err != nil {
+				return err
+			}
+		} else if tb.Builder.extension != nil {
+			if err := tb.Builder.finalizeExtensionImage(layoutImage, layersDir); err != nil {
+				return err
+			}
+		}
+
+		platform := v1.Platform{OS: tb.Target.OS, Architecture: tb.Target.Arch, Variant: tb.Target.ArchVariant}
+		if err := p.AppendImage(layoutImage, layout.WithPlatform(platform)); err != nil {
+			return errors.Wrap(err, "writing layout")
+		}
 	}
 
 	outputFile, err := os.Create(path)
@@ -396,7 +422,7 @@ func (b *PackageBuilder) SaveAsFile(path, imageOS string) error {
 	return archive.WriteDirToTar(tw, layoutDir, "/", 0, 0, 0755, true, false, nil)
 }
 
-func newLayoutImage(imageOS string) (*layoutImage, error) {
+func newLayoutImage(target dist.Target) (*layoutImage, error) {
 	i := empty.Image
 
 	configFile, err := i.ConfigFile()
@@ -404,13 +430,15 @@ func newLayoutImage(imageOS string) (*layoutImage, error) {
 		return nil, err
 	}
 
-	configFile.OS = imageOS
+	configFile.OS = target.OS
+	configFile.Architecture = target.Arch
+	configFile.Variant = target.ArchVariant
 	i, err = mutate.ConfigFile(i, configFile)
 	if err != nil {
 		return nil, err
 	}
 
-	if imageOS == "windows" {
+	if target.OS == "windows" {
 		opener := func() (io.ReadCloser, error) {
 			reader, err := layer.WindowsBaseLayer()
 			return io.NopCloser(reader), err
@@ -431,22 +459,28 @@ func newLayoutImage(imageOS string) (*layoutImage, error) {
 }
 
 func (b *PackageBuilder) SaveAsImage(repoName string, publish bool, imageOS string) (imgutil.Image, error) {
+	return b.SaveAsImageForTarget(repoName, publish, dist.Target{OS: imageOS})
+}
+
+// SaveAsImageForTarget saves the package image for target, setting its architecture when the target has one
+func (b *PackageBuilder) SaveAsImageForTarget(repoName string, publish bool, target dist.Target) (imgutil.Image, error) {
 	if err := b.validate(); err != nil {
 		return nil, err
 	}
 
-	image, err := b.imageFactory.NewImage(repoName, !publish, imageOS)
+	image, err := b.imageFactory.NewImage(repoName, !publish, target.OS)
 	if err != nil {
 		return nil, errors.Wrapf(err, "creating image")
 	}
-	tempDirName := ""
-	if b.buildpack != nil {
-		tempDirName = "package-buildpack"
-	} else if b.extension != nil {
-		tempDirName = "extension-buildpack"
+	if target.Arch != "" {
+		if err := image.SetArchitecture(target.Arch); err != nil {
+			return nil, errors.Wrapf(err, "setting architecture")
+		}
+		if err := image.SetVariant(target.ArchVariant); err != nil {
+			return nil, errors.Wrapf(err, "setting architecture variant")
+		}
 	}
-
-	tmpDir, err := os.MkdirTemp("", tempDirName)
+	tmpDir, err := os.MkdirTemp("", b.tempDirName())
 	if err != nil {
 		return nil, err
 	}
@@ -468,6 +502,13 @@ func (b *PackageBuilder) SaveAsImage(repoName string, publish bool, imageOS stri
 	return image, nil
 }
 
+func (b *PackageBuilder) tempDirName() string {
+	if b.extension != nil {
+		return "extension-buildpack"
+	}
+	return "package-buildpack"
+}
+
 func validateBuildpacks(mainBP BuildModule, depBPs []BuildModule) error {
 	depsWithRefs := map[string][]dist.ModuleInfo{}
 
@@ -588,6 +588,28 @@ func testPackageBuilder(t *testing.T, when spec.G, it spec.S) {
 			h.AssertEq(t, osVal, "linux")
 		})
 
+		it("sets the architecture of the target", func() {
+			buildpack1, err := ifakes.NewFakeBuildpack(dist.BuildpackDescriptor{
+				WithAPI:    api.MustParse("0.2"),
+				WithInfo:   dist.ModuleInfo{ID: "bp.1.id", Version: "bp.1.version"},
+				WithStacks: []dist.Stack{{ID: "stack.id.1"}},
+			}, 0644)
+			h.AssertNil(t, err)
+
+			builder := buildpack.NewBuilder(mockImageFactory("linux"))
+			builder.SetBuildpack(buildpack1)
+
+			packageImage, err := builder.SaveAsImageForTarget("some/package", false, dist.Target{OS: "linux", Arch: "arm", ArchVariant: "v7"})
+			h.AssertNil(t, err)
+
+			arch, err := packageImage.Architecture()
+			h.AssertNil(t, err)
+			h.AssertEq(t, arch, "arm")
+			variant, err := packageImage.Variant()
+			h.AssertNil(t, err)
+			h.AssertEq(t, variant, "v7")
+		})
+
 		it("sets extension metadata", func() {
 			extension1, err := ifakes.NewFakeExtension(dist.ExtensionDescriptor{
 				WithAPI: api.MustParse("0.2"),
@@ -875,6 +897,51 @@ func testPackageBuilder(t *testing.T, when spec.G, it spec.S) {
 		})
 	})
 
+	when("#SaveAsMultiPlatformFile", func() {
+		it("references an image per target from the index", func() {
+			var builders []buildpack.TargetBuilder
+			for _, target := range []dist.Target{{OS: "linux", Arch: "amd64"}, {OS: "linux", Arch: "arm", ArchVariant: "v7"}} {
+				bp, err := ifakes.NewFakeBuildpack(dist.BuildpackDescriptor{
+					WithAPI:    api.MustParse("0.2"),
+					WithInfo:   dist.ModuleInfo{ID: "bp.1.id", Version: "bp.1.version"},
+					WithStacks: []dist.Stack{{ID: "stack.id.1"}},
+				}, 0644)
+				h.AssertNil(t, err)
+
+				builder := buildpack.NewBuilder(mockImageFactory(""))
+				builder.SetBuildpack(bp)
+				builders = append(builders, buildpack.TargetBuilder{Target: target, Builder: builder})
+			}
+
+			outputFile := filepath.Join(tmpDir, fmt.Sprintf("package-%s.cnb", h.RandString(10)))
+			h.AssertNil(t, buildpack.SaveAsMultiPlatformFile(outputFile, builders))
+
+			h.AssertOnTarEntry(t, outputFile, "/index.json",
+				func(t *testing.T, header *tar.Header, data []byte) {
+					index := v1.Index{}
+					h.AssertNil(t, json.Unmarshal(data, &index))
+					h.AssertEq(t, len(index.Manifests), 2)
+					h.AssertEq(t, index.Manifests[0].Platform.OS, "linux")
+					h.AssertEq(t, index.Manifests[0].Platform.Architecture, "amd64")
+					h.AssertEq(t, index.Manifests[1].Platform.Architecture, "arm")
+					h.AssertEq(t, index.Manifests[1].Platform.Variant, "v7")
+
+					h.AssertOnTarEntry(t, outputFile,
+						"/blobs/sha256/"+index.Manifests[1].Digest.Hex(),
+						func(t *testing.T, header *tar.Header, data []byte) {
+							manifest := v1.Manifest{}
+							h.AssertNil(t, json.Unmarshal(data, &manifest))
+
+							h.AssertOnTarEntry(t, outputFile,
+								"/blobs/sha256/"+manifest.Config.Digest.Hex(),
+								h.ContentContains(`"architecture":"arm"`),
+								h.ContentContains(`"variant":"v7"`),
+							)
+						})
+				})
+		})
+	})
+
 	when("#SaveAsFile", func() {
 		it("sets metadata", func() {
 			buildpack1, err := ifakes.NewFakeBuildpack(dist.BuildpackDescriptor{
@@ -67,6 +67,9 @@ type DownloadOptions struct {
 	// The OS of the builder image
 	ImageOS string
 
+	// The platform of the builder image. When set, multi-platform packages are resolved to the image for this platform.
+	Target *dist.Target
+
 	// Deprecated: the older alternative to buildpack URI
 	ImageName string
 
@@ -102,7 +105,7 @@ func (c *buildpackDownloader) Download(ctx context.Context, moduleURI string, op
 	case PackageLocator:
 		imageName := ParsePackageLocator(moduleURI)
 		c.logger.Debugf("Downloading %s from image: %s", kind, style.Symbol(imageName))
-		mainBP, depBPs, err = extractPackaged(ctx, kind, imageName, c.imageFetcher, image.FetchOptions{Daemon: opts.Daemon, PullPolicy: opts.PullPolicy})
+		mainBP, depBPs, err = extractPackaged(ctx, kind, imageName, c.imageFetcher, image.FetchOptions{Daemon: opts.Daemon, PullPolicy: opts.PullPolicy, Platform: targetPlatform(opts.Target)})
 		if err != nil {
 			return nil, nil, errors.Wrapf(err, "extracting from registry %s", style.Symbol(moduleURI))
 		}
@@ -113,7 +116,7 @@ func (c *buildpackDownloader) Download(ctx context.Context, moduleURI string, op
 			return nil, nil, errors.Wrapf(err, "locating in registry: %s", style.Symbol(moduleURI))
 		}
 
-		mainBP, depBPs, err = extractPackaged(ctx, kind, address, c.imageFetcher, image.FetchOptions{Daemon: opts.Daemon, PullPolicy: opts.PullPolicy})
+		mainBP, depBPs, err = extractPackaged(ctx, kind, address, c.imageFetcher, image.FetchOptions{Daemon: opts.Daemon, PullPolicy: opts.PullPolicy, Platform: targetPlatform(opts.Target)})
 		if err != nil {
 			return nil, nil, errors.Wrapf(err, "extracting from registry %s", style.Symbol(moduleURI))
 		}
@@ -130,7 +133,7 @@ func (c *buildpackDownloader) Download(ctx context.Context, moduleURI string, op
 			return nil, nil, errors.Wrapf(err, "downloading %s from %s", kind, style.Symbol(moduleURI))
 		}
 
-		mainBP, depBPs, err = decomposeBlob(blob, kind, opts.ImageOS)
+		mainBP, depBPs, err = decomposeBlob(blob, kind, opts.ImageOS, opts.Target)
 		if err != nil {
 			return nil, nil, errors.Wrapf(err, "extracting from %s", style.Symbol(moduleURI))
 		}
@@ -142,14 +145,14 @@ func (c *buildpackDownloader) Download(ctx context.Context, moduleURI string, op
 
 // decomposeBlob decomposes a buildpack or extension blob into the main module (order buildpack or extension) and
 // (for buildpack blobs) its dependent buildpacks.
-func decomposeBlob(blob blob.Blob, kind string, imageOS string) (mainModule BuildModule, depModules []BuildModule, err error) {
+func decomposeBlob(blob blob.Blob, kind string, imageOS string, target *dist.Target) (mainModule BuildModule, depModules []BuildModule, err error) {
 	isOCILayout, err := IsOCILayoutBlob(blob)
 	if err != nil {
 		return mainModule, depModules, errors.Wrapf(err, "inspecting %s blob", kind)
 	}
 
 	if isOCILayout {
-		mainModule, depModules, err = fromOCILayoutBlob(blob, kind)
+		mainModule, depModules, err = fromOCILayoutBlob(blob, kind, target)
 		if err != nil {
 			return mainModule, depModules, errors.Wrapf(err, "extracting %ss", kind)
 		}
@@ -172,12 +175,12 @@ func decomposeBlob(blob blob.Blob, kind string, imageOS string) (mainModule Buil
 	return mainModule, depModules, nil
 }
 
-func fromOCILayoutBlob(blob blob.Blob, kind string) (mainModule BuildModule, depModules []BuildModule, err error) {
+func fromOCILayoutBlob(blob blob.Blob, kind string, target *dist.Target) (mainModule BuildModule, depModules []BuildModule, err error) {
 	switch kind {
 	case KindBuildpack:
-		mainModule, depModules, err = BuildpacksFromOCILayoutBlob(blob)
+		mainModule, depModules, err = buildpacksFromOCILayoutBlob(blob, target)
 	case KindExtension:
-		mainModule, err = ExtensionsFromOCILayoutBlob(blob)
+		mainModule, err = extensionsFromOCILayoutBlob(blob, target)
 	default:
 		return nil, nil, fmt.Errorf("unknown module kind: %s", kind)
 	}
@@ -206,3 +209,10 @@ func extractPackaged(ctx context.Context, kind string, pkgImageRef string, fetch
 	}
 	return mainModule, depModules, nil
 }
+
+func targetPlatform(target *dist.Target) string {
+	if target == nil {
+		return ""
+	}
+	return target.Platform()
+}
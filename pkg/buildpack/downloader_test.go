@@ -211,6 +211,23 @@ func testBuildpackDownloader(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 
+			when("a target is provided", func() {
+				it("should fetch the package image for the target platform", func() {
+					mockImageFetcher.EXPECT().Fetch(gomock.Any(), packageImage.Name(), image.FetchOptions{Daemon: false, PullPolicy: image.PullAlways, Platform: "linux/arm64"}).Return(packageImage, nil)
+
+					downloadOptions = buildpack.DownloadOptions{
+						ImageOS:    "linux",
+						ImageName:  packageImage.Name(),
+						Daemon:     false,
+						PullPolicy: image.PullAlways,
+						Target:     &dist.Target{OS: "linux", Arch: "arm64"},
+					}
+					mainBP, _, err := buildpackDownloader.Download(context.TODO(), "", downloadOptions)
+					h.AssertNil(t, err)
+					h.AssertEq(t, mainBP.Descriptor().Info().ID, "example/foo")
+				})
+			})
+
 			when("daemon=false and pull-policy=always", func() {
 				it("should use remote package image", func() {
 					downloadOptions = buildpack.DownloadOptions{
@@ -295,6 +312,57 @@ func testBuildpackDownloader(t *testing.T, when spec.G, it spec.S) {
 				h.AssertEq(t, mainBP.Descriptor().Info().ID, "bp.one")
 			})
 
+			when("package is a multi-platform file", func() {
+				var packageURI string
+
+				it.Before(func() {
+					var builders []buildpack.TargetBuilder
+					for _, target := range []dist.Target{{OS: "linux", Arch: "amd64"}, {OS: "linux", Arch: "arm64"}} {
+						bp, err := ifakes.NewFakeBuildpack(dist.BuildpackDescriptor{
+							WithAPI:    api.MustParse("0.3"),
+							WithInfo:   dist.ModuleInfo{ID: "example/foo", Version: "1.1.0-" + target.Arch},
+							WithStacks: []dist.Stack{{ID: "some.stack.id"}},
+						}, 0644)
+						h.AssertNil(t, err)
+
+						packageBuilder := buildpack.NewBuilder(mockImageFactory)
+						packageBuilder.SetBuildpack(bp)
+						builders = append(builders, buildpack.TargetBuilder{Target: target, Builder: packageBuilder})
+					}
+
+					packagePath := filepath.Join(tmpDir, "package.cnb")
+					h.AssertNil(t, buildpack.SaveAsMultiPlatformFile(packagePath, builders))
+
+					var err error
+					packageURI, err = paths.FilePathToURI(packagePath, "")
+					h.AssertNil(t, err)
+					mockDownloader.EXPECT().Download(gomock.Any(), packageURI).Return(blob.NewBlob(packagePath), nil).AnyTimes()
+				})
+
+				it("resolves the package for the target platform", func() {
+					mainBP, _, err := buildpackDownloader.Download(context.TODO(), packageURI, buildpack.DownloadOptions{
+						ImageOS: "linux",
+						Target:  &dist.Target{OS: "linux", Arch: "arm64"},
+					})
+					h.AssertNil(t, err)
+					h.AssertEq(t, mainBP.Descriptor().Info().Version, "1.1.0-arm64")
+				})
+
+				it("uses the first package when no target is provided", func() {
+					mainBP, _, err := buildpackDownloader.Download(context.TODO(), packageURI, buildpack.DownloadOptions{ImageOS: "linux"})
+					h.AssertNil(t, err)
+					h.AssertEq(t, mainBP.Descriptor().Info().Version, "1.1.0-amd64")
+				})
+
+				it("errors when no package matches the target platform", func() {
+					_, _, err := buildpackDownloader.Download(context.TODO(), packageURI, buildpack.DownloadOptions{
+						ImageOS: "linux",
+						Target:  &dist.Target{OS: "linux", Arch: "s390x"},
+					})
+					h.AssertError(t, err, "unable to find manifest for platform 'linux/s390x'")
+				})
+			})
+
 			when("kind == extension", func() {
 				it("succeeds", func() {
 					extensionPath := filepath.Join("testdata", "extension")
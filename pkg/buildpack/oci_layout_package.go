@@ -42,7 +42,11 @@ func IsOCILayoutBlob(blob blob2.Blob) (bool, error) {
 
 // BuildpacksFromOCILayoutBlob constructs buildpacks from a blob in OCI layout format.
 func BuildpacksFromOCILayoutBlob(blob Blob) (mainBP BuildModule, dependencies []BuildModule, err error) {
-	layoutPackage, err := newOCILayoutPackage(blob, KindBuildpack)
+	return buildpacksFromOCILayoutBlob(blob, nil)
+}
+
+func buildpacksFromOCILayoutBlob(blob Blob, target *dist.Target) (mainBP BuildModule, dependencies []BuildModule, err error) {
+	layoutPackage, err := newOCILayoutPackage(blob, KindBuildpack, target)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -52,7 +56,11 @@ func BuildpacksFromOCILayoutBlob(blob Blob) (mainBP BuildModule, dependencies []
 
 // ExtensionsFromOCILayoutBlob constructs extensions from a blob in OCI layout format.
 func ExtensionsFromOCILayoutBlob(blob Blob) (mainExt BuildModule, err error) {
-	layoutPackage, err := newOCILayoutPackage(blob, KindExtension)
+	return extensionsFromOCILayoutBlob(blob, nil)
+}
+
+func extensionsFromOCILayoutBlob(blob Blob, target *dist.Target) (mainExt BuildModule, err error) {
+	layoutPackage, err := newOCILayoutPackage(blob, KindExtension, target)
 	if err != nil {
 		return nil, err
 	}
@@ -61,7 +69,7 @@ func ExtensionsFromOCILayoutBlob(blob Blob) (mainExt BuildModule, err error) {
 }
 
 func ConfigFromOCILayoutBlob(blob Blob) (config v1.ImageConfig, err error) {
-	layoutPackage, err := newOCILayoutPackage(blob, KindBuildpack)
+	layoutPackage, err := newOCILayoutPackage(blob, KindBuildpack, nil)
 	if err != nil {
 		return v1.ImageConfig{}, err
 	}
@@ -74,7 +82,7 @@ type ociLayoutPackage struct {
 	blob      Blob
 }
 
-func newOCILayoutPackage(blob Blob, kind string) (*ociLayoutPackage, error) {
+func newOCILayoutPackage(blob Blob, kind string, target *dist.Target) (*ociLayoutPackage, error) {
 	index := &v1.Index{}
 
 	if err := unmarshalJSONFromBlob(blob, "/index.json", index); err != nil {
@@ -83,13 +91,16 @@ func newOCILayoutPackage(blob Blob, kind string) (*ociLayoutPackage, error) {
 
 	var manifestDescriptor *v1.Descriptor
 	for _, m := range index.Manifests {
-		if m.MediaType == "application/vnd.docker.distribution.manifest.v2+json" {
+		if m.MediaType == "application/vnd.docker.distribution.manifest.v2+json" && matchesTarget(m.Platform, target) {
 			manifestDescriptor = &m // nolint:exportloopref
 			break
 		}
 	}
 
 	if manifestDescriptor == nil {
+		if target != nil {
+			return nil, errors.Errorf("unable to find manifest for platform %s", style.Symbol(target.Platform()))
+		}
 		return nil, errors.New("unable to find manifest")
 	}
 
@@ -130,6 +141,17 @@ func newOCILayoutPackage(blob Blob, kind string) (*ociLayoutPackage, error) {
 	}, nil
 }
 
+// matchesTarget returns true when a manifest of the given platform can be used for target.
+// Manifests without platform information predate multi-platform packages and match any target.
+func matchesTarget(platform *v1.Platform, target *dist.Target) bool {
+	if target == nil || platform == nil {
+		return true
+	}
+	return (platform.OS == "" || platform.OS == target.OS) &&
+		(target.Arch == "" || platform.Architecture == "" || platform.Architecture == target.Arch) &&
+		(target.ArchVariant == "" || platform.Variant == "" || platform.Variant == target.ArchVariant)
+}
+
 func (o *ociLayoutPackage) Label(name string) (value string, err error) {
 	return o.imageInfo.Config.Labels[name], nil
 }
@@ -0,0 +1,401 @@
+package cache
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/paths"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/archive"
+)
+
+const (
+	ArchiveTypeLabel     = "io.buildpacks.cache.type"
+	ArchiveAppImageLabel = "io.buildpacks.cache.app-image"
+)
+
+// ArchiveMetadata describes the cache stored in a cache archive.
+type ArchiveMetadata struct {
+	// Type is the kind of data held by the cache: build, launch or kaniko.
+	Type string
+
+	// AppImage is the name of the app image built with the cache, if known.
+	AppImage string
+}
+
+// WriteArchive writes an OCI layout archive to archivePath holding a single image whose only layer contains the files
+// of the contents tar stream, so that a cache can be restored on another machine.
+func WriteArchive(archivePath string, contents io.Reader, metadata ArchiveMetadata) error {
+	tmpDir, err := os.MkdirTemp("", "cache-archive")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	layerPath := filepath.Join(tmpDir, "layer.tar")
+	if err := writeFile(layerPath, contents); err != nil {
+		return errors.Wrap(err, "reading cache contents")
+	}
+
+	layer, err := tarball.LayerFromFile(layerPath, tarball.WithMediaType(types.OCILayer), tarball.WithCompressionLevel(gzip.DefaultCompression))
+	if err != nil {
+		return errors.Wrap(err, "creating cache layer")
+	}
+
+	img := mutate.MediaType(empty.Image, types.OCIManifestSchema1)
+	img = mutate.ConfigMediaType(img, types.OCIConfigJSON)
+	img, err = mutate.Append(img, mutate.Addendum{Layer: layer, MediaType: types.OCILayer})
+	if err != nil {
+		return errors.Wrap(err, "adding cache layer")
+	}
+	img, err = mutate.Config(img, v1.Config{Labels: map[string]string{
+		ArchiveTypeLabel:     metadata.Type,
+		ArchiveAppImageLabel: metadata.AppImage,
+	}})
+	if err != nil {
+		return errors.Wrap(err, "setting cache labels")
+	}
+	img, err = mutate.CreatedAt(img, v1.Time{Time: time.Now().UTC()})
+	if err != nil {
+		return err
+	}
+
+	layoutDir := filepath.Join(tmpDir, "oci-layout")
+	p, err := layout.Write(layoutDir, empty.Index)
+	if err != nil {
+		return errors.Wrap(err, "writing index")
+	}
+	if err := p.AppendImage(img); err != nil {
+		return errors.Wrap(err, "writing layout")
+	}
+
+	outputFile, err := os.Create(archivePath)
+	if err != nil {
+		return errors.Wrap(err, "creating output file")
+	}
+	defer outputFile.Close()
+
+	tw := tar.NewWriter(outputFile)
+	defer tw.Close()
+
+	return archive.WriteDirToTar(tw, layoutDir, "/", 0, 0, 0755, true, false, nil)
+}
+
+// OpenArchive reads the cache archive at archivePath written by WriteArchive. The returned reader streams the files of the
+// cache as a tar archive, and must be closed by the caller.
+func OpenArchive(archivePath string) (ArchiveMetadata, io.ReadCloser, error) {
+	indexManifest := &v1.IndexManifest{}
+	if err := readArchiveJSON(archivePath, "/index.json", func(r io.Reader) (err error) {
+		indexManifest, err = v1.ParseIndexManifest(r)
+		return err
+	}); err != nil {
+		return ArchiveMetadata{}, nil, err
+	}
+	if len(indexManifest.Manifests) != 1 {
+		return ArchiveMetadata{}, nil, errors.Errorf("cache archive %s must contain exactly one image", style.Symbol(archivePath))
+	}
+
+	manifest := &v1.Manifest{}
+	if err := readArchiveJSON(archivePath, blobPath(indexManifest.Manifests[0].Digest), func(r io.Reader) (err error) {
+		manifest, err = v1.ParseManifest(r)
+		return err
+	}); err != nil {
+		return ArchiveMetadata{}, nil, err
+	}
+	if len(manifest.Layers) != 1 {
+		return ArchiveMetadata{}, nil, errors.Errorf("cache archive %s must contain exactly one layer", style.Symbol(archivePath))
+	}
+
+	configFile := &v1.ConfigFile{}
+	if err := readArchiveJSON(archivePath, blobPath(manifest.Config.Digest), func(r io.Reader) (err error) {
+		configFile, err = v1.ParseConfigFile(r)
+		return err
+	}); err != nil {
+		return ArchiveMetadata{}, nil, err
+	}
+
+	metadata := ArchiveMetadata{
+		Type:     configFile.Config.Labels[ArchiveTypeLabel],
+		AppImage: configFile.Config.Labels[ArchiveAppImageLabel],
+	}
+	if metadata.Type == "" {
+		return ArchiveMetadata{}, nil, errors.Errorf("label %s not found in cache archive %s", style.Symbol(ArchiveTypeLabel), style.Symbol(archivePath))
+	}
+
+	contents, err := openArchiveLayer(archivePath, manifest.Layers[0])
+	if err != nil {
+		return ArchiveMetadata{}, nil, err
+	}
+	return metadata, contents, nil
+}
+
+func readArchiveJSON(archivePath, entryPath string, parse func(io.Reader) error) error {
+	f, err := os.Open(filepath.Clean(archivePath))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, contents, err := archive.ReadTarEntry(f, entryPath)
+	if err != nil {
+		return errors.Wrapf(err, "reading cache archive %s", style.Symbol(archivePath))
+	}
+	if err := parse(bytes.NewReader(contents)); err != nil {
+		return errors.Wrapf(err, "parsing %s of cache archive %s", style.Symbol(entryPath), style.Symbol(archivePath))
+	}
+	return nil
+}
+
+func openArchiveLayer(archivePath string, descriptor v1.Descriptor) (io.ReadCloser, error) {
+	f, err := os.Open(filepath.Clean(archivePath))
+	if err != nil {
+		return nil, err
+	}
+
+	layerPath := paths.CanonicalTarPath(blobPath(descriptor.Digest))
+	tr := tar.NewReader(f)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			f.Close()
+			return nil, errors.Wrapf(err, "reading cache archive %s", style.Symbol(archivePath))
+		}
+
+		if paths.CanonicalTarPath(header.Name) != layerPath {
+			continue
+		}
+
+		gzipReader, err := gzip.NewReader(tr)
+		if err != nil {
+			f.Close()
+			return nil, errors.Wrap(err, "decompressing cache layer")
+		}
+		return &layerReader{Reader: gzipReader, closers: []io.Closer{gzipReader, f}}, nil
+	}
+
+	f.Close()
+	return nil, errors.Errorf("layer blob %s not found in cache archive %s", style.Symbol(layerPath), style.Symbol(archivePath))
+}
+
+type layerReader struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (r *layerReader) Close() error {
+	var err error
+	for _, closer := range r.closers {
+		if closeErr := closer.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+func blobPath(digest v1.Hash) string {
+	return path.Join("/blobs", digest.Algorithm, digest.Hex)
+}
+
+// DirContents returns the files of a bind cache as a tar stream, keeping their ownership.
+func DirContents(dir string) io.ReadCloser {
+	return archive.GenerateTar(func(tw archive.TarWriter) error {
+		return filepath.WalkDir(dir, func(file string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			relPath, err := filepath.Rel(dir, file)
+			if err != nil || relPath == "." {
+				return err
+			}
+
+			info, err := entry.Info()
+			if err != nil {
+				return err
+			}
+			if info.Mode()&fs.ModeSocket != 0 {
+				return nil
+			}
+
+			var link string
+			if info.Mode()&fs.ModeSymlink != 0 {
+				if link, err = os.Readlink(file); err != nil {
+					return err
+				}
+			}
+			header, err := tar.FileInfoHeader(info, link)
+			if err != nil {
+				return err
+			}
+			header.Name = filepath.ToSlash(relPath)
+			if err := tw.WriteHeader(header); err != nil {
+				return err
+			}
+
+			if info.Mode().IsRegular() {
+				f, err := os.Open(filepath.Clean(file))
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+
+				if _, err := io.Copy(tw, f); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	})
+}
+
+// ExtractContents writes the files of a cache tar stream to dir. Ownership is not restored, the files belong to
+// the current user. Entries which would be written outside of dir, including through the symlinks of the stream,
+// and symlinks pointing outside of dir are rejected.
+func ExtractContents(contents io.Reader, dir string) error {
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return err
+	}
+	root, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return err
+	}
+	root, err = filepath.Abs(root)
+	if err != nil {
+		return err
+	}
+
+	tr := tar.NewReader(contents)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "reading cache contents")
+		}
+
+		target := filepath.Join(root, filepath.FromSlash(paths.CanonicalTarPath(header.Name)))
+		if !isWithin(root, target) {
+			return errors.Errorf("invalid path %s in cache contents", style.Symbol(header.Name))
+		}
+		if target == root {
+			continue
+		}
+
+		parent, err := resolveParent(root, target)
+		if err != nil {
+			return err
+		}
+		if parent == "" {
+			return errors.Errorf("invalid path %s in cache contents, it is outside of the directory", style.Symbol(header.Name))
+		}
+		target = filepath.Join(parent, filepath.Base(target))
+
+		// an existing symlink is replaced rather than followed
+		if info, err := os.Lstat(target); err == nil && info.Mode()&fs.ModeSymlink != 0 {
+			if err := os.Remove(target); err != nil {
+				return err
+			}
+		}
+
+		mode := header.FileInfo().Mode()
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, mode.Perm()); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			f, err := os.OpenFile(filepath.Clean(target), os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode.Perm())
+			if err != nil {
+				return err
+			}
+			/* #nosec G110 */
+			_, err = io.Copy(f, tr)
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			if filepath.IsAbs(header.Linkname) || !isWithin(root, filepath.Join(parent, header.Linkname)) {
+				return errors.Errorf("invalid symlink %s to %s in cache contents, it points outside of the directory", style.Symbol(header.Name), style.Symbol(header.Linkname))
+			}
+			if err := os.Symlink(header.Linkname, target); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// resolveParent creates the parent directory of target, and returns it with its symlinks resolved. It returns an
+// empty path when the parent resolves outside of root.
+func resolveParent(root, target string) (string, error) {
+	parent := filepath.Dir(target)
+
+	// the symlinks of the existing directories are resolved before creating the missing ones
+	existing := parent
+	for {
+		if _, err := os.Lstat(existing); err == nil {
+			break
+		} else if !os.IsNotExist(err) {
+			return "", err
+		}
+		existing = filepath.Dir(existing)
+	}
+	resolved, err := filepath.EvalSymlinks(existing)
+	if err != nil {
+		return "", err
+	}
+	if !isWithin(root, resolved) {
+		return "", nil
+	}
+
+	if err := os.MkdirAll(parent, 0750); err != nil {
+		return "", err
+	}
+	resolved, err = filepath.EvalSymlinks(parent)
+	if err != nil {
+		return "", err
+	}
+	if !isWithin(root, resolved) {
+		return "", nil
+	}
+	return resolved, nil
+}
+
+// isWithin returns true when path is root or one of its descendants
+func isWithin(root, path string) bool {
+	path = filepath.Clean(path)
+	return path == root || strings.HasPrefix(path, root+string(filepath.Separator))
+}
+
+func writeFile(path string, contents io.Reader) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(f, contents)
+	return err
+}
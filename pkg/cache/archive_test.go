@@ -0,0 +1,155 @@
+package cache_test
+
+import (
+	"archive/tar"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/archive"
+	"github.com/buildpacks/pack/pkg/cache"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestArchive(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Archive", testArchive, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testArchive(t *testing.T, when spec.G, it spec.S) {
+	var tmpDir string
+
+	it.Before(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "cache-archive")
+		h.AssertNil(t, err)
+	})
+
+	it.After(func() {
+		h.AssertNil(t, os.RemoveAll(tmpDir))
+	})
+
+	when("#WriteArchive", func() {
+		it("writes an OCI layout which can be read back", func() {
+			archivePath := filepath.Join(tmpDir, "cache.tar")
+			contents := archive.CreateSingleFileTarReader("some-layer/some-file", "some-content")
+			h.AssertNil(t, cache.WriteArchive(archivePath, contents, cache.ArchiveMetadata{Type: "build", AppImage: "index.docker.io/some/app:latest"}))
+
+			h.AssertTarHasFile(t, archivePath, "/oci-layout")
+			h.AssertTarHasFile(t, archivePath, "/index.json")
+
+			metadata, reader, err := cache.OpenArchive(archivePath)
+			h.AssertNil(t, err)
+			defer reader.Close()
+
+			h.AssertEq(t, metadata, cache.ArchiveMetadata{Type: "build", AppImage: "index.docker.io/some/app:latest"})
+			_, fileContents, err := archive.ReadTarEntry(reader, "some-layer/some-file")
+			h.AssertNil(t, err)
+			h.AssertEq(t, string(fileContents), "some-content")
+		})
+	})
+
+	when("#OpenArchive", func() {
+		it("errors when the file is not a cache archive", func() {
+			archivePath := filepath.Join(tmpDir, "other.tar")
+			h.AssertNil(t, archive.CreateSingleFileTar(archivePath, "some-file", "some-content"))
+
+			_, _, err := cache.OpenArchive(archivePath)
+			h.AssertError(t, err, "reading cache archive")
+		})
+	})
+
+	when("#DirContents and #ExtractContents", func() {
+		it("copies the files of a directory", func() {
+			srcDir := filepath.Join(tmpDir, "src")
+			h.AssertNil(t, os.MkdirAll(filepath.Join(srcDir, "some-layer"), 0750))
+			h.AssertNil(t, os.WriteFile(filepath.Join(srcDir, "some-layer", "some-file"), []byte("some-content"), 0600))
+			h.AssertNil(t, os.Symlink("some-file", filepath.Join(srcDir, "some-layer", "some-link")))
+
+			contents := cache.DirContents(srcDir)
+			defer contents.Close()
+
+			dstDir := filepath.Join(tmpDir, "dst")
+			h.AssertNil(t, cache.ExtractContents(contents, dstDir))
+
+			fileContents, err := os.ReadFile(filepath.Join(dstDir, "some-layer", "some-file"))
+			h.AssertNil(t, err)
+			h.AssertEq(t, string(fileContents), "some-content")
+
+			target, err := os.Readlink(filepath.Join(dstDir, "some-layer", "some-link"))
+			h.AssertNil(t, err)
+			h.AssertEq(t, target, "some-file")
+		})
+
+		it("rejects entries outside of the directory", func() {
+			contents := archive.GenerateTar(func(tw archive.TarWriter) error {
+				return tw.WriteHeader(&tar.Header{Name: "../some-file", Typeflag: tar.TypeReg, Mode: 0600})
+			})
+			defer contents.Close()
+
+			err := cache.ExtractContents(contents, filepath.Join(tmpDir, "dst"))
+			h.AssertError(t, err, "invalid path '../some-file' in cache contents")
+		})
+
+		it("rejects entries written through a symlink pointing outside of the directory", func() {
+			outsideDir := filepath.Join(tmpDir, "outside")
+			h.AssertNil(t, os.MkdirAll(outsideDir, 0750))
+
+			contents := archive.GenerateTar(func(tw archive.TarWriter) error {
+				if err := tw.WriteHeader(&tar.Header{Name: "some-link", Typeflag: tar.TypeSymlink, Linkname: outsideDir, Mode: 0777}); err != nil {
+					return err
+				}
+				if err := tw.WriteHeader(&tar.Header{Name: "some-link/passwd", Typeflag: tar.TypeReg, Mode: 0600, Size: 4}); err != nil {
+					return err
+				}
+				_, err := tw.Write([]byte("evil"))
+				return err
+			})
+			defer contents.Close()
+
+			err := cache.ExtractContents(contents, filepath.Join(tmpDir, "dst"))
+			h.AssertError(t, err, "invalid symlink 'some-link'")
+
+			_, err = os.Stat(filepath.Join(outsideDir, "passwd"))
+			h.AssertTrue(t, os.IsNotExist(err))
+		})
+
+		it("rejects symlinks escaping the directory", func() {
+			contents := archive.GenerateTar(func(tw archive.TarWriter) error {
+				return tw.WriteHeader(&tar.Header{Name: "some-layer/some-link", Typeflag: tar.TypeSymlink, Linkname: "../../outside", Mode: 0777})
+			})
+			defer contents.Close()
+
+			err := cache.ExtractContents(contents, filepath.Join(tmpDir, "dst"))
+			h.AssertError(t, err, "invalid symlink 'some-layer/some-link' to '../../outside'")
+		})
+
+		it("does not write through symlinks of the directory pointing outside of it", func() {
+			outsideDir := filepath.Join(tmpDir, "outside")
+			dstDir := filepath.Join(tmpDir, "dst")
+			h.AssertNil(t, os.MkdirAll(outsideDir, 0750))
+			h.AssertNil(t, os.MkdirAll(dstDir, 0750))
+			h.AssertNil(t, os.Symlink(outsideDir, filepath.Join(dstDir, "some-link")))
+
+			contents := archive.GenerateTar(func(tw archive.TarWriter) error {
+				if err := tw.WriteHeader(&tar.Header{Name: "some-link/passwd", Typeflag: tar.TypeReg, Mode: 0600, Size: 4}); err != nil {
+					return err
+				}
+				_, err := tw.Write([]byte("evil"))
+				return err
+			})
+			defer contents.Close()
+
+			err := cache.ExtractContents(contents, dstDir)
+			h.AssertError(t, err, "invalid path 'some-link/passwd' in cache contents, it is outside of the directory")
+
+			_, err = os.Stat(filepath.Join(outsideDir, "passwd"))
+			h.AssertTrue(t, os.IsNotExist(err))
+		})
+	})
+}
@@ -39,6 +39,26 @@ func (f Format) String() string {
 	return ""
 }
 
+// MarshalText makes Format satisfy the encoding.TextMarshaler interface.
+func (f Format) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+// UnmarshalText makes Format satisfy the encoding.TextUnmarshaler interface.
+func (f *Format) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "image":
+		*f = CacheImage
+	case "volume":
+		*f = CacheVolume
+	case "bind":
+		*f = CacheBind
+	default:
+		return errors.Errorf("invalid cache format '%s'", text)
+	}
+	return nil
+}
+
 func (c *CacheInfo) SourceName() string {
 	switch c.Format {
 	case CacheImage:
@@ -0,0 +1,184 @@
+package cache
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// VolumePrefix is the prefix of the names of the volume caches generated by pack
+const VolumePrefix = "pack-cache-"
+
+const (
+	indexLockTimeout = 30 * time.Second
+	indexLockRetry   = 50 * time.Millisecond
+	// a lock file older than this was left behind by a pack process that did not exit cleanly
+	indexLockStale = 2 * time.Minute
+)
+
+// IndexEntry records a cache used by a build.
+type IndexEntry struct {
+	// Name of the volume or image, or path of the bind cache.
+	Name string `json:"name"`
+
+	Format Format `json:"format"`
+
+	// Type is the kind of data held by the cache: build, launch or kaniko.
+	Type string `json:"type"`
+
+	// AppImage is the name of the app image built with the cache.
+	AppImage string `json:"app_image"`
+
+	LastUsed time.Time `json:"last_used"`
+}
+
+// Index keeps track of the caches used by builds, so that caches which cannot be discovered from the daemon,
+// such as bind and image caches, can be listed and removed. A nil Index records nothing.
+//
+// Updates hold a lock file next to the index, so that concurrent pack processes do not lose each other's entries.
+type Index struct {
+	path string
+}
+
+func NewIndex(path string) *Index {
+	return &Index{path: path}
+}
+
+// Entries returns the recorded caches, sorted by name.
+func (i *Index) Entries() ([]IndexEntry, error) {
+	if i == nil {
+		return nil, nil
+	}
+
+	contents, err := os.ReadFile(i.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "reading cache index")
+	}
+
+	var entries []IndexEntry
+	if err := json.Unmarshal(contents, &entries); err != nil {
+		return nil, errors.Wrap(err, "parsing cache index")
+	}
+	return entries, nil
+}
+
+// Record adds entries to the index, replacing the entries for caches with the same format and name.
+func (i *Index) Record(entries ...IndexEntry) error {
+	if i == nil {
+		return nil
+	}
+
+	unlock, err := i.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	existing, err := i.Entries()
+	if err != nil {
+		return err
+	}
+
+	byKey := map[string]IndexEntry{}
+	for _, entry := range append(existing, entries...) {
+		byKey[entry.key()] = entry
+	}
+
+	merged := make([]IndexEntry, 0, len(byKey))
+	for _, entry := range byKey {
+		merged = append(merged, entry)
+	}
+	return i.write(merged)
+}
+
+// Remove removes the entry of the cache with the given format and name, if any.
+func (i *Index) Remove(format Format, name string) error {
+	if i == nil {
+		return nil
+	}
+
+	unlock, err := i.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	existing, err := i.Entries()
+	if err != nil {
+		return err
+	}
+
+	removed := IndexEntry{Format: format, Name: name}
+	var remaining []IndexEntry
+	for _, entry := range existing {
+		if entry.key() != removed.key() {
+			remaining = append(remaining, entry)
+		}
+	}
+	return i.write(remaining)
+}
+
+func (i *Index) write(entries []IndexEntry) error {
+	sort.Slice(entries, func(a, b int) bool {
+		return entries[a].Name < entries[b].Name
+	})
+
+	if entries == nil {
+		entries = []IndexEntry{}
+	}
+	contents, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "encoding cache index")
+	}
+
+	// the index is replaced at once, so that it is never read while partially written
+	tmpPath := i.path + ".tmp"
+	if err := os.WriteFile(tmpPath, contents, 0600); err != nil {
+		return errors.Wrap(err, "writing cache index")
+	}
+	if err := os.Rename(tmpPath, i.path); err != nil {
+		return errors.Wrap(err, "writing cache index")
+	}
+	return nil
+}
+
+// lock creates the lock file of the index, waiting for other pack processes to release it, and returns a function
+// releasing it
+func (i *Index) lock() (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(i.path), 0750); err != nil {
+		return nil, errors.Wrap(err, "locking cache index")
+	}
+
+	lockPath := i.path + ".lock"
+	deadline := time.Now().Add(indexLockTimeout)
+	for {
+		f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err == nil {
+			_ = f.Close()
+			return func() { _ = os.Remove(lockPath) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, errors.Wrap(err, "locking cache index")
+		}
+
+		if info, err := os.Stat(lockPath); err == nil && time.Since(info.ModTime()) > indexLockStale {
+			_ = os.Remove(lockPath)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, errors.Errorf("timed out waiting for the cache index lock %s", lockPath)
+		}
+		time.Sleep(indexLockRetry)
+	}
+}
+
+func (e IndexEntry) key() string {
+	return e.Format.String() + ":" + e.Name
+}
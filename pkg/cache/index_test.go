@@ -0,0 +1,140 @@
+package cache_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/cache"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestIndex(t *testing.T) {
+	spec.Run(t, "Index", testIndex, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testIndex(t *testing.T, when spec.G, it spec.S) {
+	var (
+		tmpDir  string
+		subject *cache.Index
+		now     = time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	)
+
+	it.Before(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "cache-index")
+		h.AssertNil(t, err)
+		subject = cache.NewIndex(filepath.Join(tmpDir, "some-dir", "cache-index.json"))
+	})
+
+	it.After(func() {
+		h.AssertNil(t, os.RemoveAll(tmpDir))
+	})
+
+	when("#Entries", func() {
+		it("returns no entries when the index does not exist", func() {
+			entries, err := subject.Entries()
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(entries), 0)
+		})
+
+		it("errors when the index cannot be parsed", func() {
+			h.AssertNil(t, os.MkdirAll(filepath.Join(tmpDir, "some-dir"), 0750))
+			h.AssertNil(t, os.WriteFile(filepath.Join(tmpDir, "some-dir", "cache-index.json"), []byte("{"), 0600))
+
+			_, err := subject.Entries()
+			h.AssertError(t, err, "parsing cache index")
+		})
+	})
+
+	when("#Record", func() {
+		it("adds entries sorted by name", func() {
+			h.AssertNil(t, subject.Record(
+				cache.IndexEntry{Name: "some-volume", Format: cache.CacheVolume, Type: "build", AppImage: "some/app", LastUsed: now},
+				cache.IndexEntry{Name: "/some/bind", Format: cache.CacheBind, Type: "build", AppImage: "some/app", LastUsed: now},
+			))
+
+			entries, err := subject.Entries()
+			h.AssertNil(t, err)
+			h.AssertEq(t, entries, []cache.IndexEntry{
+				{Name: "/some/bind", Format: cache.CacheBind, Type: "build", AppImage: "some/app", LastUsed: now},
+				{Name: "some-volume", Format: cache.CacheVolume, Type: "build", AppImage: "some/app", LastUsed: now},
+			})
+		})
+
+		it("replaces the entry of the same cache", func() {
+			later := now.Add(time.Hour)
+			h.AssertNil(t, subject.Record(cache.IndexEntry{Name: "some-volume", Format: cache.CacheVolume, Type: "build", LastUsed: now}))
+			h.AssertNil(t, subject.Record(cache.IndexEntry{Name: "some-volume", Format: cache.CacheVolume, Type: "build", LastUsed: later}))
+			h.AssertNil(t, subject.Record(cache.IndexEntry{Name: "some-volume", Format: cache.CacheImage, Type: "build", LastUsed: now}))
+
+			entries, err := subject.Entries()
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(entries), 2)
+			for _, entry := range entries {
+				if entry.Format == cache.CacheVolume {
+					h.AssertEq(t, entry.LastUsed, later)
+				}
+			}
+		})
+
+		it("keeps the entries recorded concurrently", func() {
+			var wg sync.WaitGroup
+			for n := 0; n < 10; n++ {
+				wg.Add(1)
+				go func(n int) {
+					defer wg.Done()
+					h.AssertNil(t, subject.Record(cache.IndexEntry{Name: fmt.Sprintf("some-volume-%d", n), Format: cache.CacheVolume, Type: "build", LastUsed: now}))
+				}(n)
+			}
+			wg.Wait()
+
+			entries, err := subject.Entries()
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(entries), 10)
+			_, err = os.Stat(filepath.Join(tmpDir, "some-dir", "cache-index.json.lock"))
+			h.AssertTrue(t, os.IsNotExist(err))
+		})
+
+		it("takes over a stale lock", func() {
+			lockPath := filepath.Join(tmpDir, "some-dir", "cache-index.json.lock")
+			h.AssertNil(t, os.MkdirAll(filepath.Dir(lockPath), 0750))
+			h.AssertNil(t, os.WriteFile(lockPath, nil, 0600))
+			h.AssertNil(t, os.Chtimes(lockPath, now, now))
+
+			h.AssertNil(t, subject.Record(cache.IndexEntry{Name: "some-volume", Format: cache.CacheVolume, Type: "build", LastUsed: now}))
+
+			entries, err := subject.Entries()
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(entries), 1)
+		})
+
+		it("does nothing for a nil index", func() {
+			var nilIndex *cache.Index
+			h.AssertNil(t, nilIndex.Record(cache.IndexEntry{Name: "some-volume"}))
+		})
+	})
+
+	when("#Remove", func() {
+		it("removes the entry of the cache", func() {
+			h.AssertNil(t, subject.Record(
+				cache.IndexEntry{Name: "some-volume", Format: cache.CacheVolume, Type: "build", LastUsed: now},
+				cache.IndexEntry{Name: "other-volume", Format: cache.CacheVolume, Type: "launch", LastUsed: now},
+			))
+
+			h.AssertNil(t, subject.Remove(cache.CacheVolume, "some-volume"))
+
+			entries, err := subject.Entries()
+			h.AssertNil(t, err)
+			h.AssertEq(t, entries, []cache.IndexEntry{
+				{Name: "other-volume", Format: cache.CacheVolume, Type: "launch", LastUsed: now},
+			})
+		})
+	})
+}
@@ -0,0 +1,188 @@
+package client
+
+import (
+	"context"
+
+	"github.com/BurntSushi/toml"
+	"github.com/buildpacks/imgutil"
+	"github.com/buildpacks/imgutil/local"
+	"github.com/buildpacks/imgutil/remote"
+	"github.com/buildpacks/lifecycle/platform/files"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	v1remote "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/attest"
+	pname "github.com/buildpacks/pack/internal/name"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/dist"
+)
+
+// attestImage signs the published app image with the given digest, as reported by the exporter, and attaches its SBOM
+// and provenance to it as OCI referrers, in the repository of the app image and in the repository of each additional
+// tag. The manifest of the app image is read through the registry mirror of its registry, when one is configured.
+func (c *Client) attestImage(ctx context.Context, imageRef name.Reference, digest v1.Hash, opts BuildOptions, signer *attest.Signer, provenance *attest.Statement, sbomDir string) error {
+	remoteOpts := c.remoteOptions(ctx)
+
+	manifestName, err := pname.TranslateRegistry(imageRef.Context().Digest(digest.String()).Name(), c.registryMirrors, c.logger)
+	if err != nil {
+		return err
+	}
+	manifestRef, err := parseRegistryReference(manifestName, opts.InsecureRegistries)
+	if err != nil {
+		return err
+	}
+	descriptor, err := v1remote.Head(manifestRef, remoteOpts...)
+	if err != nil {
+		return errors.Wrapf(err, "reading manifest of %s", style.Symbol(manifestRef.Name()))
+	}
+
+	var repositories []name.Repository
+	for _, imageName := range append([]string{imageRef.Name()}, opts.AdditionalTags...) {
+		ref, err := parseRegistryReference(imageName, opts.InsecureRegistries)
+		if err != nil {
+			return errors.Wrapf(err, "invalid image name '%s'", imageName)
+		}
+		if !containsRepository(repositories, ref.Context()) {
+			repositories = append(repositories, ref.Context())
+		}
+	}
+
+	var artifacts []attest.Artifact
+	if opts.Attest && sbomDir != "" {
+		sboms, err := attest.SBOMArtifacts(sbomDir)
+		if err != nil {
+			return err
+		}
+		artifacts = append(artifacts, sboms...)
+	}
+	if provenance != nil {
+		artifact, err := attest.ProvenanceArtifact(*provenance, signer)
+		if err != nil {
+			return errors.Wrap(err, "creating provenance")
+		}
+		artifacts = append(artifacts, artifact)
+	}
+
+	for _, repository := range repositories {
+		subject := repository.Digest(digest.String())
+		attacher := attest.NewAttacher(subject, *descriptor, remoteOpts...)
+
+		if signer != nil {
+			signature, err := attest.SignatureArtifact(signer, repository, digest)
+			if err != nil {
+				return err
+			}
+			if _, err := attacher.Attach(signature); err != nil {
+				return err
+			}
+			c.logger.Infof("Signed image %s", style.Symbol(subject.Name()))
+		}
+
+		for _, artifact := range artifacts {
+			referrerDigest, err := attacher.Attach(artifact)
+			if err != nil {
+				return err
+			}
+			c.logger.Debugf("Attached %s referrer %s to image %s", style.Symbol(artifact.MediaType), style.Symbol(referrerDigest.String()), style.Symbol(subject.Name()))
+		}
+		if len(artifacts) > 0 {
+			c.logger.Infof("Attached %d attestation(s) to image %s", len(artifacts), style.Symbol(subject.Name()))
+		}
+	}
+
+	return nil
+}
+
+// exportedDigest returns the digest of the app image reported by the exporter in the report at reportPath.
+func exportedDigest(reportPath string) (v1.Hash, error) {
+	var report files.Report
+	if _, err := toml.DecodeFile(reportPath, &report); err != nil {
+		return v1.Hash{}, errors.Wrap(err, "reading export report")
+	}
+	if report.Image.Digest == "" {
+		return v1.Hash{}, errors.New("the exporter did not report the digest of the app image")
+	}
+	return v1.NewHash(report.Image.Digest)
+}
+
+// provenanceStatement completes info with the digest of the app image and the buildpack group the lifecycle wrote to
+// groupPath, and returns the provenance statement describing its build. The subject has no digest when digest is
+// empty, as for an image exported to the daemon, whose ID is not the digest of a manifest.
+func provenanceStatement(imageRef name.Reference, digest v1.Hash, groupPath string, info attest.BuildInfo) (*attest.Statement, error) {
+	var group struct {
+		Group []dist.ModuleInfo `toml:"group"`
+	}
+	if _, err := toml.DecodeFile(groupPath, &group); err != nil {
+		return nil, errors.Wrap(err, "reading buildpack group")
+	}
+
+	info.Image = imageRef.Context().Name()
+	info.Digest = digest
+	for _, bp := range group.Group {
+		info.Buildpacks = append(info.Buildpacks, dist.ModuleInfo{ID: bp.ID, Version: bp.Version, Homepage: bp.Homepage})
+	}
+
+	statement := attest.NewProvenanceStatement(info)
+	return &statement, nil
+}
+
+// parseRegistryReference parses the name of an image in a registry, which is reached over HTTP when it is one of
+// insecureRegistries.
+func parseRegistryReference(imageName string, insecureRegistries []string) (name.Reference, error) {
+	ref, err := name.ParseReference(imageName, name.WeakValidation)
+	if err != nil {
+		return nil, err
+	}
+	for _, registry := range insecureRegistries {
+		insecureRegistry, err := name.NewRegistry(registry, name.WeakValidation)
+		if err == nil && insecureRegistry.RegistryStr() == ref.Context().RegistryStr() {
+			return name.ParseReference(imageName, name.WeakValidation, name.Insecure)
+		}
+	}
+	return ref, nil
+}
+
+// imageDigest returns the digest of the manifest of img in its repository, or an empty string when it is not known.
+// The ID of a daemon image is the digest of its config, so the digest of a daemon image is taken from its repo
+// digests, which it only has once it was pushed to or pulled from its repository.
+func (c *Client) imageDigest(ctx context.Context, img imgutil.Image) string {
+	id, err := img.Identifier()
+	if err != nil {
+		return ""
+	}
+
+	switch v := id.(type) {
+	case remote.DigestIdentifier:
+		return v.Digest.DigestStr()
+	case local.IDIdentifier:
+		if c.docker == nil {
+			return ""
+		}
+		ref, err := name.ParseReference(img.Name(), name.WeakValidation)
+		if err != nil {
+			return ""
+		}
+		inspect, _, err := c.docker.ImageInspectWithRaw(ctx, v.String())
+		if err != nil {
+			return ""
+		}
+		for _, repoDigest := range inspect.RepoDigests {
+			digestRef, err := name.NewDigest(repoDigest, name.WeakValidation)
+			if err == nil && digestRef.Context().Name() == ref.Context().Name() {
+				return digestRef.DigestStr()
+			}
+		}
+	}
+	return ""
+}
+
+func containsRepository(repositories []name.Repository, repository name.Repository) bool {
+	for _, r := range repositories {
+		if r.Name() == repository.Name() {
+			return true
+		}
+	}
+	return false
+}
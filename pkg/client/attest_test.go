@@ -0,0 +1,324 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/buildpacks/imgutil/local"
+	"github.com/buildpacks/imgutil/remote"
+	"github.com/docker/docker/api/types"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	v1remote "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/attest"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestAttest(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Attest", testAttest, spec.Report(report.Terminal{}))
+}
+
+func testAttest(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject      *Client
+		server       *httptest.Server
+		registryHost string
+		imageRef     name.Reference
+		digest       v1.Hash
+		signer       *attest.Signer
+		sbomDir      string
+		outBuf       bytes.Buffer
+	)
+
+	it.Before(func() {
+		server = httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0)), registry.WithReferrersSupport(true)))
+		registryHost = strings.TrimPrefix(server.URL, "http://")
+
+		logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+		subject = &Client{
+			logger:   logger,
+			keychain: authn.DefaultKeychain,
+		}
+
+		img, err := random.Image(1024, 1)
+		h.AssertNil(t, err)
+		configFile, err := img.ConfigFile()
+		h.AssertNil(t, err)
+		configFile.OS, configFile.Architecture = "linux", "amd64"
+		img, err = mutate.ConfigFile(img, configFile)
+		h.AssertNil(t, err)
+		digest, err = img.Digest()
+		h.AssertNil(t, err)
+
+		imageRef, err = name.ParseReference(registryHost+"/some/app:latest", name.WeakValidation)
+		h.AssertNil(t, err)
+		h.AssertNil(t, v1remote.Write(imageRef, img))
+		otherRef, err := name.ParseReference(registryHost+"/other/app:latest", name.WeakValidation)
+		h.AssertNil(t, err)
+		h.AssertNil(t, v1remote.Write(otherRef, img))
+
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		h.AssertNil(t, err)
+		signer = attest.NewSigner(key)
+
+		sbomDir = t.TempDir()
+		h.AssertNil(t, os.MkdirAll(filepath.Join(sbomDir, "sbom", "launch"), 0750))
+		h.AssertNil(t, os.WriteFile(filepath.Join(sbomDir, "sbom", "launch", "sbom.cdx.json"), []byte(`{"bomFormat":"CycloneDX"}`), 0600))
+	})
+
+	it.After(func() {
+		server.Close()
+	})
+
+	referrers := func(repository string) map[string]v1.Descriptor {
+		t.Helper()
+		index, err := v1remote.Referrers(imageRef.Context().Registry.Repo(repository).Digest(digest.String()))
+		h.AssertNil(t, err)
+		manifest, err := index.IndexManifest()
+		h.AssertNil(t, err)
+
+		byType := map[string]v1.Descriptor{}
+		for _, descriptor := range manifest.Manifests {
+			byType[descriptor.ArtifactType] = descriptor
+		}
+		return byType
+	}
+
+	when("#attestImage", func() {
+		it("signs the image and attaches its SBOM and provenance", func() {
+			statement := attest.NewProvenanceStatement(attest.BuildInfo{Image: imageRef.Context().Name(), Digest: digest, Builder: "some/builder"})
+
+			opts := BuildOptions{Attest: true}
+			h.AssertNil(t, subject.attestImage(context.TODO(), imageRef, digest, opts, signer, &statement, sbomDir))
+
+			attached := referrers("some/app")
+			h.AssertEq(t, len(attached), 3)
+			_, ok := attached[attest.SignatureArtifactType]
+			h.AssertTrue(t, ok)
+			_, ok = attached[attest.CycloneDXMediaType]
+			h.AssertTrue(t, ok)
+
+			provenance, err := v1remote.Image(imageRef.Context().Digest(attached[attest.InTotoMediaType].Digest.String()))
+			h.AssertNil(t, err)
+			layers, err := provenance.Layers()
+			h.AssertNil(t, err)
+			mediaType, err := layers[0].MediaType()
+			h.AssertNil(t, err)
+			h.AssertEq(t, string(mediaType), attest.DSSEMediaType)
+
+			rc, err := layers[0].Compressed()
+			h.AssertNil(t, err)
+			defer rc.Close()
+			var envelope struct {
+				Payload []byte `json:"payload"`
+			}
+			h.AssertNil(t, json.NewDecoder(rc).Decode(&envelope))
+			var decoded attest.Statement
+			h.AssertNil(t, json.Unmarshal(envelope.Payload, &decoded))
+			h.AssertEq(t, decoded.Subject[0].Name, imageRef.Context().Name())
+			h.AssertEq(t, decoded.Subject[0].Digest["sha256"], digest.Hex)
+
+			h.AssertContains(t, outBuf.String(), "Signed image")
+			h.AssertContains(t, outBuf.String(), "Attached 2 attestation(s)")
+		})
+
+		it("attaches the provenance without the SBOM when only provenance is requested", func() {
+			statement := attest.NewProvenanceStatement(attest.BuildInfo{Image: imageRef.Context().Name(), Digest: digest})
+			h.AssertNil(t, subject.attestImage(context.TODO(), imageRef, digest, BuildOptions{Provenance: true}, nil, &statement, sbomDir))
+
+			attached := referrers("some/app")
+			h.AssertEq(t, len(attached), 1)
+			_, ok := attached[attest.InTotoMediaType]
+			h.AssertTrue(t, ok)
+		})
+
+		it("only signs the image when attestations are not requested", func() {
+			h.AssertNil(t, subject.attestImage(context.TODO(), imageRef, digest, BuildOptions{}, signer, nil, ""))
+
+			attached := referrers("some/app")
+			h.AssertEq(t, len(attached), 1)
+			_, ok := attached[attest.SignatureArtifactType]
+			h.AssertTrue(t, ok)
+		})
+
+		it("signs the image in the repository of each additional tag", func() {
+			opts := BuildOptions{AdditionalTags: []string{registryHost + "/other/app:v1", registryHost + "/some/app:v1"}}
+			h.AssertNil(t, subject.attestImage(context.TODO(), imageRef, digest, opts, signer, nil, ""))
+
+			h.AssertEq(t, len(referrers("some/app")), 1)
+			h.AssertEq(t, len(referrers("other/app")), 1)
+		})
+
+		it("signs the given digest once the tag points to another image", func() {
+			otherImg, err := random.Image(1024, 1)
+			h.AssertNil(t, err)
+			h.AssertNil(t, v1remote.Write(imageRef, otherImg))
+
+			h.AssertNil(t, subject.attestImage(context.TODO(), imageRef, digest, BuildOptions{}, signer, nil, ""))
+
+			h.AssertEq(t, len(referrers("some/app")), 1)
+		})
+
+		it("reads the manifest of the image through the registry mirror", func() {
+			mirror := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+			defer mirror.Close()
+			mirrorHost := strings.TrimPrefix(mirror.URL, "http://")
+
+			img, err := v1remote.Image(imageRef)
+			h.AssertNil(t, err)
+			mirrorRef, err := name.ParseReference(mirrorHost+"/missing/app:latest", name.WeakValidation)
+			h.AssertNil(t, err)
+			h.AssertNil(t, v1remote.Write(mirrorRef, img))
+			// only the mirror serves the manifest of the image in this repository
+			missingRef, err := name.ParseReference(registryHost+"/missing/app:latest", name.WeakValidation)
+			h.AssertNil(t, err)
+
+			subject.registryMirrors = map[string]string{registryHost: mirrorHost}
+			h.AssertNil(t, subject.attestImage(context.TODO(), missingRef, digest, BuildOptions{}, signer, nil, ""))
+
+			h.AssertEq(t, len(referrers("missing/app")), 1)
+		})
+
+		it("errors when the image does not exist", func() {
+			missingRef, err := name.ParseReference(registryHost+"/missing/app:latest", name.WeakValidation)
+			h.AssertNil(t, err)
+
+			err = subject.attestImage(context.TODO(), missingRef, digest, BuildOptions{}, signer, nil, "")
+			h.AssertError(t, err, "reading manifest of")
+		})
+	})
+
+	when("#exportedDigest", func() {
+		it("returns the digest reported by the exporter", func() {
+			reportPath := filepath.Join(t.TempDir(), "report.toml")
+			h.AssertNil(t, os.WriteFile(reportPath, []byte("[image]\ntags = [\"some/app:latest\"]\ndigest = \""+digest.String()+"\"\n"), 0600))
+
+			reported, err := exportedDigest(reportPath)
+			h.AssertNil(t, err)
+			h.AssertEq(t, reported, digest)
+		})
+
+		it("errors when the exporter did not report a digest", func() {
+			reportPath := filepath.Join(t.TempDir(), "report.toml")
+			h.AssertNil(t, os.WriteFile(reportPath, []byte("[image]\ntags = [\"some/app:latest\"]\n"), 0600))
+
+			_, err := exportedDigest(reportPath)
+			h.AssertError(t, err, "the exporter did not report the digest of the app image")
+		})
+	})
+
+	when("#provenanceStatement", func() {
+		var groupPath string
+
+		it.Before(func() {
+			groupPath = filepath.Join(t.TempDir(), "group.toml")
+			h.AssertNil(t, os.WriteFile(groupPath, []byte("[[group]]\nid = \"some/buildpack\"\nversion = \"1.2.3\"\napi = \"0.10\"\n"), 0600))
+		})
+
+		it("describes the built image and its buildpack group", func() {
+			info := attest.BuildInfo{
+				Builder:          "some/builder",
+				RunImage:         "some/run",
+				LifecycleVersion: "0.17.0",
+			}
+			statement, err := provenanceStatement(imageRef, digest, groupPath, info)
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, statement.Subject, []attest.Subject{{Name: imageRef.Context().Name(), Digest: map[string]string{"sha256": digest.Hex}}})
+			parameters := statement.Predicate.BuildDefinition.ExternalParameters
+			h.AssertEq(t, parameters["buildpacks"], []string{"some/buildpack@1.2.3"})
+			h.AssertEq(t, parameters["runImage"], "some/run")
+			h.AssertEq(t, statement.Predicate.RunDetails.Builder.Version["lifecycle"], "0.17.0")
+		})
+
+		it("omits the digest of an image exported to the daemon", func() {
+			statement, err := provenanceStatement(imageRef, v1.Hash{}, groupPath, attest.BuildInfo{})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, statement.Subject, []attest.Subject{{Name: imageRef.Context().Name()}})
+		})
+
+		it("errors when the buildpack group cannot be read", func() {
+			_, err := provenanceStatement(imageRef, digest, filepath.Join(t.TempDir(), "group.toml"), attest.BuildInfo{})
+			h.AssertError(t, err, "reading buildpack group")
+		})
+	})
+
+	when("#parseRegistryReference", func() {
+		it("reaches insecure registries over HTTP", func() {
+			ref, err := parseRegistryReference("registry.local:5000/some/app:latest", []string{"registry.local:5000"})
+			h.AssertNil(t, err)
+			h.AssertEq(t, ref.Context().Registry.Scheme(), "http")
+		})
+
+		it("reaches other registries over HTTPS", func() {
+			ref, err := parseRegistryReference("registry.example.com/some/app:latest", []string{"registry.local:5000"})
+			h.AssertNil(t, err)
+			h.AssertEq(t, ref.Context().Registry.Scheme(), "https")
+		})
+	})
+
+	when("#imageDigest", func() {
+		var (
+			mockController   *gomock.Controller
+			mockDockerClient *testmocks.MockCommonAPIClient
+			imageID          = local.IDIdentifier{ImageID: strings.Repeat("1", 64)}
+		)
+
+		it.Before(func() {
+			mockController = gomock.NewController(t)
+			mockDockerClient = testmocks.NewMockCommonAPIClient(mockController)
+			subject.docker = mockDockerClient
+		})
+
+		it.After(func() {
+			mockController.Finish()
+		})
+
+		it("returns the digest of a registry image", func() {
+			img := fakes.NewImage("some/image", "", remote.DigestIdentifier{Digest: imageRef.Context().Digest(digest.String())})
+			h.AssertEq(t, subject.imageDigest(context.TODO(), img), digest.String())
+		})
+
+		it("returns the repo digest of a daemon image", func() {
+			mockDockerClient.EXPECT().ImageInspectWithRaw(gomock.Any(), imageID.String()).Return(types.ImageInspect{
+				RepoDigests: []string{"other/image@sha256:" + strings.Repeat("2", 64), "some/image@" + digest.String()},
+			}, nil, nil)
+
+			img := fakes.NewImage("some/image:latest", "", imageID)
+			h.AssertEq(t, subject.imageDigest(context.TODO(), img), digest.String())
+		})
+
+		it("omits the digest of a daemon image which has no repo digest", func() {
+			mockDockerClient.EXPECT().ImageInspectWithRaw(gomock.Any(), imageID.String()).Return(types.ImageInspect{}, nil, nil)
+
+			img := fakes.NewImage("some/image:latest", "", imageID)
+			h.AssertEq(t, subject.imageDigest(context.TODO(), img), "")
+		})
+	})
+}
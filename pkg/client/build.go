@@ -1,10 +1,13 @@
 package client
 
 import (
+	"bytes"
 	"context"
 	"crypto/rand"
 	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -21,24 +24,30 @@ import (
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/volume/mounts"
 	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	v1remote "github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/pkg/errors"
 	ignore "github.com/sabhiram/go-gitignore"
 
+	"github.com/buildpacks/pack/internal/attest"
 	"github.com/buildpacks/pack/internal/build"
 	"github.com/buildpacks/pack/internal/builder"
 	internalConfig "github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/events"
 	pname "github.com/buildpacks/pack/internal/name"
 	"github.com/buildpacks/pack/internal/paths"
 	"github.com/buildpacks/pack/internal/stack"
 	"github.com/buildpacks/pack/internal/stringset"
 	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/internal/termui"
+	"github.com/buildpacks/pack/internal/timing"
 	"github.com/buildpacks/pack/pkg/archive"
 	"github.com/buildpacks/pack/pkg/buildpack"
 	"github.com/buildpacks/pack/pkg/cache"
 	"github.com/buildpacks/pack/pkg/dist"
 	"github.com/buildpacks/pack/pkg/image"
 	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/offline"
 	projectTypes "github.com/buildpacks/pack/pkg/project/types"
 	v02 "github.com/buildpacks/pack/pkg/project/v02"
 )
@@ -47,6 +56,12 @@ const (
 	minLifecycleVersionSupportingCreator = "0.7.4"
 	prevLifecycleVersionSupportingImage  = "0.6.1"
 	minLifecycleVersionSupportingImage   = "0.7.5"
+
+	// timingReportFile is the name of the timing report written next to report.toml
+	timingReportFile = "timings.json"
+
+	// provenanceReportFile is the name of the provenance statement written next to report.toml
+	provenanceReportFile = "provenance.json"
 )
 
 // LifecycleExecutor executes the lifecycle which satisfies the Cloud Native Buildpacks Lifecycle specification.
@@ -200,6 +215,61 @@ type BuildOptions struct {
 
 	// Configuration to export to OCI layout format
 	LayoutConfig *LayoutConfig
+
+	// Platforms to build the app image for, e.g. linux/amd64.
+	// When more than one target is provided, the app image is built once per target
+	// and an image index referencing each platform-specific image is published as Image.
+	Targets []dist.Target
+
+	// When set, structured events about the progress of the build are written to it as newline-delimited JSON.
+	// Events include the start and end of each lifecycle phase, per-buildpack detect and build results,
+	// layer cache hits and misses, the exported image digest and warnings.
+	Events io.Writer
+
+	// Records events to Events, shared by the builds for each target.
+	eventRecorder *events.Recorder
+
+	// When true, the duration of each step of the build is measured, including image fetches and lifecycle phases.
+	// A summary table is logged once the build finishes and, when ReportDestinationDir is set,
+	// a JSON report is written to timings.json in that directory.
+	Timings bool
+
+	// Records the duration of each step when Timings is set, shared by the builds for each target.
+	timingRecorder *timing.Recorder
+
+	// Path of a PEM encoded private key used to sign the published app image. The digest of the app image is signed
+	// for the repository of Image and of each additional tag, and the signatures are attached to the app image as
+	// OCI referrers in the format verified by cosign. Requires Publish.
+	SigningKey string
+
+	// Password decrypting SigningKey when it is encrypted, as keys generated by cosign are.
+	SigningKeyPassword []byte
+
+	// Registries that the signature and attestations of the app image are pushed to over HTTP.
+	InsecureRegistries []string
+
+	// When true, the SBOM written by the lifecycle and a SLSA provenance statement describing the builder, buildpacks
+	// and source of the app are attached to the published app image as OCI referrers.
+	// When SigningKey is set, the provenance statement is signed. Requires Publish.
+	Attest bool
+
+	// When true, a SLSA provenance statement describing the builder, run image, lifecycle, buildpack group and project
+	// of the app image is generated once the build finishes. It is written to provenance.json in ReportDestinationDir
+	// when set, and attached to the app image as an OCI referrer when Publish is set.
+	// Attest implies Provenance.
+	Provenance bool
+
+	// Directory holding the artifacts of the build saved by PrepareBuild. When set, the builder, run image, lifecycle
+	// image and buildpacks are read from this directory instead of being pulled or downloaded, and the Builder,
+	// RunImage and LifecycleImage saved to it are used when they are not set. Requires exporting to the daemon.
+	VendorDir string
+
+	// When set, only the analyze and detect phases are run and the resulting group and plan are copied to this directory.
+	// It is set by Detect.
+	detectOutputDir string
+
+	// Receives the debug output of the detector when detectOutputDir is set.
+	detectLog io.Writer
 }
 
 func (b *BuildOptions) Layout() bool {
@@ -279,6 +349,23 @@ var IsSuggestedBuilderFunc = func(b string) bool {
 // If any configuration is deemed invalid, or if any lifecycle phases fail,
 // an error will be returned and no image produced.
 func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
+	if opts.VendorDir != "" {
+		return c.buildVendored(ctx, opts)
+	}
+
+	if opts.Events != nil && opts.eventRecorder == nil {
+		opts.eventRecorder = events.NewRecorder(opts.Events)
+	}
+
+	if opts.Timings && opts.timingRecorder == nil {
+		opts.timingRecorder = timing.NewRecorder()
+		defer c.reportTimings(opts.timingRecorder, opts.ReportDestinationDir)
+	}
+
+	if len(opts.Targets) > 1 {
+		return c.buildMultiPlatform(ctx, opts)
+	}
+
 	var pathsConfig layoutPathConfig
 
 	imageRef, err := c.parseReference(opts)
@@ -287,6 +374,62 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 	}
 	imgRegistry := imageRef.Context().RegistryStr()
 	imageName := imageRef.Name()
+	startedOn := time.Now()
+
+	var signer *attest.Signer
+	if opts.SigningKey != "" || opts.Attest {
+		if !opts.Publish || opts.Layout() {
+			return errors.New("signing or attesting the app image requires publishing it to a registry")
+		}
+		if opts.SigningKey != "" {
+			if signer, err = attest.LoadSigner(opts.SigningKey, opts.SigningKeyPassword); err != nil {
+				return err
+			}
+		}
+	}
+
+	provenance := opts.Provenance || opts.Attest
+	if provenance {
+		if opts.Layout() {
+			return errors.New("generating provenance is not supported when exporting to OCI layout")
+		}
+		if !opts.Publish && opts.ReportDestinationDir == "" {
+			return errors.New("generating provenance requires publishing the app image or a report output directory")
+		}
+	}
+
+	sbomDir := opts.SBOMDestinationDir
+	if opts.Attest && sbomDir == "" {
+		if sbomDir, err = os.MkdirTemp("", "pack.sbom."); err != nil {
+			return err
+		}
+		defer os.RemoveAll(sbomDir)
+	}
+
+	// the digest of the app image and its buildpack group are read from the files written by the lifecycle, as the
+	// tag of the app image may already point to another image once the build finishes
+	var attestDir string
+	if signer != nil || provenance {
+		if attestDir, err = os.MkdirTemp("", "pack.attest."); err != nil {
+			return err
+		}
+		defer os.RemoveAll(attestDir)
+	}
+	reportDir := opts.ReportDestinationDir
+	if reportDir == "" {
+		reportDir = attestDir
+	}
+	var groupDir string
+	if provenance {
+		groupDir = attestDir
+	}
+
+	if opts.Cache.Build.Format == cache.CacheImage && !opts.Publish && !opts.Layout() {
+		// without this check, missing credentials would only surface when the lifecycle exports the cache
+		if err := c.checkCacheImageAccess(opts.Cache.Build.Source); err != nil {
+			return err
+		}
+	}
 
 	if opts.Layout() {
 		pathsConfig, err = c.processLayoutPath(opts.LayoutConfig.InputImage, opts.LayoutConfig.PreviousInputImage)
@@ -311,19 +454,32 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		return errors.Wrapf(err, "invalid builder '%s'", opts.Builder)
 	}
 
-	rawBuilderImage, err := c.imageFetcher.Fetch(ctx, builderRef.Name(), image.FetchOptions{Daemon: true, PullPolicy: opts.PullPolicy})
+	var platform string
+	if len(opts.Targets) == 1 {
+		platform = opts.Targets[0].Platform()
+	}
+
+	doneFetchBuilder := opts.timingRecorder.Track(timing.CategoryPack, "fetch builder image")
+	rawBuilderImage, err := c.imageFetcher.Fetch(ctx, builderRef.Name(), image.FetchOptions{Daemon: true, PullPolicy: opts.PullPolicy, Platform: platform})
 	if err != nil {
 		return errors.Wrapf(err, "failed to fetch builder image '%s'", builderRef.Name())
 	}
+	doneFetchBuilder()
 
 	bldr, err := c.getBuilder(rawBuilderImage)
 	if err != nil {
 		return errors.Wrapf(err, "invalid builder %s", style.Symbol(opts.Builder))
 	}
 
+	if len(opts.Targets) == 1 {
+		if err := validateImageTarget(rawBuilderImage, opts.Targets[0]); err != nil {
+			return errors.Wrapf(err, "invalid builder %s", style.Symbol(opts.Builder))
+		}
+	}
+
 	runImageName := c.resolveRunImage(opts.RunImage, imgRegistry, builderRef.Context().RegistryStr(), bldr.DefaultRunImage(), opts.AdditionalMirrors, opts.Publish)
 
-	fetchOptions := image.FetchOptions{Daemon: !opts.Publish, PullPolicy: opts.PullPolicy}
+	fetchOptions := image.FetchOptions{Daemon: !opts.Publish, PullPolicy: opts.PullPolicy, Platform: platform}
 	if opts.Layout() {
 		targetRunImagePath, err := layout.ParseRefToPath(runImageName)
 		if err != nil {
@@ -339,31 +495,68 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		pathsConfig.targetRunImagePath = targetRunImagePath
 		pathsConfig.hostRunImagePath = hostRunImagePath
 	}
+	// offline, the run image, buildpacks, extensions and lifecycle image which are not available locally are reported
+	// together once they were all looked up
+	missing := &offline.MissingArtifactsError{}
+
+	doneFetchRunImage := opts.timingRecorder.Track(timing.CategoryPack, "fetch run image")
 	runImage, err := c.validateRunImage(ctx, runImageName, fetchOptions, bldr.StackID)
-	if err != nil {
+	if err != nil && !missing.Add(err) {
 		return errors.Wrapf(err, "invalid run-image '%s'", runImageName)
 	}
+	doneFetchRunImage()
 
 	var runMixins []string
-	if _, err := dist.GetLabel(runImage, stack.MixinsLabel, &runMixins); err != nil {
-		return err
+	if runImage != nil {
+		if len(opts.Targets) == 1 {
+			if err := validateImageTarget(runImage, opts.Targets[0]); err != nil {
+				return errors.Wrapf(err, "invalid run-image '%s'", runImageName)
+			}
+		}
+
+		if _, err := dist.GetLabel(runImage, stack.MixinsLabel, &runMixins); err != nil {
+			return err
+		}
 	}
 
+	doneFetchModules := opts.timingRecorder.Track(timing.CategoryPack, "fetch buildpacks and extensions")
 	fetchedBPs, order, err := c.processBuildpacks(ctx, bldr.Image(), bldr.Buildpacks(), bldr.Order(), bldr.StackID, opts)
-	if err != nil {
+	if err != nil && !missing.Add(err) {
 		return err
 	}
 
 	fetchedExs, orderExtensions, err := c.processExtensions(ctx, bldr.Image(), bldr.Extensions(), bldr.OrderExtensions(), bldr.StackID, opts)
-	if err != nil {
+	if err != nil && !missing.Add(err) {
 		return err
 	}
+	doneFetchModules()
 
 	imgOS, err := rawBuilderImage.OS()
 	if err != nil {
 		return errors.Wrapf(err, "getting builder OS")
 	}
 
+	if len(opts.ProjectDescriptor.Launch.Env) > 0 {
+		if imgOS == "windows" {
+			return errors.New("launch environment variables from the project descriptor are not supported on Windows builders")
+		}
+
+		launchEnvBP, err := createLaunchEnvBuildpack(opts.ProjectDescriptor.Launch.Env, bldr.StackID)
+		if err != nil {
+			return errors.Wrap(err, "creating launch environment buildpack")
+		}
+
+		newFetchedBPs, moduleInfo, err := c.fetchBuildpack(ctx, launchEnvBP, "", bldr.Image(), bldr.Buildpacks(), opts, buildpack.KindBuildpack)
+		if err != nil {
+			return err
+		}
+		fetchedBPs = append(fetchedBPs, newFetchedBPs...)
+		if len(order) == 0 || len(order[0].Group) == 0 {
+			order = bldr.Order()
+		}
+		order = appendBuildpackToOrder(order, *moduleInfo)
+	}
+
 	// Default mode: if the TrustBuilder option is not set, trust the suggested builders.
 	if opts.TrustBuilder == nil {
 		opts.TrustBuilder = IsSuggestedBuilderFunc
@@ -381,7 +574,7 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 
 	// Get the platform API version to use
 	lifecycleVersion := bldr.LifecycleDescriptor().Info.Version
-	useCreator := supportsCreator(lifecycleVersion) && opts.TrustBuilder(opts.Builder)
+	useCreator := supportsCreator(lifecycleVersion) && opts.TrustBuilder(opts.Builder) && opts.detectOutputDir == ""
 	var (
 		lifecycleOptsLifecycleImage string
 		lifecycleAPIs               []string
@@ -399,28 +592,19 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 				return errors.Wrapf(err, "getting builder architecture")
 			}
 
-			lifecycleImage, err := c.imageFetcher.Fetch(
-				ctx,
-				lifecycleImageName,
-				image.FetchOptions{Daemon: true, PullPolicy: opts.PullPolicy, Platform: fmt.Sprintf("%s/%s", imgOS, imgArch)},
-			)
-			if err != nil {
-				return fmt.Errorf("fetching lifecycle image: %w", err)
-			}
-
-			lifecycleOptsLifecycleImage = lifecycleImage.Name()
-			labels, err := lifecycleImage.Labels()
-			if err != nil {
-				return fmt.Errorf("reading labels of lifecycle image: %w", err)
-			}
-
-			lifecycleAPIs, err = extractSupportedLifecycleApis(labels)
-			if err != nil {
-				return fmt.Errorf("reading api versions of lifecycle image: %w", err)
+			doneFetchLifecycle := opts.timingRecorder.Track(timing.CategoryPack, "fetch lifecycle image")
+			lifecycleOptsLifecycleImage, lifecycleAPIs, err = c.fetchLifecycleImage(ctx, lifecycleImageName, opts.PullPolicy, fmt.Sprintf("%s/%s", imgOS, imgArch))
+			if err != nil && !missing.Add(err) {
+				return err
 			}
+			doneFetchLifecycle()
 		}
 	}
 
+	if err := missing.ErrOrNil(); err != nil {
+		return err
+	}
+
 	usingPlatformAPI, err := build.FindLatestSupported(append(
 		bldr.LifecycleDescriptor().APIs.Platform.Deprecated,
 		bldr.LifecycleDescriptor().APIs.Platform.Supported...),
@@ -443,10 +627,12 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		buildEnvs[k] = v
 	}
 
+	doneExtendBuilder := opts.timingRecorder.Track(timing.CategoryPack, "extend builder")
 	ephemeralBuilder, err := c.createEphemeralBuilder(rawBuilderImage, buildEnvs, order, fetchedBPs, orderExtensions, fetchedExs, usingPlatformAPI.LessThan("0.12"))
 	if err != nil {
 		return err
 	}
+	doneExtendBuilder()
 	defer c.docker.ImageRemove(context.Background(), ephemeralBuilder.Name(), types.ImageRemoveOptions{Force: true})
 
 	if len(bldr.OrderExtensions()) > 0 {
@@ -465,10 +651,12 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		opts.ContainerConfig.Volumes = appendLayoutVolumes(opts.ContainerConfig.Volumes, pathsConfig)
 	}
 
+	doneVolumes := opts.timingRecorder.Track(timing.CategoryPack, "set up volumes")
 	processedVolumes, warnings, err := processVolumes(imgOS, opts.ContainerConfig.Volumes)
 	if err != nil {
 		return err
 	}
+	doneVolumes()
 
 	for _, warning := range warnings {
 		c.logger.Warn(warning)
@@ -532,10 +720,16 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		PreviousImage:        opts.PreviousImage,
 		Interactive:          opts.Interactive,
 		Termui:               termui.NewTermui(imageName, ephemeralBuilder, runImageName),
-		ReportDestinationDir: opts.ReportDestinationDir,
-		SBOMDestinationDir:   opts.SBOMDestinationDir,
+		ReportDestinationDir: reportDir,
+		SBOMDestinationDir:   sbomDir,
+		GroupDestinationDir:  groupDir,
 		CreationTime:         opts.CreationTime,
 		Layout:               opts.Layout(),
+		DetectOnly:           opts.detectOutputDir != "",
+		DetectOutputDir:      opts.detectOutputDir,
+		DetectLog:            opts.detectLog,
+		Events:               opts.eventRecorder,
+		Timings:              opts.timingRecorder,
 	}
 
 	switch {
@@ -548,12 +742,174 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		return errors.Errorf("Lifecycle %s does not have an associated lifecycle image. Builder must be trusted.", lifecycleVersion.String())
 	}
 
+	if opts.detectOutputDir == "" {
+		c.recordCaches(imageRef, opts)
+	}
+
 	if err = c.lifecycleExecutor.Execute(ctx, lifecycleOpts); err != nil {
 		return fmt.Errorf("executing lifecycle: %w", err)
 	}
+	if opts.detectOutputDir != "" {
+		return nil
+	}
+	if opts.eventRecorder != nil && !opts.Layout() {
+		c.recordImageExported(ctx, opts.eventRecorder, opts.Publish, imageRef)
+	}
+	var digest v1.Hash
+	if opts.Publish && attestDir != "" {
+		if digest, err = exportedDigest(filepath.Join(reportDir, "report.toml")); err != nil {
+			return err
+		}
+	}
+	var statement *attest.Statement
+	if provenance {
+		source := projectMetadata.Source
+		if source == nil {
+			source = v02.GitMetadata(opts.AppPath)
+		}
+		info := attest.BuildInfo{
+			Builder:          builderRef.Name(),
+			BuilderDigest:    c.imageDigest(ctx, rawBuilderImage),
+			RunImage:         runImageName,
+			RunImageDigest:   c.imageDigest(ctx, runImage),
+			LifecycleVersion: lifecycleVersion.String(),
+			Project:          opts.ProjectDescriptor.Project,
+			Source:           source,
+			PackVersion:      c.version,
+			StartedOn:        startedOn,
+			FinishedOn:       time.Now(),
+		}
+		if statement, err = provenanceStatement(imageRef, digest, filepath.Join(groupDir, "group.toml"), info); err != nil {
+			return errors.Wrap(err, "generating provenance")
+		}
+		if opts.ReportDestinationDir != "" {
+			if err := os.MkdirAll(opts.ReportDestinationDir, 0750); err != nil {
+				return err
+			}
+			if err := attest.WriteProvenance(*statement, filepath.Join(opts.ReportDestinationDir, provenanceReportFile)); err != nil {
+				return errors.Wrap(err, "writing provenance")
+			}
+		}
+	}
+	if opts.Publish && (signer != nil || statement != nil) {
+		if err := c.attestImage(ctx, imageRef, digest, opts, signer, statement, sbomDir); err != nil {
+			return errors.Wrap(err, "attesting image")
+		}
+	}
 	return c.logImageNameAndSha(ctx, opts.Publish, imageRef)
 }
 
+// buildMultiPlatform builds the app image once per target, tagging each platform-specific image
+// with a platform suffix, and publishes an image index referencing all of them as the app image.
+func (c *Client) buildMultiPlatform(ctx context.Context, opts BuildOptions) error {
+	if !opts.Publish {
+		return errors.New("building for multiple platforms requires publishing to a registry")
+	}
+	if opts.Layout() {
+		return errors.New("building for multiple platforms is not supported when exporting to OCI layout")
+	}
+
+	imageRef, err := c.parseTagReference(opts.Image)
+	if err != nil {
+		return errors.Wrapf(err, "invalid image name '%s'", opts.Image)
+	}
+
+	var platformImages []name.Reference
+	for _, target := range opts.Targets {
+		platformOpts := opts
+		platformOpts.Targets = []dist.Target{target}
+		platformOpts.AdditionalTags = nil
+		if platformOpts.Image, err = platformImageName(opts.Image, target); err != nil {
+			return errors.Wrapf(err, "invalid image name '%s'", opts.Image)
+		}
+		if opts.PreviousImage != "" {
+			if platformOpts.PreviousImage, err = platformImageName(opts.PreviousImage, target); err != nil {
+				return errors.Wrapf(err, "invalid previous image name '%s'", opts.PreviousImage)
+			}
+		}
+		if opts.CacheImage != "" {
+			if platformOpts.CacheImage, err = platformImageName(opts.CacheImage, target); err != nil {
+				return errors.Wrapf(err, "invalid cache image name '%s'", opts.CacheImage)
+			}
+		}
+		if opts.Cache.Build.Format == cache.CacheImage {
+			if platformOpts.Cache.Build.Source, err = platformImageName(opts.Cache.Build.Source, target); err != nil {
+				return errors.Wrapf(err, "invalid cache image name '%s'", opts.Cache.Build.Source)
+			}
+		}
+
+		c.logger.Infof("Building image for platform %s", style.Symbol(target.Platform()))
+		if err := c.Build(ctx, platformOpts); err != nil {
+			return errors.Wrapf(err, "building for platform %s", style.Symbol(target.Platform()))
+		}
+
+		platformRef, err := name.ParseReference(platformOpts.Image, name.WeakValidation)
+		if err != nil {
+			return err
+		}
+		platformImages = append(platformImages, platformRef)
+	}
+
+	tags := []name.Reference{imageRef}
+	for _, additionalTag := range opts.AdditionalTags {
+		tag, err := c.parseTagReference(additionalTag)
+		if err != nil {
+			return errors.Wrapf(err, "invalid additional tag '%s'", additionalTag)
+		}
+		tags = append(tags, tag)
+	}
+
+	digest, err := c.pushImageIndex(ctx, platformImages, tags)
+	if err != nil {
+		return errors.Wrap(err, "publishing image index")
+	}
+
+	for _, tag := range tags {
+		c.logger.Infof("Published image index %s", style.Symbol(fmt.Sprintf("%s@%s", tag.Name(), digest)))
+	}
+
+	if logging.IsQuiet(c.logger) {
+		_, err = c.logger.Writer().Write([]byte(fmt.Sprintf("%s@%s\n", imageRef.Context().Name(), digest)))
+		return err
+	}
+	return nil
+}
+
+// platformImageName appends the target platform to the tag of imageName,
+// e.g. registry.io/app:latest becomes registry.io/app:latest-linux-arm64
+func platformImageName(imageName string, target dist.Target) (string, error) {
+	tag, err := name.NewTag(imageName, name.WeakValidation)
+	if err != nil {
+		return "", err
+	}
+	suffix := strings.ReplaceAll(target.Platform(), "/", "-")
+	return fmt.Sprintf("%s:%s-%s", tag.Context().Name(), tag.TagStr(), suffix), nil
+}
+
+// validateImageTarget ensures the os, architecture and variant of img match the requested target
+func validateImageTarget(img imgutil.Image, target dist.Target) error {
+	imgOS, err := img.OS()
+	if err != nil {
+		return errors.Wrapf(err, "getting OS of %s", style.Symbol(img.Name()))
+	}
+	imgArch, err := img.Architecture()
+	if err != nil {
+		return errors.Wrapf(err, "getting architecture of %s", style.Symbol(img.Name()))
+	}
+	imgVariant, err := img.Variant()
+	if err != nil {
+		return errors.Wrapf(err, "getting architecture variant of %s", style.Symbol(img.Name()))
+	}
+
+	actual := dist.Target{OS: imgOS, Arch: imgArch, ArchVariant: imgVariant}
+	if target.OS != imgOS ||
+		(target.Arch != "" && target.Arch != imgArch) ||
+		(target.ArchVariant != "" && target.ArchVariant != imgVariant) {
+		return errors.Errorf("image platform %s does not match requested platform %s", style.Symbol(actual.Platform()), style.Symbol(target.Platform()))
+	}
+	return nil
+}
+
 func extractSupportedLifecycleApis(labels map[string]string) ([]string, error) {
 	// sample contents of labels:
 	//    {io.buildpacks.builder.metadata:\"{\"lifecycle\":{\"version\":\"0.15.3\"},\"api\":{\"buildpack\":\"0.2\",\"platform\":\"0.3\"}}",
@@ -646,6 +1002,25 @@ func (c *Client) getBuilder(img imgutil.Image) (*builder.Builder, error) {
 	return bldr, nil
 }
 
+// fetchLifecycleImage fetches a lifecycle image, and returns its name with the platform APIs it supports
+func (c *Client) fetchLifecycleImage(ctx context.Context, name string, pullPolicy image.PullPolicy, platform string) (string, []string, error) {
+	lifecycleImage, err := c.imageFetcher.Fetch(ctx, name, image.FetchOptions{Daemon: true, PullPolicy: pullPolicy, Platform: platform})
+	if err != nil {
+		return "", nil, fmt.Errorf("fetching lifecycle image: %w", err)
+	}
+
+	labels, err := lifecycleImage.Labels()
+	if err != nil {
+		return "", nil, fmt.Errorf("reading labels of lifecycle image: %w", err)
+	}
+
+	lifecycleAPIs, err := extractSupportedLifecycleApis(labels)
+	if err != nil {
+		return "", nil, fmt.Errorf("reading api versions of lifecycle image: %w", err)
+	}
+	return lifecycleImage.Name(), lifecycleAPIs, nil
+}
+
 func (c *Client) validateRunImage(context context.Context, name string, opts image.FetchOptions, expectedStack string) (imgutil.Image, error) {
 	if name == "" {
 		return nil, errors.New("run image must be specified")
@@ -919,6 +1294,9 @@ func (c *Client) processBuildpacks(ctx context.Context, builderImage imgutil.Ima
 		}
 	}
 
+	// offline, every buildpack which is not available locally is reported at once
+	missing := &offline.MissingArtifactsError{}
+
 	order = dist.Order{{Group: []dist.ModuleRef{}}}
 	for _, bp := range declaredBPs {
 		locatorType, err := buildpack.GetLocatorType(bp, relativeBaseDir, builderBPs)
@@ -947,6 +1325,9 @@ func (c *Client) processBuildpacks(ctx context.Context, builderImage imgutil.Ima
 		default:
 			newFetchedBPs, moduleInfo, err := c.fetchBuildpack(ctx, bp, relativeBaseDir, builderImage, builderBPs, opts, buildpack.KindBuildpack)
 			if err != nil {
+				if missing.Add(err) {
+					continue
+				}
 				return fetchedBPs, order, err
 			}
 			fetchedBPs = append(fetchedBPs, newFetchedBPs...)
@@ -981,6 +1362,9 @@ func (c *Client) processBuildpacks(ctx context.Context, builderImage imgutil.Ima
 			for _, bp := range preBuildpacks {
 				newFetchedBPs, moduleInfo, err := c.fetchBuildpack(ctx, bp, relativeBaseDir, builderImage, builderBPs, opts, buildpack.KindBuildpack)
 				if err != nil {
+					if missing.Add(err) {
+						continue
+					}
 					return fetchedBPs, order, err
 				}
 				fetchedBPs = append(fetchedBPs, newFetchedBPs...)
@@ -990,6 +1374,9 @@ func (c *Client) processBuildpacks(ctx context.Context, builderImage imgutil.Ima
 			for _, bp := range postBuildpacks {
 				newFetchedBPs, moduleInfo, err := c.fetchBuildpack(ctx, bp, relativeBaseDir, builderImage, builderBPs, opts, buildpack.KindBuildpack)
 				if err != nil {
+					if missing.Add(err) {
+						continue
+					}
 					return fetchedBPs, order, err
 				}
 				fetchedBPs = append(fetchedBPs, newFetchedBPs...)
@@ -998,7 +1385,7 @@ func (c *Client) processBuildpacks(ctx context.Context, builderImage imgutil.Ima
 		}
 	}
 
-	return fetchedBPs, order, nil
+	return fetchedBPs, order, missing.ErrOrNil()
 }
 
 func (c *Client) fetchBuildpack(ctx context.Context, bp string, relativeBaseDir string, builderImage imgutil.Image, builderBPs []dist.ModuleInfo, opts BuildOptions, kind string) ([]buildpack.BuildModule, *dist.ModuleInfo, error) {
@@ -1035,6 +1422,9 @@ func (c *Client) fetchBuildpack(ctx context.Context, bp string, relativeBaseDir
 		if kind == buildpack.KindExtension {
 			downloadOptions.ModuleKind = kind
 		}
+		if len(opts.Targets) == 1 {
+			downloadOptions.Target = &opts.Targets[0]
+		}
 		mainBP, depBPs, err := c.buildpackDownloader.Download(ctx, bp, downloadOptions)
 		if err != nil {
 			return nil, nil, errors.Wrap(err, "downloading buildpack")
@@ -1098,6 +1488,9 @@ func (c *Client) processExtensions(ctx context.Context, builderImage imgutil.Ima
 	relativeBaseDir := opts.RelativeBaseDir
 	declaredExs := opts.Extensions
 
+	// offline, every extension which is not available locally is reported at once
+	missing := &offline.MissingArtifactsError{}
+
 	orderExtensions = dist.Order{{Group: []dist.ModuleRef{}}}
 	for _, ex := range declaredExs {
 		locatorType, err := buildpack.GetLocatorType(ex, relativeBaseDir, builderExs)
@@ -1113,6 +1506,9 @@ func (c *Client) processExtensions(ctx context.Context, builderImage imgutil.Ima
 		default:
 			newFetchedExs, moduleInfo, err := c.fetchBuildpack(ctx, ex, relativeBaseDir, builderImage, builderExs, opts, buildpack.KindExtension)
 			if err != nil {
+				if missing.Add(err) {
+					continue
+				}
 				return fetchedExs, orderExtensions, err
 			}
 			fetchedExs = append(fetchedExs, newFetchedExs...)
@@ -1120,7 +1516,7 @@ func (c *Client) processExtensions(ctx context.Context, builderImage imgutil.Ima
 		}
 	}
 
-	return fetchedExs, orderExtensions, nil
+	return fetchedExs, orderExtensions, missing.ErrOrNil()
 }
 
 func (c *Client) createEphemeralBuilder(
@@ -1141,7 +1537,7 @@ func (c *Client) createEphemeralBuilder(
 	bldr.SetEnv(env)
 	for _, bp := range buildpacks {
 		bpInfo := bp.Descriptor().Info()
-		c.logger.Debugf("Adding buildpack %s version %s to builder", style.Symbol(bpInfo.ID), style.Symbol(bpInfo.Version))
+		logging.WithFields(c.logger, logging.FieldBuildpackID, bpInfo.ID).Debugf("Adding buildpack %s version %s to builder", style.Symbol(bpInfo.ID), style.Symbol(bpInfo.Version))
 		bldr.AddBuildpack(bp)
 	}
 	if len(order) > 0 && len(order[0].Group) > 0 {
@@ -1244,6 +1640,70 @@ func (c *Client) logImageNameAndSha(ctx context.Context, publish bool, imageRef
 	return err
 }
 
+// checkCacheImageAccess ensures that the cache image can be pushed with the credentials available to pack, if any.
+func (c *Client) checkCacheImageAccess(cacheImage string) error {
+	ref, err := name.ParseReference(cacheImage, name.WeakValidation)
+	if err != nil {
+		return errors.Wrapf(err, "invalid cache image name '%s'", cacheImage)
+	}
+
+	// registries such as ttl.sh accept pushes without credentials, so only the push itself is checked
+	if err := v1remote.CheckPushPermission(ref, c.keychain, http.DefaultTransport); err != nil {
+		return errors.Wrapf(err, "cache image %s cannot be pushed to registry %s", style.Symbol(ref.Name()), style.Symbol(ref.Context().RegistryStr()))
+	}
+
+	c.logger.Debugf("Using registry cache image %s", style.Symbol(ref.Name()))
+	return nil
+}
+
+// reportTimings logs a summary of the duration of each build step, and writes the timing report to reportDir when it
+// is set. Failing to write the report does not fail the build.
+func (c *Client) reportTimings(recorder *timing.Recorder, reportDir string) {
+	var table bytes.Buffer
+	if err := recorder.WriteTable(&table); err == nil {
+		c.logger.Info(style.Step("TIMINGS"))
+		c.logger.Info(strings.TrimSuffix(table.String(), "\n"))
+	}
+
+	if reportDir == "" {
+		return
+	}
+	if err := writeTimingReport(recorder, reportDir); err != nil {
+		c.logger.Warnf("Unable to write timing report: %s", err)
+	}
+}
+
+func writeTimingReport(recorder *timing.Recorder, reportDir string) error {
+	if err := os.MkdirAll(reportDir, 0750); err != nil {
+		return err
+	}
+
+	file, err := os.Create(filepath.Join(reportDir, timingReportFile))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return recorder.WriteJSON(file)
+}
+
+// recordImageExported records the digest of the built image. Failing to read the digest does not fail the build.
+func (c *Client) recordImageExported(ctx context.Context, recorder *events.Recorder, publish bool, imageRef name.Reference) {
+	img, err := c.imageFetcher.Fetch(ctx, imageRef.Name(), image.FetchOptions{Daemon: !publish, PullPolicy: image.PullNever})
+	if err != nil {
+		c.logger.Debugf("Unable to record the digest of the built image: %s", err)
+		return
+	}
+
+	id, err := img.Identifier()
+	if err != nil {
+		c.logger.Debugf("Unable to record the digest of the built image: %s", err)
+		return
+	}
+
+	recorder.ImageExported(imageRef.Name(), parseDigestFromImageID(id))
+}
+
 func parseDigestFromImageID(id imgutil.Identifier) string {
 	var digest string
 	switch v := id.(type) {
@@ -1305,6 +1765,39 @@ exit 0
 	return pathToInlineBuilpack, nil
 }
 
+// createLaunchEnvBuildpack creates an inline buildpack contributing the launch environment variables declared in
+// the project descriptor to the app image
+func createLaunchEnvBuildpack(env []projectTypes.LaunchEnvVar, stackID string) (string, error) {
+	script := []string{
+		"set -e",
+		`layer="${CNB_LAYERS_DIR:-$1}/launch-env"`,
+		`printf '[types]\nlaunch = true\n' > "$layer.toml"`,
+	}
+	for _, envVar := range env {
+		dir := `"$layer/env.launch"`
+		if envVar.Process != "" {
+			dir = fmt.Sprintf(`"$layer/env.launch/"%s`, shellQuote(envVar.Process))
+		}
+		script = append(script,
+			fmt.Sprintf("mkdir -p %s", dir),
+			fmt.Sprintf("printf '%%s' %s > %s/%s", shellQuote(envVar.Value), dir, shellQuote(envVar.Name+".override")),
+		)
+	}
+
+	return createInlineBuildpack(projectTypes.Buildpack{
+		ID:      "pack/project-launch-env",
+		Version: "0.0.0",
+		Script: projectTypes.Script{
+			API:    "0.8",
+			Inline: strings.Join(script, "\n"),
+		},
+	}, stackID)
+}
+
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
 // fullImagePath parses the inputImageReference provided by the user and creates the directory
 // structure if create value is true
 func fullImagePath(inputImageRef InputImageReference, create bool) (string, error) {
@@ -8,7 +8,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -23,21 +25,26 @@ import (
 	"github.com/buildpacks/lifecycle/api"
 	"github.com/buildpacks/lifecycle/platform/files"
 	dockerclient "github.com/docker/docker/client"
+	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
 	"github.com/heroku/color"
 	"github.com/onsi/gomega/ghttp"
 	"github.com/pkg/errors"
 	"github.com/sclevine/spec"
 	"github.com/sclevine/spec/report"
 
+	"github.com/buildpacks/pack/internal/attest"
 	"github.com/buildpacks/pack/internal/build"
 	"github.com/buildpacks/pack/internal/builder"
 	cfg "github.com/buildpacks/pack/internal/config"
 	ifakes "github.com/buildpacks/pack/internal/fakes"
 	rg "github.com/buildpacks/pack/internal/registry"
 	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/internal/timing"
 	"github.com/buildpacks/pack/pkg/blob"
 	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/cache"
 	"github.com/buildpacks/pack/pkg/dist"
 	"github.com/buildpacks/pack/pkg/image"
 	"github.com/buildpacks/pack/pkg/logging"
@@ -681,6 +688,136 @@ func testBuild(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("Cache option with an image build cache", func() {
+			when("not publishing", func() {
+				var server *httptest.Server
+
+				it.Before(func() {
+					server = httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+					subject.keychain = authn.NewMultiKeychain()
+				})
+
+				it.After(func() {
+					server.Close()
+				})
+
+				it("passes it through to lifecycle", func() {
+					cacheImage := fmt.Sprintf("%s/some/cache", strings.TrimPrefix(server.URL, "http://"))
+					h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+						Image:   "some/app",
+						Builder: defaultBuilderName,
+						Cache: cache.CacheOpts{
+							Build: cache.CacheInfo{Format: cache.CacheImage, Source: cacheImage},
+						},
+					}))
+					h.AssertEq(t, fakeLifecycle.Opts.Cache.Build.Source, cacheImage)
+					h.AssertEq(t, fakeLifecycle.Opts.Publish, false)
+				})
+
+				it("errors when the cache image cannot be pushed", func() {
+					server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+					})
+
+					cacheImage := fmt.Sprintf("%s/some/cache", strings.TrimPrefix(server.URL, "http://"))
+					err := subject.Build(context.TODO(), BuildOptions{
+						Image:   "some/app",
+						Builder: defaultBuilderName,
+						Cache: cache.CacheOpts{
+							Build: cache.CacheInfo{Format: cache.CacheImage, Source: cacheImage},
+						},
+					})
+					h.AssertError(t, err, fmt.Sprintf("cache image '%s:latest' cannot be pushed to registry", cacheImage))
+				})
+			})
+		})
+
+		when("signing or attesting the image", func() {
+			it("requires publishing", func() {
+				err := subject.Build(context.TODO(), BuildOptions{
+					Image:   "some/app",
+					Builder: defaultBuilderName,
+					Attest:  true,
+				})
+				h.AssertError(t, err, "signing or attesting the app image requires publishing it to a registry")
+			})
+
+			it("errors when the signing key cannot be read", func() {
+				err := subject.Build(context.TODO(), BuildOptions{
+					Image:      "some/app",
+					Builder:    defaultBuilderName,
+					Publish:    true,
+					SigningKey: filepath.Join(tmpDir, "missing-key.pem"),
+				})
+				h.AssertError(t, err, "reading signing key")
+			})
+		})
+
+		when("generating provenance", func() {
+			it("requires publishing or a report directory", func() {
+				err := subject.Build(context.TODO(), BuildOptions{
+					Image:      "some/app",
+					Builder:    defaultBuilderName,
+					Provenance: true,
+				})
+				h.AssertError(t, err, "generating provenance requires publishing the app image or a report output directory")
+			})
+
+			it("is not supported when exporting to OCI layout", func() {
+				inputImage := ParseInputImageReference(fmt.Sprintf("oci:%s", filepath.Join(tmpDir, "my-app")))
+				err := subject.Build(context.TODO(), BuildOptions{
+					Image:                inputImage.Name(),
+					Builder:              defaultBuilderName,
+					Provenance:           true,
+					ReportDestinationDir: tmpDir,
+					LayoutConfig:         &LayoutConfig{InputImage: inputImage, LayoutRepoDir: filepath.Join(tmpDir, "local-repo")},
+				})
+				h.AssertError(t, err, "generating provenance is not supported when exporting to OCI layout")
+			})
+		})
+
+		when("cache index", func() {
+			it.Before(func() {
+				subject.cacheIndex = cache.NewIndex(filepath.Join(tmpDir, "cache-index.json"))
+			})
+
+			it("records the caches used by the build", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:   "some/app",
+					Builder: defaultBuilderName,
+					Cache: cache.CacheOpts{
+						Build: cache.CacheInfo{Format: cache.CacheBind, Source: filepath.Join(tmpDir, "some-bind-cache")},
+					},
+				}))
+
+				entries, err := subject.cacheIndex.Entries()
+				h.AssertNil(t, err)
+				h.AssertEq(t, len(entries), 2)
+				h.AssertEq(t, entries[0].Name, filepath.Join(tmpDir, "some-bind-cache"))
+				h.AssertEq(t, entries[0].Type, "build")
+				h.AssertEq(t, entries[0].AppImage, "index.docker.io/some/app:latest")
+				h.AssertEq(t, entries[1].Format, cache.CacheVolume)
+				h.AssertEq(t, entries[1].Type, "launch")
+			})
+
+			it("does not record a launch cache when publishing", func() {
+				fakeImageFetcher.RemoteImages[fakeDefaultRunImage.Name()] = fakeDefaultRunImage
+
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:      "example.com/some/app",
+					Builder:    defaultBuilderName,
+					Publish:    true,
+					CacheImage: "example.com/some/cache",
+				}))
+
+				entries, err := subject.cacheIndex.Entries()
+				h.AssertNil(t, err)
+				h.AssertEq(t, len(entries), 1)
+				h.AssertEq(t, entries[0].Name, "example.com/some/cache")
+				h.AssertEq(t, entries[0].Format, cache.CacheImage)
+			})
+		})
+
 		when("Buildpacks option", func() {
 			assertOrderEquals := func(content string) {
 				t.Helper()
@@ -1611,6 +1748,46 @@ func testBuild(t *testing.T, when spec.G, it spec.S) {
 					})
 				})
 
+				when("project descriptor declares launch env vars", func() {
+					var launchEnv = []projectTypes.LaunchEnvVar{
+						{Name: "JAVA_OPTS", Value: "-Xmx300m"},
+						{Name: "PORT", Value: "8080", Process: "web"},
+					}
+
+					it("appends a buildpack contributing the launch env to each group of the builder order", func() {
+						err := subject.Build(context.TODO(), BuildOptions{
+							Image:   "some/app",
+							Builder: defaultBuilderName,
+							ProjectDescriptor: projectTypes.Descriptor{
+								Launch: projectTypes.Launch{Env: launchEnv},
+							},
+						})
+
+						h.AssertNil(t, err)
+						bldr, err := builder.FromImage(defaultBuilderImage)
+						h.AssertNil(t, err)
+						launchEnvBP := dist.ModuleRef{ModuleInfo: dist.ModuleInfo{ID: "pack/project-launch-env", Version: "0.0.0"}}
+						h.AssertNotEq(t, len(bldr.Order()), 0)
+						for _, entry := range bldr.Order() {
+							h.AssertEq(t, entry.Group[len(entry.Group)-1], launchEnvBP)
+						}
+						buildpacks := bldr.Buildpacks()
+						h.AssertEq(t, buildpacks[len(buildpacks)-1], launchEnvBP.ModuleInfo)
+					})
+
+					it("fails for windows builders", func() {
+						err := subject.Build(context.TODO(), BuildOptions{
+							Image:   "some/app",
+							Builder: defaultWindowsBuilderName,
+							ProjectDescriptor: projectTypes.Descriptor{
+								Launch: projectTypes.Launch{Env: launchEnv},
+							},
+						})
+
+						h.AssertError(t, err, "launch environment variables from the project descriptor are not supported on Windows builders")
+					})
+				})
+
 				when("buildpack is from a registry", func() {
 					var (
 						fakePackage     *fakes.Image
@@ -1892,6 +2069,48 @@ func testBuild(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("Targets option", func() {
+			when("a single target is provided", func() {
+				it("fetches the builder and run image for that platform", func() {
+					h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+						Image:   "some/app",
+						Builder: defaultBuilderName,
+						Targets: []dist.Target{{OS: "linux", Arch: "amd64"}},
+					}))
+
+					h.AssertEq(t, fakeImageFetcher.FetchCalls[defaultBuilderName].Platform, "linux/amd64")
+					h.AssertEq(t, fakeImageFetcher.FetchCalls["default/run"].Platform, "linux/amd64")
+				})
+
+				it("fails when the builder does not match the platform", func() {
+					h.AssertError(t, subject.Build(context.TODO(), BuildOptions{
+						Image:   "some/app",
+						Builder: defaultBuilderName,
+						Targets: []dist.Target{{OS: "linux", Arch: "arm64"}},
+					}), "image platform 'linux/amd64' does not match requested platform 'linux/arm64'")
+				})
+
+				it("fails when the run image does not match the platform variant", func() {
+					h.AssertNil(t, defaultBuilderImage.SetVariant("v8"))
+					h.AssertError(t, subject.Build(context.TODO(), BuildOptions{
+						Image:   "some/app",
+						Builder: defaultBuilderName,
+						Targets: []dist.Target{{OS: "linux", Arch: "amd64", ArchVariant: "v8"}},
+					}), "invalid run-image 'default/run'")
+				})
+			})
+
+			when("multiple targets are provided", func() {
+				it("requires publishing", func() {
+					h.AssertError(t, subject.Build(context.TODO(), BuildOptions{
+						Image:   "some/app",
+						Builder: defaultBuilderName,
+						Targets: []dist.Target{{OS: "linux", Arch: "amd64"}, {OS: "linux", Arch: "arm64"}},
+					}), "building for multiple platforms requires publishing to a registry")
+				})
+			})
+		})
+
 		when("Publish option", func() {
 			var remoteRunImage, builderWithoutLifecycleImageOrCreator *fakes.Image
 
@@ -2143,6 +2362,97 @@ func testBuild(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("offline", func() {
+			it.Before(func() {
+				fakeImageFetcher.Offline = true
+				blobDownloader := blob.NewDownloader(logger, t.TempDir(), blob.WithOffline(true))
+				subject.offline = true
+				subject.downloader = blobDownloader
+				subject.buildpackDownloader = buildpack.NewDownloader(logger, fakeImageFetcher, blobDownloader, &registryResolver{logger: logger, offline: true})
+			})
+
+			it("builds with the images on the daemon", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:      "some/app",
+					Builder:    defaultBuilderName,
+					PullPolicy: image.PullAlways,
+				}))
+				h.AssertEq(t, fakeLifecycle.Opts.RunImage, "default/run")
+			})
+
+			it("lists every artifact which is not available locally", func() {
+				delete(fakeImageFetcher.LocalImages, "default/run")
+				delete(fakeImageFetcher.LocalImages, fakeLifecycleImage.Name())
+
+				err := subject.Build(context.TODO(), BuildOptions{
+					Image:      "some/app",
+					Builder:    defaultBuilderName,
+					PullPolicy: image.PullAlways,
+					Buildpacks: []string{
+						"https://example.com/some-buildpack.tgz",
+						"docker://example.com/some/buildpack",
+					},
+				})
+				h.AssertError(t, err, "running offline, the following artifacts are not available locally:")
+				h.AssertContains(t, err.Error(), "\n  - image 'default/run'")
+				h.AssertContains(t, err.Error(), "\n  - download 'https://example.com/some-buildpack.tgz'")
+				h.AssertContains(t, err.Error(), "\n  - image 'example.com/some/buildpack'")
+				h.AssertContains(t, err.Error(), fmt.Sprintf("\n  - image '%s'", fakeLifecycleImage.Name()))
+				h.AssertNil(t, fakeLifecycle.Opts.Builder)
+			})
+		})
+
+		when("VendorDir option", func() {
+			var vendorDir string
+
+			it.Before(func() {
+				vendorDir = t.TempDir()
+				h.AssertNil(t, writeVendoredBuild(vendorDir, &VendoredBuild{
+					Builder:  defaultBuilderName,
+					RunImage: "registry1.example.com/run/mirror",
+					Images:   []string{defaultBuilderName, "registry1.example.com/run/mirror"},
+				}))
+			})
+
+			it("builds with the builder and run image of the vendor directory", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:      "some/app",
+					VendorDir:  vendorDir,
+					PullPolicy: image.PullAlways,
+				}))
+				h.AssertEq(t, fakeLifecycle.Opts.Builder.Name(), defaultBuilderImage.Name())
+				h.AssertEq(t, fakeLifecycle.Opts.RunImage, "registry1.example.com/run/mirror")
+				h.AssertEq(t, fakeImageFetcher.FetchCalls[defaultBuilderName].PullPolicy, image.PullNever)
+			})
+
+			it("reports the images which are neither on the daemon nor in the vendor directory", func() {
+				delete(fakeImageFetcher.LocalImages, "registry1.example.com/run/mirror")
+
+				err := subject.Build(context.TODO(), BuildOptions{
+					Image:     "some/app",
+					VendorDir: vendorDir,
+				})
+				h.AssertError(t, err, "running offline, image 'registry1.example.com/run/mirror' is not available locally")
+			})
+
+			it("requires exporting to the daemon", func() {
+				err := subject.Build(context.TODO(), BuildOptions{
+					Image:     "some/app",
+					VendorDir: vendorDir,
+					Publish:   true,
+				})
+				h.AssertError(t, err, "building from a vendor directory requires exporting the app image to the daemon")
+			})
+
+			it("fails when the directory was not prepared", func() {
+				err := subject.Build(context.TODO(), BuildOptions{
+					Image:     "some/app",
+					VendorDir: t.TempDir(),
+				})
+				h.AssertError(t, err, "is not a vendor directory")
+			})
+		})
+
 		when("ProxyConfig option", func() {
 			when("ProxyConfig is nil", func() {
 				it.Before(func() {
@@ -2813,6 +3123,110 @@ func testBuild(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("events option", func() {
+			var builtImage *fakes.Image
+
+			it.Before(func() {
+				builtImage = fakes.NewImage("example.com/some/repo:tag", "", local.IDIdentifier{ImageID: "sha256:some-image-id"})
+				fakeImageFetcher.LocalImages[builtImage.Name()] = builtImage
+			})
+
+			it.After(func() {
+				h.AssertNilE(t, builtImage.Cleanup())
+			})
+
+			it("records events and the exported image", func() {
+				var eventsBuf bytes.Buffer
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Builder: defaultBuilderName,
+					Image:   "example.com/some/repo:tag",
+					Events:  &eventsBuf,
+				}))
+				h.AssertNotNil(t, fakeLifecycle.Opts.Events)
+				h.AssertContains(t, eventsBuf.String(), `"type":"image_exported","image":"example.com/some/repo:tag","digest":"sha256:some-image-id"`)
+			})
+
+			it("does not record events by default", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Builder: defaultBuilderName,
+					Image:   "example.com/some/repo:tag",
+				}))
+				h.AssertNil(t, fakeLifecycle.Opts.Events)
+			})
+		})
+
+		when("provenance option", func() {
+			it.Before(func() {
+				fakeLifecycle.Group = "[[group]]\nid = \"buildpack.1.id\"\nversion = \"buildpack.1.version\"\n"
+			})
+
+			it("writes the provenance statement to the report destination dir", func() {
+				reportDir := filepath.Join(tmpDir, "report")
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Builder:              defaultBuilderName,
+					Image:                "example.com/some/repo:tag",
+					ReportDestinationDir: reportDir,
+					Provenance:           true,
+				}))
+
+				contents, err := os.ReadFile(filepath.Join(reportDir, "provenance.json"))
+				h.AssertNil(t, err)
+
+				var statement attest.Statement
+				h.AssertNil(t, json.Unmarshal(contents, &statement))
+				h.AssertEq(t, statement.Subject[0].Name, "example.com/some/repo")
+				// the image was exported to the daemon, where its ID is not the digest of a manifest
+				h.AssertEq(t, len(statement.Subject[0].Digest), 0)
+
+				parameters := statement.Predicate.BuildDefinition.ExternalParameters
+				h.AssertEq(t, parameters["builder"], defaultBuilderName)
+				h.AssertEq(t, parameters["runImage"], "default/run")
+				h.AssertEq(t, parameters["buildpacks"], []interface{}{"buildpack.1.id@buildpack.1.version"})
+				h.AssertEq(t, statement.Predicate.RunDetails.Builder.Version["lifecycle"], builder.DefaultLifecycleVersion)
+			})
+		})
+
+		when("timings option", func() {
+			it("logs how long each step took", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Builder: defaultBuilderName,
+					Image:   "example.com/some/repo:tag",
+					Timings: true,
+				}))
+				h.AssertNotNil(t, fakeLifecycle.Opts.Timings)
+				h.AssertContains(t, outBuf.String(), "TIMINGS")
+				h.AssertContains(t, outBuf.String(), "fetch builder image")
+				h.AssertContains(t, outBuf.String(), "fetch run image")
+				h.AssertContains(t, outBuf.String(), "extend builder")
+			})
+
+			it("writes the timing report to the report destination dir", func() {
+				reportDir := filepath.Join(tmpDir, "report")
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Builder:              defaultBuilderName,
+					Image:                "example.com/some/repo:tag",
+					ReportDestinationDir: reportDir,
+					Timings:              true,
+				}))
+
+				contents, err := os.ReadFile(filepath.Join(reportDir, "timings.json"))
+				h.AssertNil(t, err)
+
+				var report timing.Report
+				h.AssertNil(t, json.Unmarshal(contents, &report))
+				h.AssertEq(t, report.Steps[0].Name, "fetch builder image")
+			})
+
+			it("does not measure timings by default", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Builder: defaultBuilderName,
+					Image:   "example.com/some/repo:tag",
+				}))
+				h.AssertNil(t, fakeLifecycle.Opts.Timings)
+				h.AssertNotContains(t, outBuf.String(), "TIMINGS")
+			})
+		})
+
 		when("there are extensions", func() {
 			withExtensionsLabel = true
 
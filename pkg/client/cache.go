@@ -0,0 +1,344 @@
+package client
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1remote "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/cache"
+)
+
+// BuildCache describes a cache used by builds.
+type BuildCache struct {
+	// Name of the volume or image, or path of the bind cache.
+	Name string
+
+	Format cache.Format
+
+	// Type is the kind of data held by the cache: build, launch or kaniko.
+	Type string
+
+	// AppImage is the app image built with the cache. It is empty when unknown.
+	AppImage string
+
+	// Size of the cache in bytes, or -1 when unknown.
+	Size int64
+
+	// LastUsed is the last time a build used the cache. For volumes that were not used since pack started keeping
+	// track of caches, it is the creation time of the volume.
+	LastUsed time.Time
+}
+
+// PruneCachesOptions is a configuration struct that controls the behavior of the PruneCaches function.
+// Image caches are never pruned, they must be removed with RemoveCache.
+type PruneCachesOptions struct {
+	// When set, caches last used longer ago than OlderThan are removed.
+	OlderThan time.Duration
+
+	// When set, the least recently used caches are removed until the total size of the remaining caches is at most
+	// MaxSize bytes.
+	MaxSize int64
+
+	// Report the caches that would be removed without removing them.
+	DryRun bool
+
+	// Also prune bind caches. Their directories belong to the user, so only the contents of the directories are removed.
+	IncludeBind bool
+}
+
+// ListCaches returns the volume caches created by pack, along with the bind and image caches used by builds,
+// sorted by name.
+func (c *Client) ListCaches(ctx context.Context) ([]BuildCache, error) {
+	entries, err := c.cacheIndex.Entries()
+	if err != nil {
+		return nil, err
+	}
+
+	indexed := map[string]cache.IndexEntry{}
+	for _, entry := range entries {
+		if entry.Format == cache.CacheVolume {
+			indexed[entry.Name] = entry
+		}
+	}
+
+	diskUsage, err := c.docker.DiskUsage(ctx, types.DiskUsageOptions{Types: []types.DiskUsageObject{types.VolumeObject}})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing volumes")
+	}
+
+	var caches []BuildCache
+	for _, volume := range diskUsage.Volumes {
+		entry, ok := indexed[volume.Name]
+		if !ok && !strings.HasPrefix(volume.Name, cache.VolumePrefix) {
+			continue
+		}
+		delete(indexed, volume.Name)
+
+		buildCache := BuildCache{
+			Name:     volume.Name,
+			Format:   cache.CacheVolume,
+			Type:     entry.Type,
+			AppImage: entry.AppImage,
+			Size:     -1,
+			LastUsed: entry.LastUsed,
+		}
+		if !ok {
+			buildCache.Type = volumeCacheType(volume.Name)
+			buildCache.LastUsed, _ = time.Parse(time.RFC3339, volume.CreatedAt)
+		}
+		if volume.UsageData != nil && volume.UsageData.Size >= 0 {
+			buildCache.Size = volume.UsageData.Size
+		}
+		caches = append(caches, buildCache)
+	}
+
+	// volumes that no longer exist were removed outside of pack
+	for name := range indexed {
+		if err := c.cacheIndex.Remove(cache.CacheVolume, name); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, entry := range entries {
+		switch entry.Format {
+		case cache.CacheBind:
+			size, err := dirSize(entry.Name)
+			if os.IsNotExist(err) {
+				if err := c.cacheIndex.Remove(cache.CacheBind, entry.Name); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			if err != nil {
+				return nil, errors.Wrapf(err, "reading bind cache %s", style.Symbol(entry.Name))
+			}
+			caches = append(caches, newBuildCache(entry, size))
+		case cache.CacheImage:
+			caches = append(caches, newBuildCache(entry, -1))
+		}
+	}
+
+	sort.Slice(caches, func(i, j int) bool {
+		return caches[i].Name < caches[j].Name
+	})
+	return caches, nil
+}
+
+// InspectCache returns the cache with the given name.
+func (c *Client) InspectCache(ctx context.Context, name string) (BuildCache, error) {
+	caches, err := c.ListCaches(ctx)
+	if err != nil {
+		return BuildCache{}, err
+	}
+
+	for _, buildCache := range caches {
+		if buildCache.Name == name {
+			return buildCache, nil
+		}
+	}
+	return BuildCache{}, errors.Errorf("cache %s not found", style.Symbol(name))
+}
+
+// RemoveCache removes the cache with the given name. Image caches are deleted from their registry, and only the
+// contents of the directory of a bind cache are removed.
+func (c *Client) RemoveCache(ctx context.Context, name string) error {
+	buildCache, err := c.InspectCache(ctx, name)
+	if err != nil {
+		return err
+	}
+	return c.removeCache(ctx, buildCache)
+}
+
+// PruneCaches removes the volume caches, and the bind caches when opts.IncludeBind is set, selected by opts, and
+// returns the caches that were removed.
+func (c *Client) PruneCaches(ctx context.Context, opts PruneCachesOptions) ([]BuildCache, error) {
+	caches, err := c.ListCaches(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []BuildCache
+	for _, buildCache := range caches {
+		if buildCache.Format == cache.CacheVolume || (buildCache.Format == cache.CacheBind && opts.IncludeBind) {
+			candidates = append(candidates, buildCache)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].LastUsed.Before(candidates[j].LastUsed)
+	})
+
+	var (
+		pruned    []BuildCache
+		remaining []BuildCache
+		totalSize int64
+	)
+	cutoff := time.Now().Add(-opts.OlderThan)
+	for _, buildCache := range candidates {
+		if opts.OlderThan > 0 && buildCache.LastUsed.Before(cutoff) {
+			pruned = append(pruned, buildCache)
+			continue
+		}
+		remaining = append(remaining, buildCache)
+		if buildCache.Size > 0 {
+			totalSize += buildCache.Size
+		}
+	}
+
+	if opts.MaxSize > 0 {
+		for _, buildCache := range remaining {
+			if totalSize <= opts.MaxSize {
+				break
+			}
+			if buildCache.Size <= 0 {
+				continue
+			}
+			pruned = append(pruned, buildCache)
+			totalSize -= buildCache.Size
+		}
+	}
+
+	if opts.DryRun {
+		return pruned, nil
+	}
+
+	for _, buildCache := range pruned {
+		if err := c.removeCache(ctx, buildCache); err != nil {
+			return nil, err
+		}
+	}
+	return pruned, nil
+}
+
+func (c *Client) removeCache(ctx context.Context, buildCache BuildCache) error {
+	switch buildCache.Format {
+	case cache.CacheVolume:
+		if err := cache.NewVolumeCache(nil, cache.CacheInfo{Source: buildCache.Name}, "", c.docker).Clear(ctx); err != nil {
+			return errors.Wrapf(err, "removing volume %s", style.Symbol(buildCache.Name))
+		}
+	case cache.CacheBind:
+		if err := clearDir(buildCache.Name); err != nil {
+			return errors.Wrapf(err, "removing bind cache %s", style.Symbol(buildCache.Name))
+		}
+	case cache.CacheImage:
+		if err := c.deleteCacheImage(ctx, buildCache.Name); err != nil {
+			return errors.Wrapf(err, "deleting cache image %s", style.Symbol(buildCache.Name))
+		}
+	}
+
+	return c.cacheIndex.Remove(buildCache.Format, buildCache.Name)
+}
+
+// clearDir removes the contents of dir, keeping dir itself
+func clearDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	for _, entry := range entries {
+		if err := os.RemoveAll(filepath.Join(dir, entry.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteCacheImage deletes the manifest of a cache image, which registries only allow by digest
+func (c *Client) deleteCacheImage(ctx context.Context, imageName string) error {
+	ref, err := name.ParseReference(imageName, name.WeakValidation)
+	if err != nil {
+		return err
+	}
+
+	options := []v1remote.Option{v1remote.WithAuthFromKeychain(c.keychain), v1remote.WithContext(ctx)}
+	desc, err := v1remote.Head(ref, options...)
+	if err != nil {
+		return err
+	}
+	return v1remote.Delete(ref.Context().Digest(desc.Digest.String()), options...)
+}
+
+// recordCaches keeps track of the caches used by a build, so that they can be listed and pruned
+func (c *Client) recordCaches(imageRef name.Reference, opts BuildOptions) {
+	now := time.Now().UTC()
+	appImage := imageRef.Name()
+
+	var entries []cache.IndexEntry
+	switch {
+	case opts.CacheImage != "":
+		entries = append(entries, cache.IndexEntry{Name: opts.CacheImage, Format: cache.CacheImage})
+	case opts.Cache.Build.Format == cache.CacheVolume:
+		entries = append(entries, cache.IndexEntry{Name: cache.NewVolumeCache(imageRef, opts.Cache.Build, "build", c.docker).Name(), Format: cache.CacheVolume})
+	default:
+		entries = append(entries, cache.IndexEntry{Name: opts.Cache.Build.Source, Format: opts.Cache.Build.Format})
+	}
+	entries[0].Type = "build"
+
+	if !opts.Publish && !opts.Layout() {
+		entries = append(entries, cache.IndexEntry{
+			Name:   cache.NewVolumeCache(imageRef, opts.Cache.Launch, "launch", c.docker).Name(),
+			Format: cache.CacheVolume,
+			Type:   "launch",
+		})
+	}
+
+	for i := range entries {
+		entries[i].AppImage = appImage
+		entries[i].LastUsed = now
+	}
+	if err := c.cacheIndex.Record(entries...); err != nil {
+		c.logger.Debugf("Unable to record the caches used by the build: %s", err)
+	}
+}
+
+func newBuildCache(entry cache.IndexEntry, size int64) BuildCache {
+	return BuildCache{
+		Name:     entry.Name,
+		Format:   entry.Format,
+		Type:     entry.Type,
+		AppImage: entry.AppImage,
+		Size:     size,
+		LastUsed: entry.LastUsed,
+	}
+}
+
+// volumeCacheType returns the type of a volume cache from the suffix of its generated name
+func volumeCacheType(volumeName string) string {
+	for _, cacheType := range []string{"build", "launch", "kaniko"} {
+		if strings.HasSuffix(volumeName, "."+cacheType) {
+			return cacheType
+		}
+	}
+	return ""
+}
+
+func dirSize(path string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(path, func(_ string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.Type().IsRegular() {
+			info, err := entry.Info()
+			if err != nil {
+				return err
+			}
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
@@ -0,0 +1,246 @@
+package client
+
+import (
+	"archive/tar"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	containertypes "github.com/docker/docker/api/types/container"
+	dockerClient "github.com/docker/docker/client"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/builder"
+	internalConfig "github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/paths"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/archive"
+	"github.com/buildpacks/pack/pkg/cache"
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+// cacheMountPath is where volume caches are mounted in the helper container used to copy their contents
+const cacheMountPath = "/cache"
+
+// ExportCacheOptions is a configuration struct that controls the behavior of the ExportCache function.
+type ExportCacheOptions struct {
+	// Name of the volume or path of the bind cache to export.
+	Name string
+
+	// Path of the archive to write.
+	Path string
+
+	// Image used to create the container which gives access to volume caches.
+	// Defaults to the default lifecycle image.
+	HelperImage string
+
+	// Strategy for pulling the helper image.
+	PullPolicy image.PullPolicy
+}
+
+// ImportCacheOptions is a configuration struct that controls the behavior of the ImportCache function.
+type ImportCacheOptions struct {
+	// Path of the archive written by ExportCache.
+	Path string
+
+	// Name of the volume to restore the cache to. Defaults to the volume used by builds of the app image
+	// recorded in the archive, so that these builds start from the restored cache.
+	Volume string
+
+	// Path of a directory to restore the cache to, instead of a volume.
+	Bind string
+
+	// Image used to create the container which gives access to volume caches.
+	// Defaults to the default lifecycle image.
+	HelperImage string
+
+	// Strategy for pulling the helper image.
+	PullPolicy image.PullPolicy
+}
+
+// ExportCache writes the contents of a volume or bind cache to an OCI layout archive, which can be restored on
+// another machine with ImportCache.
+func (c *Client) ExportCache(ctx context.Context, opts ExportCacheOptions) error {
+	buildCache, err := c.InspectCache(ctx, opts.Name)
+	if err != nil {
+		return err
+	}
+
+	metadata := cache.ArchiveMetadata{Type: buildCache.Type, AppImage: buildCache.AppImage}
+	switch buildCache.Format {
+	case cache.CacheBind:
+		contents := cache.DirContents(buildCache.Name)
+		defer contents.Close()
+		return cache.WriteArchive(opts.Path, contents, metadata)
+	case cache.CacheVolume:
+		ctrID, err := c.createCacheHelperContainer(ctx, buildCache.Name, opts.HelperImage, opts.PullPolicy)
+		if err != nil {
+			return err
+		}
+		defer c.docker.ContainerRemove(context.Background(), ctrID, types.ContainerRemoveOptions{Force: true})
+
+		reader, _, err := c.docker.CopyFromContainer(ctx, ctrID, cacheMountPath)
+		if err != nil {
+			return errors.Wrapf(err, "reading volume %s", style.Symbol(buildCache.Name))
+		}
+		defer reader.Close()
+
+		contents := renameTarEntries(reader, func(entryName string) (string, bool) {
+			relPath := strings.TrimPrefix(paths.CanonicalTarPath(entryName), path.Base(cacheMountPath))
+			relPath = strings.TrimPrefix(relPath, "/")
+			return relPath, relPath != ""
+		})
+		defer contents.Close()
+		return cache.WriteArchive(opts.Path, contents, metadata)
+	default:
+		return errors.Errorf("cache %s is an image cache, which is already stored in a registry and cannot be exported", style.Symbol(buildCache.Name))
+	}
+}
+
+// ImportCache restores a cache from an archive written by ExportCache, and returns the restored cache.
+func (c *Client) ImportCache(ctx context.Context, opts ImportCacheOptions) (BuildCache, error) {
+	metadata, contents, err := cache.OpenArchive(opts.Path)
+	if err != nil {
+		return BuildCache{}, err
+	}
+	defer contents.Close()
+
+	entry := cache.IndexEntry{Type: metadata.Type, AppImage: metadata.AppImage, LastUsed: time.Now().UTC()}
+	if opts.Bind != "" {
+		entry.Name, entry.Format = opts.Bind, cache.CacheBind
+		if err := c.importBindCache(contents, opts.Bind); err != nil {
+			return BuildCache{}, err
+		}
+	} else {
+		entry.Name, entry.Format = opts.Volume, cache.CacheVolume
+		if entry.Name == "" {
+			if entry.Name, err = defaultCacheVolume(metadata); err != nil {
+				return BuildCache{}, err
+			}
+		}
+		if err := c.importVolumeCache(ctx, contents, entry.Name, opts.HelperImage, opts.PullPolicy); err != nil {
+			return BuildCache{}, err
+		}
+	}
+
+	if err := c.cacheIndex.Record(entry); err != nil {
+		return BuildCache{}, err
+	}
+	return newBuildCache(entry, -1), nil
+}
+
+func (c *Client) importBindCache(contents io.Reader, dir string) error {
+	files, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if len(files) > 0 {
+		return errors.Errorf("directory %s is not empty", style.Symbol(dir))
+	}
+
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return err
+	}
+	return cache.ExtractContents(contents, dir)
+}
+
+func (c *Client) importVolumeCache(ctx context.Context, contents io.Reader, volumeName, helperImage string, pullPolicy image.PullPolicy) error {
+	_, err := c.docker.VolumeInspect(ctx, volumeName)
+	if err == nil {
+		return errors.Errorf("volume %s already exists, remove it with 'pack cache rm %s' first", style.Symbol(volumeName), volumeName)
+	}
+	if !dockerClient.IsErrNotFound(err) {
+		return errors.Wrapf(err, "inspecting volume %s", style.Symbol(volumeName))
+	}
+
+	ctrID, err := c.createCacheHelperContainer(ctx, volumeName, helperImage, pullPolicy)
+	if err != nil {
+		return err
+	}
+	defer c.docker.ContainerRemove(context.Background(), ctrID, types.ContainerRemoveOptions{Force: true})
+
+	prefixed := renameTarEntries(contents, func(entryName string) (string, bool) {
+		return path.Join(path.Base(cacheMountPath), paths.CanonicalTarPath(entryName)), true
+	})
+	defer prefixed.Close()
+
+	if err := c.docker.CopyToContainer(ctx, ctrID, "/", prefixed, types.CopyToContainerOptions{}); err != nil {
+		return errors.Wrapf(err, "writing volume %s", style.Symbol(volumeName))
+	}
+	return nil
+}
+
+// createCacheHelperContainer creates a container with the volume mounted at cacheMountPath. The container is never
+// started, it only gives access to the contents of the volume.
+func (c *Client) createCacheHelperContainer(ctx context.Context, volumeName, helperImage string, pullPolicy image.PullPolicy) (string, error) {
+	if helperImage == "" {
+		helperImage = fmt.Sprintf("%s:%s", internalConfig.DefaultLifecycleImageRepo, builder.DefaultLifecycleVersion)
+	}
+	img, err := c.imageFetcher.Fetch(ctx, helperImage, image.FetchOptions{Daemon: true, PullPolicy: pullPolicy})
+	if err != nil {
+		return "", errors.Wrap(err, "fetching helper image")
+	}
+
+	ctr, err := c.docker.ContainerCreate(ctx,
+		&containertypes.Config{
+			Image: img.Name(),
+			Cmd:   []string{"/cnb/lifecycle/lifecycle"},
+		},
+		&containertypes.HostConfig{
+			Binds: []string{fmt.Sprintf("%s:%s", volumeName, cacheMountPath)},
+		},
+		nil, nil, "",
+	)
+	if err != nil {
+		return "", errors.Wrap(err, "creating helper container")
+	}
+	return ctr.ID, nil
+}
+
+// defaultCacheVolume returns the name of the volume used by builds of the app image of a cache archive
+func defaultCacheVolume(metadata cache.ArchiveMetadata) (string, error) {
+	if metadata.AppImage == "" {
+		return "", errors.New("the cache archive does not record an app image, a volume name must be provided")
+	}
+
+	imageRef, err := name.ParseReference(metadata.AppImage, name.WeakValidation)
+	if err != nil {
+		return "", errors.Wrapf(err, "parsing app image of the cache archive")
+	}
+	return cache.NewVolumeCache(imageRef, cache.CacheInfo{}, metadata.Type, nil).Name(), nil
+}
+
+// renameTarEntries returns a tar stream with the entries of reader renamed by rename. Entries for which rename
+// returns false are dropped.
+func renameTarEntries(reader io.Reader, rename func(entryName string) (string, bool)) io.ReadCloser {
+	return archive.GenerateTar(func(tw archive.TarWriter) error {
+		tr := tar.NewReader(reader)
+		for {
+			header, err := tr.Next()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			entryName, ok := rename(header.Name)
+			if !ok {
+				continue
+			}
+			header.Name = entryName
+			if err := tw.WriteHeader(header); err != nil {
+				return err
+			}
+			if _, err := io.Copy(tw, tr); err != nil {
+				return err
+			}
+		}
+	})
+}
@@ -0,0 +1,231 @@
+package client
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/docker/docker/api/types"
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/errdefs"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/archive"
+	"github.com/buildpacks/pack/pkg/cache"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestCacheArchive(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "CacheArchive", testCacheArchive, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testCacheArchive(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject          *Client
+		mockDockerClient *testmocks.MockCommonAPIClient
+		mockImageFetcher *testmocks.MockImageFetcher
+		mockController   *gomock.Controller
+		index            *cache.Index
+		tmpDir           string
+		archivePath      string
+		out              bytes.Buffer
+	)
+
+	const helperImage = "buildpacksio/lifecycle:some-version"
+
+	expectHelperContainer := func(volumeName string) {
+		mockImageFetcher.EXPECT().
+			Fetch(gomock.Any(), helperImage, image.FetchOptions{Daemon: true, PullPolicy: image.PullIfNotPresent}).
+			Return(fakes.NewImage(helperImage, "", nil), nil)
+		mockDockerClient.EXPECT().
+			ContainerCreate(gomock.Any(), gomock.Any(), &containertypes.HostConfig{Binds: []string{volumeName + ":/cache"}}, nil, nil, "").
+			Return(containertypes.CreateResponse{ID: "some-container-id"}, nil)
+		mockDockerClient.EXPECT().
+			ContainerRemove(gomock.Any(), "some-container-id", types.ContainerRemoveOptions{Force: true}).
+			Return(nil)
+	}
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockDockerClient = testmocks.NewMockCommonAPIClient(mockController)
+		mockImageFetcher = testmocks.NewMockImageFetcher(mockController)
+
+		var err error
+		tmpDir, err = os.MkdirTemp("", "pack.cache.archive.test.")
+		h.AssertNil(t, err)
+		archivePath = filepath.Join(tmpDir, "cache.tar")
+
+		indexPath := filepath.Join(tmpDir, "cache-index.json")
+		index = cache.NewIndex(indexPath)
+
+		subject, err = NewClient(
+			WithLogger(logging.NewLogWithWriters(&out, &out)),
+			WithDockerClient(mockDockerClient),
+			WithFetcher(mockImageFetcher),
+			WithCacheIndex(indexPath),
+		)
+		h.AssertNil(t, err)
+
+		mockDockerClient.EXPECT().DiskUsage(gomock.Any(), gomock.Any()).Return(types.DiskUsage{Volumes: []*volume.Volume{
+			{Name: "pack-cache-some_app_latest-123.build", UsageData: &volume.UsageData{Size: 1024}},
+		}}, nil).AnyTimes()
+		h.AssertNil(t, index.Record(cache.IndexEntry{
+			Name:     "pack-cache-some_app_latest-123.build",
+			Format:   cache.CacheVolume,
+			Type:     "build",
+			AppImage: "index.docker.io/some/app:latest",
+			LastUsed: time.Now(),
+		}))
+	})
+
+	it.After(func() {
+		mockController.Finish()
+		h.AssertNil(t, os.RemoveAll(tmpDir))
+	})
+
+	when("#ExportCache", func() {
+		it("exports a volume cache", func() {
+			expectHelperContainer("pack-cache-some_app_latest-123.build")
+			volumeContents := archive.GenerateTar(func(tw archive.TarWriter) error {
+				if err := tw.WriteHeader(&tar.Header{Name: "cache", Typeflag: tar.TypeDir, Mode: 0755}); err != nil {
+					return err
+				}
+				return writeTarFile(tw, "cache/some-layer/some-file", "some-content")
+			})
+			mockDockerClient.EXPECT().CopyFromContainer(gomock.Any(), "some-container-id", "/cache").Return(volumeContents, types.ContainerPathStat{}, nil)
+
+			h.AssertNil(t, subject.ExportCache(context.TODO(), ExportCacheOptions{
+				Name:        "pack-cache-some_app_latest-123.build",
+				Path:        archivePath,
+				HelperImage: helperImage,
+				PullPolicy:  image.PullIfNotPresent,
+			}))
+
+			metadata, contents, err := cache.OpenArchive(archivePath)
+			h.AssertNil(t, err)
+			defer contents.Close()
+			h.AssertEq(t, metadata, cache.ArchiveMetadata{Type: "build", AppImage: "index.docker.io/some/app:latest"})
+			_, fileContents, err := archive.ReadTarEntry(contents, "some-layer/some-file")
+			h.AssertNil(t, err)
+			h.AssertEq(t, string(fileContents), "some-content")
+		})
+
+		it("exports a bind cache", func() {
+			bindDir := filepath.Join(tmpDir, "some-bind-cache")
+			h.AssertNil(t, os.MkdirAll(bindDir, 0750))
+			h.AssertNil(t, os.WriteFile(filepath.Join(bindDir, "some-file"), []byte("some-content"), 0600))
+			h.AssertNil(t, index.Record(cache.IndexEntry{Name: bindDir, Format: cache.CacheBind, Type: "build", AppImage: "index.docker.io/bind/app:latest"}))
+
+			h.AssertNil(t, subject.ExportCache(context.TODO(), ExportCacheOptions{Name: bindDir, Path: archivePath}))
+
+			metadata, contents, err := cache.OpenArchive(archivePath)
+			h.AssertNil(t, err)
+			defer contents.Close()
+			h.AssertEq(t, metadata.AppImage, "index.docker.io/bind/app:latest")
+			_, fileContents, err := archive.ReadTarEntry(contents, "some-file")
+			h.AssertNil(t, err)
+			h.AssertEq(t, string(fileContents), "some-content")
+		})
+
+		it("errors for an image cache", func() {
+			h.AssertNil(t, index.Record(cache.IndexEntry{Name: "registry.example.com/some/cache", Format: cache.CacheImage, Type: "build"}))
+
+			err := subject.ExportCache(context.TODO(), ExportCacheOptions{Name: "registry.example.com/some/cache", Path: archivePath})
+			h.AssertError(t, err, "cache 'registry.example.com/some/cache' is an image cache")
+		})
+	})
+
+	when("#ImportCache", func() {
+		it.Before(func() {
+			contents := archive.GenerateTar(func(tw archive.TarWriter) error {
+				return writeTarFile(tw, "some-layer/some-file", "some-content")
+			})
+			defer contents.Close()
+			h.AssertNil(t, cache.WriteArchive(archivePath, contents, cache.ArchiveMetadata{Type: "build", AppImage: "index.docker.io/other/app:latest"}))
+		})
+
+		it("restores the cache to the volume used by builds of the app image", func() {
+			appRef, err := name.ParseReference("index.docker.io/other/app:latest")
+			h.AssertNil(t, err)
+			expectedVolume := cache.NewVolumeCache(appRef, cache.CacheInfo{}, "build", nil).Name()
+			mockDockerClient.EXPECT().VolumeInspect(gomock.Any(), expectedVolume).Return(volume.Volume{}, errdefs.NotFound(errors.New("no such volume")))
+			expectHelperContainer(expectedVolume)
+
+			var copied []byte
+			mockDockerClient.EXPECT().
+				CopyToContainer(gomock.Any(), "some-container-id", "/", gomock.Any(), types.CopyToContainerOptions{}).
+				DoAndReturn(func(_ context.Context, _, _ string, content io.Reader, _ types.CopyToContainerOptions) error {
+					var err error
+					copied, err = io.ReadAll(content)
+					return err
+				})
+
+			buildCache, err := subject.ImportCache(context.TODO(), ImportCacheOptions{Path: archivePath, HelperImage: helperImage, PullPolicy: image.PullIfNotPresent})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, buildCache.Name, expectedVolume)
+			h.AssertEq(t, buildCache.Format, cache.CacheVolume)
+			_, fileContents, err := archive.ReadTarEntry(bytes.NewReader(copied), "cache/some-layer/some-file")
+			h.AssertNil(t, err)
+			h.AssertEq(t, string(fileContents), "some-content")
+
+			entries, err := index.Entries()
+			h.AssertNil(t, err)
+			h.AssertEq(t, entries[0].Name, expectedVolume)
+			h.AssertEq(t, entries[0].AppImage, "index.docker.io/other/app:latest")
+		})
+
+		it("errors when the volume already exists", func() {
+			mockDockerClient.EXPECT().VolumeInspect(gomock.Any(), "some-volume").Return(volume.Volume{Name: "some-volume"}, nil)
+
+			_, err := subject.ImportCache(context.TODO(), ImportCacheOptions{Path: archivePath, Volume: "some-volume"})
+			h.AssertError(t, err, "volume 'some-volume' already exists")
+		})
+
+		it("restores the cache to a directory", func() {
+			bindDir := filepath.Join(tmpDir, "some-bind-cache")
+
+			buildCache, err := subject.ImportCache(context.TODO(), ImportCacheOptions{Path: archivePath, Bind: bindDir})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, buildCache.Format, cache.CacheBind)
+			fileContents, err := os.ReadFile(filepath.Join(bindDir, "some-layer", "some-file"))
+			h.AssertNil(t, err)
+			h.AssertEq(t, string(fileContents), "some-content")
+		})
+
+		it("errors when the directory is not empty", func() {
+			bindDir := filepath.Join(tmpDir, "some-bind-cache")
+			h.AssertNil(t, os.MkdirAll(bindDir, 0750))
+			h.AssertNil(t, os.WriteFile(filepath.Join(bindDir, "some-file"), []byte("some-content"), 0600))
+
+			_, err := subject.ImportCache(context.TODO(), ImportCacheOptions{Path: archivePath, Bind: bindDir})
+			h.AssertError(t, err, "is not empty")
+		})
+	})
+}
+
+func writeTarFile(tw archive.TarWriter, name, contents string) error {
+	if err := tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(contents))}); err != nil {
+		return err
+	}
+	_, err := tw.Write([]byte(contents))
+	return err
+}
@@ -0,0 +1,213 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/cache"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestCaches(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Caches", testCaches, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testCaches(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject          *Client
+		mockDockerClient *testmocks.MockCommonAPIClient
+		mockController   *gomock.Controller
+		index            *cache.Index
+		tmpDir           string
+		bindDir          string
+		out              bytes.Buffer
+		now              = time.Now().UTC().Truncate(time.Second)
+	)
+
+	volumeUsage := func(volumes ...*volume.Volume) {
+		mockDockerClient.EXPECT().
+			DiskUsage(gomock.Any(), types.DiskUsageOptions{Types: []types.DiskUsageObject{types.VolumeObject}}).
+			Return(types.DiskUsage{Volumes: volumes}, nil).
+			AnyTimes()
+	}
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockDockerClient = testmocks.NewMockCommonAPIClient(mockController)
+
+		var err error
+		tmpDir, err = os.MkdirTemp("", "pack.caches.test.")
+		h.AssertNil(t, err)
+
+		bindDir = filepath.Join(tmpDir, "some-bind-cache")
+		h.AssertNil(t, os.MkdirAll(filepath.Join(bindDir, "layer"), 0750))
+		h.AssertNil(t, os.WriteFile(filepath.Join(bindDir, "layer", "some-file"), []byte("some-content"), 0600))
+
+		indexPath := filepath.Join(tmpDir, "cache-index.json")
+		index = cache.NewIndex(indexPath)
+		h.AssertNil(t, index.Record(
+			cache.IndexEntry{Name: "pack-cache-some_app_latest-123.build", Format: cache.CacheVolume, Type: "build", AppImage: "index.docker.io/some/app:latest", LastUsed: now.Add(-time.Hour)},
+			cache.IndexEntry{Name: "pack-cache-removed_app_latest-456.build", Format: cache.CacheVolume, Type: "build", AppImage: "index.docker.io/removed/app:latest", LastUsed: now},
+			cache.IndexEntry{Name: bindDir, Format: cache.CacheBind, Type: "build", AppImage: "index.docker.io/bind/app:latest", LastUsed: now.Add(-48 * time.Hour)},
+			cache.IndexEntry{Name: "registry.example.com/some/cache:latest", Format: cache.CacheImage, Type: "build", AppImage: "registry.example.com/some/app:latest", LastUsed: now.Add(-72 * time.Hour)},
+		))
+
+		subject, err = NewClient(
+			WithLogger(logging.NewLogWithWriters(&out, &out)),
+			WithDockerClient(mockDockerClient),
+			WithCacheIndex(indexPath),
+		)
+		h.AssertNil(t, err)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+		h.AssertNil(t, os.RemoveAll(tmpDir))
+	})
+
+	when("#ListCaches", func() {
+		it("lists pack volumes, bind caches and cache images", func() {
+			volumeUsage(
+				&volume.Volume{Name: "pack-cache-some_app_latest-123.build", UsageData: &volume.UsageData{Size: 1024}},
+				&volume.Volume{Name: "pack-cache-old_app_latest-789.launch", CreatedAt: "2023-01-02T03:04:05Z", UsageData: &volume.UsageData{Size: -1}},
+				&volume.Volume{Name: "some-other-volume", UsageData: &volume.UsageData{Size: 2048}},
+			)
+
+			caches, err := subject.ListCaches(context.TODO())
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, caches, []BuildCache{
+				{Name: bindDir, Format: cache.CacheBind, Type: "build", AppImage: "index.docker.io/bind/app:latest", Size: int64(len("some-content")), LastUsed: now.Add(-48 * time.Hour)},
+				{Name: "pack-cache-old_app_latest-789.launch", Format: cache.CacheVolume, Type: "launch", Size: -1, LastUsed: time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)},
+				{Name: "pack-cache-some_app_latest-123.build", Format: cache.CacheVolume, Type: "build", AppImage: "index.docker.io/some/app:latest", Size: 1024, LastUsed: now.Add(-time.Hour)},
+				{Name: "registry.example.com/some/cache:latest", Format: cache.CacheImage, Type: "build", AppImage: "registry.example.com/some/app:latest", Size: -1, LastUsed: now.Add(-72 * time.Hour)},
+			})
+		})
+
+		it("forgets caches that no longer exist", func() {
+			volumeUsage()
+			h.AssertNil(t, os.RemoveAll(bindDir))
+
+			_, err := subject.ListCaches(context.TODO())
+			h.AssertNil(t, err)
+
+			entries, err := index.Entries()
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(entries), 1)
+			h.AssertEq(t, entries[0].Format, cache.CacheImage)
+		})
+	})
+
+	when("#InspectCache", func() {
+		it("returns the cache", func() {
+			volumeUsage(&volume.Volume{Name: "pack-cache-some_app_latest-123.build", UsageData: &volume.UsageData{Size: 1024}})
+
+			buildCache, err := subject.InspectCache(context.TODO(), "pack-cache-some_app_latest-123.build")
+			h.AssertNil(t, err)
+			h.AssertEq(t, buildCache.AppImage, "index.docker.io/some/app:latest")
+			h.AssertEq(t, buildCache.Size, int64(1024))
+		})
+
+		it("errors when the cache does not exist", func() {
+			volumeUsage()
+
+			_, err := subject.InspectCache(context.TODO(), "some-missing-cache")
+			h.AssertError(t, err, "cache 'some-missing-cache' not found")
+		})
+	})
+
+	when("#RemoveCache", func() {
+		it("removes a volume cache", func() {
+			volumeUsage(&volume.Volume{Name: "pack-cache-some_app_latest-123.build", UsageData: &volume.UsageData{Size: 1024}})
+			mockDockerClient.EXPECT().VolumeRemove(gomock.Any(), "pack-cache-some_app_latest-123.build", true).Return(nil)
+
+			h.AssertNil(t, subject.RemoveCache(context.TODO(), "pack-cache-some_app_latest-123.build"))
+
+			entries, err := index.Entries()
+			h.AssertNil(t, err)
+			for _, entry := range entries {
+				h.AssertNotEq(t, entry.Name, "pack-cache-some_app_latest-123.build")
+			}
+		})
+
+		it("removes the contents of a bind cache", func() {
+			volumeUsage()
+
+			h.AssertNil(t, subject.RemoveCache(context.TODO(), bindDir))
+
+			entries, err := os.ReadDir(bindDir)
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(entries), 0)
+		})
+	})
+
+	when("#PruneCaches", func() {
+		it.Before(func() {
+			volumeUsage(
+				&volume.Volume{Name: "pack-cache-some_app_latest-123.build", UsageData: &volume.UsageData{Size: 1024}},
+				&volume.Volume{Name: "pack-cache-old_app_latest-789.launch", CreatedAt: now.Add(-96 * time.Hour).Format(time.RFC3339), UsageData: &volume.UsageData{Size: 4096}},
+			)
+		})
+
+		it("removes caches older than the given age", func() {
+			mockDockerClient.EXPECT().VolumeRemove(gomock.Any(), "pack-cache-old_app_latest-789.launch", true).Return(nil)
+
+			pruned, err := subject.PruneCaches(context.TODO(), PruneCachesOptions{OlderThan: 24 * time.Hour})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, len(pruned), 1)
+			h.AssertEq(t, pruned[0].Name, "pack-cache-old_app_latest-789.launch")
+			_, err = os.Stat(filepath.Join(bindDir, "layer", "some-file"))
+			h.AssertNil(t, err)
+		})
+
+		it("removes the contents of bind caches when they are included", func() {
+			mockDockerClient.EXPECT().VolumeRemove(gomock.Any(), "pack-cache-old_app_latest-789.launch", true).Return(nil)
+
+			pruned, err := subject.PruneCaches(context.TODO(), PruneCachesOptions{OlderThan: 24 * time.Hour, IncludeBind: true})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, len(pruned), 2)
+			h.AssertEq(t, pruned[0].Name, "pack-cache-old_app_latest-789.launch")
+			h.AssertEq(t, pruned[1].Name, bindDir)
+			entries, err := os.ReadDir(bindDir)
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(entries), 0)
+		})
+
+		it("removes the least recently used caches until the total size fits the budget", func() {
+			mockDockerClient.EXPECT().VolumeRemove(gomock.Any(), "pack-cache-old_app_latest-789.launch", true).Return(nil)
+
+			pruned, err := subject.PruneCaches(context.TODO(), PruneCachesOptions{MaxSize: 2048})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, len(pruned), 1)
+			h.AssertEq(t, pruned[0].Name, "pack-cache-old_app_latest-789.launch")
+		})
+
+		it("removes nothing on a dry run", func() {
+			pruned, err := subject.PruneCaches(context.TODO(), PruneCachesOptions{OlderThan: 24 * time.Hour, DryRun: true, IncludeBind: true})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, len(pruned), 2)
+			h.AssertEq(t, pruned[0].Name, "pack-cache-old_app_latest-789.launch")
+			_, err = os.Stat(bindDir)
+			h.AssertNil(t, err)
+		})
+	})
+}
@@ -32,6 +32,7 @@ import (
 	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/pkg/blob"
 	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/cache"
 	"github.com/buildpacks/pack/pkg/image"
 	"github.com/buildpacks/pack/pkg/logging"
 )
@@ -97,6 +98,10 @@ type Client struct {
 	experimental    bool
 	registryMirrors map[string]string
 	version         string
+	manifestDir     string
+	cacheIndex      *cache.Index
+	requireDigests  bool
+	offline         bool
 }
 
 // Option is a type of function that mutate settings on the client.
@@ -178,6 +183,36 @@ func WithKeychain(keychain authn.Keychain) Option {
 	}
 }
 
+// WithManifestDir sets the directory where image indexes are stored until they are pushed.
+func WithManifestDir(path string) Option {
+	return func(c *Client) {
+		c.manifestDir = path
+	}
+}
+
+// WithRequireDigests rejects remote buildpack and lifecycle URIs which are not pinned to a sha256 digest.
+func WithRequireDigests(require bool) Option {
+	return func(c *Client) {
+		c.requireDigests = require
+	}
+}
+
+// WithOffline sets whether pack runs without network access. Offline, images are never pulled to the daemon, remote
+// URIs are served from the download cache only and buildpack registries are not refreshed. Artifacts which are not
+// available locally fail with an offline.MissingArtifactsError. It only applies to the default fetcher and downloaders.
+func WithOffline(offline bool) Option {
+	return func(c *Client) {
+		c.offline = offline
+	}
+}
+
+// WithCacheIndex sets the file where the caches used by builds are recorded.
+func WithCacheIndex(path string) Option {
+	return func(c *Client) {
+		c.cacheIndex = cache.NewIndex(path)
+	}
+}
+
 const DockerAPIVersion = "1.38"
 
 // NewClient allocates and returns a Client configured with the specified options.
@@ -206,16 +241,24 @@ func NewClient(opts ...Option) (*Client, error) {
 		}
 	}
 
-	if client.downloader == nil {
+	if client.downloader == nil || client.manifestDir == "" || client.cacheIndex == nil {
 		packHome, err := iconfig.PackHome()
 		if err != nil {
 			return nil, errors.Wrap(err, "getting pack home")
 		}
-		client.downloader = blob.NewDownloader(client.logger, filepath.Join(packHome, "download-cache"))
+		if client.downloader == nil {
+			client.downloader = blob.NewDownloader(client.logger, filepath.Join(packHome, "download-cache"), blob.WithRequireDigests(client.requireDigests), blob.WithOffline(client.offline))
+		}
+		if client.manifestDir == "" {
+			client.manifestDir = filepath.Join(packHome, "manifests")
+		}
+		if client.cacheIndex == nil {
+			client.cacheIndex = cache.NewIndex(filepath.Join(packHome, "cache-index.json"))
+		}
 	}
 
 	if client.imageFetcher == nil {
-		client.imageFetcher = image.NewFetcher(client.logger, client.docker, image.WithRegistryMirrors(client.registryMirrors), image.WithKeychain(client.keychain))
+		client.imageFetcher = image.NewFetcher(client.logger, client.docker, image.WithRegistryMirrors(client.registryMirrors), image.WithKeychain(client.keychain), image.WithOffline(client.offline))
 	}
 
 	if client.imageFactory == nil {
@@ -231,7 +274,8 @@ func NewClient(opts ...Option) (*Client, error) {
 			client.imageFetcher,
 			client.downloader,
 			&registryResolver{
-				logger: client.logger,
+				logger:  client.logger,
+				offline: client.offline,
 			},
 		)
 	}
@@ -242,11 +286,12 @@ func NewClient(opts ...Option) (*Client, error) {
 }
 
 type registryResolver struct {
-	logger logging.Logger
+	logger  logging.Logger
+	offline bool
 }
 
 func (r *registryResolver) Resolve(registryName, bpName string) (string, error) {
-	cache, err := getRegistry(r.logger, registryName)
+	cache, err := getRegistry(r.logger, registryName, r.offline)
 	if err != nil {
 		return "", errors.Wrapf(err, "lookup registry %s", style.Symbol(registryName))
 	}
@@ -57,7 +57,18 @@ func (c *Client) resolveRunImage(runImage, imgRegistry, bldrRegistry string, run
 	return runImageName
 }
 
-func getRegistry(logger logging.Logger, registryName string) (registry.Cache, error) {
+// getRegistry returns the cache of a registry from the config, or of the default registry when registryName is empty.
+// Offline caches are used without being refreshed.
+func getRegistry(logger logging.Logger, registryName string, offline bool) (registry.Cache, error) {
+	cache, err := newRegistryCache(logger, registryName)
+	if err != nil {
+		return registry.Cache{}, err
+	}
+	cache.Offline = offline
+	return cache, nil
+}
+
+func newRegistryCache(logger logging.Logger, registryName string) (registry.Cache, error) {
 	home, err := config.PackHome()
 	if err != nil {
 		return registry.Cache{}, err
@@ -78,7 +89,7 @@ func getRegistry(logger logging.Logger, registryName string) (registry.Cache, er
 
 	for _, reg := range config.GetRegistries(cfg) {
 		if reg.Name == registryName {
-			return registry.NewRegistryCache(logger, home, reg.URL)
+			return registry.NewRegistryCacheWithType(logger, home, reg.URL, reg.Type)
 		}
 	}
 
@@ -8,6 +8,7 @@ import (
 
 	"github.com/Masterminds/semver"
 	"github.com/buildpacks/imgutil"
+	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/pkg/errors"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
@@ -17,6 +18,7 @@ import (
 	"github.com/buildpacks/pack/internal/paths"
 	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/dist"
 	"github.com/buildpacks/pack/pkg/image"
 )
 
@@ -50,11 +52,23 @@ type CreateBuilderOptions struct {
 
 	// List of buildpack images to exclude from the package been flatten.
 	FlattenExclude []string
+
+	// Platforms to create the builder for. Defaults to the targets declared in Config.
+	// When more than one target is provided, a builder is created for each target
+	// and an image index referencing each platform-specific builder is published as BuilderName.
+	Targets []dist.Target
 }
 
 // CreateBuilder creates and saves a builder image to a registry with the provided options.
 // If any configuration is invalid, it will error and exit without creating any images.
 func (c *Client) CreateBuilder(ctx context.Context, opts CreateBuilderOptions) error {
+	if len(opts.Targets) == 0 {
+		opts.Targets = opts.Config.Targets
+	}
+	if len(opts.Targets) > 1 {
+		return c.createMultiPlatformBuilder(ctx, opts)
+	}
+
 	if err := c.validateConfig(ctx, opts); err != nil {
 		return err
 	}
@@ -83,6 +97,47 @@ func (c *Client) CreateBuilder(ctx context.Context, opts CreateBuilderOptions) e
 	return bldr.Save(c.logger, builder.CreatorMetadata{Version: c.version})
 }
 
+// createMultiPlatformBuilder creates a builder for each target, tagging each platform-specific builder
+// with a platform suffix, and publishes an image index referencing all of them as the builder.
+func (c *Client) createMultiPlatformBuilder(ctx context.Context, opts CreateBuilderOptions) error {
+	if !opts.Publish {
+		return errors.New("creating a builder for multiple platforms requires publishing to a registry")
+	}
+
+	builderRef, err := c.parseTagReference(opts.BuilderName)
+	if err != nil {
+		return errors.Wrapf(err, "invalid builder name '%s'", opts.BuilderName)
+	}
+
+	var platformBuilders []name.Reference
+	for _, target := range opts.Targets {
+		platformOpts := opts
+		platformOpts.Targets = []dist.Target{target}
+		if platformOpts.BuilderName, err = platformImageName(opts.BuilderName, target); err != nil {
+			return errors.Wrapf(err, "invalid builder name '%s'", opts.BuilderName)
+		}
+
+		c.logger.Infof("Creating builder for platform %s", style.Symbol(target.Platform()))
+		if err := c.CreateBuilder(ctx, platformOpts); err != nil {
+			return errors.Wrapf(err, "creating builder for platform %s", style.Symbol(target.Platform()))
+		}
+
+		platformRef, err := name.ParseReference(platformOpts.BuilderName, name.WeakValidation)
+		if err != nil {
+			return err
+		}
+		platformBuilders = append(platformBuilders, platformRef)
+	}
+
+	digest, err := c.pushImageIndex(ctx, platformBuilders, []name.Reference{builderRef})
+	if err != nil {
+		return errors.Wrap(err, "publishing image index")
+	}
+
+	c.logger.Infof("Published image index %s", style.Symbol(fmt.Sprintf("%s@%s", builderRef.Name(), digest)))
+	return nil
+}
+
 func (c *Client) validateConfig(ctx context.Context, opts CreateBuilderOptions) error {
 	if err := pubbldr.ValidateConfig(opts.Config); err != nil {
 		return errors.Wrap(err, "invalid builder config")
@@ -96,11 +151,13 @@ func (c *Client) validateConfig(ctx context.Context, opts CreateBuilderOptions)
 }
 
 func (c *Client) validateRunImageConfig(ctx context.Context, opts CreateBuilderOptions) error {
+	platform := targetPlatform(opts.Targets)
+
 	var runImages []imgutil.Image
 	for _, r := range opts.Config.Run.Images {
 		for _, i := range append([]string{r.Image}, r.Mirrors...) {
 			if !opts.Publish {
-				img, err := c.imageFetcher.Fetch(ctx, i, image.FetchOptions{Daemon: true, PullPolicy: opts.PullPolicy})
+				img, err := c.imageFetcher.Fetch(ctx, i, image.FetchOptions{Daemon: true, PullPolicy: opts.PullPolicy, Platform: platform})
 				if err != nil {
 					if errors.Cause(err) != image.ErrNotFound {
 						return errors.Wrap(err, "failed to fetch image")
@@ -111,7 +168,7 @@ func (c *Client) validateRunImageConfig(ctx context.Context, opts CreateBuilderO
 				}
 			}
 
-			img, err := c.imageFetcher.Fetch(ctx, i, image.FetchOptions{Daemon: false, PullPolicy: opts.PullPolicy})
+			img, err := c.imageFetcher.Fetch(ctx, i, image.FetchOptions{Daemon: false, PullPolicy: opts.PullPolicy, Platform: platform})
 			if err != nil {
 				if errors.Cause(err) != image.ErrNotFound {
 					return errors.Wrap(err, "failed to fetch image")
@@ -124,6 +181,12 @@ func (c *Client) validateRunImageConfig(ctx context.Context, opts CreateBuilderO
 	}
 
 	for _, img := range runImages {
+		if len(opts.Targets) == 1 {
+			if err := validateImageTarget(img, opts.Targets[0]); err != nil {
+				return errors.Wrapf(err, "invalid run image %s", style.Symbol(img.Name()))
+			}
+		}
+
 		if opts.Config.Stack.ID != "" {
 			stackID, err := img.Label("io.buildpacks.stack.id")
 			if err != nil {
@@ -145,11 +208,17 @@ func (c *Client) validateRunImageConfig(ctx context.Context, opts CreateBuilderO
 }
 
 func (c *Client) createBaseBuilder(ctx context.Context, opts CreateBuilderOptions) (*builder.Builder, error) {
-	baseImage, err := c.imageFetcher.Fetch(ctx, opts.Config.Build.Image, image.FetchOptions{Daemon: !opts.Publish, PullPolicy: opts.PullPolicy})
+	baseImage, err := c.imageFetcher.Fetch(ctx, opts.Config.Build.Image, image.FetchOptions{Daemon: !opts.Publish, PullPolicy: opts.PullPolicy, Platform: targetPlatform(opts.Targets)})
 	if err != nil {
 		return nil, errors.Wrap(err, "fetch build image")
 	}
 
+	if len(opts.Targets) == 1 {
+		if err := validateImageTarget(baseImage, opts.Targets[0]); err != nil {
+			return nil, errors.Wrap(err, "invalid build-image")
+		}
+	}
+
 	c.logger.Debugf("Creating builder %s from build-image %s", style.Symbol(opts.BuilderName), style.Symbol(baseImage.Name()))
 
 	var builderOpts []builder.BuilderOption
@@ -203,6 +272,14 @@ func (c *Client) fetchLifecycle(ctx context.Context, config pubbldr.LifecycleCon
 		)
 	}
 
+	// the lifecycle releases are downloaded from URIs which are not pinned to a digest
+	if config.URI == "" && c.requireDigests {
+		return nil, errors.Errorf(
+			"%s cannot be downloaded by version when digests are required, declare a %s pinned to a digest instead",
+			style.Symbol("lifecycle"), style.Symbol("lifecycle.uri"),
+		)
+	}
+
 	var uri string
 	var err error
 	switch {
@@ -260,7 +337,7 @@ func (c *Client) addConfig(ctx context.Context, kind string, config pubbldr.Modu
 	if err != nil {
 		return errors.Wrapf(err, "getting OS from %s", style.Symbol(bldr.Image().Name()))
 	}
-	mainBP, depBPs, err := c.buildpackDownloader.Download(ctx, config.URI, buildpack.DownloadOptions{
+	downloadOptions := buildpack.DownloadOptions{
 		Daemon:          !opts.Publish,
 		ImageName:       config.ImageName,
 		ImageOS:         imageOS,
@@ -268,7 +345,11 @@ func (c *Client) addConfig(ctx context.Context, kind string, config pubbldr.Modu
 		PullPolicy:      opts.PullPolicy,
 		RegistryName:    opts.Registry,
 		RelativeBaseDir: opts.RelativeBaseDir,
-	})
+	}
+	if len(opts.Targets) == 1 {
+		downloadOptions.Target = &opts.Targets[0]
+	}
+	mainBP, depBPs, err := c.buildpackDownloader.Download(ctx, config.URI, downloadOptions)
 	if err != nil {
 		return errors.Wrapf(err, "downloading %s", kind)
 	}
@@ -336,6 +417,15 @@ func validateModule(kind string, module buildpack.BuildModule, source, expectedI
 	return nil
 }
 
+// targetPlatform returns the platform of the only target in targets, or an empty string
+// when the platform should be left to the image fetcher
+func targetPlatform(targets []dist.Target) string {
+	if len(targets) != 1 {
+		return ""
+	}
+	return targets[0].Platform()
+}
+
 func uriFromLifecycleVersion(version semver.Version, os string, architecture string) string {
 	arch := "x86-64"
 
@@ -343,7 +433,8 @@ func uriFromLifecycleVersion(version semver.Version, os string, architecture str
 		return fmt.Sprintf("https://github.com/buildpacks/lifecycle/releases/download/v%s/lifecycle-v%s+windows.%s.tgz", version.String(), version.String(), arch)
 	}
 
-	if architecture == "arm64" {
+	switch architecture {
+	case "arm64", "ppc64le", "s390x":
 		arch = architecture
 	}
 
@@ -4,6 +4,9 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
+	"log"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
@@ -13,6 +16,11 @@ import (
 	"github.com/buildpacks/lifecycle/api"
 	"github.com/docker/docker/api/types"
 	"github.com/golang/mock/gomock"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	v1remote "github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/heroku/color"
 	"github.com/pkg/errors"
 	"github.com/sclevine/spec"
@@ -512,6 +520,42 @@ func testCreateBuilder(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("digests are required", func() {
+			it.Before(func() {
+				var err error
+				subject, err = client.NewClient(
+					client.WithLogger(logger),
+					client.WithDownloader(mockDownloader),
+					client.WithImageFactory(mockImageFactory),
+					client.WithFetcher(mockImageFetcher),
+					client.WithDockerClient(mockDockerClient),
+					client.WithBuildpackDownloader(mockBuildpackDownloader),
+					client.WithRequireDigests(true),
+				)
+				h.AssertNil(t, err)
+			})
+
+			it("fails when only a lifecycle version is provided", func() {
+				prepareFetcherWithBuildImage()
+				prepareFetcherWithRunImages()
+				opts.Config.Lifecycle.URI = ""
+				opts.Config.Lifecycle.Version = "3.4.5"
+
+				err := subject.CreateBuilder(context.TODO(), opts)
+				h.AssertError(t, err, "'lifecycle' cannot be downloaded by version when digests are required, declare a 'lifecycle.uri' pinned to a digest instead")
+			})
+
+			it("fails when no lifecycle version or URI is provided", func() {
+				prepareFetcherWithBuildImage()
+				prepareFetcherWithRunImages()
+				opts.Config.Lifecycle.URI = ""
+				opts.Config.Lifecycle.Version = ""
+
+				err := subject.CreateBuilder(context.TODO(), opts)
+				h.AssertError(t, err, "'lifecycle' cannot be downloaded by version when digests are required")
+			})
+		})
+
 		when("only lifecycle version is provided", func() {
 			it("should download from predetermined uri", func() {
 				prepareFetcherWithBuildImage()
@@ -548,6 +592,24 @@ func testCreateBuilder(t *testing.T, when spec.G, it spec.S) {
 				h.AssertNil(t, err)
 			})
 
+			it("should download from predetermined uri for ppc64le", func() {
+				prepareFetcherWithBuildImage()
+				prepareFetcherWithRunImages()
+				opts.Config.Lifecycle.URI = ""
+				opts.Config.Lifecycle.Version = "3.4.5"
+				h.AssertNil(t, fakeBuildImage.SetArchitecture("ppc64le"))
+
+				mockDownloader.EXPECT().Download(
+					gomock.Any(),
+					"https://github.com/buildpacks/lifecycle/releases/download/v3.4.5/lifecycle-v3.4.5+linux.ppc64le.tgz",
+				).Return(
+					blob.NewBlob(filepath.Join("testdata", "lifecycle", "platform-0.4")), nil,
+				)
+
+				err := subject.CreateBuilder(context.TODO(), opts)
+				h.AssertNil(t, err)
+			})
+
 			when("windows", func() {
 				it("should download from predetermined uri", func() {
 					opts.Config.Extensions = nil      // TODO: downloading extensions doesn't work yet; to be implemented in https://github.com/buildpacks/pack/issues/1489
@@ -660,6 +722,105 @@ func testCreateBuilder(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("targets are provided", func() {
+			var newPlatformImage = func(name, platform string) *fakes.Image {
+				target, err := dist.ParseTarget(platform)
+				h.AssertNil(t, err)
+
+				img := fakes.NewImage(name, "", nil)
+				h.AssertNil(t, img.SetArchitecture(target.Arch))
+				h.AssertNil(t, img.SetVariant(target.ArchVariant))
+				h.AssertNil(t, img.SetLabel("io.buildpacks.stack.id", "some.stack.id"))
+				h.AssertNil(t, img.SetLabel("io.buildpacks.stack.mixins", `["mixinX", "build:mixinY"]`))
+				h.AssertNil(t, img.SetEnv("CNB_USER_ID", "1234"))
+				h.AssertNil(t, img.SetEnv("CNB_GROUP_ID", "4321"))
+				return img
+			}
+
+			when("a single target is provided", func() {
+				it.Before(func() {
+					opts.Config.Targets = []dist.Target{{OS: "linux", Arch: "arm64"}}
+				})
+
+				it("fetches images for the target platform", func() {
+					fakeBuildImage = newPlatformImage("some/build-image", "linux/arm64")
+					mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/build-image", image.FetchOptions{Daemon: true, PullPolicy: image.PullAlways, Platform: "linux/arm64"}).Return(fakeBuildImage, nil)
+					mockImageFetcher.EXPECT().Fetch(gomock.Any(), gomock.Any(), image.FetchOptions{Daemon: true, PullPolicy: image.PullAlways, Platform: "linux/arm64"}).
+						DoAndReturn(func(_ context.Context, name string, _ image.FetchOptions) (*fakes.Image, error) {
+							return newPlatformImage(name, "linux/arm64"), nil
+						}).Times(2)
+
+					bldr := successfullyCreateBuilder()
+					arch, err := bldr.Image().Architecture()
+					h.AssertNil(t, err)
+					h.AssertEq(t, arch, "arm64")
+				})
+
+				it("fails when the build image does not match the target", func() {
+					prepareFetcherWithBuildImage()
+					mockImageFetcher.EXPECT().Fetch(gomock.Any(), gomock.Any(), gomock.Any()).
+						DoAndReturn(func(_ context.Context, name string, _ image.FetchOptions) (*fakes.Image, error) {
+							return newPlatformImage(name, "linux/arm64"), nil
+						}).AnyTimes()
+
+					err := subject.CreateBuilder(context.TODO(), opts)
+					h.AssertError(t, err, "image platform 'linux/amd64' does not match requested platform 'linux/arm64'")
+				})
+			})
+
+			when("multiple targets are provided", func() {
+				it.Before(func() {
+					opts.Config.Targets = []dist.Target{{OS: "linux", Arch: "amd64"}, {OS: "linux", Arch: "arm64"}}
+				})
+
+				it("fails when not publishing", func() {
+					err := subject.CreateBuilder(context.TODO(), opts)
+					h.AssertError(t, err, "creating a builder for multiple platforms requires publishing to a registry")
+				})
+
+				it("creates a builder per target and publishes an image index", func() {
+					server := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+					defer server.Close()
+					registryHost := strings.TrimPrefix(server.URL, "http://")
+
+					opts.Publish = true
+					opts.BuilderName = registryHost + "/some/builder"
+
+					var builderNames []string
+					mockImageFetcher.EXPECT().Fetch(gomock.Any(), gomock.Any(), gomock.Any()).
+						DoAndReturn(func(_ context.Context, name string, fetchOpts image.FetchOptions) (*fakes.Image, error) {
+							img := newPlatformImage(name, fetchOpts.Platform)
+							if name == "some/build-image" {
+								// saving a fake image does not reach the registry, so push a stand-in for the platform builder
+								target, err := dist.ParseTarget(fetchOpts.Platform)
+								h.AssertNil(t, err)
+								builderName := fmt.Sprintf("%s:latest-%s-%s", opts.BuilderName, target.OS, target.Arch)
+								builderNames = append(builderNames, builderName)
+								pushPlatformImage(t, builderName, target)
+							}
+							return img, nil
+						}).AnyTimes()
+
+					h.AssertNil(t, subject.CreateBuilder(context.TODO(), opts))
+					h.AssertEq(t, builderNames, []string{
+						registryHost + "/some/builder:latest-linux-amd64",
+						registryHost + "/some/builder:latest-linux-arm64",
+					})
+
+					ref, err := name.ParseReference(opts.BuilderName, name.WeakValidation)
+					h.AssertNil(t, err)
+					index, err := v1remote.Index(ref)
+					h.AssertNil(t, err)
+					manifest, err := index.IndexManifest()
+					h.AssertNil(t, err)
+					h.AssertEq(t, len(manifest.Manifests), 2)
+					h.AssertEq(t, manifest.Manifests[0].Platform.Architecture, "amd64")
+					h.AssertEq(t, manifest.Manifests[1].Platform.Architecture, "arm64")
+					h.AssertContains(t, out.String(), "Creating builder for platform 'linux/arm64'")
+				})
+			})
+		})
+
 		when("buildpack mixins are not satisfied", func() {
 			it("should return an error", func() {
 				prepareFetcherWithBuildImage()
@@ -1127,3 +1288,20 @@ func (i fakeBadImageStruct) Name() string {
 func (i fakeBadImageStruct) Label(str string) (string, error) {
 	return "", errors.New("error here")
 }
+
+func pushPlatformImage(t *testing.T, repoName string, target dist.Target) {
+	t.Helper()
+
+	img, err := random.Image(1024, 1)
+	h.AssertNil(t, err)
+	configFile, err := img.ConfigFile()
+	h.AssertNil(t, err)
+	configFile.OS = target.OS
+	configFile.Architecture = target.Arch
+	img, err = mutate.ConfigFile(img, configFile)
+	h.AssertNil(t, err)
+
+	ref, err := name.ParseReference(repoName, name.WeakValidation)
+	h.AssertNil(t, err)
+	h.AssertNil(t, v1remote.Write(ref, img))
+}
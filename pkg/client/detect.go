@@ -0,0 +1,178 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/pkg/dist"
+)
+
+const (
+	// detectImageName is the app image name used for detection when none is provided
+	detectImageName = "pack.local/detect"
+
+	detectLogFile       = "detector.log"
+	detectResultsMarker = "======== Results ========"
+)
+
+// skippedBuildpackLine matches the lines logged by the detector for optional buildpacks that were skipped,
+// for example 'skip: some/buildpack@1.2.3 requires some-dependency'.
+var skippedBuildpackLine = regexp.MustCompile(`^skip: (\S+)@(\S+)(?: (requires|provides unused) (.+))?$`)
+
+// DetectResult describes the outcome of running detection against an app.
+type DetectResult struct {
+	// Group lists the buildpacks that passed detection, in the order they would run during a build.
+	Group []dist.ModuleInfo
+
+	// GroupExtensions lists the image extensions that passed detection.
+	GroupExtensions []dist.ModuleInfo
+
+	// Plan lists the build plan entries resolved during detection.
+	Plan []DetectPlanEntry
+
+	// SkippedBuildpacks lists the optional buildpacks of the selected order group that did not participate.
+	SkippedBuildpacks []SkippedBuildpack
+}
+
+// DetectPlanEntry is a dependency of the build plan along with the buildpacks that provide it.
+type DetectPlanEntry struct {
+	Providers []dist.ModuleInfo `toml:"providers"`
+	Requires  []DetectRequire   `toml:"requires"`
+}
+
+// DetectRequire is a requirement of a build plan entry.
+type DetectRequire struct {
+	Name     string                 `toml:"name"`
+	Version  string                 `toml:"version,omitempty"`
+	Metadata map[string]interface{} `toml:"metadata"`
+}
+
+// SkippedBuildpack is an optional buildpack that did not participate in the build, and the reason why.
+type SkippedBuildpack struct {
+	dist.ModuleInfo
+	Reason string
+}
+
+// Detect runs the analyze and detect phases of a build, as configured by opts, without building or exporting an
+// app image. It returns the group of buildpacks that passed detection along with the resolved build plan.
+// When opts.Image is empty a placeholder image name is used.
+func (c *Client) Detect(ctx context.Context, opts BuildOptions) (DetectResult, error) {
+	if len(opts.Targets) > 1 {
+		return DetectResult{}, errors.New("detection can only be run for a single platform")
+	}
+
+	if opts.Image == "" {
+		opts.Image = detectImageName
+	}
+
+	outputDir, err := os.MkdirTemp("", "pack.detect")
+	if err != nil {
+		return DetectResult{}, errors.Wrap(err, "creating detect output directory")
+	}
+	defer os.RemoveAll(outputDir)
+
+	detectLog, err := os.Create(filepath.Join(outputDir, detectLogFile))
+	if err != nil {
+		return DetectResult{}, errors.Wrap(err, "creating detect log")
+	}
+	defer detectLog.Close()
+
+	opts.detectOutputDir = outputDir
+	opts.detectLog = detectLog
+	if err := c.Build(ctx, opts); err != nil {
+		return DetectResult{}, err
+	}
+
+	return readDetectResult(outputDir)
+}
+
+func readDetectResult(dir string) (DetectResult, error) {
+	var group struct {
+		Group           []dist.ModuleInfo `toml:"group"`
+		GroupExtensions []dist.ModuleInfo `toml:"group-extensions"`
+	}
+	if _, err := toml.DecodeFile(filepath.Join(dir, "group.toml"), &group); err != nil {
+		return DetectResult{}, errors.Wrap(err, "reading detected group")
+	}
+
+	var plan struct {
+		Entries []DetectPlanEntry `toml:"entries"`
+	}
+	if _, err := toml.DecodeFile(filepath.Join(dir, "plan.toml"), &plan); err != nil && !os.IsNotExist(err) {
+		return DetectResult{}, errors.Wrap(err, "reading build plan")
+	}
+
+	skipped, err := readSkippedBuildpacks(filepath.Join(dir, detectLogFile))
+	if err != nil {
+		return DetectResult{}, err
+	}
+
+	return DetectResult{
+		Group:             group.Group,
+		GroupExtensions:   group.GroupExtensions,
+		Plan:              plan.Entries,
+		SkippedBuildpacks: skipped,
+	}, nil
+}
+
+// readSkippedBuildpacks finds the optional buildpacks skipped by the detector. Only the results logged for the
+// last group are considered, as the detector stops at the first group that passes.
+func readSkippedBuildpacks(path string) ([]SkippedBuildpack, error) {
+	file, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "reading detect log")
+	}
+	defer file.Close()
+
+	var (
+		skipped []SkippedBuildpack
+		seen    = map[string]bool{}
+	)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == detectResultsMarker {
+			skipped = nil
+			seen = map[string]bool{}
+			continue
+		}
+
+		match := skippedBuildpackLine.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+
+		bp := dist.ModuleInfo{ID: match[1], Version: match[2]}
+		if seen[bp.FullName()] {
+			continue
+		}
+		seen[bp.FullName()] = true
+
+		var reason string
+		switch match[3] {
+		case "requires":
+			reason = fmt.Sprintf("requires '%s', which no buildpack provides", match[4])
+		case "provides unused":
+			reason = fmt.Sprintf("provides '%s', which no buildpack requires", match[4])
+		default:
+			reason = "failed detection"
+		}
+		skipped = append(skipped, SkippedBuildpack{ModuleInfo: bp, Reason: reason})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "reading detect log")
+	}
+
+	return skipped, nil
+}
@@ -0,0 +1,210 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/buildpacks/imgutil/fakes"
+	dockerclient "github.com/docker/docker/client"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/build"
+	"github.com/buildpacks/pack/internal/builder"
+	cfg "github.com/buildpacks/pack/internal/config"
+	ifakes "github.com/buildpacks/pack/internal/fakes"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestDetect(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "detect", testDetect, spec.Report(report.Terminal{}))
+}
+
+type fakeDetectLifecycle struct {
+	opts  build.LifecycleOptions
+	group string
+	plan  string
+	log   string
+}
+
+func (f *fakeDetectLifecycle) Execute(ctx context.Context, opts build.LifecycleOptions) error {
+	f.opts = opts
+	if _, err := opts.DetectLog.Write([]byte(f.log)); err != nil {
+		return err
+	}
+	if f.plan != "" {
+		if err := os.WriteFile(filepath.Join(opts.DetectOutputDir, "plan.toml"), []byte(f.plan), 0600); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(filepath.Join(opts.DetectOutputDir, "group.toml"), []byte(f.group), 0600)
+}
+
+func testDetect(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject          *Client
+		fakeImageFetcher *ifakes.FakeImageFetcher
+		fakeLifecycle    *fakeDetectLifecycle
+		builderImage     *fakes.Image
+		runImage         *fakes.Image
+		tmpDir           string
+		outBuf           bytes.Buffer
+	)
+
+	const (
+		builderName = "example.com/default/builder:tag"
+		stackID     = "some.stack.id"
+	)
+
+	it.Before(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "detect-test")
+		h.AssertNil(t, err)
+
+		fakeImageFetcher = ifakes.NewFakeImageFetcher()
+		builderImage = newFakeBuilderImage(t, tmpDir, builderName, stackID, "default/run", builder.DefaultLifecycleVersion, newLinuxImage)
+		fakeImageFetcher.LocalImages[builderImage.Name()] = builderImage
+
+		runImage = newLinuxImage("default/run", "", nil)
+		h.AssertNil(t, runImage.SetLabel("io.buildpacks.stack.id", stackID))
+		fakeImageFetcher.LocalImages[runImage.Name()] = runImage
+
+		lifecycleImage := newLinuxImage(fmt.Sprintf("%s:%s", cfg.DefaultLifecycleImageRepo, builder.DefaultLifecycleVersion), "", nil)
+		fakeImageFetcher.LocalImages[lifecycleImage.Name()] = lifecycleImage
+
+		fakeLifecycle = &fakeDetectLifecycle{group: `
+[[group]]
+id = "buildpack.1.id"
+version = "buildpack.1.version"
+api = "0.3"
+
+[[group-extensions]]
+id = "some-extension-id"
+version = "some-extension-version"
+api = "0.9"
+`}
+
+		docker, err := dockerclient.NewClientWithOpts(dockerclient.FromEnv, dockerclient.WithVersion("1.38"))
+		h.AssertNil(t, err)
+
+		subject = &Client{
+			logger:            logging.NewLogWithWriters(&outBuf, &outBuf),
+			imageFetcher:      fakeImageFetcher,
+			lifecycleExecutor: fakeLifecycle,
+			docker:            docker,
+		}
+	})
+
+	it.After(func() {
+		h.AssertNilE(t, builderImage.Cleanup())
+		h.AssertNilE(t, runImage.Cleanup())
+		h.AssertNilE(t, os.RemoveAll(tmpDir))
+	})
+
+	when("#Detect", func() {
+		it("returns the detected group", func() {
+			result, err := subject.Detect(context.TODO(), BuildOptions{Builder: builderName})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, result, DetectResult{
+				Group:           []dist.ModuleInfo{{ID: "buildpack.1.id", Version: "buildpack.1.version"}},
+				GroupExtensions: []dist.ModuleInfo{{ID: "some-extension-id", Version: "some-extension-version"}},
+			})
+		})
+
+		it("returns the build plan", func() {
+			fakeLifecycle.plan = `
+[[entries]]
+  [[entries.providers]]
+    id = "buildpack.1.id"
+    version = "buildpack.1.version"
+  [[entries.requires]]
+    name = "some-dependency"
+    [entries.requires.metadata]
+      version = "1.2.3"
+`
+			result, err := subject.Detect(context.TODO(), BuildOptions{Builder: builderName})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, result.Plan, []DetectPlanEntry{{
+				Providers: []dist.ModuleInfo{{ID: "buildpack.1.id", Version: "buildpack.1.version"}},
+				Requires: []DetectRequire{{
+					Name:     "some-dependency",
+					Metadata: map[string]interface{}{"version": "1.2.3"},
+				}},
+			}})
+		})
+
+		it("returns the optional buildpacks skipped in the selected group", func() {
+			fakeLifecycle.log = `======== Output: other.id@1.0.0 ========
+skip: not-a-result@1.0.0
+======== Results ========
+skip: first-group.id@1.0.0
+fail: no viable buildpacks in group
+======== Results ========
+pass: buildpack.1.id@buildpack.1.version
+skip: optional.id@1.0.0
+pass: requiring.id@2.0.0
+pass: providing.id@3.0.0
+Resolving plan... (try #1)
+skip: requiring.id@2.0.0 requires missing-dependency
+skip: providing.id@3.0.0 provides unused other-dependency
+1 of 4 buildpacks participating
+`
+			result, err := subject.Detect(context.TODO(), BuildOptions{Builder: builderName})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, result.SkippedBuildpacks, []SkippedBuildpack{
+				{ModuleInfo: dist.ModuleInfo{ID: "optional.id", Version: "1.0.0"}, Reason: "failed detection"},
+				{ModuleInfo: dist.ModuleInfo{ID: "requiring.id", Version: "2.0.0"}, Reason: "requires 'missing-dependency', which no buildpack provides"},
+				{ModuleInfo: dist.ModuleInfo{ID: "providing.id", Version: "3.0.0"}, Reason: "provides 'other-dependency', which no buildpack requires"},
+			})
+		})
+
+		it("only runs detection", func() {
+			_, err := subject.Detect(context.TODO(), BuildOptions{
+				Builder: builderName,
+				TrustBuilder: func(string) bool {
+					return true
+				},
+			})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, fakeLifecycle.opts.DetectOnly, true)
+			h.AssertNotNil(t, fakeLifecycle.opts.DetectLog)
+			h.AssertEq(t, fakeLifecycle.opts.UseCreator, false)
+			h.AssertEq(t, fakeLifecycle.opts.Image.Name(), "pack.local/detect:latest")
+		})
+
+		it("uses the provided image name", func() {
+			_, err := subject.Detect(context.TODO(), BuildOptions{Builder: builderName, Image: "some/app"})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, fakeLifecycle.opts.Image.Name(), "index.docker.io/some/app:latest")
+		})
+
+		it("fails for multiple targets", func() {
+			_, err := subject.Detect(context.TODO(), BuildOptions{
+				Builder: builderName,
+				Targets: []dist.Target{{OS: "linux", Arch: "amd64"}, {OS: "linux", Arch: "arm64"}},
+			})
+			h.AssertError(t, err, "detection can only be run for a single platform")
+		})
+
+		it("fails when the group cannot be read", func() {
+			fakeLifecycle.group = "[[group]"
+
+			_, err := subject.Detect(context.TODO(), BuildOptions{Builder: builderName})
+			h.AssertError(t, err, "reading detected group")
+		})
+	})
+}
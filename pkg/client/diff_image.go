@@ -0,0 +1,307 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/buildpacks/lifecycle/launch"
+	"github.com/buildpacks/lifecycle/platform"
+	"github.com/buildpacks/lifecycle/platform/files"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+const (
+	ChangeAdded    = "added"
+	ChangeRemoved  = "removed"
+	ChangeModified = "modified"
+)
+
+// DiffImageOptions configures how the app images are compared.
+type DiffImageOptions struct {
+	// When true, the first app image is read from the daemon, otherwise from its registry.
+	BeforeDaemon bool
+
+	// When true, the second app image is read from the daemon, otherwise from its registry.
+	AfterDaemon bool
+}
+
+// ImageDiff lists what changed between two app images. Each list is sorted by name.
+type ImageDiff struct {
+	// Reference, image and top layer of the run image.
+	RunImage []Change `json:"runImage" yaml:"runImage"`
+
+	// Versions of the buildpacks that took part in the build.
+	Buildpacks []Change `json:"buildpacks" yaml:"buildpacks"`
+
+	// Versions of the BOM entries of the build metadata label, named after the buildpack that provided them.
+	BOM []Change `json:"bom" yaml:"bom"`
+
+	// Digests of the SBOM documents of the SBOM layer, named after their path in the layer.
+	SBOM []Change `json:"sbom" yaml:"sbom"`
+
+	// Command lines of the processes, named after their type.
+	Processes []Change `json:"processes" yaml:"processes"`
+
+	// Labels other than the lifecycle, build and project metadata labels, which are compared field by field.
+	Labels []Change `json:"labels" yaml:"labels"`
+
+	// Diff IDs of the layers of the app image, named after the part of the image they belong to.
+	Layers []Change `json:"layers" yaml:"layers"`
+}
+
+// Change describes a value that was added, removed or modified between the two app images.
+type Change struct {
+	Name   string `json:"name" yaml:"name"`
+	Status string `json:"status" yaml:"status"`
+	Before string `json:"before,omitempty" yaml:"before,omitempty"`
+	After  string `json:"after,omitempty" yaml:"after,omitempty"`
+}
+
+// Empty returns true when nothing changed between the two app images.
+func (d *ImageDiff) Empty() bool {
+	return len(d.RunImage)+len(d.Buildpacks)+len(d.BOM)+len(d.SBOM)+len(d.Processes)+len(d.Labels)+len(d.Layers) == 0
+}
+
+// imageSnapshot holds the values of an app image that are compared
+type imageSnapshot struct {
+	runImage   map[string]string
+	buildpacks map[string]string
+	bom        map[string]string
+	sbom       map[string]string
+	processes  map[string]string
+	labels     map[string]string
+	layers     map[string]string
+}
+
+// diffedLabels are compared through the fields they hold rather than as a whole
+var diffedLabels = map[string]bool{
+	platform.LifecycleMetadataLabel: true,
+	platform.BuildMetadataLabel:     true,
+	platform.ProjectMetadataLabel:   true,
+}
+
+// DiffImage compares the run image, buildpacks, BOM, SBOM, processes, labels and layers of two app images.
+func (c *Client) DiffImage(ctx context.Context, before, after string, opts DiffImageOptions) (*ImageDiff, error) {
+	beforeSnapshot, err := c.snapshotImage(ctx, before, opts.BeforeDaemon)
+	if err != nil {
+		return nil, err
+	}
+	afterSnapshot, err := c.snapshotImage(ctx, after, opts.AfterDaemon)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ImageDiff{
+		RunImage:   diffValues(beforeSnapshot.runImage, afterSnapshot.runImage),
+		Buildpacks: diffValues(beforeSnapshot.buildpacks, afterSnapshot.buildpacks),
+		BOM:        diffValues(beforeSnapshot.bom, afterSnapshot.bom),
+		SBOM:       diffValues(beforeSnapshot.sbom, afterSnapshot.sbom),
+		Processes:  diffValues(beforeSnapshot.processes, afterSnapshot.processes),
+		Labels:     diffValues(beforeSnapshot.labels, afterSnapshot.labels),
+		Layers:     diffValues(beforeSnapshot.layers, afterSnapshot.layers),
+	}, nil
+}
+
+func (c *Client) snapshotImage(ctx context.Context, imageName string, daemon bool) (imageSnapshot, error) {
+	info, err := c.InspectImage(imageName, daemon)
+	if err != nil {
+		return imageSnapshot{}, errors.Wrapf(err, "inspecting image %s", style.Symbol(imageName))
+	}
+	if info == nil {
+		return imageSnapshot{}, errors.Errorf("image %s cannot be found", style.Symbol(imageName))
+	}
+
+	img, err := c.imageFetcher.Fetch(ctx, imageName, image.FetchOptions{Daemon: daemon, PullPolicy: image.PullNever})
+	if err != nil {
+		return imageSnapshot{}, err
+	}
+	labels, err := img.Labels()
+	if err != nil {
+		return imageSnapshot{}, errors.Wrapf(err, "reading labels of %s", style.Symbol(imageName))
+	}
+	var layersMD files.LayersMetadataCompat
+	if _, err := dist.GetLabel(img, platform.LifecycleMetadataLabel, &layersMD); err != nil {
+		return imageSnapshot{}, err
+	}
+
+	snapshot := imageSnapshot{
+		runImage:   map[string]string{},
+		buildpacks: map[string]string{},
+		bom:        map[string]string{},
+		processes:  map[string]string{},
+		labels:     map[string]string{},
+		layers:     layerDiffIDs(layersMD),
+	}
+
+	for key, value := range map[string]string{
+		"image":     info.Stack.RunImage.Image,
+		"reference": info.Base.Reference,
+		"top-layer": info.Base.TopLayer,
+	} {
+		if value != "" {
+			snapshot.runImage[key] = value
+		}
+	}
+	for _, bp := range info.Buildpacks {
+		snapshot.buildpacks[bp.ID] = bp.Version
+	}
+	for _, entry := range info.BOM {
+		snapshot.bom[entry.Buildpack.ID+"/"+entry.Name] = bomEntryVersion(entry.Metadata)
+	}
+	if info.Processes.DefaultProcess != nil {
+		snapshot.processes[info.Processes.DefaultProcess.Type] = processCommandLine(*info.Processes.DefaultProcess) + " (default)"
+	}
+	for _, proc := range info.Processes.OtherProcesses {
+		snapshot.processes[proc.Type] = processCommandLine(proc)
+	}
+	for key, value := range labels {
+		if !diffedLabels[key] {
+			snapshot.labels[key] = value
+		}
+	}
+
+	if layersMD.BOM != nil && layersMD.BOM.SHA != "" {
+		if snapshot.sbom, err = c.sbomDigests(imageName, daemon); err != nil {
+			return imageSnapshot{}, err
+		}
+	}
+
+	return snapshot, nil
+}
+
+// sbomDigests downloads the SBOM layer of an image and returns the digest of each document, by path
+func (c *Client) sbomDigests(imageName string, daemon bool) (map[string]string, error) {
+	sbomDir, err := os.MkdirTemp("", "pack.sbom.diff.")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(sbomDir)
+
+	if err := c.DownloadSBOM(imageName, DownloadSBOMOptions{Daemon: daemon, DestinationDir: sbomDir}); err != nil {
+		return nil, errors.Wrapf(err, "downloading SBOM of %s", style.Symbol(imageName))
+	}
+
+	digests := map[string]string{}
+	err = filepath.Walk(sbomDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		relPath, err := filepath.Rel(sbomDir, path)
+		if err != nil {
+			return err
+		}
+		digest, err := fileSHA256(path)
+		if err != nil {
+			return err
+		}
+		digests[filepath.ToSlash(relPath)] = digest
+		return nil
+	})
+	return digests, err
+}
+
+func fileSHA256(path string) (string, error) {
+	file, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, file); err != nil {
+		return "", err
+	}
+	return "sha256:" + hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// layerDiffIDs returns the diff ID of each layer recorded in the lifecycle metadata, by the part of the image it belongs to
+func layerDiffIDs(layersMD files.LayersMetadataCompat) map[string]string {
+	diffIDs := map[string]string{}
+	if layersMD.RunImage.TopLayer != "" {
+		diffIDs["run-image/top-layer"] = layersMD.RunImage.TopLayer
+	}
+	if appLayers, ok := layersMD.App.([]interface{}); ok {
+		for i, appLayer := range appLayers {
+			if layer, ok := appLayer.(map[string]interface{}); ok {
+				if sha, ok := layer["sha"].(string); ok {
+					diffIDs[fmt.Sprintf("app/%d", i)] = sha
+				}
+			}
+		}
+	}
+	for _, bp := range layersMD.Buildpacks {
+		for layerName, layer := range bp.Layers {
+			if layer.SHA != "" {
+				diffIDs[fmt.Sprintf("buildpacks/%s/%s", bp.ID, layerName)] = layer.SHA
+			}
+		}
+	}
+	for layerName, layer := range map[string]files.LayerMetadata{
+		"config":        layersMD.Config,
+		"launcher":      layersMD.Launcher,
+		"process-types": layersMD.ProcessTypes,
+	} {
+		if layer.SHA != "" {
+			diffIDs[layerName] = layer.SHA
+		}
+	}
+	if layersMD.BOM != nil && layersMD.BOM.SHA != "" {
+		diffIDs["sbom"] = layersMD.BOM.SHA
+	}
+	return diffIDs
+}
+
+// bomEntryVersion returns the version of a BOM entry, or its whole metadata when it has no version
+func bomEntryVersion(metadata map[string]interface{}) string {
+	if version, ok := metadata["version"].(string); ok {
+		return version
+	}
+	if len(metadata) == 0 {
+		return ""
+	}
+	contents, err := json.Marshal(metadata)
+	if err != nil {
+		return ""
+	}
+	return string(contents)
+}
+
+func processCommandLine(proc launch.Process) string {
+	return strings.Join(append(append([]string{}, proc.Command.Entries...), proc.Args...), " ")
+}
+
+// diffValues compares two sets of named values, sorted by name
+func diffValues(before, after map[string]string) []Change {
+	var changes []Change
+	for name, beforeValue := range before {
+		afterValue, ok := after[name]
+		switch {
+		case !ok:
+			changes = append(changes, Change{Name: name, Status: ChangeRemoved, Before: beforeValue})
+		case beforeValue != afterValue:
+			changes = append(changes, Change{Name: name, Status: ChangeModified, Before: beforeValue, After: afterValue})
+		}
+	}
+	for name, afterValue := range after {
+		if _, ok := before[name]; !ok {
+			changes = append(changes, Change{Name: name, Status: ChangeAdded, After: afterValue})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Name < changes[j].Name
+	})
+	return changes
+}
@@ -0,0 +1,162 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/archive"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestDiffImage(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "DiffImage", testDiffImage, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testDiffImage(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject          *Client
+		mockImageFetcher *testmocks.MockImageFetcher
+		mockController   *gomock.Controller
+		tmpDir           string
+		out              bytes.Buffer
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockImageFetcher = testmocks.NewMockImageFetcher(mockController)
+
+		var err error
+		subject, err = NewClient(WithLogger(logging.NewLogWithWriters(&out, &out)), WithFetcher(mockImageFetcher))
+		h.AssertNil(t, err)
+
+		tmpDir = t.TempDir()
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	// newAppImage returns an app image built by buildpacks with the given versions and an SBOM layer holding sbomContent
+	newAppImage := func(imageName string, daemon bool, topLayer, nodeVersion, sbomContent string, labels map[string]string) *testmocks.MockImage {
+		sbomLayer := filepath.Join(tmpDir, imageName+".tar")
+		h.AssertNil(t, os.MkdirAll(filepath.Dir(sbomLayer), 0750))
+		h.AssertNil(t, archive.CreateSingleFileTar(sbomLayer, "launch/some-buildpack/sbom.cdx.json", sbomContent))
+		data, err := os.ReadFile(sbomLayer)
+		h.AssertNil(t, err)
+		sum := sha256.Sum256(data)
+		sbomSHA := "sha256:" + hex.EncodeToString(sum[:])
+
+		img := testmocks.NewImage(imageName, "", nil)
+		img.AddLayerWithDiffID(sbomLayer, sbomSHA)
+		h.AssertNil(t, img.SetLabel("io.buildpacks.stack.id", "some.stack.id"))
+		h.AssertNil(t, img.SetLabel("io.buildpacks.lifecycle.metadata", fmt.Sprintf(`{
+  "app": [{"sha": "sha256:app-%[1]s"}],
+  "buildpacks": [{"key": "some/buildpack", "version": "1.0.0", "layers": {"node": {"sha": "sha256:node-%[1]s"}}}],
+  "launcher": {"sha": "sha256:launcher"},
+  "sbom": {"sha": "%[3]s"},
+  "runImage": {"topLayer": "%[2]s", "reference": "some-run-image@%[2]s", "image": "some/run-image"}
+}`, nodeVersion, topLayer, sbomSHA)))
+		h.AssertNil(t, img.SetLabel("io.buildpacks.build.metadata", fmt.Sprintf(`{
+  "bom": [{"name": "node", "metadata": {"version": "%s"}, "buildpack": {"id": "some/buildpack", "version": "1.0.0"}}],
+  "buildpacks": [{"id": "some/buildpack", "version": "%s"}],
+  "processes": [{"type": "web", "command": "node", "args": ["server.js"], "direct": true}],
+  "launcher": {"version": "0.17.0"}
+}`, nodeVersion, nodeVersion)))
+		for key, value := range labels {
+			h.AssertNil(t, img.SetLabel(key, value))
+		}
+
+		mockImageFetcher.EXPECT().
+			Fetch(gomock.Any(), imageName, image.FetchOptions{Daemon: daemon, PullPolicy: image.PullNever}).
+			Return(img, nil).
+			AnyTimes()
+		return img
+	}
+
+	when("#DiffImage", func() {
+		it("lists what changed between the images", func() {
+			newAppImage("some/app:v1", true, "sha256:top-1", "18.0.0", `{"bomFormat":"CycloneDX","version":1}`, map[string]string{
+				"some-label":    "some-value",
+				"removed-label": "removed-value",
+			})
+			newAppImage("some/app:v2", true, "sha256:top-2", "20.0.0", `{"bomFormat":"CycloneDX","version":2}`, map[string]string{
+				"some-label":  "other-value",
+				"added-label": "added-value",
+			})
+
+			diff, err := subject.DiffImage(context.TODO(), "some/app:v1", "some/app:v2", DiffImageOptions{BeforeDaemon: true, AfterDaemon: true})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, diff.RunImage, []Change{
+				{Name: "reference", Status: ChangeModified, Before: "some-run-image@sha256:top-1", After: "some-run-image@sha256:top-2"},
+				{Name: "top-layer", Status: ChangeModified, Before: "sha256:top-1", After: "sha256:top-2"},
+			})
+			h.AssertEq(t, diff.Buildpacks, []Change{
+				{Name: "some/buildpack", Status: ChangeModified, Before: "18.0.0", After: "20.0.0"},
+			})
+			h.AssertEq(t, diff.BOM, []Change{
+				{Name: "some/buildpack/node", Status: ChangeModified, Before: "18.0.0", After: "20.0.0"},
+			})
+			h.AssertEq(t, len(diff.SBOM), 1)
+			h.AssertEq(t, diff.SBOM[0].Name, "launch/some-buildpack/sbom.cdx.json")
+			h.AssertEq(t, diff.SBOM[0].Status, ChangeModified)
+			h.AssertEq(t, len(diff.Processes), 0)
+			h.AssertEq(t, diff.Labels, []Change{
+				{Name: "added-label", Status: ChangeAdded, After: "added-value"},
+				{Name: "removed-label", Status: ChangeRemoved, Before: "removed-value"},
+				{Name: "some-label", Status: ChangeModified, Before: "some-value", After: "other-value"},
+			})
+
+			var layerNames []string
+			for _, change := range diff.Layers {
+				layerNames = append(layerNames, change.Name)
+			}
+			h.AssertEq(t, layerNames, []string{"app/0", "buildpacks/some/buildpack/node", "run-image/top-layer", "sbom"})
+		})
+
+		it("reads each image from its own source", func() {
+			newAppImage("some/app:v1", true, "sha256:top-1", "18.0.0", `{}`, nil)
+			newAppImage("registry.example.com/some/app:v2", false, "sha256:top-1", "20.0.0", `{}`, nil)
+
+			diff, err := subject.DiffImage(context.TODO(), "some/app:v1", "registry.example.com/some/app:v2", DiffImageOptions{BeforeDaemon: true, AfterDaemon: false})
+			h.AssertNil(t, err)
+			h.AssertEq(t, diff.Buildpacks, []Change{
+				{Name: "some/buildpack", Status: ChangeModified, Before: "18.0.0", After: "20.0.0"},
+			})
+		})
+
+		it("is empty when the images are the same", func() {
+			newAppImage("some/app:v1", true, "sha256:top-1", "18.0.0", `{}`, nil)
+
+			diff, err := subject.DiffImage(context.TODO(), "some/app:v1", "some/app:v1", DiffImageOptions{BeforeDaemon: true, AfterDaemon: true})
+			h.AssertNil(t, err)
+			h.AssertTrue(t, diff.Empty())
+		})
+
+		it("errors when an image cannot be found", func() {
+			newAppImage("some/app:v1", true, "sha256:top-1", "18.0.0", `{}`, nil)
+			mockImageFetcher.EXPECT().
+				Fetch(gomock.Any(), "some/missing", image.FetchOptions{Daemon: true, PullPolicy: image.PullNever}).
+				Return(nil, image.ErrNotFound)
+
+			_, err := subject.DiffImage(context.TODO(), "some/app:v1", "some/missing", DiffImageOptions{BeforeDaemon: true, AfterDaemon: true})
+			h.AssertError(t, err, "image 'some/missing' cannot be found")
+		})
+	})
+}
@@ -0,0 +1,174 @@
+package client
+
+import (
+	"context"
+	"runtime"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	v1remote "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// manifestEntry is an image referenced by an image index, along with the digest reference it was resolved from and
+// the reference it was added by, which is an image index when the image is one of its platforms
+type manifestEntry struct {
+	Source     string        `json:"source"`
+	Reference  string        `json:"reference,omitempty"`
+	Descriptor v1.Descriptor `json:"descriptor"`
+}
+
+// matches reports whether the entry is the image referenced by ref, either by the reference it was added by or by
+// the digest reference it was resolved from
+func (e manifestEntry) matches(ref name.Reference) bool {
+	return e.Reference == ref.Name() || e.Source == ref.Name()
+}
+
+// pushImageIndex creates an image index referencing the provided platform-specific images, which must
+// already exist in a registry, and pushes it to each of the provided tags.
+// The platform of each entry is taken from the config of the referenced image.
+func (c *Client) pushImageIndex(ctx context.Context, images []name.Reference, tags []name.Reference) (v1.Hash, error) {
+	mediaType := types.OCIImageIndex
+	var entries []manifestEntry
+	for _, ref := range images {
+		resolved, err := c.resolveManifestEntries(ctx, ref, false)
+		if err != nil {
+			return v1.Hash{}, err
+		}
+		if resolved[0].Descriptor.MediaType == types.DockerManifestSchema2 {
+			mediaType = types.DockerManifestList
+		}
+		entries = append(entries, resolved...)
+	}
+
+	return c.writeImageIndex(ctx, mediaType, entries, nil, tags, false)
+}
+
+// resolveManifestEntries returns the image manifests referenced by ref. When ref points to an image index, every
+// image in the index is returned if all is true, otherwise only the image matching the current platform is returned.
+func (c *Client) resolveManifestEntries(ctx context.Context, ref name.Reference, all bool) ([]manifestEntry, error) {
+	desc, err := v1remote.Get(ref, c.remoteOptions(ctx)...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "fetching manifest of %s", style.Symbol(ref.Name()))
+	}
+
+	if desc.MediaType.IsIndex() {
+		index, err := desc.ImageIndex()
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading image index %s", style.Symbol(ref.Name()))
+		}
+		indexManifest, err := index.IndexManifest()
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading image index %s", style.Symbol(ref.Name()))
+		}
+
+		currentPlatform := v1.Platform{OS: runtime.GOOS, Architecture: runtime.GOARCH}
+		var entries []manifestEntry
+		for _, manifest := range indexManifest.Manifests {
+			if !manifest.MediaType.IsImage() {
+				continue
+			}
+			if !all && (manifest.Platform == nil || !manifest.Platform.Satisfies(currentPlatform)) {
+				continue
+			}
+			entries = append(entries, manifestEntry{
+				Source:     ref.Context().Digest(manifest.Digest.String()).Name(),
+				Reference:  ref.Name(),
+				Descriptor: manifest,
+			})
+			if !all {
+				break
+			}
+		}
+		if len(entries) == 0 {
+			return nil, errors.Errorf("no image for platform %s found in image index %s", style.Symbol(currentPlatform.String()), style.Symbol(ref.Name()))
+		}
+		return entries, nil
+	}
+
+	img, err := desc.Image()
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading image %s", style.Symbol(ref.Name()))
+	}
+	configFile, err := img.ConfigFile()
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading config of %s", style.Symbol(ref.Name()))
+	}
+
+	return []manifestEntry{{
+		Source:    ref.Context().Digest(desc.Digest.String()).Name(),
+		Reference: ref.Name(),
+		Descriptor: v1.Descriptor{
+			MediaType: desc.MediaType,
+			Size:      desc.Size,
+			Digest:    desc.Digest,
+			Platform: &v1.Platform{
+				OS:           configFile.OS,
+				Architecture: configFile.Architecture,
+				Variant:      configFile.Variant,
+				OSVersion:    configFile.OSVersion,
+			},
+		},
+	}}, nil
+}
+
+// writeImageIndex assembles an image index of the provided media type from the given entries and pushes it to each tag.
+// Images referenced by the entries are copied to the repository of each tag when they are not already present there.
+func (c *Client) writeImageIndex(ctx context.Context, mediaType types.MediaType, entries []manifestEntry, annotations map[string]string, tags []name.Reference, insecure bool) (v1.Hash, error) {
+	var addendums []mutate.IndexAddendum
+	for _, entry := range entries {
+		ref, err := parseManifestReference(entry.Source, insecure)
+		if err != nil {
+			return v1.Hash{}, err
+		}
+
+		desc, err := v1remote.Get(ref, c.remoteOptions(ctx)...)
+		if err != nil {
+			return v1.Hash{}, errors.Wrapf(err, "fetching manifest of %s", style.Symbol(ref.Name()))
+		}
+		img, err := desc.Image()
+		if err != nil {
+			return v1.Hash{}, errors.Wrapf(err, "reading image %s", style.Symbol(ref.Name()))
+		}
+
+		addendums = append(addendums, mutate.IndexAddendum{
+			Add:        img,
+			Descriptor: entry.Descriptor,
+		})
+	}
+
+	index := mutate.IndexMediaType(mutate.AppendManifests(empty.Index, addendums...), mediaType)
+	if len(annotations) > 0 {
+		index = mutate.Annotations(index, annotations).(v1.ImageIndex)
+	}
+
+	for _, tag := range tags {
+		if err := v1remote.WriteIndex(tag, index, c.remoteOptions(ctx)...); err != nil {
+			return v1.Hash{}, errors.Wrapf(err, "pushing image index to %s", style.Symbol(tag.Name()))
+		}
+	}
+
+	return index.Digest()
+}
+
+func (c *Client) remoteOptions(ctx context.Context) []v1remote.Option {
+	return []v1remote.Option{v1remote.WithAuthFromKeychain(c.keychain), v1remote.WithContext(ctx)}
+}
+
+func parseManifestReference(repoName string, insecure bool) (name.Reference, error) {
+	opts := []name.Option{name.WeakValidation}
+	if insecure {
+		opts = append(opts, name.Insecure)
+	}
+
+	ref, err := name.ParseReference(repoName, opts...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid image name %s", style.Symbol(repoName))
+	}
+	return ref, nil
+}
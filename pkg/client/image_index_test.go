@@ -0,0 +1,124 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	v1remote "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestImageIndex(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ImageIndex", testImageIndex, spec.Report(report.Terminal{}))
+}
+
+func testImageIndex(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject      *Client
+		server       *httptest.Server
+		registryHost string
+	)
+
+	it.Before(func() {
+		server = httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+		registryHost = strings.TrimPrefix(server.URL, "http://")
+
+		var outBuf bytes.Buffer
+		subject = &Client{
+			logger:   logging.NewLogWithWriters(&outBuf, &outBuf),
+			keychain: authn.DefaultKeychain,
+		}
+	})
+
+	it.After(func() {
+		server.Close()
+	})
+
+	pushImage := func(repoName, os, arch, variant string) name.Reference {
+		img, err := random.Image(1024, 1)
+		h.AssertNil(t, err)
+		configFile, err := img.ConfigFile()
+		h.AssertNil(t, err)
+		configFile.OS = os
+		configFile.Architecture = arch
+		configFile.Variant = variant
+		img, err = mutate.ConfigFile(img, configFile)
+		h.AssertNil(t, err)
+
+		ref, err := name.ParseReference(repoName, name.WeakValidation)
+		h.AssertNil(t, err)
+		h.AssertNil(t, v1remote.Write(ref, img))
+		return ref
+	}
+
+	when("#pushImageIndex", func() {
+		it("publishes an index referencing each platform image to every tag", func() {
+			amd64 := pushImage(registryHost+"/some/app:latest-linux-amd64", "linux", "amd64", "")
+			arm64 := pushImage(registryHost+"/some/app:latest-linux-arm64-v8", "linux", "arm64", "v8")
+
+			tag, err := name.NewTag(registryHost+"/some/app:latest", name.WeakValidation)
+			h.AssertNil(t, err)
+			additionalTag, err := name.NewTag(registryHost+"/some/app:v1", name.WeakValidation)
+			h.AssertNil(t, err)
+
+			digest, err := subject.pushImageIndex(context.TODO(), []name.Reference{amd64, arm64}, []name.Reference{tag, additionalTag})
+			h.AssertNil(t, err)
+
+			for _, ref := range []name.Reference{tag, additionalTag} {
+				index, err := v1remote.Index(ref)
+				h.AssertNil(t, err)
+
+				indexDigest, err := index.Digest()
+				h.AssertNil(t, err)
+				h.AssertEq(t, indexDigest, digest)
+
+				manifest, err := index.IndexManifest()
+				h.AssertNil(t, err)
+				h.AssertEq(t, manifest.MediaType, types.DockerManifestList)
+				h.AssertEq(t, len(manifest.Manifests), 2)
+				h.AssertEq(t, *manifest.Manifests[0].Platform, v1.Platform{OS: "linux", Architecture: "amd64"})
+				h.AssertEq(t, *manifest.Manifests[1].Platform, v1.Platform{OS: "linux", Architecture: "arm64", Variant: "v8"})
+			}
+		})
+
+		it("fails when a platform image does not exist", func() {
+			missing, err := name.ParseReference(registryHost+"/some/app:missing", name.WeakValidation)
+			h.AssertNil(t, err)
+
+			_, err = subject.pushImageIndex(context.TODO(), []name.Reference{missing}, []name.Reference{missing})
+			h.AssertError(t, err, "fetching manifest of")
+		})
+	})
+
+	when("#platformImageName", func() {
+		it("appends the platform to the tag", func() {
+			imageName, err := platformImageName("registry.io/some/app", dist.Target{OS: "linux", Arch: "arm64", ArchVariant: "v8"})
+			h.AssertNil(t, err)
+			h.AssertEq(t, imageName, "registry.io/some/app:latest-linux-arm64-v8")
+
+			imageName, err = platformImageName("registry.io/some/app:1.0", dist.Target{OS: "linux", Arch: "amd64"})
+			h.AssertNil(t, err)
+			h.AssertEq(t, imageName, "registry.io/some/app:1.0-linux-amd64")
+		})
+	})
+}
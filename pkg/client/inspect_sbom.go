@@ -0,0 +1,184 @@
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/sbom"
+	"github.com/buildpacks/pack/internal/style"
+)
+
+type InspectSBOMOptions struct {
+	// When true, the app image is read from the daemon, otherwise from its registry.
+	Daemon bool
+}
+
+// SBOMInfo holds the packages listed by the SBOM documents of an app image.
+type SBOMInfo struct {
+	Image string `json:"image" yaml:"image"`
+
+	// Packages of each buildpack, sorted by scope and buildpack ID.
+	Buildpacks []BuildpackSBOM `json:"buildpacks" yaml:"buildpacks"`
+}
+
+// BuildpackSBOM holds the packages a buildpack listed for an image, merged across the formats of its documents.
+type BuildpackSBOM struct {
+	ID string `json:"id" yaml:"id"`
+
+	// Scope is launch for the packages of the app image, or build for the packages used to build it.
+	Scope string `json:"scope" yaml:"scope"`
+
+	// Packages listed by the documents of the buildpack that are not specific to a layer.
+	Packages []sbom.Package `json:"packages,omitempty" yaml:"packages,omitempty"`
+
+	// Packages listed by the documents of each layer of the buildpack, sorted by layer name.
+	Layers []LayerSBOM `json:"layers,omitempty" yaml:"layers,omitempty"`
+}
+
+type LayerSBOM struct {
+	Name     string         `json:"name" yaml:"name"`
+	Packages []sbom.Package `json:"packages" yaml:"packages"`
+}
+
+// SBOMMatch is a package found by SBOMInfo.Search.
+type SBOMMatch struct {
+	Image     string       `json:"image" yaml:"image"`
+	Buildpack string       `json:"buildpack" yaml:"buildpack"`
+	Scope     string       `json:"scope" yaml:"scope"`
+	Layer     string       `json:"layer,omitempty" yaml:"layer,omitempty"`
+	Package   sbom.Package `json:"package" yaml:"package"`
+}
+
+// Search returns the packages whose name contains name and whose version starts with version.
+// See sbom.Package.Matches.
+func (s *SBOMInfo) Search(name, version string) []SBOMMatch {
+	var matches []SBOMMatch
+	for _, bp := range s.Buildpacks {
+		for _, pkg := range bp.Packages {
+			if pkg.Matches(name, version) {
+				matches = append(matches, SBOMMatch{Image: s.Image, Buildpack: bp.ID, Scope: bp.Scope, Package: pkg})
+			}
+		}
+		for _, layer := range bp.Layers {
+			for _, pkg := range layer.Packages {
+				if pkg.Matches(name, version) {
+					matches = append(matches, SBOMMatch{Image: s.Image, Buildpack: bp.ID, Scope: bp.Scope, Layer: layer.Name, Package: pkg})
+				}
+			}
+		}
+	}
+	return matches
+}
+
+// InspectSBOM downloads the SBOM layer of an image and reads the packages listed by the CycloneDX, SPDX and Syft
+// documents in it, merged per buildpack and per layer.
+func (c *Client) InspectSBOM(name string, options InspectSBOMOptions) (*SBOMInfo, error) {
+	sbomDir, err := os.MkdirTemp("", "pack.sbom.inspect.")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(sbomDir)
+
+	if err := c.DownloadSBOM(name, DownloadSBOMOptions{Daemon: options.Daemon, DestinationDir: sbomDir}); err != nil {
+		return nil, err
+	}
+
+	// buildpack IDs are escaped in the paths of the SBOM layer, the build metadata gives them back
+	buildpackIDs := map[string]string{}
+	if info, err := c.InspectImage(name, options.Daemon); err == nil && info != nil {
+		for _, bp := range info.Buildpacks {
+			buildpackIDs[escapeBuildpackID(bp.ID)] = bp.ID
+		}
+	}
+
+	type documentKey struct{ scope, buildpack, layer string }
+	documents := map[documentKey][][]sbom.Package{}
+	err = filepath.Walk(sbomDir, func(path string, fileInfo os.FileInfo, err error) error {
+		if err != nil || fileInfo.IsDir() {
+			return err
+		}
+		format := sbom.Format(path)
+		if format == "" {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(sbomDir, path)
+		if err != nil {
+			return err
+		}
+		// documents are found at sbom/<scope>/<buildpack>/[<layer>/]sbom.<format>.json
+		parts := strings.Split(filepath.ToSlash(relPath), "/")
+		for len(parts) > 0 && parts[0] != "sbom" {
+			parts = parts[1:]
+		}
+		if len(parts) < 4 || len(parts) > 5 {
+			c.logger.Debugf("Skipping SBOM document %s at an unexpected path", style.Symbol(relPath))
+			return nil
+		}
+
+		content, err := os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return err
+		}
+		packages, err := sbom.ParsePackages(format, content)
+		if err != nil {
+			return errors.Wrapf(err, "reading SBOM document %s", style.Symbol(relPath))
+		}
+
+		key := documentKey{scope: parts[1], buildpack: parts[2]}
+		if len(parts) == 5 {
+			key.layer = parts[3]
+		}
+		documents[key] = append(documents[key], packages)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	type buildpackKey struct{ scope, buildpack string }
+	buildpacks := map[buildpackKey]*BuildpackSBOM{}
+	for key, packageLists := range documents {
+		bpKey := buildpackKey{key.scope, key.buildpack}
+		bp, ok := buildpacks[bpKey]
+		if !ok {
+			id := key.buildpack
+			if unescaped, ok := buildpackIDs[id]; ok {
+				id = unescaped
+			}
+			bp = &BuildpackSBOM{ID: id, Scope: key.scope}
+			buildpacks[bpKey] = bp
+		}
+
+		packages := sbom.Merge(packageLists...)
+		if key.layer == "" {
+			bp.Packages = packages
+		} else {
+			bp.Layers = append(bp.Layers, LayerSBOM{Name: key.layer, Packages: packages})
+		}
+	}
+
+	info := &SBOMInfo{Image: name, Buildpacks: []BuildpackSBOM{}}
+	for _, bp := range buildpacks {
+		sort.Slice(bp.Layers, func(i, j int) bool {
+			return bp.Layers[i].Name < bp.Layers[j].Name
+		})
+		info.Buildpacks = append(info.Buildpacks, *bp)
+	}
+	sort.Slice(info.Buildpacks, func(i, j int) bool {
+		if info.Buildpacks[i].Scope != info.Buildpacks[j].Scope {
+			return info.Buildpacks[i].Scope > info.Buildpacks[j].Scope // launch before build
+		}
+		return info.Buildpacks[i].ID < info.Buildpacks[j].ID
+	})
+	return info, nil
+}
+
+// escapeBuildpackID escapes a buildpack ID the way the lifecycle does to name buildpack directories
+func escapeBuildpackID(id string) string {
+	return strings.ReplaceAll(id, "/", "_")
+}
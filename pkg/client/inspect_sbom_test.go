@@ -0,0 +1,156 @@
+package client
+
+import (
+	"archive/tar"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/sbom"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestInspectSBOM(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "InspectSBOM", testInspectSBOM, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testInspectSBOM(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject          *Client
+		mockImageFetcher *testmocks.MockImageFetcher
+		mockController   *gomock.Controller
+		tmpDir           string
+		out              bytes.Buffer
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockImageFetcher = testmocks.NewMockImageFetcher(mockController)
+
+		var err error
+		subject, err = NewClient(WithLogger(logging.NewLogWithWriters(&out, &out)), WithFetcher(mockImageFetcher))
+		h.AssertNil(t, err)
+
+		tmpDir = t.TempDir()
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	// newSBOMImage returns an app image with an SBOM layer holding files
+	newSBOMImage := func(files map[string]string) *testmocks.MockImage {
+		var buf bytes.Buffer
+		tw := tar.NewWriter(&buf)
+		for path, content := range files {
+			h.AssertNil(t, tw.WriteHeader(&tar.Header{Name: path, Mode: 0644, Size: int64(len(content))}))
+			_, err := tw.Write([]byte(content))
+			h.AssertNil(t, err)
+		}
+		h.AssertNil(t, tw.Close())
+
+		layerPath := filepath.Join(tmpDir, "sbom.tar")
+		h.AssertNil(t, os.WriteFile(layerPath, buf.Bytes(), 0600))
+		sum := sha256.Sum256(buf.Bytes())
+		diffID := "sha256:" + hex.EncodeToString(sum[:])
+
+		img := testmocks.NewImage("some/image", "", nil)
+		img.AddLayerWithDiffID(layerPath, diffID)
+		h.AssertNil(t, img.SetLabel("io.buildpacks.lifecycle.metadata", fmt.Sprintf(`{"sbom": {"sha": "%s"}}`, diffID)))
+		h.AssertNil(t, img.SetLabel("io.buildpacks.build.metadata", `{"buildpacks": [{"id": "some/java", "version": "1.0.0"}]}`))
+
+		mockImageFetcher.EXPECT().
+			Fetch(gomock.Any(), "some/image", image.FetchOptions{Daemon: true, PullPolicy: image.PullNever}).
+			Return(img, nil).
+			AnyTimes()
+		return img
+	}
+
+	when("#InspectSBOM", func() {
+		it.Before(func() {
+			newSBOMImage(map[string]string{
+				"layers/sbom/launch/some_java/jre/sbom.cdx.json": `{"components": [{"name": "openjdk-jre", "version": "17.0.6"}]}`,
+				"layers/sbom/launch/some_java/jre/sbom.syft.json": `{"artifacts": [
+					{"name": "openjdk-jre", "version": "17.0.6", "purl": "pkg:generic/openjdk-jre@17.0.6"}
+				]}`,
+				"layers/sbom/launch/some_java/app/sbom.spdx.json": `{"packages": [
+					{"name": "log4j-core", "versionInfo": "2.14.1"},
+					{"name": "spring-core", "versionInfo": "5.3.20"}
+				]}`,
+				"layers/sbom/launch/some_java/sbom.cdx.json":               `{"components": [{"name": "maven", "version": "3.9.0"}]}`,
+				"layers/sbom/build/some_java/sbom.cdx.json":                `{"components": [{"name": "gradle", "version": "8.0.0"}]}`,
+				"layers/sbom/launch/buildpacksio_lifecycle/sbom.cdx.json":  `{"components": [{"name": "lifecycle", "version": "0.17.0"}]}`,
+				"layers/sbom/launch/buildpacksio_lifecycle/launcher.toml":  `some = "metadata"`,
+				"layers/sbom/launch/buildpacksio_lifecycle/other/sbom.txt": `not an sbom`,
+			})
+		})
+
+		it("merges the documents per buildpack and per layer", func() {
+			info, err := subject.InspectSBOM("some/image", InspectSBOMOptions{Daemon: true})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, info.Image, "some/image")
+			h.AssertEq(t, info.Buildpacks, []BuildpackSBOM{
+				{
+					ID:       "buildpacksio_lifecycle",
+					Scope:    "launch",
+					Packages: []sbom.Package{{Name: "lifecycle", Version: "0.17.0"}},
+				},
+				{
+					ID:       "some/java",
+					Scope:    "launch",
+					Packages: []sbom.Package{{Name: "maven", Version: "3.9.0"}},
+					Layers: []LayerSBOM{
+						{Name: "app", Packages: []sbom.Package{{Name: "log4j-core", Version: "2.14.1"}, {Name: "spring-core", Version: "5.3.20"}}},
+						{Name: "jre", Packages: []sbom.Package{{Name: "openjdk-jre", Version: "17.0.6", PURL: "pkg:generic/openjdk-jre@17.0.6"}}},
+					},
+				},
+				{
+					ID:       "some/java",
+					Scope:    "build",
+					Packages: []sbom.Package{{Name: "gradle", Version: "8.0.0"}},
+				},
+			})
+		})
+
+		it("searches packages by name and version", func() {
+			info, err := subject.InspectSBOM("some/image", InspectSBOMOptions{Daemon: true})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, info.Search("log4j", "2.14"), []SBOMMatch{{
+				Image:     "some/image",
+				Buildpack: "some/java",
+				Scope:     "launch",
+				Layer:     "app",
+				Package:   sbom.Package{Name: "log4j-core", Version: "2.14.1"},
+			}})
+			h.AssertEq(t, len(info.Search("log4j", "2.15")), 0)
+			h.AssertEq(t, len(info.Search("", "")), 6)
+		})
+	})
+
+	when("an SBOM document is invalid", func() {
+		it("errors", func() {
+			newSBOMImage(map[string]string{
+				"layers/sbom/launch/some_java/sbom.cdx.json": `not json`,
+			})
+
+			_, err := subject.InspectSBOM("some/image", InspectSBOMOptions{Daemon: true})
+			h.AssertError(t, err, "reading SBOM document 'layers/sbom/launch/some_java/sbom.cdx.json'")
+		})
+	})
+}
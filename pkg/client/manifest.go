@@ -0,0 +1,450 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	v1remote "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+const (
+	// ManifestFormatOCI produces an OCI image index
+	ManifestFormatOCI = "oci"
+	// ManifestFormatDocker produces a Docker manifest list
+	ManifestFormatDocker = "docker"
+)
+
+// CreateManifestOptions defines the images to assemble into a new image index.
+type CreateManifestOptions struct {
+	// Name of the image index to create.
+	IndexRepoName string
+
+	// Images to reference from the image index.
+	RepoNames []string
+
+	// Media type of the image index, either 'oci' or 'docker'. Defaults to 'oci'.
+	Format string
+
+	// When an image is itself an image index, add every image it references
+	// instead of only the image for the current platform.
+	All bool
+
+	// Allow communicating with registries over HTTP.
+	Insecure bool
+
+	// Push the image index to the registry once created.
+	Publish bool
+}
+
+// AddManifestOptions defines an image to add to an existing image index.
+type AddManifestOptions struct {
+	// Name of the local image index.
+	IndexRepoName string
+
+	// Image to reference from the image index.
+	RepoName string
+
+	// When RepoName is itself an image index, add every image it references
+	// instead of only the image for the current platform.
+	All bool
+
+	// Allow communicating with registries over HTTP.
+	Insecure bool
+}
+
+// AnnotateManifestOptions defines platform and annotation overrides for an image in an image index.
+type AnnotateManifestOptions struct {
+	// Name of the local image index.
+	IndexRepoName string
+
+	// Image in the image index to annotate.
+	RepoName string
+
+	// Platform overrides, left unchanged when empty.
+	OS          string
+	OSArch      string
+	OSVariant   string
+	OSVersion   string
+	OSFeatures  []string
+	Features    []string
+	Annotations map[string]string
+
+	// Allow communicating with registries over HTTP.
+	Insecure bool
+}
+
+// RemoveManifestOptions defines images to remove from an image index.
+type RemoveManifestOptions struct {
+	// Name of the local image index.
+	IndexRepoName string
+
+	// Images to remove from the image index.
+	RepoNames []string
+
+	// Allow communicating with registries over HTTP.
+	Insecure bool
+}
+
+// PushManifestOptions defines how to push a local image index to a registry.
+type PushManifestOptions struct {
+	// Name of the local image index.
+	IndexRepoName string
+
+	// Media type of the pushed image index, either 'oci' or 'docker'. Defaults to the format the index was created with.
+	Format string
+
+	// Allow communicating with registries over HTTP.
+	Insecure bool
+
+	// Delete the local image index once pushed.
+	Purge bool
+}
+
+// manifestList is an image index under construction, persisted in the manifest directory until pushed
+type manifestList struct {
+	Name        string            `json:"name"`
+	MediaType   types.MediaType   `json:"mediaType"`
+	Manifests   []manifestEntry   `json:"manifests"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+func (l *manifestList) indexManifest() *v1.IndexManifest {
+	manifests := []v1.Descriptor{}
+	for _, entry := range l.Manifests {
+		manifests = append(manifests, entry.Descriptor)
+	}
+	return &v1.IndexManifest{
+		SchemaVersion: 2,
+		MediaType:     l.MediaType,
+		Manifests:     manifests,
+		Annotations:   l.Annotations,
+	}
+}
+
+func (l *manifestList) add(entries []manifestEntry) {
+	for _, entry := range entries {
+		l.removeDigest(entry.Descriptor.Digest)
+		l.Manifests = append(l.Manifests, entry)
+	}
+}
+
+func (l *manifestList) removeDigest(digest v1.Hash) {
+	var manifests []manifestEntry
+	for _, entry := range l.Manifests {
+		if entry.Descriptor.Digest != digest {
+			manifests = append(manifests, entry)
+		}
+	}
+	l.Manifests = manifests
+}
+
+// remove removes the entries added by ref, or resolved from it, and reports whether there were any
+func (l *manifestList) remove(ref name.Reference) bool {
+	var (
+		manifests []manifestEntry
+		removed   bool
+	)
+	for _, entry := range l.Manifests {
+		if entry.matches(ref) {
+			removed = true
+			continue
+		}
+		manifests = append(manifests, entry)
+	}
+	l.Manifests = manifests
+	return removed
+}
+
+// CreateManifest creates a local image index referencing the provided images.
+// The image index is stored in the manifest directory until it is pushed with PushManifest.
+func (c *Client) CreateManifest(ctx context.Context, opts CreateManifestOptions) error {
+	mediaType, err := indexMediaType(opts.Format)
+	if err != nil {
+		return err
+	}
+
+	indexRef, err := parseManifestReference(opts.IndexRepoName, opts.Insecure)
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(c.manifestPath(indexRef)); err == nil {
+		return errors.Errorf("image index %s already exists, use %s to modify it", style.Symbol(indexRef.Name()), style.Symbol("pack manifest add"))
+	}
+
+	list := &manifestList{Name: indexRef.Name(), MediaType: mediaType}
+	for _, repoName := range opts.RepoNames {
+		ref, err := parseManifestReference(repoName, opts.Insecure)
+		if err != nil {
+			return err
+		}
+
+		entries, err := c.resolveManifestEntries(ctx, ref, opts.All)
+		if err != nil {
+			return err
+		}
+		list.add(entries)
+	}
+
+	if err := c.saveManifestList(indexRef, list); err != nil {
+		return err
+	}
+	c.logger.Infof("Successfully created image index %s", style.Symbol(indexRef.Name()))
+
+	if opts.Publish {
+		return c.PushManifest(ctx, PushManifestOptions{IndexRepoName: opts.IndexRepoName, Insecure: opts.Insecure})
+	}
+	return nil
+}
+
+// AddManifest adds an image to a local image index.
+// If the image is already part of the index its entry is replaced.
+func (c *Client) AddManifest(ctx context.Context, opts AddManifestOptions) error {
+	indexRef, list, err := c.loadManifestList(opts.IndexRepoName, opts.Insecure)
+	if err != nil {
+		return err
+	}
+
+	ref, err := parseManifestReference(opts.RepoName, opts.Insecure)
+	if err != nil {
+		return err
+	}
+
+	entries, err := c.resolveManifestEntries(ctx, ref, opts.All)
+	if err != nil {
+		return err
+	}
+	list.add(entries)
+
+	if err := c.saveManifestList(indexRef, list); err != nil {
+		return err
+	}
+	c.logger.Infof("Successfully added image %s to image index %s", style.Symbol(ref.Name()), style.Symbol(indexRef.Name()))
+	return nil
+}
+
+// AnnotateManifest overrides the platform and annotations of an image in a local image index.
+func (c *Client) AnnotateManifest(ctx context.Context, opts AnnotateManifestOptions) error {
+	indexRef, list, err := c.loadManifestList(opts.IndexRepoName, opts.Insecure)
+	if err != nil {
+		return err
+	}
+
+	ref, err := parseManifestReference(opts.RepoName, opts.Insecure)
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for i := range list.Manifests {
+		if !list.Manifests[i].matches(ref) {
+			continue
+		}
+		desc := &list.Manifests[i].Descriptor
+		found = true
+
+		if desc.Platform == nil {
+			desc.Platform = &v1.Platform{}
+		}
+		if opts.OS != "" {
+			desc.Platform.OS = opts.OS
+		}
+		if opts.OSArch != "" {
+			desc.Platform.Architecture = opts.OSArch
+		}
+		if opts.OSVariant != "" {
+			desc.Platform.Variant = opts.OSVariant
+		}
+		if opts.OSVersion != "" {
+			desc.Platform.OSVersion = opts.OSVersion
+		}
+		if len(opts.OSFeatures) > 0 {
+			desc.Platform.OSFeatures = opts.OSFeatures
+		}
+		if len(opts.Features) > 0 {
+			desc.Platform.Features = opts.Features
+		}
+		if len(opts.Annotations) > 0 {
+			if desc.Annotations == nil {
+				desc.Annotations = map[string]string{}
+			}
+			for k, v := range opts.Annotations {
+				desc.Annotations[k] = v
+			}
+		}
+	}
+
+	if !found {
+		return errors.Errorf("image %s is not part of image index %s", style.Symbol(opts.RepoName), style.Symbol(indexRef.Name()))
+	}
+
+	if err := c.saveManifestList(indexRef, list); err != nil {
+		return err
+	}
+	c.logger.Infof("Successfully annotated image %s in image index %s", style.Symbol(opts.RepoName), style.Symbol(indexRef.Name()))
+	return nil
+}
+
+// RemoveManifest removes images from a local image index.
+func (c *Client) RemoveManifest(ctx context.Context, opts RemoveManifestOptions) error {
+	indexRef, list, err := c.loadManifestList(opts.IndexRepoName, opts.Insecure)
+	if err != nil {
+		return err
+	}
+
+	for _, repoName := range opts.RepoNames {
+		ref, err := parseManifestReference(repoName, opts.Insecure)
+		if err != nil {
+			return err
+		}
+
+		if !list.remove(ref) {
+			return errors.Errorf("image %s is not part of image index %s", style.Symbol(repoName), style.Symbol(indexRef.Name()))
+		}
+		c.logger.Infof("Successfully removed image %s from image index %s", style.Symbol(repoName), style.Symbol(indexRef.Name()))
+	}
+
+	return c.saveManifestList(indexRef, list)
+}
+
+// InspectManifest returns the manifest of an image index. The local image index is returned
+// when one exists, otherwise the image index is fetched from its registry.
+func (c *Client) InspectManifest(ctx context.Context, indexRepoName string) (*v1.IndexManifest, error) {
+	indexRef, err := parseManifestReference(indexRepoName, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, list, err := c.loadManifestList(indexRepoName, false); err == nil {
+		return list.indexManifest(), nil
+	}
+
+	desc, err := v1remote.Get(indexRef, c.remoteOptions(ctx)...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "image index %s does not exist locally or in its registry", style.Symbol(indexRef.Name()))
+	}
+	if !desc.MediaType.IsIndex() {
+		return nil, errors.Errorf("%s is not an image index", style.Symbol(indexRef.Name()))
+	}
+
+	index, err := desc.ImageIndex()
+	if err != nil {
+		return nil, err
+	}
+	return index.IndexManifest()
+}
+
+// PushManifest pushes a local image index, and any images it references which are missing from the
+// index repository, to the registry.
+func (c *Client) PushManifest(ctx context.Context, opts PushManifestOptions) error {
+	indexRef, list, err := c.loadManifestList(opts.IndexRepoName, opts.Insecure)
+	if err != nil {
+		return err
+	}
+
+	mediaType := list.MediaType
+	if opts.Format != "" {
+		if mediaType, err = indexMediaType(opts.Format); err != nil {
+			return err
+		}
+	}
+
+	digest, err := c.writeImageIndex(ctx, mediaType, list.Manifests, list.Annotations, []name.Reference{indexRef}, opts.Insecure)
+	if err != nil {
+		return err
+	}
+	c.logger.Infof("Successfully pushed image index %s", style.Symbol(indexRef.Context().Digest(digest.String()).Name()))
+
+	if opts.Purge {
+		return c.DeleteManifest(ctx, []string{opts.IndexRepoName})
+	}
+	return nil
+}
+
+// DeleteManifest deletes local image indexes. Image indexes already pushed to a registry are not affected.
+func (c *Client) DeleteManifest(ctx context.Context, indexRepoNames []string) error {
+	var errs []string
+	for _, indexRepoName := range indexRepoNames {
+		indexRef, err := parseManifestReference(indexRepoName, false)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+
+		if err := os.Remove(c.manifestPath(indexRef)); err != nil {
+			if os.IsNotExist(err) {
+				errs = append(errs, errors.Errorf("image index %s does not exist", style.Symbol(indexRef.Name())).Error())
+				continue
+			}
+			errs = append(errs, err.Error())
+			continue
+		}
+		c.logger.Infof("Successfully deleted image index %s", style.Symbol(indexRef.Name()))
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "\n"))
+	}
+	return nil
+}
+
+// manifestPath returns the file of the local image index, named by escaping the reference so that distinct
+// references never share a file
+func (c *Client) manifestPath(indexRef name.Reference) string {
+	return filepath.Join(c.manifestDir, url.QueryEscape(indexRef.Name())+".json")
+}
+
+func (c *Client) loadManifestList(indexRepoName string, insecure bool) (name.Reference, *manifestList, error) {
+	indexRef, err := parseManifestReference(indexRepoName, insecure)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	contents, err := os.ReadFile(c.manifestPath(indexRef))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil, errors.Errorf("image index %s does not exist, use %s to create it", style.Symbol(indexRef.Name()), style.Symbol("pack manifest create"))
+		}
+		return nil, nil, errors.Wrapf(err, "reading image index %s", style.Symbol(indexRef.Name()))
+	}
+
+	list := &manifestList{}
+	if err := json.Unmarshal(contents, list); err != nil {
+		return nil, nil, errors.Wrapf(err, "parsing image index %s", style.Symbol(indexRef.Name()))
+	}
+	return indexRef, list, nil
+}
+
+func (c *Client) saveManifestList(indexRef name.Reference, list *manifestList) error {
+	if err := os.MkdirAll(c.manifestDir, 0750); err != nil {
+		return errors.Wrap(err, "creating manifest directory")
+	}
+
+	contents, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(c.manifestPath(indexRef), contents, 0600)
+}
+
+func indexMediaType(format string) (types.MediaType, error) {
+	switch format {
+	case "", ManifestFormatOCI:
+		return types.OCIImageIndex, nil
+	case ManifestFormatDocker:
+		return types.DockerManifestList, nil
+	default:
+		return "", errors.Errorf("unsupported image index format %s, must be one of %s or %s", style.Symbol(format), style.Symbol(ManifestFormatOCI), style.Symbol(ManifestFormatDocker))
+	}
+}
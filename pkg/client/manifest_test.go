@@ -0,0 +1,374 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"net/http/httptest"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	v1remote "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestManifest(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Manifest", testManifest, spec.Report(report.Terminal{}))
+}
+
+func testManifest(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject      *Client
+		server       *httptest.Server
+		registryHost string
+		manifestDir  string
+		outBuf       bytes.Buffer
+		indexName    string
+		amd64Image   string
+		arm64Image   string
+		amd64Digest  v1.Hash
+		arm64Digest  v1.Hash
+	)
+
+	randomImage := func(os, arch string) v1.Image {
+		img, err := random.Image(1024, 1)
+		h.AssertNil(t, err)
+		configFile, err := img.ConfigFile()
+		h.AssertNil(t, err)
+		configFile.OS = os
+		configFile.Architecture = arch
+		img, err = mutate.ConfigFile(img, configFile)
+		h.AssertNil(t, err)
+		return img
+	}
+
+	pushImage := func(repoName string, img v1.Image) v1.Hash {
+		ref, err := name.ParseReference(repoName, name.WeakValidation)
+		h.AssertNil(t, err)
+		h.AssertNil(t, v1remote.Write(ref, img))
+		digest, err := img.Digest()
+		h.AssertNil(t, err)
+		return digest
+	}
+
+	it.Before(func() {
+		var err error
+		server = httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+		registryHost = strings.TrimPrefix(server.URL, "http://")
+
+		manifestDir, err = os.MkdirTemp("", "manifests")
+		h.AssertNil(t, err)
+
+		subject = &Client{
+			logger:      logging.NewLogWithWriters(&outBuf, &outBuf),
+			keychain:    authn.DefaultKeychain,
+			manifestDir: manifestDir,
+		}
+
+		indexName = registryHost + "/some/index:latest"
+		amd64Image = registryHost + "/some/app:amd64"
+		arm64Image = registryHost + "/other/app:arm64"
+		amd64Digest = pushImage(amd64Image, randomImage("linux", "amd64"))
+		arm64Digest = pushImage(arm64Image, randomImage("linux", "arm64"))
+	})
+
+	it.After(func() {
+		server.Close()
+		h.AssertNil(t, os.RemoveAll(manifestDir))
+	})
+
+	when("#CreateManifest", func() {
+		it("creates a local image index with the platform of each image", func() {
+			h.AssertNil(t, subject.CreateManifest(context.TODO(), CreateManifestOptions{
+				IndexRepoName: indexName,
+				RepoNames:     []string{amd64Image, arm64Image},
+			}))
+
+			manifest, err := subject.InspectManifest(context.TODO(), indexName)
+			h.AssertNil(t, err)
+			h.AssertEq(t, manifest.MediaType, types.OCIImageIndex)
+			h.AssertEq(t, len(manifest.Manifests), 2)
+			h.AssertEq(t, manifest.Manifests[0].Digest, amd64Digest)
+			h.AssertEq(t, manifest.Manifests[0].Platform.Architecture, "amd64")
+			h.AssertEq(t, manifest.Manifests[1].Digest, arm64Digest)
+			h.AssertEq(t, manifest.Manifests[1].Platform.Architecture, "arm64")
+		})
+
+		it("supports the docker format", func() {
+			h.AssertNil(t, subject.CreateManifest(context.TODO(), CreateManifestOptions{
+				IndexRepoName: indexName,
+				RepoNames:     []string{amd64Image},
+				Format:        ManifestFormatDocker,
+			}))
+
+			manifest, err := subject.InspectManifest(context.TODO(), indexName)
+			h.AssertNil(t, err)
+			h.AssertEq(t, manifest.MediaType, types.DockerManifestList)
+		})
+
+		it("fails for an unknown format", func() {
+			h.AssertError(t, subject.CreateManifest(context.TODO(), CreateManifestOptions{
+				IndexRepoName: indexName,
+				Format:        "some-format",
+			}), "unsupported image index format 'some-format'")
+		})
+
+		it("keeps image indexes whose names only differ by separators apart", func() {
+			h.AssertNil(t, subject.CreateManifest(context.TODO(), CreateManifestOptions{IndexRepoName: registryHost + "/some/index:v1"}))
+			h.AssertNil(t, subject.CreateManifest(context.TODO(), CreateManifestOptions{IndexRepoName: registryHost + "/some_index:v1"}))
+		})
+
+		it("fails when the image index already exists", func() {
+			h.AssertNil(t, subject.CreateManifest(context.TODO(), CreateManifestOptions{IndexRepoName: indexName}))
+			h.AssertError(t, subject.CreateManifest(context.TODO(), CreateManifestOptions{IndexRepoName: indexName}), "already exists")
+		})
+
+		when("an image is itself an image index", func() {
+			var sourceIndex string
+
+			it.Before(func() {
+				sourceIndex = registryHost + "/some/source-index:latest"
+				ref, err := name.ParseReference(sourceIndex, name.WeakValidation)
+				h.AssertNil(t, err)
+				index := mutate.AppendManifests(empty.Index,
+					mutate.IndexAddendum{Add: randomImage("linux", "s390x"), Descriptor: v1.Descriptor{Platform: &v1.Platform{OS: "linux", Architecture: "s390x"}}},
+					mutate.IndexAddendum{Add: randomImage(runtime.GOOS, runtime.GOARCH), Descriptor: v1.Descriptor{Platform: &v1.Platform{OS: runtime.GOOS, Architecture: runtime.GOARCH}}},
+				)
+				h.AssertNil(t, v1remote.WriteIndex(ref, index))
+			})
+
+			it("adds the image for the current platform", func() {
+				h.AssertNil(t, subject.CreateManifest(context.TODO(), CreateManifestOptions{
+					IndexRepoName: indexName,
+					RepoNames:     []string{sourceIndex},
+				}))
+
+				manifest, err := subject.InspectManifest(context.TODO(), indexName)
+				h.AssertNil(t, err)
+				h.AssertEq(t, len(manifest.Manifests), 1)
+				h.AssertEq(t, manifest.Manifests[0].Platform.Architecture, runtime.GOARCH)
+			})
+
+			it("adds every image when all is set", func() {
+				h.AssertNil(t, subject.CreateManifest(context.TODO(), CreateManifestOptions{
+					IndexRepoName: indexName,
+					RepoNames:     []string{sourceIndex},
+					All:           true,
+				}))
+
+				manifest, err := subject.InspectManifest(context.TODO(), indexName)
+				h.AssertNil(t, err)
+				h.AssertEq(t, len(manifest.Manifests), 2)
+			})
+
+			it("annotates and removes the image by the tag of the image index", func() {
+				h.AssertNil(t, subject.CreateManifest(context.TODO(), CreateManifestOptions{
+					IndexRepoName: indexName,
+					RepoNames:     []string{sourceIndex, amd64Image},
+				}))
+
+				h.AssertNil(t, subject.AnnotateManifest(context.TODO(), AnnotateManifestOptions{
+					IndexRepoName: indexName,
+					RepoName:      sourceIndex,
+					Annotations:   map[string]string{"some-key": "some-value"},
+				}))
+				manifest, err := subject.InspectManifest(context.TODO(), indexName)
+				h.AssertNil(t, err)
+				h.AssertEq(t, manifest.Manifests[0].Annotations, map[string]string{"some-key": "some-value"})
+
+				h.AssertNil(t, subject.RemoveManifest(context.TODO(), RemoveManifestOptions{
+					IndexRepoName: indexName,
+					RepoNames:     []string{sourceIndex},
+				}))
+				manifest, err = subject.InspectManifest(context.TODO(), indexName)
+				h.AssertNil(t, err)
+				h.AssertEq(t, len(manifest.Manifests), 1)
+				h.AssertEq(t, manifest.Manifests[0].Digest, amd64Digest)
+			})
+		})
+	})
+
+	when("the image index exists locally", func() {
+		it.Before(func() {
+			h.AssertNil(t, subject.CreateManifest(context.TODO(), CreateManifestOptions{
+				IndexRepoName: indexName,
+				RepoNames:     []string{amd64Image},
+			}))
+		})
+
+		when("#AddManifest", func() {
+			it("adds the image", func() {
+				h.AssertNil(t, subject.AddManifest(context.TODO(), AddManifestOptions{
+					IndexRepoName: indexName,
+					RepoName:      arm64Image,
+				}))
+
+				manifest, err := subject.InspectManifest(context.TODO(), indexName)
+				h.AssertNil(t, err)
+				h.AssertEq(t, len(manifest.Manifests), 2)
+				h.AssertEq(t, manifest.Manifests[1].Digest, arm64Digest)
+			})
+
+			it("does not duplicate an image already in the index", func() {
+				h.AssertNil(t, subject.AddManifest(context.TODO(), AddManifestOptions{
+					IndexRepoName: indexName,
+					RepoName:      amd64Image,
+				}))
+
+				manifest, err := subject.InspectManifest(context.TODO(), indexName)
+				h.AssertNil(t, err)
+				h.AssertEq(t, len(manifest.Manifests), 1)
+			})
+
+			it("fails when the image index does not exist", func() {
+				h.AssertError(t, subject.AddManifest(context.TODO(), AddManifestOptions{
+					IndexRepoName: registryHost + "/missing/index",
+					RepoName:      arm64Image,
+				}), "does not exist, use 'pack manifest create' to create it")
+			})
+		})
+
+		when("#AnnotateManifest", func() {
+			it("overrides the platform and adds annotations", func() {
+				h.AssertNil(t, subject.AnnotateManifest(context.TODO(), AnnotateManifestOptions{
+					IndexRepoName: indexName,
+					RepoName:      amd64Image,
+					OSArch:        "arm",
+					OSVariant:     "v7",
+					Annotations:   map[string]string{"some-key": "some-value"},
+				}))
+
+				manifest, err := subject.InspectManifest(context.TODO(), indexName)
+				h.AssertNil(t, err)
+				h.AssertEq(t, *manifest.Manifests[0].Platform, v1.Platform{OS: "linux", Architecture: "arm", Variant: "v7"})
+				h.AssertEq(t, manifest.Manifests[0].Annotations, map[string]string{"some-key": "some-value"})
+			})
+
+			it("fails when the image is not part of the index", func() {
+				h.AssertError(t, subject.AnnotateManifest(context.TODO(), AnnotateManifestOptions{
+					IndexRepoName: indexName,
+					RepoName:      arm64Image,
+					OS:            "linux",
+				}), "is not part of image index")
+			})
+		})
+
+		when("#RemoveManifest", func() {
+			it("removes the image by tag or digest", func() {
+				h.AssertNil(t, subject.AddManifest(context.TODO(), AddManifestOptions{IndexRepoName: indexName, RepoName: arm64Image}))
+
+				h.AssertNil(t, subject.RemoveManifest(context.TODO(), RemoveManifestOptions{
+					IndexRepoName: indexName,
+					RepoNames:     []string{amd64Image, registryHost + "/other/app@" + arm64Digest.String()},
+				}))
+
+				manifest, err := subject.InspectManifest(context.TODO(), indexName)
+				h.AssertNil(t, err)
+				h.AssertEq(t, len(manifest.Manifests), 0)
+			})
+
+			it("fails when the image is not part of the index", func() {
+				h.AssertError(t, subject.RemoveManifest(context.TODO(), RemoveManifestOptions{
+					IndexRepoName: indexName,
+					RepoNames:     []string{arm64Image},
+				}), "is not part of image index")
+			})
+		})
+
+		when("#PushManifest", func() {
+			it("pushes the image index and the images it references", func() {
+				h.AssertNil(t, subject.AddManifest(context.TODO(), AddManifestOptions{IndexRepoName: indexName, RepoName: arm64Image}))
+				h.AssertNil(t, subject.AnnotateManifest(context.TODO(), AnnotateManifestOptions{
+					IndexRepoName: indexName,
+					RepoName:      arm64Image,
+					OSVariant:     "v8",
+				}))
+
+				h.AssertNil(t, subject.PushManifest(context.TODO(), PushManifestOptions{IndexRepoName: indexName}))
+				h.AssertContains(t, outBuf.String(), "Successfully pushed image index")
+
+				ref, err := name.ParseReference(indexName, name.WeakValidation)
+				h.AssertNil(t, err)
+				index, err := v1remote.Index(ref)
+				h.AssertNil(t, err)
+				manifest, err := index.IndexManifest()
+				h.AssertNil(t, err)
+				h.AssertEq(t, manifest.MediaType, types.OCIImageIndex)
+				h.AssertEq(t, len(manifest.Manifests), 2)
+				h.AssertEq(t, manifest.Manifests[1].Platform.Variant, "v8")
+
+				_, err = v1remote.Image(ref.Context().Digest(arm64Digest.String()))
+				h.AssertNil(t, err)
+			})
+
+			it("overrides the format", func() {
+				h.AssertNil(t, subject.PushManifest(context.TODO(), PushManifestOptions{IndexRepoName: indexName, Format: ManifestFormatDocker}))
+
+				ref, err := name.ParseReference(indexName, name.WeakValidation)
+				h.AssertNil(t, err)
+				desc, err := v1remote.Head(ref)
+				h.AssertNil(t, err)
+				h.AssertEq(t, desc.MediaType, types.DockerManifestList)
+			})
+
+			it("deletes the local image index when purging", func() {
+				h.AssertNil(t, subject.PushManifest(context.TODO(), PushManifestOptions{IndexRepoName: indexName, Purge: true}))
+
+				_, _, err := subject.loadManifestList(indexName, false)
+				h.AssertError(t, err, "does not exist")
+			})
+		})
+
+		when("#DeleteManifest", func() {
+			it("deletes the local image index", func() {
+				h.AssertNil(t, subject.DeleteManifest(context.TODO(), []string{indexName}))
+
+				_, _, err := subject.loadManifestList(indexName, false)
+				h.AssertError(t, err, "does not exist")
+			})
+
+			it("fails for unknown image indexes", func() {
+				h.AssertError(t, subject.DeleteManifest(context.TODO(), []string{registryHost + "/missing/index"}), "does not exist")
+			})
+		})
+	})
+
+	when("#InspectManifest", func() {
+		it("fetches the image index from the registry when it does not exist locally", func() {
+			h.AssertNil(t, subject.CreateManifest(context.TODO(), CreateManifestOptions{
+				IndexRepoName: indexName,
+				RepoNames:     []string{amd64Image},
+				Publish:       true,
+			}))
+			h.AssertNil(t, subject.DeleteManifest(context.TODO(), []string{indexName}))
+
+			manifest, err := subject.InspectManifest(context.TODO(), indexName)
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(manifest.Manifests), 1)
+			h.AssertEq(t, manifest.Manifests[0].Digest, amd64Digest)
+		})
+
+		it("fails when the image is not an index", func() {
+			_, err := subject.InspectManifest(context.TODO(), amd64Image)
+			h.AssertError(t, err, "is not an image index")
+		})
+	})
+}
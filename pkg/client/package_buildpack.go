@@ -1,16 +1,25 @@
 package client
 
 import (
+	"archive/tar"
 	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
 
+	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/pkg/errors"
 
 	pubbldpkg "github.com/buildpacks/pack/buildpackage"
 	"github.com/buildpacks/pack/internal/layer"
 	"github.com/buildpacks/pack/internal/paths"
 	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/archive"
 	"github.com/buildpacks/pack/pkg/blob"
 	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/dist"
 	"github.com/buildpacks/pack/pkg/image"
 )
 
@@ -59,6 +68,11 @@ type PackageBuildpackOptions struct {
 
 	// List of buildpack images to exclude from the package been flatten.
 	FlattenExclude []string
+
+	// Platforms to package the buildpack for. Defaults to the OS of Config.Platform.
+	// When more than one target is provided, the package holds an image per target: published images are
+	// referenced by an image index named Name, and files hold every image in a single OCI layout.
+	Targets []dist.Target
 }
 
 // PackageBuildpack packages buildpack(s) into either an image or file.
@@ -67,18 +81,62 @@ func (c *Client) PackageBuildpack(ctx context.Context, opts PackageBuildpackOpti
 		opts.Format = FormatImage
 	}
 
-	if opts.Config.Platform.OS == "windows" && !c.experimental {
-		return NewExperimentError("Windows buildpackage support is currently experimental.")
+	targets := opts.Targets
+	if len(targets) == 0 {
+		targets = []dist.Target{{OS: opts.Config.Platform.OS}}
 	}
 
-	err := c.validateOSPlatform(ctx, opts.Config.Platform.OS, opts.Publish, opts.Format)
-	if err != nil {
-		return err
+	for _, target := range targets {
+		if target.OS == "windows" && !c.experimental {
+			return NewExperimentError("Windows buildpackage support is currently experimental.")
+		}
+	}
+
+	if len(targets) > 1 && opts.Format == FormatImage && !opts.Publish {
+		return errors.New("packaging for multiple platforms as an image requires publishing to a registry")
+	}
+
+	if len(targets) == 1 {
+		if err := c.validateOSPlatform(ctx, targets[0].OS, opts.Publish, opts.Format); err != nil {
+			return err
+		}
+	}
+
+	bpURI := opts.Config.Buildpack.URI
+	if bpURI == "" {
+		return errors.New("buildpack URI must be provided")
+	}
+
+	var builders []buildpack.TargetBuilder
+	for _, target := range targets {
+		packageBuilder, err := c.newPackageBuilder(ctx, opts, target, len(opts.Targets) > 0)
+		if err != nil {
+			return err
+		}
+		builders = append(builders, buildpack.TargetBuilder{Target: target, Builder: packageBuilder})
+	}
+
+	switch opts.Format {
+	case FormatFile:
+		return buildpack.SaveAsMultiPlatformFile(opts.Name, builders)
+	case FormatImage:
+		if len(builders) == 1 {
+			_, err := builders[0].Builder.SaveAsImageForTarget(opts.Name, opts.Publish, builders[0].Target)
+			return errors.Wrapf(err, "saving image")
+		}
+		return c.saveMultiPlatformPackage(ctx, opts.Name, builders)
+	default:
+		return errors.Errorf("unknown format: %s", style.Symbol(opts.Format))
 	}
+}
 
-	writerFactory, err := layer.NewWriterFactory(opts.Config.Platform.OS)
+// newPackageBuilder prepares a PackageBuilder holding the buildpack and its dependencies for target.
+// When forTarget is true, as it is whenever targets are provided, platform specific files of the buildpack are
+// taken from the directory for target in the buildpack source, e.g. linux/arm64/v8.
+func (c *Client) newPackageBuilder(ctx context.Context, opts PackageBuildpackOptions, target dist.Target, forTarget bool) (*buildpack.PackageBuilder, error) {
+	writerFactory, err := layer.NewWriterFactory(target.OS)
 	if err != nil {
-		return errors.Wrap(err, "creating layer writer factory")
+		return nil, errors.Wrap(err, "creating layer writer factory")
 	}
 
 	var packageBuilderOpts []buildpack.PackageBuilderOption
@@ -89,48 +147,156 @@ func (c *Client) PackageBuildpack(ctx context.Context, opts PackageBuildpackOpti
 	packageBuilder := buildpack.NewBuilder(c.imageFactory, packageBuilderOpts...)
 
 	bpURI := opts.Config.Buildpack.URI
-	if bpURI == "" {
-		return errors.New("buildpack URI must be provided")
-	}
-
 	mainBlob, err := c.downloadBuildpackFromURI(ctx, bpURI, opts.RelativeBaseDir)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	if forTarget {
+		if mainBlob, err = c.targetBlob(mainBlob, bpURI, opts.RelativeBaseDir, target); err != nil {
+			return nil, err
+		}
 	}
 
 	bp, err := buildpack.FromBuildpackRootBlob(mainBlob, writerFactory)
 	if err != nil {
-		return errors.Wrapf(err, "creating buildpack from %s", style.Symbol(bpURI))
+		return nil, errors.Wrapf(err, "creating buildpack from %s", style.Symbol(bpURI))
 	}
 
 	packageBuilder.SetBuildpack(bp)
 
 	for _, dep := range opts.Config.Dependencies {
-		mainBP, deps, err := c.buildpackDownloader.Download(ctx, dep.URI, buildpack.DownloadOptions{
+		downloadOpts := buildpack.DownloadOptions{
 			RegistryName:    opts.Registry,
 			RelativeBaseDir: opts.RelativeBaseDir,
-			ImageOS:         opts.Config.Platform.OS,
+			ImageOS:         target.OS,
 			ImageName:       dep.ImageName,
 			Daemon:          !opts.Publish,
 			PullPolicy:      opts.PullPolicy,
-		})
+		}
+		if forTarget {
+			downloadOpts.Target = &target
+		}
 
+		mainBP, deps, err := c.buildpackDownloader.Download(ctx, dep.URI, downloadOpts)
 		if err != nil {
-			return errors.Wrapf(err, "packaging dependencies (uri=%s,image=%s)", style.Symbol(dep.URI), style.Symbol(dep.ImageName))
+			return nil, errors.Wrapf(err, "packaging dependencies (uri=%s,image=%s)", style.Symbol(dep.URI), style.Symbol(dep.ImageName))
 		}
 
 		packageBuilder.AddDependencies(mainBP, deps)
 	}
 
-	switch opts.Format {
-	case FormatFile:
-		return packageBuilder.SaveAsFile(opts.Name, opts.Config.Platform.OS)
-	case FormatImage:
-		_, err = packageBuilder.SaveAsImage(opts.Name, opts.Publish, opts.Config.Platform.OS)
-		return errors.Wrapf(err, "saving image")
-	default:
-		return errors.Errorf("unknown format: %s", style.Symbol(opts.Format))
+	return packageBuilder, nil
+}
+
+// saveMultiPlatformPackage publishes a package image for each target, tagging each platform-specific image
+// with a platform suffix, and publishes an image index referencing all of them as repoName.
+func (c *Client) saveMultiPlatformPackage(ctx context.Context, repoName string, builders []buildpack.TargetBuilder) error {
+	ref, err := c.parseTagReference(repoName)
+	if err != nil {
+		return errors.Wrapf(err, "invalid package name '%s'", repoName)
+	}
+
+	var platformImages []name.Reference
+	for _, tb := range builders {
+		platformName, err := platformImageName(repoName, tb.Target)
+		if err != nil {
+			return errors.Wrapf(err, "invalid package name '%s'", repoName)
+		}
+
+		c.logger.Infof("Packaging for platform %s", style.Symbol(tb.Target.Platform()))
+		if _, err := tb.Builder.SaveAsImageForTarget(platformName, true, tb.Target); err != nil {
+			return errors.Wrapf(err, "saving image for platform %s", style.Symbol(tb.Target.Platform()))
+		}
+
+		platformRef, err := name.ParseReference(platformName, name.WeakValidation)
+		if err != nil {
+			return err
+		}
+		platformImages = append(platformImages, platformRef)
+	}
+
+	digest, err := c.pushImageIndex(ctx, platformImages, []name.Reference{ref})
+	if err != nil {
+		return errors.Wrap(err, "publishing image index")
+	}
+
+	c.logger.Infof("Published image index %s", style.Symbol(fmt.Sprintf("%s@%s", ref.Name(), digest)))
+	return nil
+}
+
+// targetBlob returns the blob of the buildpack for target. When the buildpack source is a directory containing
+// a '<os>/<arch>[/<variant>]' directory for target, the blob holds the buildpack.toml of the buildpack root along
+// with the contents of that directory, otherwise the buildpack is considered platform independent.
+func (c *Client) targetBlob(mainBlob blob.Blob, uri, relativeBaseDir string, target dist.Target) (blob.Blob, error) {
+	absURI, err := paths.FilePathToURI(uri, relativeBaseDir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "making absolute: %s", style.Symbol(uri))
+	}
+	if !paths.IsURI(absURI) || !strings.HasPrefix(absURI, "file://") {
+		return mainBlob, nil
+	}
+
+	rootDir, err := paths.URIToFilePath(absURI)
+	if err != nil {
+		return nil, err
+	}
+	if isDir, err := paths.IsDir(rootDir); err != nil || !isDir {
+		return mainBlob, nil
+	}
+
+	targetDir := filepath.Join(rootDir, target.OS)
+	for _, part := range []string{target.Arch, target.ArchVariant} {
+		if part == "" {
+			break
+		}
+		targetDir = filepath.Join(targetDir, part)
+	}
+	if isDir, err := paths.IsDir(targetDir); err != nil || !isDir {
+		c.logger.Debugf("No directory found for platform %s, packaging %s as is", style.Symbol(target.Platform()), style.Symbol(uri))
+		return mainBlob, nil
 	}
+
+	c.logger.Debugf("Packaging %s for platform %s from %s", style.Symbol(uri), style.Symbol(target.Platform()), style.Symbol(targetDir))
+	return &targetDirBlob{rootDir: rootDir, targetDir: targetDir}, nil
+}
+
+// targetDirBlob is a buildpack blob assembled from the buildpack.toml of a buildpack root directory and the
+// platform specific files of a target directory
+type targetDirBlob struct {
+	rootDir   string
+	targetDir string
+}
+
+func (b *targetDirBlob) Open() (io.ReadCloser, error) {
+	return archive.GenerateTar(func(tw archive.TarWriter) error {
+		if _, err := os.Stat(filepath.Join(b.targetDir, "buildpack.toml")); err != nil {
+			descriptor := filepath.Join(b.rootDir, "buildpack.toml")
+			fi, err := os.Stat(descriptor)
+			if err != nil {
+				return errors.Wrapf(err, "reading %s", style.Symbol(descriptor))
+			}
+			contents, err := os.ReadFile(filepath.Clean(descriptor))
+			if err != nil {
+				return errors.Wrapf(err, "reading %s", style.Symbol(descriptor))
+			}
+
+			header, err := tar.FileInfoHeader(fi, "")
+			if err != nil {
+				return err
+			}
+			header.Name = "buildpack.toml"
+			archive.NormalizeHeader(header, true)
+			if err := tw.WriteHeader(header); err != nil {
+				return err
+			}
+			if _, err := tw.Write(contents); err != nil {
+				return err
+			}
+		}
+
+		return archive.WriteDirToTar(tw, b.targetDir, ".", 0, 0, -1, true, false, nil)
+	}), nil
 }
 
 func (c *Client) downloadBuildpackFromURI(ctx context.Context, uri, relativeBaseDir string) (blob.Blob, error) {
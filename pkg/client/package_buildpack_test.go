@@ -901,6 +901,109 @@ func testPackageBuildpack(t *testing.T, when spec.G, it spec.S) {
 		})
 	})
 
+	when("targets are provided", func() {
+		var bpDir string
+
+		it.Before(func() {
+			var err error
+			bpDir, err = os.MkdirTemp("", "package-buildpack-targets")
+			h.AssertNil(t, err)
+
+			h.AssertNil(t, os.WriteFile(filepath.Join(bpDir, "buildpack.toml"), []byte(`
+api = "0.3"
+
+[buildpack]
+id = "bp.one"
+version = "1.0.0"
+
+[[stacks]]
+id = "*"
+`), 0644))
+			for _, arch := range []string{"amd64", "arm64"} {
+				binDir := filepath.Join(bpDir, "linux", arch, "bin")
+				h.AssertNil(t, os.MkdirAll(binDir, 0755))
+				h.AssertNil(t, os.WriteFile(filepath.Join(binDir, "detect"), []byte("detect-"+arch), 0755))
+				h.AssertNil(t, os.WriteFile(filepath.Join(binDir, "build"), []byte("build-"+arch), 0755))
+			}
+
+			bpURI, err := paths.FilePathToURI(bpDir, "")
+			h.AssertNil(t, err)
+			mockDownloader.EXPECT().Download(gomock.Any(), bpURI).Return(blob.NewBlob(bpDir), nil).AnyTimes()
+		})
+
+		it.After(func() {
+			h.AssertNil(t, os.RemoveAll(bpDir))
+		})
+
+		it("fails to package as an image without publishing", func() {
+			err := subject.PackageBuildpack(context.TODO(), client.PackageBuildpackOptions{
+				Name:    "some/package",
+				Format:  client.FormatImage,
+				Config:  pubbldpkg.Config{Buildpack: dist.BuildpackURI{URI: bpDir}},
+				Targets: []dist.Target{{OS: "linux", Arch: "amd64"}, {OS: "linux", Arch: "arm64"}},
+			})
+			h.AssertError(t, err, "packaging for multiple platforms as an image requires publishing to a registry")
+		})
+
+		it("packages the binaries of each target into a single file", func() {
+			packagePath := filepath.Join(bpDir, "package.cnb")
+			h.AssertNil(t, subject.PackageBuildpack(context.TODO(), client.PackageBuildpackOptions{
+				Name:    packagePath,
+				Format:  client.FormatFile,
+				Config:  pubbldpkg.Config{Buildpack: dist.BuildpackURI{URI: bpDir}},
+				Targets: []dist.Target{{OS: "linux", Arch: "amd64"}, {OS: "linux", Arch: "arm64"}},
+			}))
+
+			packageURI, err := paths.FilePathToURI(packagePath, "")
+			h.AssertNil(t, err)
+			mockDownloader.EXPECT().Download(gomock.Any(), packageURI).Return(blob.NewBlob(packagePath), nil).AnyTimes()
+
+			downloader := buildpack.NewDownloader(logging.NewLogWithWriters(&out, &out), mockImageFetcher, mockDownloader, nil)
+			for _, arch := range []string{"amd64", "arm64"} {
+				mainBP, _, err := downloader.Download(context.TODO(), packageURI, buildpack.DownloadOptions{
+					ImageOS: "linux",
+					Target:  &dist.Target{OS: "linux", Arch: arch},
+				})
+				h.AssertNil(t, err)
+
+				rc, err := mainBP.Open()
+				h.AssertNil(t, err)
+				_, contents, err := archive.ReadTarEntry(rc, "/cnb/buildpacks/bp.one/1.0.0/bin/detect")
+				h.AssertNil(t, rc.Close())
+				h.AssertNil(t, err)
+				h.AssertEq(t, string(contents), "detect-"+arch)
+			}
+		})
+
+		it("packages the binaries of a single target", func() {
+			packagePath := filepath.Join(bpDir, "package.cnb")
+			h.AssertNil(t, subject.PackageBuildpack(context.TODO(), client.PackageBuildpackOptions{
+				Name:    packagePath,
+				Format:  client.FormatFile,
+				Config:  pubbldpkg.Config{Buildpack: dist.BuildpackURI{URI: bpDir}},
+				Targets: []dist.Target{{OS: "linux", Arch: "arm64"}},
+			}))
+
+			packageURI, err := paths.FilePathToURI(packagePath, "")
+			h.AssertNil(t, err)
+			mockDownloader.EXPECT().Download(gomock.Any(), packageURI).Return(blob.NewBlob(packagePath), nil).AnyTimes()
+
+			downloader := buildpack.NewDownloader(logging.NewLogWithWriters(&out, &out), mockImageFetcher, mockDownloader, nil)
+			mainBP, _, err := downloader.Download(context.TODO(), packageURI, buildpack.DownloadOptions{
+				ImageOS: "linux",
+				Target:  &dist.Target{OS: "linux", Arch: "arm64"},
+			})
+			h.AssertNil(t, err)
+
+			rc, err := mainBP.Open()
+			h.AssertNil(t, err)
+			_, contents, err := archive.ReadTarEntry(rc, "/cnb/buildpacks/bp.one/1.0.0/bin/detect")
+			h.AssertNil(t, rc.Close())
+			h.AssertNil(t, err)
+			h.AssertEq(t, string(contents), "detect-arm64")
+		})
+	})
+
 	when("unknown format is provided", func() {
 		it("should error", func() {
 			mockDockerClient.EXPECT().Info(context.TODO()).Return(types.Info{OSType: "linux"}, nil).AnyTimes()
@@ -6,10 +6,12 @@ import (
 	"path/filepath"
 
 	"github.com/BurntSushi/toml"
+	"github.com/buildpacks/imgutil"
 	"github.com/buildpacks/lifecycle"
 	"github.com/buildpacks/lifecycle/platform"
 	"github.com/buildpacks/lifecycle/platform/files"
 	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/buildpacks/pack/internal/build"
 	"github.com/buildpacks/pack/internal/builder"
@@ -47,25 +49,157 @@ type RebaseOptions struct {
 	Force bool
 }
 
+// defaultRebaseConcurrency is the number of app images rebased at once by RebaseBatch when no concurrency is set
+const defaultRebaseConcurrency = 4
+
+// RebaseBatchOptions is a configuration struct that controls the rebase of several app images.
+type RebaseBatchOptions struct {
+	// Options applied to the rebase of each app image. RepoName and ReportDestinationDir are ignored.
+	RebaseOptions
+
+	// Names of the images we wish to rebase.
+	Images []string
+
+	// Maximum number of app images rebased at once. Defaults to 4.
+	Concurrency int
+}
+
+// RebaseResult describes the rebase of an app image.
+type RebaseResult struct {
+	// Name of the app image.
+	Image string
+
+	// Run image the app image was based on before the rebase, and after it.
+	PreviousRunImage string
+	RunImage         string
+
+	// Skipped is true when the app image was already based on the run image and was not rebased.
+	Skipped bool
+
+	// Err is the reason the rebase of the app image failed, if it did.
+	Err error
+}
+
 // Rebase updates the run image layers in an app image.
 // This operation mutates the image specified in opts.
 func (c *Client) Rebase(ctx context.Context, opts RebaseOptions) error {
+	_, err := c.rebase(ctx, opts, false)
+	return err
+}
+
+// RebaseBatch rebases several app images in parallel, skipping the app images already based on the latest run image.
+// The failure to rebase an app image does not stop the rebase of the others: it is recorded in the result of the app
+// image, and results are returned in the order of opts.Images.
+func (c *Client) RebaseBatch(ctx context.Context, opts RebaseBatchOptions) []RebaseResult {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultRebaseConcurrency
+	}
+
+	results := make([]RebaseResult, len(opts.Images))
+	var group errgroup.Group
+	group.SetLimit(concurrency)
+	for i, imageName := range opts.Images {
+		i, imageName := i, imageName
+		group.Go(func() error {
+			imageOpts := opts.RebaseOptions
+			imageOpts.RepoName = imageName
+			imageOpts.ReportDestinationDir = ""
+
+			result, err := c.rebase(ctx, imageOpts, true)
+			result.Err = err
+			results[i] = result
+			return nil
+		})
+	}
+	_ = group.Wait()
+
+	return results
+}
+
+// rebase rebases the app image of opts. When skipUpToDate is true, app images already based on the top layer of the
+// run image are left untouched.
+func (c *Client) rebase(ctx context.Context, opts RebaseOptions, skipUpToDate bool) (RebaseResult, error) {
+	result := RebaseResult{Image: opts.RepoName}
+
+	appImage, baseImage, md, err := c.fetchRebaseImages(ctx, opts)
+	if err != nil {
+		return result, err
+	}
+	result.PreviousRunImage = runImageReference(md.RunImage)
+
+	if skipUpToDate {
+		topLayer, err := baseImage.TopLayer()
+		if err != nil {
+			return result, errors.Wrapf(err, "reading top layer of run image %s", style.Symbol(baseImage.Name()))
+		}
+		if topLayer == md.RunImage.TopLayer {
+			c.logger.Infof("Image %s is already based on run image %s", style.Symbol(appImage.Name()), style.Symbol(baseImage.Name()))
+			result.RunImage = result.PreviousRunImage
+			result.Skipped = true
+			return result, nil
+		}
+	}
+
+	c.logger.Infof("Rebasing %s on run image %s", style.Symbol(appImage.Name()), style.Symbol(baseImage.Name()))
+	rebaser := &lifecycle.Rebaser{Logger: c.logger, PlatformAPI: build.SupportedPlatformAPIVersions.Latest(), Force: opts.Force}
+	report, err := rebaser.Rebase(appImage, baseImage, appImage.Name(), nil)
+	if err != nil {
+		return result, err
+	}
+
+	appImageIdentifier, err := appImage.Identifier()
+	if err != nil {
+		return result, err
+	}
+
+	var rebasedMD files.LayersMetadataCompat
+	if _, err := dist.GetLabel(appImage, platform.LifecycleMetadataLabel, &rebasedMD); err != nil {
+		return result, err
+	}
+	result.RunImage = runImageReference(rebasedMD.RunImage)
+
+	c.logger.Infof("Rebased Image: %s", style.Symbol(appImageIdentifier.String()))
+
+	if opts.ReportDestinationDir != "" {
+		reportPath := filepath.Join(opts.ReportDestinationDir, "report.toml")
+		reportFile, err := os.OpenFile(reportPath, os.O_RDWR|os.O_CREATE, 0644)
+		if err != nil {
+			c.logger.Warnf("unable to open %s for writing rebase report", reportPath)
+			return result, err
+		}
+
+		defer reportFile.Close()
+		err = toml.NewEncoder(reportFile).Encode(report)
+		if err != nil {
+			c.logger.Warnf("unable to write rebase report to %s", reportPath)
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// fetchRebaseImages fetches the app image of opts and the run image it would be rebased on, along with the lifecycle
+// metadata of the app image
+func (c *Client) fetchRebaseImages(ctx context.Context, opts RebaseOptions) (imgutil.Image, imgutil.Image, files.LayersMetadataCompat, error) {
+	var md files.LayersMetadataCompat
+
 	imageRef, err := c.parseTagReference(opts.RepoName)
 	if err != nil {
-		return errors.Wrapf(err, "invalid image name '%s'", opts.RepoName)
+		return nil, nil, md, errors.Wrapf(err, "invalid image name '%s'", opts.RepoName)
 	}
 
 	appImage, err := c.imageFetcher.Fetch(ctx, opts.RepoName, image.FetchOptions{Daemon: !opts.Publish, PullPolicy: opts.PullPolicy})
 	if err != nil {
-		return err
+		return nil, nil, md, err
 	}
 
-	var md files.LayersMetadataCompat
 	if ok, err := dist.GetLabel(appImage, platform.LifecycleMetadataLabel, &md); err != nil {
-		return err
+		return nil, nil, md, err
 	} else if !ok {
-		return errors.Errorf("could not find label %s on image", style.Symbol(platform.LifecycleMetadataLabel))
+		return nil, nil, md, errors.Errorf("could not find label %s on image", style.Symbol(platform.LifecycleMetadataLabel))
 	}
+
 	var runImageMD builder.RunImageMetadata
 	if md.RunImage.Image != "" {
 		runImageMD = builder.RunImageMetadata{
@@ -87,42 +221,21 @@ func (c *Client) Rebase(ctx context.Context, opts RebaseOptions) error {
 		opts.Publish)
 
 	if runImageName == "" {
-		return errors.New("run image must be specified")
+		return nil, nil, md, errors.New("run image must be specified")
 	}
 
 	baseImage, err := c.imageFetcher.Fetch(ctx, runImageName, image.FetchOptions{Daemon: !opts.Publish, PullPolicy: opts.PullPolicy})
 	if err != nil {
-		return err
-	}
-
-	c.logger.Infof("Rebasing %s on run image %s", style.Symbol(appImage.Name()), style.Symbol(baseImage.Name()))
-	rebaser := &lifecycle.Rebaser{Logger: c.logger, PlatformAPI: build.SupportedPlatformAPIVersions.Latest(), Force: opts.Force}
-	report, err := rebaser.Rebase(appImage, baseImage, appImage.Name(), nil)
-	if err != nil {
-		return err
-	}
-
-	appImageIdentifier, err := appImage.Identifier()
-	if err != nil {
-		return err
+		return nil, nil, md, err
 	}
+	return appImage, baseImage, md, nil
+}
 
-	c.logger.Infof("Rebased Image: %s", style.Symbol(appImageIdentifier.String()))
-
-	if opts.ReportDestinationDir != "" {
-		reportPath := filepath.Join(opts.ReportDestinationDir, "report.toml")
-		reportFile, err := os.OpenFile(reportPath, os.O_RDWR|os.O_CREATE, 0644)
-		if err != nil {
-			c.logger.Warnf("unable to open %s for writing rebase report", reportPath)
-			return err
-		}
-
-		defer reportFile.Close()
-		err = toml.NewEncoder(reportFile).Encode(report)
-		if err != nil {
-			c.logger.Warnf("unable to write rebase report to %s", reportPath)
-			return err
-		}
+// runImageReference returns the reference to the run image recorded in the lifecycle metadata of an app image,
+// falling back to the top layer of the run image for app images that predate run image references
+func runImageReference(runImage files.RunImageForRebase) string {
+	if runImage.Reference != "" {
+		return runImage.Reference
 	}
-	return nil
+	return runImage.TopLayer
 }
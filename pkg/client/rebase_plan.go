@@ -0,0 +1,226 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/buildpacks/imgutil"
+	"github.com/buildpacks/lifecycle/api"
+	"github.com/buildpacks/lifecycle/platform"
+	"github.com/buildpacks/lifecycle/platform/files"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/stack"
+	"github.com/buildpacks/pack/internal/stringset"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/dist"
+)
+
+// Names of the checks run by PlanRebase.
+const (
+	RebaseCheckStackID   = "stack-id"
+	RebaseCheckMixins    = "mixins"
+	RebaseCheckRebasable = "rebasable"
+	RebaseCheckTarget    = "target"
+	RebaseCheckRunImage  = "run-image"
+)
+
+// RebasePlan describes what the rebase of an app image would do, as computed by PlanRebase.
+type RebasePlan struct {
+	Image string `json:"image" yaml:"image"`
+
+	// Name of the run image the app image would be rebased on, resolved from the run image option, the app image
+	// metadata and the mirrors.
+	RunImage string `json:"runImage" yaml:"runImage"`
+
+	// Layers swapped by the rebase: the run image layers of the app image, up to PreviousTopLayer, are replaced by the
+	// layers of the run image, up to TopLayer. The references are the ones recorded in the app image metadata before
+	// and after the rebase.
+	PreviousRunImage  string `json:"previousRunImage" yaml:"previousRunImage"`
+	PreviousTopLayer  string `json:"previousTopLayer" yaml:"previousTopLayer"`
+	RunImageReference string `json:"runImageReference" yaml:"runImageReference"`
+	TopLayer          string `json:"topLayer" yaml:"topLayer"`
+
+	// UpToDate is true when the app image is already based on the run image, in which case no layers would be swapped.
+	UpToDate bool `json:"upToDate" yaml:"upToDate"`
+
+	// Checks run by the lifecycle before rebasing the app image.
+	Checks []RebaseCheck `json:"checks" yaml:"checks"`
+}
+
+// RebaseCheck is the outcome of a compatibility check between an app image and the run image it would be rebased on.
+type RebaseCheck struct {
+	Name   string `json:"name" yaml:"name"`
+	Passed bool   `json:"passed" yaml:"passed"`
+
+	// Forced is true when the check failed but would be ignored because of the force option.
+	Forced bool `json:"forced,omitempty" yaml:"forced,omitempty"`
+
+	// Message explains why the check failed.
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
+// Safe returns true when every check passed, or failed but would be ignored because of the force option.
+func (p *RebasePlan) Safe() bool {
+	for _, check := range p.Checks {
+		if !check.Passed && !check.Forced {
+			return false
+		}
+	}
+	return true
+}
+
+// PlanRebase resolves the run image an app image would be rebased on and runs the checks the lifecycle runs before
+// a rebase, without rebasing or writing anything. Failed checks are recorded in the plan rather than returned as errors.
+func (c *Client) PlanRebase(ctx context.Context, opts RebaseOptions) (*RebasePlan, error) {
+	appImage, baseImage, md, err := c.fetchRebaseImages(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	topLayer, err := baseImage.TopLayer()
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading top layer of run image %s", style.Symbol(baseImage.Name()))
+	}
+
+	plan := &RebasePlan{
+		Image:            opts.RepoName,
+		RunImage:         baseImage.Name(),
+		PreviousRunImage: runImageReference(md.RunImage),
+		PreviousTopLayer: md.RunImage.TopLayer,
+		TopLayer:         topLayer,
+		UpToDate:         topLayer == md.RunImage.TopLayer,
+	}
+	identifier, err := baseImage.Identifier()
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading identifier of run image %s", style.Symbol(baseImage.Name()))
+	}
+	plan.RunImageReference = identifier.String()
+
+	appPlatformAPI, err := appImage.Env(platform.EnvPlatformAPI)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading platform API of app image")
+	}
+	if appPlatformAPI == "" || api.MustParse(appPlatformAPI).LessThan("0.12") {
+		plan.Checks = append(plan.Checks, checkStackID(appImage, baseImage), checkMixins(appImage, baseImage))
+	} else {
+		plan.Checks = append(plan.Checks, checkRebasable(appImage, opts.Force), checkTarget(appImage, baseImage, opts.Force))
+	}
+	plan.Checks = append(plan.Checks, checkRunImageName(md, baseImage.Name(), opts.Force))
+
+	return plan, nil
+}
+
+func checkStackID(appImage, baseImage imgutil.Image) RebaseCheck {
+	check := RebaseCheck{Name: RebaseCheckStackID}
+
+	appStackID, err := appImage.Label(platform.StackIDLabel)
+	if err != nil {
+		check.Message = fmt.Sprintf("reading stack of app image: %s", err)
+		return check
+	}
+	runStackID, err := baseImage.Label(platform.StackIDLabel)
+	if err != nil {
+		check.Message = fmt.Sprintf("reading stack of run image: %s", err)
+		return check
+	}
+
+	switch {
+	case appStackID == "":
+		check.Message = "stack not defined on app image"
+	case runStackID == "":
+		check.Message = "stack not defined on run image"
+	case appStackID != runStackID:
+		check.Message = fmt.Sprintf("run image stack %s is not compatible with app image stack %s", style.Symbol(runStackID), style.Symbol(appStackID))
+	default:
+		check.Passed = true
+	}
+	return check
+}
+
+func checkMixins(appImage, baseImage imgutil.Image) RebaseCheck {
+	check := RebaseCheck{Name: RebaseCheckMixins}
+
+	var appMixins, runMixins []string
+	if _, err := dist.GetLabel(appImage, stack.MixinsLabel, &appMixins); err != nil {
+		check.Message = fmt.Sprintf("reading mixins of app image: %s", err)
+		return check
+	}
+	if _, err := dist.GetLabel(baseImage, stack.MixinsLabel, &runMixins); err != nil {
+		check.Message = fmt.Sprintf("reading mixins of run image: %s", err)
+		return check
+	}
+
+	_, missing, _ := stringset.Compare(removeStagePrefixes(runMixins), removeStagePrefixes(appMixins))
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		check.Message = fmt.Sprintf("run image is missing required mixin(s): %s", strings.Join(missing, ", "))
+		return check
+	}
+	check.Passed = true
+	return check
+}
+
+func checkRebasable(appImage imgutil.Image, force bool) RebaseCheck {
+	check := RebaseCheck{Name: RebaseCheckRebasable}
+
+	rebasable, err := appImage.Label(platform.RebasableLabel)
+	if err != nil {
+		check.Message = fmt.Sprintf("reading rebasable label of app image: %s", err)
+		return check
+	}
+	if rebasable == "false" {
+		check.Message = "app image is not marked as rebasable"
+		check.Forced = force
+		return check
+	}
+	check.Passed = true
+	return check
+}
+
+func checkTarget(appImage, baseImage imgutil.Image, force bool) RebaseCheck {
+	check := RebaseCheck{Name: RebaseCheckTarget}
+
+	appTarget, err := platform.GetTargetMetadata(appImage)
+	if err != nil {
+		check.Message = fmt.Sprintf("reading target of app image: %s", err)
+		return check
+	}
+	runTarget, err := platform.GetTargetMetadata(baseImage)
+	if err != nil {
+		check.Message = fmt.Sprintf("reading target of run image: %s", err)
+		return check
+	}
+
+	if !platform.TargetSatisfiedForRebase(*runTarget, *appTarget) {
+		check.Message = fmt.Sprintf("run image target (%s) does not satisfy app image target (%s)", runTarget, appTarget)
+		check.Forced = force
+		return check
+	}
+	check.Passed = true
+	return check
+}
+
+// checkRunImageName checks the run image is one of the run images recorded in the app image metadata
+func checkRunImageName(md files.LayersMetadataCompat, runImageName string, force bool) RebaseCheck {
+	check := RebaseCheck{Name: RebaseCheckRunImage}
+
+	if md.RunImage.Contains(runImageName) || (md.Stack != nil && md.Stack.RunImage.Contains(runImageName)) {
+		check.Passed = true
+		return check
+	}
+	check.Message = fmt.Sprintf("run image %s is not found in the run image metadata of the app image", style.Symbol(runImageName))
+	check.Forced = force
+	return check
+}
+
+// removeStagePrefixes removes the build: and run: prefixes of mixins
+func removeStagePrefixes(mixins []string) []string {
+	var result []string
+	for _, mixin := range mixins {
+		result = append(result, strings.TrimPrefix(strings.TrimPrefix(mixin, "build:"), "run:"))
+	}
+	return result
+}
@@ -0,0 +1,179 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	ifakes "github.com/buildpacks/pack/internal/fakes"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestPlanRebase(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "PlanRebase", testPlanRebase, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testPlanRebase(t *testing.T, when spec.G, it spec.S) {
+	var (
+		fakeImageFetcher *ifakes.FakeImageFetcher
+		subject          *Client
+		fakeAppImage     *fakes.Image
+		fakeRunImage     *fakes.Image
+		out              bytes.Buffer
+	)
+
+	it.Before(func() {
+		fakeImageFetcher = ifakes.NewFakeImageFetcher()
+
+		fakeAppImage = fakes.NewImage("some/app", "", &fakeIdentifier{name: "app-image"})
+		h.AssertNil(t, fakeAppImage.SetLabel("io.buildpacks.lifecycle.metadata",
+			`{"runImage":{"topLayer":"old-top-layer-sha","reference":"some/run@sha256:old","image":"some/run","mirrors":["example.com/some/run"]}}`))
+		h.AssertNil(t, fakeAppImage.SetLabel("io.buildpacks.stack.id", "io.buildpacks.stacks.jammy"))
+		h.AssertNil(t, fakeAppImage.SetLabel("io.buildpacks.stack.mixins", `["curl"]`))
+		fakeImageFetcher.LocalImages["some/app"] = fakeAppImage
+
+		fakeRunImage = fakes.NewImage("some/run", "new-top-layer-sha", &fakeIdentifier{name: "some/run@sha256:new"})
+		h.AssertNil(t, fakeRunImage.SetLabel("io.buildpacks.stack.id", "io.buildpacks.stacks.jammy"))
+		h.AssertNil(t, fakeRunImage.SetLabel("io.buildpacks.stack.mixins", `["run:curl"]`))
+		fakeImageFetcher.LocalImages["some/run"] = fakeRunImage
+
+		subject = &Client{
+			logger:       logging.NewLogWithWriters(&out, &out),
+			imageFetcher: fakeImageFetcher,
+		}
+	})
+
+	it.After(func() {
+		h.AssertNilE(t, fakeAppImage.Cleanup())
+		h.AssertNilE(t, fakeRunImage.Cleanup())
+	})
+
+	when("#PlanRebase", func() {
+		it("describes the layers that would be swapped", func() {
+			plan, err := subject.PlanRebase(context.TODO(), RebaseOptions{RepoName: "some/app"})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, plan.Image, "some/app")
+			h.AssertEq(t, plan.RunImage, "some/run")
+			h.AssertEq(t, plan.PreviousRunImage, "some/run@sha256:old")
+			h.AssertEq(t, plan.PreviousTopLayer, "old-top-layer-sha")
+			h.AssertEq(t, plan.RunImageReference, "some/run@sha256:new")
+			h.AssertEq(t, plan.TopLayer, "new-top-layer-sha")
+			h.AssertEq(t, plan.UpToDate, false)
+			h.AssertEq(t, plan.Checks, []RebaseCheck{
+				{Name: RebaseCheckStackID, Passed: true},
+				{Name: RebaseCheckMixins, Passed: true},
+				{Name: RebaseCheckRunImage, Passed: true},
+			})
+			h.AssertTrue(t, plan.Safe())
+		})
+
+		it("does not rebase the app image", func() {
+			_, err := subject.PlanRebase(context.TODO(), RebaseOptions{RepoName: "some/app"})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, fakeAppImage.Base(), "")
+			h.AssertEq(t, fakeAppImage.IsSaved(), false)
+		})
+
+		it("resolves the run image from the mirrors", func() {
+			fakeMirror := fakes.NewImage("example.com/some/run", "old-top-layer-sha", &fakeIdentifier{name: "example.com/some/run@sha256:old"})
+			h.AssertNil(t, fakeMirror.SetLabel("io.buildpacks.stack.id", "io.buildpacks.stacks.jammy"))
+			h.AssertNil(t, fakeMirror.SetLabel("io.buildpacks.stack.mixins", `["curl"]`))
+			fakeImageFetcher.LocalImages["example.com/some/run"] = fakeMirror
+			fakeImageFetcher.LocalImages["example.com/some/app"] = fakeAppImage
+
+			plan, err := subject.PlanRebase(context.TODO(), RebaseOptions{RepoName: "example.com/some/app"})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, plan.RunImage, "example.com/some/run")
+			h.AssertEq(t, plan.UpToDate, true)
+			h.AssertTrue(t, plan.Safe())
+		})
+
+		when("the run image is not compatible", func() {
+			it("reports the stack and mixins mismatches", func() {
+				h.AssertNil(t, fakeRunImage.SetLabel("io.buildpacks.stack.id", "io.buildpacks.stacks.bionic"))
+				h.AssertNil(t, fakeRunImage.SetLabel("io.buildpacks.stack.mixins", `[]`))
+
+				plan, err := subject.PlanRebase(context.TODO(), RebaseOptions{RepoName: "some/app"})
+				h.AssertNil(t, err)
+
+				h.AssertEq(t, plan.Checks[0], RebaseCheck{
+					Name:    RebaseCheckStackID,
+					Message: "run image stack 'io.buildpacks.stacks.bionic' is not compatible with app image stack 'io.buildpacks.stacks.jammy'",
+				})
+				h.AssertEq(t, plan.Checks[1], RebaseCheck{
+					Name:    RebaseCheckMixins,
+					Message: "run image is missing required mixin(s): curl",
+				})
+				h.AssertEq(t, plan.Safe(), false)
+			})
+
+			it("reports run images missing from the app image metadata", func() {
+				fakeCustomRunImage := fakes.NewImage("custom/run", "custom-top-layer-sha", &fakeIdentifier{name: "custom/run@sha256:custom"})
+				h.AssertNil(t, fakeCustomRunImage.SetLabel("io.buildpacks.stack.id", "io.buildpacks.stacks.jammy"))
+				h.AssertNil(t, fakeCustomRunImage.SetLabel("io.buildpacks.stack.mixins", `["curl", "git"]`))
+				fakeImageFetcher.LocalImages["custom/run"] = fakeCustomRunImage
+
+				plan, err := subject.PlanRebase(context.TODO(), RebaseOptions{RepoName: "some/app", RunImage: "custom/run"})
+				h.AssertNil(t, err)
+				h.AssertEq(t, plan.Checks[2], RebaseCheck{
+					Name:    RebaseCheckRunImage,
+					Message: "run image 'custom/run' is not found in the run image metadata of the app image",
+				})
+				h.AssertEq(t, plan.Safe(), false)
+
+				plan, err = subject.PlanRebase(context.TODO(), RebaseOptions{RepoName: "some/app", RunImage: "custom/run", Force: true})
+				h.AssertNil(t, err)
+				h.AssertEq(t, plan.Checks[2].Forced, true)
+				h.AssertTrue(t, plan.Safe())
+			})
+		})
+
+		when("the app image was built with platform API 0.12 or later", func() {
+			it.Before(func() {
+				h.AssertNil(t, fakeAppImage.SetEnv("CNB_PLATFORM_API", "0.12"))
+			})
+
+			it("checks the targets instead of the stack", func() {
+				plan, err := subject.PlanRebase(context.TODO(), RebaseOptions{RepoName: "some/app"})
+				h.AssertNil(t, err)
+
+				h.AssertEq(t, plan.Checks, []RebaseCheck{
+					{Name: RebaseCheckRebasable, Passed: true},
+					{Name: RebaseCheckTarget, Passed: true},
+					{Name: RebaseCheckRunImage, Passed: true},
+				})
+			})
+
+			it("reports target mismatches", func() {
+				h.AssertNil(t, fakeRunImage.SetArchitecture("arm64"))
+				h.AssertNil(t, fakeAppImage.SetLabel("io.buildpacks.rebasable", "false"))
+
+				plan, err := subject.PlanRebase(context.TODO(), RebaseOptions{RepoName: "some/app"})
+				h.AssertNil(t, err)
+
+				h.AssertEq(t, plan.Checks[0].Message, "app image is not marked as rebasable")
+				h.AssertEq(t, plan.Checks[1].Passed, false)
+				h.AssertContains(t, plan.Checks[1].Message, "Arch: arm64")
+				h.AssertEq(t, plan.Safe(), false)
+			})
+		})
+
+		it("errors when the app image has no lifecycle metadata", func() {
+			h.AssertNil(t, fakeAppImage.RemoveLabel("io.buildpacks.lifecycle.metadata"))
+
+			_, err := subject.PlanRebase(context.TODO(), RebaseOptions{RepoName: "some/app"})
+			h.AssertError(t, err, "could not find label 'io.buildpacks.lifecycle.metadata' on image")
+		})
+	})
+}
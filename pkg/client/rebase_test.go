@@ -263,6 +263,66 @@ func testRebase(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 		})
+
+		when("#RebaseBatch", func() {
+			var fakeCurrentAppImage *fakes.Image
+
+			it.Before(func() {
+				fakeCurrentAppImage = fakes.NewImage("some/current-app", "", &fakeIdentifier{name: "current-app-image"})
+				h.AssertNil(t, fakeCurrentAppImage.SetLabel("io.buildpacks.lifecycle.metadata",
+					`{"runImage":{"image":"some/run","topLayer":"run-image-top-layer-sha","reference":"run-image-digest"}}`))
+				h.AssertNil(t, fakeCurrentAppImage.SetLabel("io.buildpacks.stack.id", "io.buildpacks.stacks.jammy"))
+				fakeImageFetcher.LocalImages["some/current-app"] = fakeCurrentAppImage
+
+				h.AssertNil(t, fakeAppImage.SetLabel("io.buildpacks.lifecycle.metadata",
+					`{"runImage":{"image":"some/run","topLayer":"old-top-layer-sha","reference":"old-run-image-digest"}}`))
+			})
+
+			it.After(func() {
+				h.AssertNilE(t, fakeCurrentAppImage.Cleanup())
+			})
+
+			it("rebases each image and skips the ones already on the run image", func() {
+				results := subject.RebaseBatch(context.TODO(), RebaseBatchOptions{
+					Images:      []string{"some/app", "some/current-app"},
+					Concurrency: 1,
+				})
+
+				h.AssertEq(t, results, []RebaseResult{
+					{Image: "some/app", PreviousRunImage: "old-run-image-digest", RunImage: "run-image-digest"},
+					{Image: "some/current-app", PreviousRunImage: "run-image-digest", RunImage: "run-image-digest", Skipped: true},
+				})
+				h.AssertEq(t, fakeAppImage.Base(), "some/run")
+				h.AssertEq(t, fakeCurrentAppImage.Base(), "")
+				h.AssertContains(t, out.String(), "Image 'some/current-app' is already based on run image 'some/run'")
+			})
+
+			it("records failures and rebases the other images", func() {
+				results := subject.RebaseBatch(context.TODO(), RebaseBatchOptions{
+					Images:      []string{"some/missing-app", "some/app"},
+					Concurrency: 1,
+				})
+
+				h.AssertEq(t, len(results), 2)
+				h.AssertEq(t, results[0].Image, "some/missing-app")
+				h.AssertError(t, results[0].Err, "image 'some/missing-app' does not exist on the daemon")
+				h.AssertNil(t, results[1].Err)
+				h.AssertEq(t, results[1].RunImage, "run-image-digest")
+			})
+
+			it("applies the rebase options to each image", func() {
+				fakeImageFetcher.RemoteImages["some/app"] = fakeAppImage
+				fakeImageFetcher.RemoteImages["some/run"] = fakeRunImage
+
+				results := subject.RebaseBatch(context.TODO(), RebaseBatchOptions{
+					RebaseOptions: RebaseOptions{Publish: true},
+					Images:        []string{"some/app"},
+				})
+
+				h.AssertNil(t, results[0].Err)
+				h.AssertEq(t, fakeImageFetcher.FetchCalls["some/app"].Daemon, false)
+			})
+		})
 	})
 }
 
@@ -3,14 +3,17 @@ package client
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/url"
 	"runtime"
 	"strings"
 
 	"github.com/buildpacks/pack/internal/registry"
+	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/pkg/buildpack"
 	"github.com/buildpacks/pack/pkg/dist"
 	"github.com/buildpacks/pack/pkg/image"
+	registryTypes "github.com/buildpacks/pack/registry"
 )
 
 // RegisterBuildpackOptions is a configuration struct that controls the
@@ -20,11 +23,22 @@ type RegisterBuildpackOptions struct {
 	Type      string
 	URL       string
 	Name      string
+
+	// Token authenticates to the REST API of a github registry. When set, the registration issue is created through
+	// the API instead of being opened in a browser.
+	Token string
+
+	// APIURL is the REST API endpoint of a github registry. When empty, it is derived from URL.
+	APIURL string
 }
 
 // RegisterBuildpack updates the Buildpack Registry with to include a new buildpack specified in
 // the opts argument
 func (c *Client) RegisterBuildpack(ctx context.Context, opts RegisterBuildpackOptions) error {
+	if opts.Type == registryTypes.TypeHTTP || opts.Type == registryTypes.TypeOCI {
+		return fmt.Errorf("registering buildpacks is not supported for %s registries, they are read-only", style.Symbol(opts.Type))
+	}
+
 	appImage, err := c.imageFetcher.Fetch(ctx, opts.ImageName, image.FetchOptions{Daemon: false, PullPolicy: image.PullAlways})
 	if err != nil {
 		return err
@@ -53,46 +67,68 @@ func (c *Client) RegisterBuildpack(ctx context.Context, opts RegisterBuildpackOp
 		Yanked:    false,
 	}
 
-	if opts.Type == "github" {
-		issueURL, err := registry.GetIssueURL(opts.URL)
+	if opts.Type == registryTypes.TypeGitHub {
+		return c.submitGithubIssue(buildpack, opts.URL, opts.APIURL, opts.Token)
+	} else if opts.Type == registryTypes.TypeGit {
+		registryCache, err := getRegistry(c.logger, opts.Name, c.offline)
 		if err != nil {
 			return err
 		}
 
-		issue, err := registry.CreateGithubIssue(buildpack)
+		username, err := parseUsernameFromURL(opts.URL)
 		if err != nil {
 			return err
 		}
 
-		params := url.Values{}
-		params.Add("title", issue.Title)
-		params.Add("body", issue.Body)
-		issueURL.RawQuery = params.Encode()
-
-		c.logger.Debugf("Open URL in browser: %s", issueURL)
-		cmd, err := registry.CreateBrowserCmd(issueURL.String(), runtime.GOOS)
-		if err != nil {
+		if err := registry.GitCommit(buildpack, username, registryCache); err != nil {
 			return err
 		}
+	}
 
-		return cmd.Start()
-	} else if opts.Type == "git" {
-		registryCache, err := getRegistry(c.logger, opts.Name)
-		if err != nil {
-			return err
+	return nil
+}
+
+// submitGithubIssue submits the registry issue of a buildpack to a github registry: through the REST API of the
+// registry when a token is provided, or by opening a prefilled issue in a browser otherwise
+func (c *Client) submitGithubIssue(buildpack registry.Buildpack, registryURL, apiURL, token string) error {
+	issueURL, err := registry.GetIssueURL(registryURL)
+	if err != nil {
+		return err
+	}
+
+	issue, err := registry.CreateGithubIssue(buildpack)
+	if err != nil {
+		return err
+	}
+
+	if token != "" {
+		if apiURL == "" {
+			if apiURL, err = registry.GetAPIURL(registryURL); err != nil {
+				return err
+			}
 		}
 
-		username, err := parseUsernameFromURL(opts.URL)
+		c.logger.Debugf("Creating issue %s through %s", style.Symbol(issue.Title), apiURL)
+		created, err := registry.CreateGithubIssueWithAPI(apiURL, registryURL, token, issue)
 		if err != nil {
 			return err
 		}
+		c.logger.Infof("Created issue #%d (%s): %s", created.Number, created.State, created.URL)
+		return nil
+	}
 
-		if err := registry.GitCommit(buildpack, username, registryCache); err != nil {
-			return err
-		}
+	params := url.Values{}
+	params.Add("title", issue.Title)
+	params.Add("body", issue.Body)
+	issueURL.RawQuery = params.Encode()
+
+	c.logger.Debugf("Open URL in browser: %s", issueURL)
+	cmd, err := registry.CreateBrowserCmd(issueURL.String(), runtime.GOOS)
+	if err != nil {
+		return err
 	}
 
-	return nil
+	return cmd.Start()
 }
 
 func parseUsernameFromURL(url string) (string, error) {
@@ -133,5 +133,73 @@ func testRegisterBuildpack(t *testing.T, when spec.G, it spec.S) {
 					Name:      registry.DefaultRegistryName,
 				}))
 		})
+
+		it("should return error for read-only registries (http)", func() {
+			err := subject.RegisterBuildpack(context.TODO(),
+				RegisterBuildpackOptions{
+					ImageName: "buildpack/image",
+					Type:      "http",
+					URL:       "https://registry.example.com/index",
+					Name:      "internal",
+				})
+			h.AssertError(t, err, "registering buildpacks is not supported for 'http' registries")
+		})
+
+		it("should return error for read-only registries without fetching the image (oci)", func() {
+			err := subject.RegisterBuildpack(context.TODO(),
+				RegisterBuildpackOptions{
+					ImageName: "missing/image",
+					Type:      "oci",
+					URL:       "oci://registry.example.com/buildpacks/index",
+					Name:      "internal",
+				})
+			h.AssertError(t, err, "registering buildpacks is not supported for 'oci' registries")
+		})
+
+		when("a token is provided (github)", func() {
+			var api *ifakes.FakeGithubAPI
+
+			it.Before(func() {
+				api = ifakes.NewFakeGithubAPI("some-token")
+			})
+
+			it.After(func() {
+				api.Close()
+			})
+
+			it("creates the registration issue through the API", func() {
+				err := subject.RegisterBuildpack(context.TODO(),
+					RegisterBuildpackOptions{
+						ImageName: "buildpack/image",
+						Type:      "github",
+						URL:       registry.DefaultRegistryURL,
+						Name:      registry.DefaultRegistryName,
+						Token:     "some-token",
+						APIURL:    api.URL(),
+					})
+				h.AssertNil(t, err)
+
+				issues := api.Issues()
+				h.AssertEq(t, len(issues), 1)
+				h.AssertEq(t, issues[0].Repository, "buildpacks/registry-index")
+				h.AssertEq(t, issues[0].Title, "ADD heroku/java-function@1.1.1")
+				h.AssertContains(t, issues[0].Body, `addr = "buildpack-image"`)
+				h.AssertContains(t, out.String(), "Created issue #1 (open)")
+			})
+
+			it("returns API errors", func() {
+				err := subject.RegisterBuildpack(context.TODO(),
+					RegisterBuildpackOptions{
+						ImageName: "buildpack/image",
+						Type:      "github",
+						URL:       registry.DefaultRegistryURL,
+						Name:      registry.DefaultRegistryName,
+						Token:     "bad-token",
+						APIURL:    api.URL(),
+					})
+				h.AssertError(t, err, "Bad credentials")
+				h.AssertEq(t, len(api.Issues()), 0)
+			})
+		})
 	})
 }
@@ -0,0 +1,107 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/registry"
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// SearchBuildpacksOptions is a configuration struct that controls the SearchBuildpacks function.
+type SearchBuildpacksOptions struct {
+	// Text the ID of the buildpacks must contain. An empty query returns every buildpack.
+	Query string
+
+	// Name of the buildpack registry to search. The default registry is searched when empty.
+	Registry string
+}
+
+// ListBuildpackVersionsOptions is a configuration struct that controls the ListBuildpackVersions function.
+type ListBuildpackVersionsOptions struct {
+	// ID of the buildpack, as <namespace>/<name>.
+	ID string
+
+	// Name of the buildpack registry to read. The default registry is read when empty.
+	Registry string
+}
+
+// RegistryBuildpack is a buildpack found in a buildpack registry.
+type RegistryBuildpack struct {
+	ID string `json:"id" yaml:"id"`
+
+	// Latest version of the buildpack that is not yanked, or the latest version when they are all yanked.
+	LatestVersion string `json:"latestVersion" yaml:"latestVersion"`
+	Address       string `json:"address" yaml:"address"`
+
+	// Number of versions of the buildpack in the registry, including yanked ones.
+	Versions int `json:"versions" yaml:"versions"`
+}
+
+// RegistryBuildpackVersion is a version of a buildpack in a buildpack registry.
+type RegistryBuildpackVersion struct {
+	ID      string `json:"id" yaml:"id"`
+	Version string `json:"version" yaml:"version"`
+	Yanked  bool   `json:"yanked" yaml:"yanked"`
+	Address string `json:"address" yaml:"address"`
+}
+
+// SearchBuildpacks returns the buildpacks of a registry whose ID contains the query, sorted by ID.
+func (c *Client) SearchBuildpacks(opts SearchBuildpacksOptions) ([]RegistryBuildpack, error) {
+	registryCache, err := getRegistry(c.logger, opts.Registry, c.offline)
+	if err != nil {
+		return nil, errors.Wrapf(err, "lookup registry %s", style.Symbol(opts.Registry))
+	}
+
+	entries, err := registryCache.SearchBuildpacks(opts.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	buildpacks := []RegistryBuildpack{}
+	for _, entry := range entries {
+		latest := entry.Buildpacks[0]
+		for _, bp := range entry.Buildpacks {
+			if !bp.Yanked {
+				latest = bp
+				break
+			}
+		}
+		buildpacks = append(buildpacks, RegistryBuildpack{
+			ID:            entryBuildpackID(latest),
+			LatestVersion: latest.Version,
+			Address:       latest.Address,
+			Versions:      len(entry.Buildpacks),
+		})
+	}
+	return buildpacks, nil
+}
+
+// ListBuildpackVersions returns every version of a buildpack in a registry, from newest to oldest.
+func (c *Client) ListBuildpackVersions(opts ListBuildpackVersionsOptions) ([]RegistryBuildpackVersion, error) {
+	registryCache, err := getRegistry(c.logger, opts.Registry, c.offline)
+	if err != nil {
+		return nil, errors.Wrapf(err, "lookup registry %s", style.Symbol(opts.Registry))
+	}
+
+	entry, err := registryCache.ListVersions(opts.ID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "listing versions of %s", style.Symbol(opts.ID))
+	}
+
+	var versions []RegistryBuildpackVersion
+	for _, bp := range entry.Buildpacks {
+		versions = append(versions, RegistryBuildpackVersion{
+			ID:      entryBuildpackID(bp),
+			Version: bp.Version,
+			Yanked:  bp.Yanked,
+			Address: bp.Address,
+		})
+	}
+	return versions, nil
+}
+
+func entryBuildpackID(bp registry.Buildpack) string {
+	return fmt.Sprintf("%s/%s", bp.Namespace, bp.Name)
+}
@@ -0,0 +1,114 @@
+package client
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestSearchBuildpacks(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	// the registries are read from the config in PACK_HOME, which is shared by the specs
+	spec.Run(t, "SearchBuildpacks", testSearchBuildpacks, spec.Sequential(), spec.Report(report.Terminal{}))
+}
+
+func testSearchBuildpacks(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject     *Client
+		out         bytes.Buffer
+		oldPackHome string
+	)
+
+	it.Before(func() {
+		tmpDir := t.TempDir()
+
+		// the fixture is extended with a yanked version before being committed to a git registry
+		fixtureDir := filepath.Join(tmpDir, "fixture")
+		h.RecursiveCopyNow(t, filepath.Join("testdata", "registry"), fixtureDir)
+		fooIndex, err := os.OpenFile(filepath.Join(fixtureDir, "3", "fo", "example_foo"), os.O_APPEND|os.O_WRONLY, 0644)
+		h.AssertNil(t, err)
+		_, err = fooIndex.WriteString(`{"ns":"example","name":"foo","version":"1.3.0","yanked":true}` + "\n")
+		h.AssertNil(t, err)
+		h.AssertNil(t, fooIndex.Close())
+		registryFixture := h.CreateRegistryFixture(t, tmpDir, fixtureDir)
+
+		packHome := filepath.Join(tmpDir, ".pack")
+		h.AssertNil(t, os.MkdirAll(packHome, 0755))
+		oldPackHome = os.Getenv("PACK_HOME")
+		h.AssertNil(t, os.Setenv("PACK_HOME", packHome))
+		h.AssertNil(t, config.Write(config.Config{
+			Registries: []config.Registry{{Name: "some-registry", Type: "git", URL: registryFixture}},
+		}, filepath.Join(packHome, "config.toml")))
+
+		subject, err = NewClient(WithLogger(logging.NewLogWithWriters(&out, &out)))
+		h.AssertNil(t, err)
+	})
+
+	it.After(func() {
+		h.AssertNil(t, os.Setenv("PACK_HOME", oldPackHome))
+	})
+
+	when("#SearchBuildpacks", func() {
+		it("returns the latest version of the matching buildpacks", func() {
+			buildpacks, err := subject.SearchBuildpacks(SearchBuildpacksOptions{Query: "example", Registry: "some-registry"})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, buildpacks, []RegistryBuildpack{
+				{
+					ID:            "example/foo",
+					LatestVersion: "1.2.0",
+					Address:       "example.com/some/package@sha256:2560f05307e8de9d830f144d09556e19dd1eb7d928aee900ed02208ae9727e7a",
+					Versions:      4,
+				},
+				{
+					ID:            "example/java",
+					LatestVersion: "1.0.0",
+					Address:       "example.com/some/package@sha256:8c27fe111c11b722081701dfed3bd55e039b9ce92865473cf4cdfa918071c566",
+					Versions:      1,
+				},
+			})
+		})
+
+		it("returns no buildpacks when none match", func() {
+			buildpacks, err := subject.SearchBuildpacks(SearchBuildpacksOptions{Query: "ruby", Registry: "some-registry"})
+			h.AssertNil(t, err)
+			h.AssertEq(t, buildpacks, []RegistryBuildpack{})
+		})
+
+		it("errors when the registry is not defined", func() {
+			_, err := subject.SearchBuildpacks(SearchBuildpacksOptions{Registry: "other-registry"})
+			h.AssertError(t, err, "registry 'other-registry' is not defined in your config file")
+		})
+	})
+
+	when("#ListBuildpackVersions", func() {
+		it("returns every version with its yanked status", func() {
+			versions, err := subject.ListBuildpackVersions(ListBuildpackVersionsOptions{ID: "example/foo", Registry: "some-registry"})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, len(versions), 4)
+			h.AssertEq(t, versions[0], RegistryBuildpackVersion{ID: "example/foo", Version: "1.3.0", Yanked: true})
+			h.AssertEq(t, versions[1].Version, "1.2.0")
+			h.AssertEq(t, versions[3], RegistryBuildpackVersion{
+				ID:      "example/foo",
+				Version: "1.0.0",
+				Address: "example.com/some/package@sha256:8c27fe111c11b722081701dfed3bd55e039b9ce92865473cf4cdfa918071c566",
+			})
+		})
+
+		it("errors when the buildpack is not in the registry", func() {
+			_, err := subject.ListBuildpackVersions(ListBuildpackVersionsOptions{ID: "example/ruby", Registry: "some-registry"})
+			h.AssertError(t, err, "listing versions of 'example/ruby'")
+		})
+	})
+}
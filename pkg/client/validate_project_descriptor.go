@@ -0,0 +1,177 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/paths"
+	"github.com/buildpacks/pack/internal/registry"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/project"
+	projectTypes "github.com/buildpacks/pack/pkg/project/types"
+)
+
+// ValidateProjectDescriptorOptions is a configuration struct that controls the
+// behavior of the ValidateProjectDescriptor function.
+type ValidateProjectDescriptorOptions struct {
+	// Path to the project descriptor to validate.
+	ProjectDescriptorPath string
+
+	// Name of the buildpack registry used to resolve buildpack references.
+	// The default registry is used when empty.
+	Registry string
+}
+
+// ProjectDescriptorValidation is the outcome of validating a project descriptor.
+type ProjectDescriptorValidation struct {
+	// The parsed project descriptor. It is empty when the descriptor could not be parsed.
+	Descriptor projectTypes.Descriptor
+
+	// Problems that would cause a build using the project descriptor to fail or to behave unexpectedly.
+	Errors []error
+
+	// Buildpack references that could not be verified, as they may be provided by the builder.
+	Warnings []string
+}
+
+// Valid returns true if no errors were found.
+func (v ProjectDescriptorValidation) Valid() bool {
+	return len(v.Errors) == 0
+}
+
+// ValidateProjectDescriptor validates a project descriptor against its schema version, reports keys that are not
+// part of the schema, and checks that the buildpacks it references can be resolved.
+func (c *Client) ValidateProjectDescriptor(ctx context.Context, opts ValidateProjectDescriptorOptions) (ProjectDescriptorValidation, error) {
+	contents, err := os.ReadFile(filepath.Clean(opts.ProjectDescriptorPath))
+	if err != nil {
+		return ProjectDescriptorValidation{}, errors.Wrap(err, "reading project descriptor")
+	}
+
+	var result ProjectDescriptorValidation
+	result.Descriptor, err = project.ParseProjectDescriptor(string(contents))
+	if err != nil {
+		result.Errors = append(result.Errors, err)
+		return result, nil
+	}
+
+	unknownKeys, err := project.FindUnknownKeys(string(contents))
+	if err != nil {
+		return ProjectDescriptorValidation{}, err
+	}
+	for _, unknownKey := range unknownKeys {
+		result.Errors = append(result.Errors, unknownKey)
+	}
+
+	var registryCache *registry.Cache
+	locateInRegistry := func(locator string) error {
+		if registryCache == nil {
+			cache, err := getRegistry(c.logger, opts.Registry, c.offline)
+			if err != nil {
+				return errors.Wrapf(err, "lookup registry %s", style.Symbol(opts.Registry))
+			}
+			registryCache = &cache
+		}
+		_, err := registryCache.LocateBuildpack(locator)
+		return err
+	}
+
+	baseDir := filepath.Dir(opts.ProjectDescriptorPath)
+	var buildpacks []projectTypes.Buildpack
+	buildpacks = append(buildpacks, result.Descriptor.Build.Pre.Buildpacks...)
+	buildpacks = append(buildpacks, result.Descriptor.Build.Buildpacks...)
+	buildpacks = append(buildpacks, result.Descriptor.Build.Post.Buildpacks...)
+	for _, bp := range buildpacks {
+		warning, err := checkBuildpackReference(bp, baseDir, locateInRegistry)
+		if err != nil {
+			result.Errors = append(result.Errors, err)
+		}
+		if warning != "" {
+			result.Warnings = append(result.Warnings, warning)
+		}
+	}
+
+	return result, nil
+}
+
+func checkBuildpackReference(bp projectTypes.Buildpack, baseDir string, locateInRegistry func(string) error) (string, error) {
+	var locator string
+	switch {
+	case bp.URI != "":
+		locator = bp.URI
+	case bp.Script.Inline != "":
+		if bp.Script.API == "" {
+			return "", errors.Errorf("inline buildpack %s must have an api defined", style.Symbol(bp.ID))
+		}
+		return "", nil
+	case bp.ID != "" && bp.Version != "":
+		locator = fmt.Sprintf("%s@%s", bp.ID, bp.Version)
+	case bp.ID != "":
+		return fmt.Sprintf("buildpack %s has no version, uri or inline script defined, it must be provided by the builder", style.Symbol(bp.ID)), nil
+	default:
+		return "", errors.New("buildpacks must have an id, uri or inline script defined")
+	}
+
+	if isRelativeOrAbsolutePath(bp.URI) {
+		if _, err := os.Stat(resolvePath(bp.URI, baseDir)); err != nil {
+			return "", errors.Errorf("buildpack %s does not exist", style.Symbol(bp.URI))
+		}
+		return "", nil
+	}
+
+	locatorType, err := buildpack.GetLocatorType(locator, baseDir, nil)
+	if err != nil {
+		return "", err
+	}
+
+	switch locatorType {
+	case buildpack.RegistryLocator:
+		if err := locateInRegistry(locator); err != nil {
+			if bp.URI == "" {
+				return fmt.Sprintf("buildpack %s could not be found in the buildpack registry, it must be provided by the builder", style.Symbol(locator)), nil
+			}
+			return "", errors.Wrapf(err, "locating buildpack %s in registry", style.Symbol(locator))
+		}
+	case buildpack.URILocator:
+		path := locator
+		if paths.IsURI(locator) {
+			uri, err := url.Parse(locator)
+			if err != nil {
+				return "", errors.Wrapf(err, "parsing buildpack uri %s", style.Symbol(locator))
+			}
+			if uri.Scheme != "file" {
+				return "", nil
+			}
+			if path, err = paths.URIToFilePath(locator); err != nil {
+				return "", errors.Wrapf(err, "parsing buildpack uri %s", style.Symbol(locator))
+			}
+		}
+		if _, err := os.Stat(resolvePath(path, baseDir)); err != nil {
+			return "", errors.Errorf("buildpack %s does not exist", style.Symbol(locator))
+		}
+	case buildpack.InvalidLocator:
+		if bp.URI == "" {
+			return fmt.Sprintf("buildpack %s is not a buildpack registry ID, it must be provided by the builder", style.Symbol(locator)), nil
+		}
+		return "", errors.Errorf("buildpack %s does not exist and is not a valid buildpack reference", style.Symbol(locator))
+	}
+
+	return "", nil
+}
+
+func isRelativeOrAbsolutePath(locator string) bool {
+	return strings.HasPrefix(locator, ".") || filepath.IsAbs(locator)
+}
+
+func resolvePath(path, baseDir string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(baseDir, path)
+}
@@ -0,0 +1,195 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	cfg "github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestValidateProjectDescriptor(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ValidateProjectDescriptor", testValidateProjectDescriptor, spec.Report(report.Terminal{}))
+}
+
+func testValidateProjectDescriptor(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject        *client.Client
+		tmpDir         string
+		descriptorPath string
+		out            bytes.Buffer
+	)
+
+	it.Before(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "validate-project-descriptor")
+		h.AssertNil(t, err)
+
+		registryFixture := h.CreateRegistryFixture(t, tmpDir, filepath.Join("testdata", "registry"))
+
+		packHome := filepath.Join(tmpDir, "packHome")
+		h.AssertNil(t, os.Setenv("PACK_HOME", packHome))
+		h.AssertNil(t, cfg.Write(cfg.Config{
+			Registries: []cfg.Registry{
+				{
+					Name: "some-registry",
+					Type: "github",
+					URL:  registryFixture,
+				},
+			},
+		}, filepath.Join(packHome, "config.toml")))
+
+		subject, err = client.NewClient(client.WithLogger(logging.NewLogWithWriters(&out, &out)))
+		h.AssertNil(t, err)
+
+		descriptorPath = filepath.Join(tmpDir, "project.toml")
+	})
+
+	it.After(func() {
+		h.AssertNil(t, os.Unsetenv("PACK_HOME"))
+		h.AssertNil(t, os.RemoveAll(tmpDir))
+	})
+
+	validate := func(contents string) client.ProjectDescriptorValidation {
+		h.AssertNil(t, os.WriteFile(descriptorPath, []byte(contents), 0600))
+
+		result, err := subject.ValidateProjectDescriptor(context.TODO(), client.ValidateProjectDescriptorOptions{
+			ProjectDescriptorPath: descriptorPath,
+			Registry:              "some-registry",
+		})
+		h.AssertNil(t, err)
+		return result
+	}
+
+	when("the descriptor is valid", func() {
+		it("returns no errors or warnings", func() {
+			h.AssertNil(t, os.Mkdir(filepath.Join(tmpDir, "local-buildpack"), 0755))
+
+			result := validate(`
+[_]
+schema-version = "0.2"
+name = "my-app"
+
+[[io.buildpacks.group]]
+id = "example/foo"
+version = "1.1.0"
+
+[[io.buildpacks.group]]
+uri = "local-buildpack"
+
+[[io.buildpacks.group]]
+uri = "https://example.com/buildpack.tgz"
+
+[[io.buildpacks.group]]
+id = "inline"
+  [io.buildpacks.group.script]
+  api = "0.8"
+  inline = "echo hi"
+`)
+			h.AssertTrue(t, result.Valid())
+			h.AssertEq(t, len(result.Warnings), 0)
+			h.AssertEq(t, result.Descriptor.Project.Name, "my-app")
+		})
+	})
+
+	when("the descriptor cannot be parsed", func() {
+		it("returns the parse error", func() {
+			result := validate(`
+[_]
+schema-version = "0.2"
+
+[[io.buildpacks.group]]
+version = "1.0.0"
+`)
+			h.AssertFalse(t, result.Valid())
+			h.AssertEq(t, len(result.Errors), 1)
+			h.AssertContains(t, result.Errors[0].Error(), "buildpacks must have an id or url defined")
+		})
+	})
+
+	when("the descriptor has unknown keys", func() {
+		it("returns an error for each unknown key", func() {
+			result := validate(`[_]
+schema-version = "0.2"
+
+[io.buildpacks]
+bulder = "some/builder"
+`)
+			h.AssertEq(t, len(result.Errors), 1)
+			h.AssertEq(t, result.Errors[0].Error(), "project.toml:5: unknown key 'io.buildpacks.bulder'")
+		})
+	})
+
+	when("a buildpack cannot be resolved", func() {
+		it("warns about ids missing from the registry", func() {
+			result := validate(`
+[_]
+schema-version = "0.2"
+
+[[io.buildpacks.group]]
+id = "example/missing"
+version = "1.0.0"
+`)
+			h.AssertTrue(t, result.Valid())
+			h.AssertEq(t, result.Warnings, []string{
+				"buildpack 'example/missing@1.0.0' could not be found in the buildpack registry, it must be provided by the builder",
+			})
+		})
+
+		it("errors for registry uris missing from the registry", func() {
+			result := validate(`
+[_]
+schema-version = "0.2"
+
+[[io.buildpacks.group]]
+uri = "urn:cnb:registry:example/foo@9.9.9"
+`)
+			h.AssertEq(t, len(result.Errors), 1)
+			h.AssertContains(t, result.Errors[0].Error(), "locating buildpack 'urn:cnb:registry:example/foo@9.9.9' in registry")
+		})
+
+		it("errors for local paths that do not exist", func() {
+			result := validate(`
+[_]
+schema-version = "0.2"
+
+[[io.buildpacks.group]]
+uri = "./missing-buildpack"
+`)
+			h.AssertEq(t, len(result.Errors), 1)
+			h.AssertEq(t, result.Errors[0].Error(), "buildpack './missing-buildpack' does not exist")
+		})
+
+		it("warns about ids without a version", func() {
+			result := validate(`
+[_]
+schema-version = "0.2"
+
+[[io.buildpacks.group]]
+id = "example/foo"
+`)
+			h.AssertEq(t, len(result.Errors), 0)
+			h.AssertEq(t, result.Warnings, []string{"buildpack 'example/foo' has no version, uri or inline script defined, it must be provided by the builder"})
+		})
+	})
+
+	when("the descriptor does not exist", func() {
+		it("returns an error", func() {
+			_, err := subject.ValidateProjectDescriptor(context.TODO(), client.ValidateProjectDescriptorOptions{
+				ProjectDescriptorPath: filepath.Join(tmpDir, "missing.toml"),
+			})
+			h.AssertError(t, err, "reading project descriptor")
+		})
+	})
+}
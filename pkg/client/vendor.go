@@ -0,0 +1,498 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/buildpacks/imgutil"
+	"github.com/buildpacks/imgutil/layout"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/daemon"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	ggcrlayout "github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/pkg/errors"
+
+	internalConfig "github.com/buildpacks/pack/internal/config"
+	pname "github.com/buildpacks/pack/internal/name"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/blob"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/offline"
+)
+
+const (
+	// vendorManifestFile records what a vendor directory holds
+	vendorManifestFile = "vendor.json"
+	// vendorImagesDir holds one OCI layout per image, at the path given by layout.ParseRefToPath
+	vendorImagesDir = "images"
+	// vendorDownloadsDir is the download cache of the remote buildpack URIs
+	vendorDownloadsDir = "downloads"
+)
+
+// VendoredBuild describes the artifacts saved to a vendor directory by PrepareBuild.
+type VendoredBuild struct {
+	Builder        string `json:"builder"`
+	RunImage       string `json:"runImage"`
+	LifecycleImage string `json:"lifecycleImage,omitempty"`
+
+	// Every image saved to the vendor directory, including the builder, run image, lifecycle image and buildpack
+	// packages.
+	Images []string `json:"images"`
+
+	// Remote buildpack URIs saved to the download cache of the vendor directory.
+	Downloads []string `json:"downloads,omitempty"`
+
+	// Address of the buildpacks located in a buildpack registry, by buildpack URI, by registry name. The default
+	// registry is recorded with an empty name.
+	Registries map[string]map[string]string `json:"registries,omitempty"`
+}
+
+// PrepareBuild resolves the builder, run image, lifecycle image and buildpacks a build configured by opts would use,
+// and saves them to opts.VendorDir. The vendor directory can be copied to a host without network access, and used
+// there by Build with the same VendorDir.
+func (c *Client) PrepareBuild(ctx context.Context, opts BuildOptions) (*VendoredBuild, error) {
+	if opts.VendorDir == "" {
+		return nil, errors.New("a vendor directory is required to prepare a build")
+	}
+	if c.offline {
+		return nil, errors.New("preparing a build downloads its artifacts, it cannot run offline")
+	}
+	if len(opts.Targets) > 1 {
+		return nil, errors.New("a build can only be prepared for a single platform")
+	}
+
+	var platform string
+	if len(opts.Targets) == 1 {
+		platform = opts.Targets[0].Platform()
+	}
+
+	builderRef, err := c.processBuilderName(opts.Builder)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid builder '%s'", opts.Builder)
+	}
+
+	imgRegistry := ""
+	if opts.Image != "" {
+		imageRef, err := name.ParseReference(opts.Image, name.WeakValidation)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid image name '%s'", opts.Image)
+		}
+		imgRegistry = imageRef.Context().RegistryStr()
+	}
+
+	// the images, downloads and buildpack registries used by the build are recorded as they are fetched
+	fetcher := &vendoringFetcher{
+		fetcher:  c.imageFetcher,
+		docker:   c.docker,
+		dir:      filepath.Join(opts.VendorDir, vendorImagesDir),
+		platform: platform,
+		images:   map[string]imgutil.Image{},
+	}
+	downloader := &vendoringDownloader{
+		downloader: blob.NewDownloader(c.logger, filepath.Join(opts.VendorDir, vendorDownloadsDir), blob.WithRequireDigests(c.requireDigests)),
+	}
+	resolver := &vendoringRegistryResolver{resolver: &registryResolver{logger: c.logger}}
+
+	vc := *c
+	vc.imageFetcher = fetcher
+	vc.downloader = downloader
+	vc.buildpackDownloader = buildpack.NewDownloader(c.logger, fetcher, downloader, resolver)
+
+	c.logger.Debugf("Saving builder %s", style.Symbol(builderRef.Name()))
+	rawBuilderImage, err := fetcher.Fetch(ctx, builderRef.Name(), image.FetchOptions{Daemon: true, PullPolicy: opts.PullPolicy, Platform: platform})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch builder image '%s'", builderRef.Name())
+	}
+
+	bldr, err := c.getBuilder(rawBuilderImage)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid builder %s", style.Symbol(opts.Builder))
+	}
+
+	imgOS, err := rawBuilderImage.OS()
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting builder OS")
+	}
+	imgArch, err := rawBuilderImage.Architecture()
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting builder architecture")
+	}
+	if platform == "" {
+		// the other images are saved for the platform of the builder
+		fetcher.platform = fmt.Sprintf("%s/%s", imgOS, imgArch)
+	}
+
+	runImageName := c.resolveRunImage(opts.RunImage, imgRegistry, builderRef.Context().RegistryStr(), bldr.DefaultRunImage(), opts.AdditionalMirrors, false)
+	c.logger.Debugf("Saving run image %s", style.Symbol(runImageName))
+	if _, err := vc.validateRunImage(ctx, runImageName, image.FetchOptions{Daemon: true, PullPolicy: opts.PullPolicy, Platform: platform}, bldr.StackID); err != nil {
+		return nil, errors.Wrapf(err, "invalid run-image '%s'", runImageName)
+	}
+
+	if _, _, err := vc.processBuildpacks(ctx, bldr.Image(), bldr.Buildpacks(), bldr.Order(), bldr.StackID, opts); err != nil {
+		return nil, err
+	}
+	if _, _, err := vc.processExtensions(ctx, bldr.Image(), bldr.Extensions(), bldr.OrderExtensions(), bldr.StackID, opts); err != nil {
+		return nil, err
+	}
+
+	vendored := &VendoredBuild{
+		Builder:    builderRef.Name(),
+		RunImage:   runImageName,
+		Registries: resolver.registries,
+	}
+
+	// the lifecycle image is saved even for trusted builders, so the build can run without trusting the builder
+	lifecycleVersion := bldr.LifecycleDescriptor().Info.Version
+	if supportsLifecycleImage(lifecycleVersion) {
+		lifecycleImageName := opts.LifecycleImage
+		if lifecycleImageName == "" {
+			lifecycleImageName = fmt.Sprintf("%s:%s", internalConfig.DefaultLifecycleImageRepo, lifecycleVersion.String())
+		}
+
+		c.logger.Debugf("Saving lifecycle image %s", style.Symbol(lifecycleImageName))
+		if _, _, err := vc.fetchLifecycleImage(ctx, lifecycleImageName, opts.PullPolicy, fmt.Sprintf("%s/%s", imgOS, imgArch)); err != nil {
+			return nil, err
+		}
+		vendored.LifecycleImage = lifecycleImageName
+	}
+
+	vendored.Images = sortedKeys(fetcher.images)
+	vendored.Downloads = downloader.uris
+	sort.Strings(vendored.Downloads)
+
+	if err := writeVendoredBuild(opts.VendorDir, vendored); err != nil {
+		return nil, err
+	}
+	return vendored, nil
+}
+
+// buildVendored runs a build from the artifacts saved to opts.VendorDir by PrepareBuild. The images of the vendor
+// directory are loaded to the daemon when they are not present, and the build fails when it needs an artifact that
+// was not saved.
+func (c *Client) buildVendored(ctx context.Context, opts BuildOptions) error {
+	if opts.Publish || opts.Layout() {
+		return errors.New("building from a vendor directory requires exporting the app image to the daemon")
+	}
+	if len(opts.Targets) > 1 {
+		return errors.New("building from a vendor directory is only supported for a single platform")
+	}
+
+	vendored, err := readVendoredBuild(opts.VendorDir)
+	if err != nil {
+		return err
+	}
+
+	if opts.Builder == "" {
+		opts.Builder = vendored.Builder
+	}
+	if opts.RunImage == "" {
+		opts.RunImage = vendored.RunImage
+	}
+	if opts.LifecycleImage == "" {
+		opts.LifecycleImage = vendored.LifecycleImage
+	}
+
+	vc := *c
+	vc.imageFetcher = &vendorFetcher{
+		fetcher:         c.imageFetcher,
+		docker:          c.docker,
+		dir:             filepath.Join(opts.VendorDir, vendorImagesDir),
+		registryMirrors: c.registryMirrors,
+		logger:          c.logger,
+	}
+	vc.downloader = blob.NewDownloader(c.logger, filepath.Join(opts.VendorDir, vendorDownloadsDir), blob.WithOffline(true))
+	vc.buildpackDownloader = buildpack.NewDownloader(c.logger, vc.imageFetcher, vc.downloader, &vendorRegistryResolver{registries: vendored.Registries})
+
+	opts.VendorDir = ""
+	return vc.Build(ctx, opts)
+}
+
+func readVendoredBuild(dir string) (*VendoredBuild, error) {
+	content, err := os.ReadFile(filepath.Join(dir, vendorManifestFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.Errorf("%s is not a vendor directory, it is created by preparing a build", style.Symbol(dir))
+		}
+		return nil, errors.Wrap(err, "reading vendor directory")
+	}
+
+	vendored := &VendoredBuild{}
+	if err := json.Unmarshal(content, vendored); err != nil {
+		return nil, errors.Wrapf(err, "parsing %s", style.Symbol(vendorManifestFile))
+	}
+	return vendored, nil
+}
+
+func writeVendoredBuild(dir string, vendored *VendoredBuild) error {
+	content, err := json.MarshalIndent(vendored, "", "  ")
+	if err != nil {
+		return err
+	}
+	return errors.Wrap(os.WriteFile(filepath.Join(dir, vendorManifestFile), content, 0644), "writing vendor directory")
+}
+
+// vendoredImagePath returns the OCI layout of the image named ref in the images directory of a vendor directory
+func vendoredImagePath(dir, ref string) (string, error) {
+	refPath, err := layout.ParseRefToPath(ref)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, refPath), nil
+}
+
+func sortedKeys(m map[string]imgutil.Image) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// vendoringFetcher saves every image it fetches to an OCI layout in dir. Daemon images are read from the daemon or
+// from their registry as their pull policy says, so that images which only exist on the daemon can be saved too.
+type vendoringFetcher struct {
+	fetcher ImageFetcher
+	docker  DockerClient
+	dir     string
+
+	// platform the images are saved for when the fetch does not define one
+	platform string
+
+	images map[string]imgutil.Image
+}
+
+func (f *vendoringFetcher) Fetch(ctx context.Context, name string, options image.FetchOptions) (imgutil.Image, error) {
+	if img, ok := f.images[name]; ok {
+		return img, nil
+	}
+
+	path, err := vendoredImagePath(f.dir, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if options.Platform == "" {
+		options.Platform = f.platform
+	}
+
+	var img imgutil.Image
+	switch {
+	case !options.Daemon:
+		img, err = f.fetchToLayout(ctx, name, path, options)
+	case options.PullPolicy == image.PullAlways:
+		img, err = f.fetchToLayout(ctx, name, path, options)
+		if err != nil {
+			if daemonImg, daemonErr := f.saveDaemonImage(ctx, name, path, options); daemonErr == nil {
+				img, err = daemonImg, nil
+			}
+		}
+	default:
+		img, err = f.saveDaemonImage(ctx, name, path, options)
+		if errors.Is(err, image.ErrNotFound) && options.PullPolicy == image.PullIfNotPresent {
+			img, err = f.fetchToLayout(ctx, name, path, options)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	f.images[name] = img
+	return img, nil
+}
+
+// fetchToLayout reads the image from its registry into the layout at path
+func (f *vendoringFetcher) fetchToLayout(ctx context.Context, name, path string, options image.FetchOptions) (imgutil.Image, error) {
+	// the layout is written again, rather than appended to, when the vendor directory is prepared again
+	if err := os.RemoveAll(path); err != nil {
+		return nil, err
+	}
+	options.LayoutOption = image.LayoutOption{Path: path}
+	return f.fetcher.Fetch(ctx, name, options)
+}
+
+// saveDaemonImage writes the image on the daemon to the layout at path, without pulling it
+func (f *vendoringFetcher) saveDaemonImage(ctx context.Context, imageName, path string, options image.FetchOptions) (imgutil.Image, error) {
+	options.PullPolicy = image.PullNever
+	img, err := f.fetcher.Fetch(ctx, imageName, options)
+	if err != nil {
+		return nil, err
+	}
+
+	ref, err := name.ParseReference(imageName, name.WeakValidation)
+	if err != nil {
+		return nil, err
+	}
+	daemonImage, err := daemon.Image(ref, daemon.WithClient(daemonClient{f.docker}), daemon.WithContext(ctx))
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading image %s from the daemon", style.Symbol(imageName))
+	}
+	configFile, err := daemonImage.ConfigFile()
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading image %s from the daemon", style.Symbol(imageName))
+	}
+
+	if err := os.RemoveAll(path); err != nil {
+		return nil, err
+	}
+	p, err := ggcrlayout.Write(path, empty.Index)
+	if err != nil {
+		return nil, errors.Wrapf(err, "writing layout for image %s", style.Symbol(imageName))
+	}
+	platform := v1.Platform{OS: configFile.OS, Architecture: configFile.Architecture, Variant: configFile.Variant}
+	if err := p.AppendImage(daemonImage, ggcrlayout.WithPlatform(platform)); err != nil {
+		return nil, errors.Wrapf(err, "writing layout for image %s", style.Symbol(imageName))
+	}
+	return img, nil
+}
+
+// vendoringDownloader records the remote URIs it downloads
+type vendoringDownloader struct {
+	downloader blob.Downloader
+	uris       []string
+}
+
+func (d *vendoringDownloader) Download(ctx context.Context, pathOrURI string) (blob.Blob, error) {
+	b, err := d.downloader.Download(ctx, pathOrURI)
+	if err != nil {
+		return nil, err
+	}
+
+	// local paths and file URIs are read from the app directory, they are not part of the vendor directory
+	if uri, err := url.Parse(pathOrURI); err == nil && (uri.Scheme == "http" || uri.Scheme == "https") {
+		d.uris = append(d.uris, pathOrURI)
+	}
+	return b, nil
+}
+
+// vendoringRegistryResolver records the addresses it resolves
+type vendoringRegistryResolver struct {
+	resolver   buildpack.RegistryResolver
+	registries map[string]map[string]string
+}
+
+func (r *vendoringRegistryResolver) Resolve(registryName, bpURI string) (string, error) {
+	address, err := r.resolver.Resolve(registryName, bpURI)
+	if err != nil {
+		return "", err
+	}
+
+	if r.registries == nil {
+		r.registries = map[string]map[string]string{}
+	}
+	if r.registries[registryName] == nil {
+		r.registries[registryName] = map[string]string{}
+	}
+	r.registries[registryName][bpURI] = address
+	return address, nil
+}
+
+// vendorRegistryResolver resolves buildpacks from the addresses recorded in a vendor directory
+type vendorRegistryResolver struct {
+	registries map[string]map[string]string
+}
+
+func (r *vendorRegistryResolver) Resolve(registryName, bpURI string) (string, error) {
+	address, ok := r.registries[registryName][bpURI]
+	if !ok {
+		return "", offline.NewMissingArtifactError(offline.KindRegistry, bpURI)
+	}
+	return address, nil
+}
+
+// vendorFetcher fetches daemon images from the OCI layouts in dir when they are not on the daemon. Images named by a
+// tag are loaded to the daemon, while images named by a digest, such as buildpacks located in a registry, are read
+// from their layout.
+type vendorFetcher struct {
+	fetcher         ImageFetcher
+	docker          DockerClient
+	dir             string
+	registryMirrors map[string]string
+	logger          logging.Logger
+}
+
+func (f *vendorFetcher) Fetch(ctx context.Context, imageName string, options image.FetchOptions) (imgutil.Image, error) {
+	if !options.Daemon || (options.LayoutOption != image.LayoutOption{}) {
+		return f.fetcher.Fetch(ctx, imageName, options)
+	}
+
+	options.PullPolicy = image.PullNever
+	img, err := f.fetcher.Fetch(ctx, imageName, options)
+	if err == nil || !errors.Is(err, image.ErrNotFound) {
+		return img, err
+	}
+
+	path, err := vendoredImagePath(f.dir, imageName)
+	if err != nil {
+		return nil, err
+	}
+	if !layout.ImageExists(path) {
+		return nil, offline.NewMissingArtifactError(offline.KindImage, imageName)
+	}
+
+	ref, err := name.ParseReference(imageName, name.WeakValidation)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := ref.(name.Tag); !ok {
+		return layout.NewImage(path, layout.FromBaseImagePath(path))
+	}
+
+	if err := f.load(ctx, imageName, path); err != nil {
+		return nil, errors.Wrapf(err, "loading %s from the vendor directory", style.Symbol(imageName))
+	}
+	return f.fetcher.Fetch(ctx, imageName, options)
+}
+
+// load loads the image of the OCI layout at path to the daemon, under the name the daemon image is fetched with
+func (f *vendorFetcher) load(ctx context.Context, imageName, path string) error {
+	img, err := readLayoutImage(path)
+	if err != nil {
+		return err
+	}
+
+	// the daemon image is fetched from the registry mirror, as it would have been pulled
+	daemonName, err := pname.TranslateRegistry(imageName, f.registryMirrors, f.logger)
+	if err != nil {
+		return err
+	}
+	tag, err := name.NewTag(daemonName, name.WeakValidation)
+	if err != nil {
+		return err
+	}
+
+	f.logger.Debugf("Loading %s from the vendor directory", style.Symbol(imageName))
+	_, err = daemon.Write(tag, img, daemon.WithClient(daemonClient{f.docker}), daemon.WithContext(ctx))
+	return err
+}
+
+// readLayoutImage reads the image of an OCI layout written by the image fetcher
+func readLayoutImage(path string) (v1.Image, error) {
+	index, err := ggcrlayout.ImageIndexFromPath(path)
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := index.IndexManifest()
+	if err != nil {
+		return nil, err
+	}
+	if len(manifest.Manifests) == 0 {
+		return nil, errors.Errorf("no image found in %s", style.Symbol(path))
+	}
+	return index.Image(manifest.Manifests[0].Digest)
+}
+
+// daemonClient adapts a DockerClient, whose API version is set when it is created, to the client of the daemon package
+type daemonClient struct {
+	DockerClient
+}
+
+func (daemonClient) NegotiateAPIVersion(context.Context) {}
@@ -0,0 +1,330 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/buildpacks/imgutil"
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/buildpacks/imgutil/layout"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/builder"
+	cfg "github.com/buildpacks/pack/internal/config"
+	ifakes "github.com/buildpacks/pack/internal/fakes"
+	"github.com/buildpacks/pack/pkg/blob"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/offline"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestVendor(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Vendor", testVendor, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testVendor(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject          *Client
+		fakeImageFetcher *ifakes.FakeImageFetcher
+		builderImage     *fakes.Image
+		vendorDir        string
+		out              bytes.Buffer
+		logger           logging.Logger
+	)
+
+	const (
+		builderName = "example.com/some/builder:tag"
+		stackID     = "some.stack.id"
+	)
+
+	it.Before(func() {
+		tmpDir := t.TempDir()
+		vendorDir = filepath.Join(tmpDir, "vendor")
+		h.AssertNil(t, os.MkdirAll(vendorDir, 0755))
+
+		fakeImageFetcher = ifakes.NewFakeImageFetcher()
+		builderImage = newFakeBuilderImage(t, tmpDir, builderName, stackID, "example.com/some/run", builder.DefaultLifecycleVersion, newLinuxImage)
+		fakeImageFetcher.LocalImages[builderImage.Name()] = builderImage
+
+		runImage := newLinuxImage("example.com/some/run", "", nil)
+		h.AssertNil(t, runImage.SetLabel("io.buildpacks.stack.id", stackID))
+		fakeImageFetcher.LocalImages[runImage.Name()] = runImage
+
+		lifecycleImage := newLinuxImage(fmt.Sprintf("%s:%s", cfg.DefaultLifecycleImageRepo, builder.DefaultLifecycleVersion), "", nil)
+		fakeImageFetcher.LocalImages[lifecycleImage.Name()] = lifecycleImage
+
+		logger = logging.NewLogWithWriters(&out, &out)
+		subject = &Client{
+			logger:       logger,
+			imageFetcher: fakeImageFetcher,
+		}
+	})
+
+	it.After(func() {
+		h.AssertNilE(t, builderImage.Cleanup())
+	})
+
+	when("#PrepareBuild", func() {
+		it("saves the builder, run image and lifecycle image to the vendor directory", func() {
+			vendored, err := subject.PrepareBuild(context.TODO(), BuildOptions{
+				Builder:   builderName,
+				VendorDir: vendorDir,
+			})
+			h.AssertNil(t, err)
+
+			lifecycleImageName := fmt.Sprintf("%s:%s", cfg.DefaultLifecycleImageRepo, builder.DefaultLifecycleVersion)
+			h.AssertEq(t, vendored.Builder, builderName)
+			h.AssertEq(t, vendored.RunImage, "example.com/some/run")
+			h.AssertEq(t, vendored.LifecycleImage, lifecycleImageName)
+			h.AssertEq(t, vendored.Images, []string{lifecycleImageName, "example.com/some/builder:tag", "example.com/some/run"})
+
+			h.AssertEq(t, fakeImageFetcher.FetchCalls[builderName].LayoutOption.Path, filepath.Join(vendorDir, "images", "example.com", "some", "builder", "tag"))
+			h.AssertEq(t, fakeImageFetcher.FetchCalls["example.com/some/run"].LayoutOption.Path, filepath.Join(vendorDir, "images", "example.com", "some", "run", "latest"))
+			h.AssertEq(t, fakeImageFetcher.FetchCalls["example.com/some/run"].Platform, "linux/amd64")
+
+			saved, err := readVendoredBuild(vendorDir)
+			h.AssertNil(t, err)
+			h.AssertEq(t, saved, vendored)
+		})
+
+		it("saves the run image given in the options", func() {
+			runImage := newLinuxImage("example.com/other/run", "", nil)
+			h.AssertNil(t, runImage.SetLabel("io.buildpacks.stack.id", stackID))
+			fakeImageFetcher.LocalImages[runImage.Name()] = runImage
+
+			vendored, err := subject.PrepareBuild(context.TODO(), BuildOptions{
+				Builder:   builderName,
+				RunImage:  "example.com/other/run",
+				VendorDir: vendorDir,
+			})
+			h.AssertNil(t, err)
+			h.AssertEq(t, vendored.RunImage, "example.com/other/run")
+		})
+
+		it("fails when the run image does not match the stack of the builder", func() {
+			runImage := newLinuxImage("example.com/other/run", "", nil)
+			h.AssertNil(t, runImage.SetLabel("io.buildpacks.stack.id", "other.stack.id"))
+			fakeImageFetcher.LocalImages[runImage.Name()] = runImage
+
+			_, err := subject.PrepareBuild(context.TODO(), BuildOptions{
+				Builder:   builderName,
+				RunImage:  "example.com/other/run",
+				VendorDir: vendorDir,
+			})
+			h.AssertError(t, err, "invalid run-image 'example.com/other/run'")
+		})
+
+		it("requires a vendor directory", func() {
+			_, err := subject.PrepareBuild(context.TODO(), BuildOptions{Builder: builderName})
+			h.AssertError(t, err, "a vendor directory is required to prepare a build")
+		})
+
+		it("cannot run offline", func() {
+			subject.offline = true
+			_, err := subject.PrepareBuild(context.TODO(), BuildOptions{Builder: builderName, VendorDir: vendorDir})
+			h.AssertError(t, err, "it cannot run offline")
+		})
+	})
+
+	when("#readVendoredBuild", func() {
+		it("fails when the directory was not prepared", func() {
+			_, err := readVendoredBuild(t.TempDir())
+			h.AssertError(t, err, "is not a vendor directory")
+		})
+	})
+
+	when("vendoringFetcher", func() {
+		var (
+			fetcher          *vendoringFetcher
+			mockController   *gomock.Controller
+			mockDockerClient *testmocks.MockCommonAPIClient
+			daemonImage      v1.Image
+		)
+
+		it.Before(func() {
+			mockController = gomock.NewController(t)
+			mockDockerClient = testmocks.NewMockCommonAPIClient(mockController)
+			fetcher = &vendoringFetcher{
+				fetcher: fakeImageFetcher,
+				docker:  mockDockerClient,
+				dir:     filepath.Join(vendorDir, "images"),
+				images:  map[string]imgutil.Image{},
+			}
+
+			var err error
+			daemonImage, err = random.Image(10, 1)
+			h.AssertNil(t, err)
+			configName, err := daemonImage.ConfigName()
+			h.AssertNil(t, err)
+
+			mockDockerClient.EXPECT().ImageInspectWithRaw(gomock.Any(), gomock.Any()).
+				Return(types.ImageInspect{ID: configName.String(), Created: "2024-01-01T00:00:00Z", Os: "linux", Architecture: "amd64", Config: &container.Config{}}, nil, nil).AnyTimes()
+			mockDockerClient.EXPECT().ImageHistory(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+			mockDockerClient.EXPECT().ImageSave(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, refs []string) (io.ReadCloser, error) {
+				tag, err := name.NewTag(refs[0])
+				h.AssertNil(t, err)
+				var buf bytes.Buffer
+				h.AssertNil(t, tarball.Write(tag, daemonImage, &buf))
+				return io.NopCloser(&buf), nil
+			}).AnyTimes()
+		})
+
+		it.After(func() {
+			mockController.Finish()
+		})
+
+		it("saves images from the daemon without reading their registry when they are never pulled", func() {
+			img, err := fetcher.Fetch(context.TODO(), builderName, image.FetchOptions{Daemon: true, PullPolicy: image.PullNever})
+			h.AssertNil(t, err)
+			h.AssertEq(t, img.Name(), builderName)
+			h.AssertEq(t, fakeImageFetcher.FetchCalls[builderName].LayoutOption, image.LayoutOption{})
+
+			path, err := vendoredImagePath(fetcher.dir, builderName)
+			h.AssertNil(t, err)
+			h.AssertTrue(t, layout.ImageExists(path))
+		})
+
+		it("falls back to the daemon when the image cannot be read from its registry", func() {
+			failingFetcher := &layoutFailingFetcher{ImageFetcher: fakeImageFetcher}
+			fetcher.fetcher = failingFetcher
+
+			_, err := fetcher.Fetch(context.TODO(), builderName, image.FetchOptions{Daemon: true, PullPolicy: image.PullAlways})
+			h.AssertNil(t, err)
+			h.AssertTrue(t, failingFetcher.layoutFetched)
+
+			path, err := vendoredImagePath(fetcher.dir, builderName)
+			h.AssertNil(t, err)
+			h.AssertTrue(t, layout.ImageExists(path))
+		})
+	})
+
+	when("vendoringDownloader", func() {
+		it("records the remote URIs it downloads", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte("some-content"))
+			}))
+			defer server.Close()
+
+			localFile := filepath.Join(t.TempDir(), "buildpack.tgz")
+			h.AssertNil(t, os.WriteFile(localFile, []byte("some-content"), 0644))
+
+			downloader := &vendoringDownloader{downloader: blob.NewDownloader(logging.NewSimpleLogger(&out), filepath.Join(vendorDir, "downloads"))}
+			_, err := downloader.Download(context.TODO(), server.URL+"/buildpack.tgz")
+			h.AssertNil(t, err)
+			_, err = downloader.Download(context.TODO(), localFile)
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, downloader.uris, []string{server.URL + "/buildpack.tgz"})
+
+			// the download can then be read from the vendor directory without network access
+			offlineDownloader := blob.NewDownloader(logging.NewSimpleLogger(&out), filepath.Join(vendorDir, "downloads"), blob.WithOffline(true))
+			server.Close()
+			_, err = offlineDownloader.Download(context.TODO(), server.URL+"/buildpack.tgz")
+			h.AssertNil(t, err)
+		})
+	})
+
+	when("vendoringRegistryResolver", func() {
+		it("records the addresses it resolves by registry", func() {
+			mockController := gomock.NewController(t)
+			defer mockController.Finish()
+			mockResolver := testmocks.NewMockRegistryResolver(mockController)
+			mockResolver.EXPECT().Resolve("some-registry", "example/foo@1.0.0").Return("example.com/foo@sha256:abc", nil)
+
+			resolver := &vendoringRegistryResolver{resolver: mockResolver}
+			address, err := resolver.Resolve("some-registry", "example/foo@1.0.0")
+			h.AssertNil(t, err)
+			h.AssertEq(t, address, "example.com/foo@sha256:abc")
+
+			vendorResolver := &vendorRegistryResolver{registries: resolver.registries}
+			address, err = vendorResolver.Resolve("some-registry", "example/foo@1.0.0")
+			h.AssertNil(t, err)
+			h.AssertEq(t, address, "example.com/foo@sha256:abc")
+		})
+	})
+
+	when("vendorRegistryResolver", func() {
+		it("reports the buildpacks which were not resolved when preparing the build", func() {
+			resolver := &vendorRegistryResolver{registries: map[string]map[string]string{"": {"example/foo": "example.com/foo@sha256:abc"}}}
+			_, err := resolver.Resolve("", "example/bar")
+			h.AssertTrue(t, offline.IsMissingArtifact(err))
+			h.AssertError(t, err, "registry 'example/bar' is not available locally")
+		})
+	})
+
+	when("vendorFetcher", func() {
+		var fetcher *vendorFetcher
+
+		it.Before(func() {
+			fetcher = &vendorFetcher{
+				fetcher: fakeImageFetcher,
+				dir:     filepath.Join(vendorDir, "images"),
+				logger:  logger,
+			}
+		})
+
+		it("fetches the images on the daemon without pulling them", func() {
+			img, err := fetcher.Fetch(context.TODO(), builderName, image.FetchOptions{Daemon: true, PullPolicy: image.PullAlways})
+			h.AssertNil(t, err)
+			h.AssertEq(t, img.Name(), builderName)
+			h.AssertEq(t, fakeImageFetcher.FetchCalls[builderName].PullPolicy, image.PullNever)
+		})
+
+		it("reads images named by a digest from the vendor directory", func() {
+			imageName := "example.com/some/package@sha256:8c27fe111c11b722081701dfed3bd55e039b9ce92865473cf4cdfa918071c566"
+			path, err := vendoredImagePath(fetcher.dir, imageName)
+			h.AssertNil(t, err)
+			saved, err := layout.NewImage(path)
+			h.AssertNil(t, err)
+			h.AssertNil(t, saved.SetLabel("some-label", "some-value"))
+			h.AssertNil(t, saved.Save())
+
+			img, err := fetcher.Fetch(context.TODO(), imageName, image.FetchOptions{Daemon: true})
+			h.AssertNil(t, err)
+			label, err := img.Label("some-label")
+			h.AssertNil(t, err)
+			h.AssertEq(t, label, "some-value")
+		})
+
+		it("reports the images which are not in the vendor directory", func() {
+			_, err := fetcher.Fetch(context.TODO(), "example.com/other/image", image.FetchOptions{Daemon: true})
+			h.AssertTrue(t, offline.IsMissingArtifact(err))
+			h.AssertError(t, err, "image 'example.com/other/image' is not available locally")
+		})
+	})
+}
+
+// layoutFailingFetcher fails the fetches to a layout, as for an image which is not in any registry
+type layoutFailingFetcher struct {
+	ImageFetcher
+	layoutFetched bool
+}
+
+func (f *layoutFailingFetcher) Fetch(ctx context.Context, name string, options image.FetchOptions) (imgutil.Image, error) {
+	if (options.LayoutOption != image.LayoutOption{}) {
+		f.layoutFetched = true
+		return nil, errors.New("some-registry-error")
+	}
+	return f.ImageFetcher.Fetch(ctx, name, options)
+}
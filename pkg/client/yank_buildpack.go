@@ -1,10 +1,11 @@
 package client
 
 import (
-	"net/url"
-	"runtime"
+	"fmt"
 
 	"github.com/buildpacks/pack/internal/registry"
+	"github.com/buildpacks/pack/internal/style"
+	registryTypes "github.com/buildpacks/pack/registry"
 )
 
 // YankBuildpackOptions is a configuration struct that controls the Yanking a buildpack
@@ -15,16 +16,23 @@ type YankBuildpackOptions struct {
 	Type    string
 	URL     string
 	Yank    bool
+
+	// Token authenticates to the REST API of the registry. When set, the yank issue is created through the API
+	// instead of being opened in a browser.
+	Token string
+
+	// APIURL is the REST API endpoint of the registry. When empty, it is derived from URL.
+	APIURL string
 }
 
 // YankBuildpack marks a buildpack on the Buildpack Registry as 'yanked'. This forbids future
 // builds from using it.
 func (c *Client) YankBuildpack(opts YankBuildpackOptions) error {
-	namespace, name, err := registry.ParseNamespaceName(opts.ID)
-	if err != nil {
-		return err
+	if opts.Type == registryTypes.TypeHTTP || opts.Type == registryTypes.TypeOCI {
+		return fmt.Errorf("yanking buildpacks is not supported for %s registries, they are read-only", style.Symbol(opts.Type))
 	}
-	issueURL, err := registry.GetIssueURL(opts.URL)
+
+	namespace, name, err := registry.ParseNamespaceName(opts.ID)
 	if err != nil {
 		return err
 	}
@@ -36,21 +44,5 @@ func (c *Client) YankBuildpack(opts YankBuildpackOptions) error {
 		Yanked:    opts.Yank,
 	}
 
-	issue, err := registry.CreateGithubIssue(buildpack)
-	if err != nil {
-		return err
-	}
-
-	params := url.Values{}
-	params.Add("title", issue.Title)
-	params.Add("body", issue.Body)
-	issueURL.RawQuery = params.Encode()
-
-	c.logger.Debugf("Open URL in browser: %s", issueURL)
-	cmd, err := registry.CreateBrowserCmd(issueURL.String(), runtime.GOOS)
-	if err != nil {
-		return err
-	}
-
-	return cmd.Start()
+	return c.submitGithubIssue(buildpack, opts.URL, opts.APIURL, opts.Token)
 }
@@ -82,5 +82,55 @@ func testYankBuildpack(t *testing.T, when spec.G, it spec.S) {
 			h.AssertNotNil(t, err)
 			h.AssertContains(t, err.Error(), "invalid URI for request")
 		})
+
+		it("should return error for read-only registries (http)", func() {
+			err := subject.YankBuildpack(YankBuildpackOptions{
+				ID:      "heroku/java",
+				Version: "0.2.1",
+				Type:    "http",
+				URL:     "https://registry.example.com/index",
+			})
+			h.AssertError(t, err, "yanking buildpacks is not supported for 'http' registries")
+		})
+
+		it("should return error for read-only registries (oci)", func() {
+			err := subject.YankBuildpack(YankBuildpackOptions{
+				ID:      "heroku/java",
+				Version: "0.2.1",
+				Type:    "oci",
+				URL:     "oci://registry.example.com/buildpacks/index",
+			})
+			h.AssertError(t, err, "yanking buildpacks is not supported for 'oci' registries")
+		})
+
+		when("a token is provided", func() {
+			var api *ifakes.FakeGithubAPI
+
+			it.Before(func() {
+				api = ifakes.NewFakeGithubAPI("some-token")
+			})
+
+			it.After(func() {
+				api.Close()
+			})
+
+			it("creates the yank issue through the API", func() {
+				err := subject.YankBuildpack(YankBuildpackOptions{
+					ID:      "heroku/java",
+					Version: "0.2.1",
+					Type:    "github",
+					URL:     "https://github.com/buildpacks/registry-index",
+					Yank:    true,
+					Token:   "some-token",
+					APIURL:  api.URL(),
+				})
+				h.AssertNil(t, err)
+
+				issues := api.Issues()
+				h.AssertEq(t, len(issues), 1)
+				h.AssertEq(t, issues[0].Title, "YANK heroku/java@0.2.1")
+				h.AssertContains(t, out.String(), "Created issue #1 (open): "+api.URL()+"/buildpacks/registry-index/issues/1")
+			})
+		})
 	})
 }
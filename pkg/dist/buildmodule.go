@@ -1,6 +1,8 @@
 package dist
 
 import (
+	"strings"
+
 	"github.com/pkg/errors"
 
 	"github.com/buildpacks/pack/internal/style"
@@ -55,9 +57,44 @@ type Stack struct {
 type Target struct {
 	OS            string         `json:"os" toml:"os"`
 	Arch          string         `json:"arch" toml:"arch"`
+	ArchVariant   string         `json:"variant,omitempty" toml:"variant,omitempty"`
 	Distributions []Distribution `json:"distributions,omitempty" toml:"distributions,omitempty"`
 }
 
+// ParseTarget parses a platform in the form '<os>[/<arch>[/<variant>]]' into a Target
+func ParseTarget(platform string) (Target, error) {
+	parts := strings.Split(platform, "/")
+	if len(parts) > 3 {
+		return Target{}, errors.Errorf("invalid platform %s, expected format %s", style.Symbol(platform), style.Symbol("<os>[/<arch>[/<variant>]]"))
+	}
+	for _, part := range parts {
+		if part == "" {
+			return Target{}, errors.Errorf("invalid platform %s, expected format %s", style.Symbol(platform), style.Symbol("<os>[/<arch>[/<variant>]]"))
+		}
+	}
+
+	target := Target{OS: parts[0]}
+	if len(parts) > 1 {
+		target.Arch = parts[1]
+	}
+	if len(parts) > 2 {
+		target.ArchVariant = parts[2]
+	}
+	return target, nil
+}
+
+// Platform returns the target in the form '<os>[/<arch>[/<variant>]]'
+func (t Target) Platform() string {
+	platform := t.OS
+	if t.Arch != "" {
+		platform += "/" + t.Arch
+		if t.ArchVariant != "" {
+			platform += "/" + t.ArchVariant
+		}
+	}
+	return platform
+}
+
 type Distribution struct {
 	Name     string   `json:"name,omitempty" toml:"name,omitempty"`
 	Versions []string `json:"versions,omitempty" toml:"versions,omitempty"`
@@ -100,4 +100,33 @@ func testBuildModule(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 	})
+
+	when("#ParseTarget", func() {
+		it("parses os, arch and variant", func() {
+			target, err := dist.ParseTarget("linux/arm64/v8")
+			h.AssertNil(t, err)
+			h.AssertEq(t, target, dist.Target{OS: "linux", Arch: "arm64", ArchVariant: "v8"})
+		})
+
+		it("parses os only", func() {
+			target, err := dist.ParseTarget("windows")
+			h.AssertNil(t, err)
+			h.AssertEq(t, target, dist.Target{OS: "windows"})
+		})
+
+		it("fails for malformed platforms", func() {
+			for _, platform := range []string{"", "linux/", "/amd64", "linux/arm/v7/extra"} {
+				_, err := dist.ParseTarget(platform)
+				h.AssertError(t, err, "invalid platform")
+			}
+		})
+	})
+
+	when("#Platform", func() {
+		it("formats the target", func() {
+			h.AssertEq(t, dist.Target{OS: "linux", Arch: "arm", ArchVariant: "v7"}.Platform(), "linux/arm/v7")
+			h.AssertEq(t, dist.Target{OS: "linux", Arch: "amd64"}.Platform(), "linux/amd64")
+			h.AssertEq(t, dist.Target{OS: "linux", ArchVariant: "v7"}.Platform(), "linux")
+		})
+	})
 }
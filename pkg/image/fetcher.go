@@ -24,6 +24,7 @@ import (
 	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/internal/term"
 	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/offline"
 )
 
 // FetcherOption is a type of function that mutate settings on the client.
@@ -48,6 +49,14 @@ func WithKeychain(keychain authn.Keychain) FetcherOption {
 	}
 }
 
+// WithOffline never pulls images to the daemon. Images which would have been pulled and are not on the daemon fail
+// with an offline.MissingArtifactsError.
+func WithOffline(offline bool) FetcherOption {
+	return func(c *Fetcher) {
+		c.offline = offline
+	}
+}
+
 type DockerClient interface {
 	local.DockerClient
 	ImagePull(ctx context.Context, ref string, options types.ImagePullOptions) (io.ReadCloser, error)
@@ -58,6 +67,7 @@ type Fetcher struct {
 	logger          logging.Logger
 	registryMirrors map[string]string
 	keychain        authn.Keychain
+	offline         bool
 }
 
 type FetchOptions struct {
@@ -90,11 +100,25 @@ func (f *Fetcher) Fetch(ctx context.Context, name string, options FetchOptions)
 	}
 
 	if (options.LayoutOption != LayoutOption{}) {
-		return f.fetchLayoutImage(name, options.LayoutOption)
+		if f.offline {
+			return f.fetchOfflineLayoutImage(name, options.LayoutOption)
+		}
+		return f.fetchLayoutImage(name, options.LayoutOption, options.Platform)
 	}
 
 	if !options.Daemon {
-		return f.fetchRemoteImage(name)
+		if f.offline {
+			return nil, offline.NewMissingArtifactError(offline.KindImage, name)
+		}
+		return f.fetchRemoteImage(name, options.Platform)
+	}
+
+	if f.offline {
+		img, err := f.fetchDaemonImage(name)
+		if errors.Is(err, ErrNotFound) {
+			return nil, offline.WrapMissingArtifact(err, offline.KindImage, name)
+		}
+		return img, err
 	}
 
 	switch options.PullPolicy {
@@ -130,8 +154,13 @@ func (f *Fetcher) fetchDaemonImage(name string) (imgutil.Image, error) {
 	return image, nil
 }
 
-func (f *Fetcher) fetchRemoteImage(name string) (imgutil.Image, error) {
-	image, err := remote.NewImage(name, f.keychain, remote.FromBaseImage(name))
+func (f *Fetcher) fetchRemoteImage(name string, platform string) (imgutil.Image, error) {
+	imageOpts := []remote.ImageOption{remote.FromBaseImage(name)}
+	if platform != "" {
+		imageOpts = append(imageOpts, remote.WithDefaultPlatform(parsePlatform(platform)))
+	}
+
+	image, err := remote.NewImage(name, f.keychain, imageOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -143,13 +172,29 @@ func (f *Fetcher) fetchRemoteImage(name string) (imgutil.Image, error) {
 	return image, nil
 }
 
-func (f *Fetcher) fetchLayoutImage(name string, options LayoutOption) (imgutil.Image, error) {
+// parsePlatform converts a platform in the form '<os>[/<arch>[/<variant>]]' to an imgutil.Platform.
+// The architecture defaults to amd64 and the variant is not taken into account when selecting an image from an index.
+func parsePlatform(platform string) imgutil.Platform {
+	parts := strings.Split(platform, "/")
+	p := imgutil.Platform{OS: parts[0], Architecture: "amd64"}
+	if len(parts) > 1 && parts[1] != "" {
+		p.Architecture = parts[1]
+	}
+	return p
+}
+
+func (f *Fetcher) fetchLayoutImage(name string, options LayoutOption, platform string) (imgutil.Image, error) {
 	var (
 		image imgutil.Image
 		err   error
 	)
 
-	v1Image, err := remote.NewV1Image(name, f.keychain)
+	var v1Opts []remote.V1ImageOption
+	if platform != "" {
+		v1Opts = append(v1Opts, remote.WithV1DefaultPlatform(parsePlatform(platform)))
+	}
+
+	v1Image, err := remote.NewV1Image(name, f.keychain, v1Opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -172,6 +217,16 @@ func (f *Fetcher) fetchLayoutImage(name string, options LayoutOption) (imgutil.I
 	return image, nil
 }
 
+// fetchOfflineLayoutImage returns the image previously saved to the layout, as the base image cannot be read from
+// its registry
+func (f *Fetcher) fetchOfflineLayoutImage(name string, options LayoutOption) (imgutil.Image, error) {
+	if !layout.ImageExists(options.Path) {
+		return nil, offline.NewMissingArtifactError(offline.KindImage, name)
+	}
+
+	return layout.NewImage(options.Path, layout.FromBaseImagePath(options.Path))
+}
+
 func (f *Fetcher) pullImage(ctx context.Context, imageID string, platform string) error {
 	regAuth, err := f.registryAuth(imageID)
 	if err != nil {
@@ -3,6 +3,7 @@ package image_test
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -18,6 +19,7 @@ import (
 
 	"github.com/buildpacks/pack/pkg/image"
 	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/offline"
 	h "github.com/buildpacks/pack/testhelpers"
 )
 
@@ -216,6 +218,56 @@ func testFetcher(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 
+			when("offline", func() {
+				it.Before(func() {
+					imageFetcher = image.NewFetcher(logging.NewLogWithWriters(&outBuf, &outBuf), docker, image.WithOffline(true))
+				})
+
+				when("there is a local image", func() {
+					it.Before(func() {
+						// an invalid host verifies that nothing is pulled
+						repoName = "invalidhost" + repoName
+
+						img, err := local.NewImage(repoName, docker)
+						h.AssertNil(t, err)
+						h.AssertNil(t, img.Save())
+					})
+
+					it.After(func() {
+						h.DockerRmi(docker, repoName)
+					})
+
+					it("returns the local image without pulling it", func() {
+						_, err := imageFetcher.Fetch(context.TODO(), repoName, image.FetchOptions{Daemon: true, PullPolicy: image.PullAlways})
+						h.AssertNil(t, err)
+					})
+				})
+
+				when("there is no local image", func() {
+					it("reports the image as missing", func() {
+						_, err := imageFetcher.Fetch(context.TODO(), repoName, image.FetchOptions{Daemon: true, PullPolicy: image.PullAlways})
+						h.AssertError(t, err, fmt.Sprintf("running offline, image '%s' is not available locally", repoName))
+						h.AssertTrue(t, offline.IsMissingArtifact(err))
+					})
+
+					it("reports the image as missing for PullNever, keeping the not found error", func() {
+						_, err := imageFetcher.Fetch(context.TODO(), repoName, image.FetchOptions{Daemon: true, PullPolicy: image.PullNever})
+						h.AssertTrue(t, offline.IsMissingArtifact(err))
+						h.AssertTrue(t, errors.Is(err, image.ErrNotFound))
+					})
+
+					it("reports remote images as missing", func() {
+						_, err := imageFetcher.Fetch(context.TODO(), repoName, image.FetchOptions{Daemon: false, PullPolicy: image.PullAlways})
+						h.AssertTrue(t, offline.IsMissingArtifact(err))
+					})
+
+					it("reports images to save to a layout as missing", func() {
+						_, err := imageFetcher.Fetch(context.TODO(), repoName, image.FetchOptions{LayoutOption: image.LayoutOption{Path: t.TempDir()}})
+						h.AssertTrue(t, offline.IsMissingArtifact(err))
+					})
+				})
+			})
+
 			when("PullIfNotPresent", func() {
 				when("there is a remote image", func() {
 					var (
@@ -0,0 +1,149 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+)
+
+// Keys of the fields attached to the entries of structured loggers during builds
+const (
+	FieldPhase       = "phase"
+	FieldBuildpackID = "buildpack_id"
+	FieldImage       = "image"
+)
+
+var _ Logger = (*SlogLogger)(nil)
+
+// SlogLogger is a Logger backed by a log/slog logger, for library users that want structured logs.
+// Fields added with With are attached to every entry, including the lines written to the writers returned by
+// Writer and WriterForLevel, which become one entry per line.
+type SlogLogger struct {
+	logger *slog.Logger
+}
+
+// NewSlogLogger creates a Logger writing its entries to logger.
+func NewSlogLogger(logger *slog.Logger) *SlogLogger {
+	return &SlogLogger{logger: logger}
+}
+
+// With returns a logger which attaches the given key-value pairs to its entries, as slog.Logger.With does.
+func (l *SlogLogger) With(args ...any) Logger {
+	return &SlogLogger{logger: l.logger.With(args...)}
+}
+
+func (l *SlogLogger) Debug(msg string) {
+	l.log(DebugLevel, msg)
+}
+
+func (l *SlogLogger) Debugf(format string, v ...interface{}) {
+	l.log(DebugLevel, fmt.Sprintf(format, v...))
+}
+
+func (l *SlogLogger) Info(msg string) {
+	l.log(InfoLevel, msg)
+}
+
+func (l *SlogLogger) Infof(format string, v ...interface{}) {
+	l.log(InfoLevel, fmt.Sprintf(format, v...))
+}
+
+func (l *SlogLogger) Warn(msg string) {
+	l.log(WarnLevel, msg)
+}
+
+func (l *SlogLogger) Warnf(format string, v ...interface{}) {
+	l.log(WarnLevel, fmt.Sprintf(format, v...))
+}
+
+func (l *SlogLogger) Error(msg string) {
+	l.log(ErrorLevel, msg)
+}
+
+func (l *SlogLogger) Errorf(format string, v ...interface{}) {
+	l.log(ErrorLevel, fmt.Sprintf(format, v...))
+}
+
+// Writer returns a Writer logging each line written to it at info level
+func (l *SlogLogger) Writer() io.Writer {
+	return l.WriterForLevel(InfoLevel)
+}
+
+// WriterForLevel returns a Writer logging each line written to it at the given level
+func (l *SlogLogger) WriterForLevel(level Level) io.Writer {
+	if !l.logger.Enabled(context.Background(), slogLevel(level)) {
+		return io.Discard
+	}
+
+	return &slogWriter{logger: l, level: level}
+}
+
+// IsVerbose returns whether debug entries are logged
+func (l *SlogLogger) IsVerbose() bool {
+	return l.logger.Enabled(context.Background(), slog.LevelDebug)
+}
+
+func (l *SlogLogger) log(level Level, msg string) {
+	msg = strings.TrimRight(string(stripColor([]byte(msg))), "\n")
+	l.logger.Log(context.Background(), slogLevel(level), msg)
+}
+
+func slogLevel(level Level) slog.Level {
+	switch level {
+	case DebugLevel:
+		return slog.LevelDebug
+	case WarnLevel:
+		return slog.LevelWarn
+	case ErrorLevel:
+		return slog.LevelError
+	}
+
+	return slog.LevelInfo
+}
+
+// slogWriter logs every complete line written to it as an entry, and the last line when it is closed. Empty lines are
+// dropped.
+type slogWriter struct {
+	sync.Mutex
+	logger *SlogLogger
+	level  Level
+	buf    bytes.Buffer
+}
+
+func (w *slogWriter) Write(p []byte) (int, error) {
+	w.Lock()
+	defer w.Unlock()
+
+	w.buf.Write(p)
+	for {
+		line, err := w.buf.ReadString(lineFeed)
+		if err != nil {
+			// keep the incomplete line until the rest of it is written
+			w.buf.Reset()
+			w.buf.WriteString(line)
+			break
+		}
+
+		if line = strings.TrimRight(line, "\r\n"); strings.TrimSpace(line) != "" {
+			w.logger.log(w.level, line)
+		}
+	}
+
+	return len(p), nil
+}
+
+// Close logs the last line when it was not terminated
+func (w *slogWriter) Close() error {
+	w.Lock()
+	defer w.Unlock()
+
+	if line := strings.TrimRight(w.buf.String(), "\r\n"); strings.TrimSpace(line) != "" {
+		w.logger.log(w.level, line)
+	}
+	w.buf.Reset()
+	return nil
+}
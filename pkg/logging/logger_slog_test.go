@@ -0,0 +1,102 @@
+package logging_test
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestSlogLogger(t *testing.T) {
+	spec.Run(t, "SlogLogger", testSlogLogger, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testSlogLogger(t *testing.T, when spec.G, it spec.S) {
+	var (
+		out    *bytes.Buffer
+		logger *logging.SlogLogger
+	)
+
+	it.Before(func() {
+		out = &bytes.Buffer{}
+		logger = logging.NewSlogLogger(slog.New(slog.NewJSONHandler(out, &slog.HandlerOptions{Level: slog.LevelInfo})))
+	})
+
+	it("logs messages at their level", func() {
+		logger.Infof("info%s", "f")
+		logger.Warn("warn_")
+		logger.Errorf("error%s", "f")
+
+		output := out.String()
+		h.AssertContains(t, output, `"level":"INFO","msg":"infof"`)
+		h.AssertContains(t, output, `"level":"WARN","msg":"warn_"`)
+		h.AssertContains(t, output, `"level":"ERROR","msg":"errorf"`)
+	})
+
+	it("does not log messages below the level of the handler", func() {
+		logger.Debug("debug_")
+		logger.Debugf("debugf")
+
+		h.AssertEq(t, out.String(), "")
+		h.AssertEq(t, logger.IsVerbose(), false)
+		h.AssertSameInstance(t, logger.WriterForLevel(logging.DebugLevel), io.Discard)
+	})
+
+	it("strips colors and trailing line feeds", func() {
+		logger.Info(color.HiBlueString("info_") + "\n")
+
+		h.AssertContains(t, out.String(), `"msg":"info_"}`)
+	})
+
+	it("attaches fields", func() {
+		fieldLogger := logging.WithFields(logger, logging.FieldBuildpackID, "some/buildpack")
+		fieldLogger = logging.WithFields(fieldLogger, logging.FieldPhase, "builder")
+		fieldLogger.Info("info_")
+
+		h.AssertContains(t, out.String(), `"msg":"info_","buildpack_id":"some/buildpack","phase":"builder"`)
+	})
+
+	when("#WriterForLevel", func() {
+		it("logs each complete line as an entry", func() {
+			writer := logging.WithFields(logger, logging.FieldPhase, "detector").Writer()
+
+			_, err := writer.Write([]byte("line 1\nline"))
+			h.AssertNil(t, err)
+			h.AssertContains(t, out.String(), `"msg":"line 1","phase":"detector"`)
+			h.AssertNotContains(t, out.String(), `"msg":"line"`)
+
+			_, err = writer.Write([]byte(" 2\r\n\n"))
+			h.AssertNil(t, err)
+			h.AssertContains(t, out.String(), `"msg":"line 2","phase":"detector"`)
+			h.AssertEq(t, bytes.Count(out.Bytes(), []byte("\n")), 2)
+		})
+
+		it("logs the last line when closed", func() {
+			writer := logger.Writer()
+
+			_, err := writer.Write([]byte("line 1\nline 2"))
+			h.AssertNil(t, err)
+			h.AssertNotContains(t, out.String(), `"msg":"line 2"`)
+
+			closer, ok := writer.(io.Closer)
+			h.AssertTrue(t, ok)
+			h.AssertNil(t, closer.Close())
+			h.AssertContains(t, out.String(), `"msg":"line 2"`)
+			h.AssertEq(t, bytes.Count(out.Bytes(), []byte("\n")), 2)
+		})
+
+		it("logs at the given level", func() {
+			_, err := logging.GetWriterForLevel(logger, logging.ErrorLevel).Write([]byte("some error\n"))
+			h.AssertNil(t, err)
+
+			h.AssertContains(t, out.String(), `"level":"ERROR","msg":"some error"`)
+		})
+	})
+}
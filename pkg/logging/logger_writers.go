@@ -4,6 +4,7 @@ package logging
 import (
 	"fmt"
 	"io"
+	"log/slog"
 	"regexp"
 	"sync"
 	"time"
@@ -40,6 +41,8 @@ type LogWithWriters struct {
 	clock    func() time.Time
 	out      io.Writer
 	errOut   io.Writer
+	// structured is set when entries are written as JSON objects
+	structured *SlogLogger
 }
 
 // NewLogWithWriters creates a logger to be used with pack CLI.
@@ -81,6 +84,11 @@ func (lw *LogWithWriters) HandleLog(e *log.Entry) error {
 	lw.Lock()
 	defer lw.Unlock()
 
+	if lw.structured != nil {
+		lw.structured.log(Level(e.Level), e.Message)
+		return nil
+	}
+
 	writer := lw.WriterForLevel(Level(e.Level))
 	_, err := fmt.Fprint(writer, appendMissingLineFeed(fmt.Sprintf("%s%s", formatLevel(e.Level), e.Message)))
 
@@ -93,6 +101,10 @@ func (lw *LogWithWriters) WriterForLevel(level Level) io.Writer {
 		return io.Discard
 	}
 
+	if lw.structured != nil {
+		return lw.structured.WriterForLevel(level)
+	}
+
 	if level == ErrorLevel {
 		return newLogWriter(lw.errOut, lw.clock, lw.wantTime)
 	}
@@ -100,8 +112,12 @@ func (lw *LogWithWriters) WriterForLevel(level Level) io.Writer {
 	return newLogWriter(lw.out, lw.clock, lw.wantTime)
 }
 
-// Writer returns the base Writer for the LogWithWriters
+// Writer returns the base Writer for the LogWithWriters, which writes JSON objects when JSON output is on
 func (lw *LogWithWriters) Writer() io.Writer {
+	if lw.structured != nil {
+		return lw.structured.Writer()
+	}
+
 	return lw.out
 }
 
@@ -110,6 +126,27 @@ func (lw *LogWithWriters) WantTime(f bool) {
 	lw.wantTime = f
 }
 
+// WantJSON turns on JSON output, every entry is written to the standard writer as a JSON object
+func (lw *LogWithWriters) WantJSON(f bool) {
+	if !f {
+		lw.structured = nil
+		return
+	}
+
+	handler := slog.NewJSONHandler(lw.out, &slog.HandlerOptions{Level: logLevel{lw}})
+	lw.structured = NewSlogLogger(slog.New(handler))
+}
+
+// With returns a logger which attaches the given key-value pairs to its entries when JSON output is on.
+// Otherwise the logger itself is returned, as text entries have no fields.
+func (lw *LogWithWriters) With(args ...any) Logger {
+	if lw.structured == nil {
+		return lw
+	}
+
+	return lw.structured.With(args...)
+}
+
 // WantQuiet reduces the number of logs returned
 func (lw *LogWithWriters) WantQuiet(f bool) {
 	if f {
@@ -129,6 +166,15 @@ func (lw *LogWithWriters) IsVerbose() bool {
 	return lw.Level == log.DebugLevel
 }
 
+// logLevel makes JSON entries follow the level of the LogWithWriters, which may change after JSON output is turned on
+type logLevel struct {
+	lw *LogWithWriters
+}
+
+func (l logLevel) Level() slog.Level {
+	return slogLevel(Level(l.lw.Level))
+}
+
 func formatLevel(ll log.Level) string {
 	switch ll {
 	case log.ErrorLevel:
@@ -210,6 +210,59 @@ func testLogWithWriters(t *testing.T, when spec.G, it spec.S) {
 		})
 	})
 
+	when("json is set to true", func() {
+		it.Before(func() {
+			logger.WantJSON(true)
+		})
+
+		it("logs entries as JSON objects to standard writer", func() {
+			logger.Info(color.HiBlueString("info_"))
+			logger.Errorf("error%s", "f")
+
+			output := fOut()
+			h.AssertContains(t, output, `"level":"INFO","msg":"info_"`)
+			h.AssertContains(t, output, `"level":"ERROR","msg":"errorf"`)
+			h.AssertEq(t, fErr(), "")
+		})
+
+		it("follows the level of the logger", func() {
+			logger.Debug("debug_")
+			logger.WantVerbose(true)
+			logger.Debug("debugf")
+
+			output := fOut()
+			h.AssertNotContains(t, output, "debug_")
+			h.AssertContains(t, output, `"level":"DEBUG","msg":"debugf"`)
+		})
+
+		it("logs each line written to writers as an entry", func() {
+			_, err := logger.WriterForLevel(logging.WarnLevel).Write([]byte("line 1\nline 2\n"))
+			h.AssertNil(t, err)
+
+			output := fOut()
+			h.AssertContains(t, output, `"level":"WARN","msg":"line 1"`)
+			h.AssertContains(t, output, `"level":"WARN","msg":"line 2"`)
+		})
+
+		it("logs each line written to the base writer as an info entry", func() {
+			_, err := logger.Writer().Write([]byte("some output\n"))
+			h.AssertNil(t, err)
+
+			h.AssertContains(t, fOut(), `"level":"INFO","msg":"some output"`)
+		})
+
+		it("attaches fields", func() {
+			logging.WithFields(logger, logging.FieldPhase, "builder").Info("info_")
+			h.AssertContains(t, fOut(), `"msg":"info_","phase":"builder"`)
+		})
+	})
+
+	when("json is set to false", func() {
+		it("ignores fields", func() {
+			h.AssertSameInstance(t, logging.WithFields(logger, logging.FieldPhase, "builder"), logger)
+		})
+	})
+
 	it("will convert an empty string to a line feed", func() {
 		logger.Info("")
 		expected := "\n"
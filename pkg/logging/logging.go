@@ -50,6 +50,20 @@ func GetWriterForLevel(logger Logger, level Level) io.Writer {
 	return logger.Writer()
 }
 
+type fieldLogger interface {
+	With(args ...any) Logger
+}
+
+// WithFields returns a logger which attaches the given key-value pairs to its entries, for loggers that support
+// structured fields such as SlogLogger. Other loggers are returned unchanged.
+func WithFields(logger Logger, args ...any) Logger {
+	if l, ok := logger.(fieldLogger); ok {
+		return l.With(args...)
+	}
+
+	return logger
+}
+
 // IsQuiet defines whether a pack logger is set to quiet mode
 func IsQuiet(logger Logger) bool {
 	if writer := GetWriterForLevel(logger, InfoLevel); writer == io.Discard {
@@ -0,0 +1,120 @@
+// Package offline describes the artifacts pack cannot get when it runs without network access.
+package offline // import "github.com/buildpacks/pack/pkg/offline"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+const (
+	// KindImage is an image which is not on the daemon
+	KindImage = "image"
+	// KindDownload is a remote URI which is not in the download cache
+	KindDownload = "download"
+	// KindRegistry is a buildpack registry which was never cached
+	KindRegistry = "registry"
+)
+
+// Artifact is something pack would download if it were online.
+type Artifact struct {
+	Kind string
+	Name string
+}
+
+func (a Artifact) String() string {
+	return fmt.Sprintf("%s %s", a.Kind, style.Symbol(a.Name))
+}
+
+// MissingArtifactsError is returned, when running offline, instead of the error pack would get from the network.
+type MissingArtifactsError struct {
+	Artifacts []Artifact
+}
+
+// NewMissingArtifactError returns a MissingArtifactsError for a single artifact.
+func NewMissingArtifactError(kind, name string) error {
+	return &MissingArtifactsError{Artifacts: []Artifact{{Kind: kind, Name: name}}}
+}
+
+// WrapMissingArtifact returns a MissingArtifactsError for a single artifact that was looked for locally and not found
+// because of cause. Both the MissingArtifactsError and cause, which callers may check for, are found in the chain of
+// the returned error.
+func WrapMissingArtifact(cause error, kind, name string) error {
+	return &causedMissingArtifact{
+		missing: &MissingArtifactsError{Artifacts: []Artifact{{Kind: kind, Name: name}}},
+		cause:   cause,
+	}
+}
+
+type causedMissingArtifact struct {
+	missing *MissingArtifactsError
+	cause   error
+}
+
+func (e *causedMissingArtifact) Error() string {
+	return e.missing.Error()
+}
+
+func (e *causedMissingArtifact) Unwrap() []error {
+	return []error{e.missing, e.cause}
+}
+
+// Cause returns the cause for github.com/pkg/errors.Cause
+func (e *causedMissingArtifact) Cause() error {
+	return e.cause
+}
+
+func (e *MissingArtifactsError) Error() string {
+	if len(e.Artifacts) == 1 {
+		return fmt.Sprintf("running offline, %s is not available locally", e.Artifacts[0])
+	}
+
+	var sb strings.Builder
+	sb.WriteString("running offline, the following artifacts are not available locally:")
+	for _, artifact := range e.Artifacts {
+		sb.WriteString("\n  - ")
+		sb.WriteString(artifact.String())
+	}
+	return sb.String()
+}
+
+// Add adds the artifacts of err to e, and returns true, when err is caused by a MissingArtifactsError. Otherwise, it
+// returns false and e is left untouched.
+func (e *MissingArtifactsError) Add(err error) bool {
+	var missing *MissingArtifactsError
+	if !errors.As(err, &missing) {
+		return false
+	}
+
+	for _, artifact := range missing.Artifacts {
+		if !e.contains(artifact) {
+			e.Artifacts = append(e.Artifacts, artifact)
+		}
+	}
+	return true
+}
+
+// ErrOrNil returns e when artifacts were added to it, or nil.
+func (e *MissingArtifactsError) ErrOrNil() error {
+	if len(e.Artifacts) == 0 {
+		return nil
+	}
+	return e
+}
+
+func (e *MissingArtifactsError) contains(artifact Artifact) bool {
+	for _, a := range e.Artifacts {
+		if a == artifact {
+			return true
+		}
+	}
+	return false
+}
+
+// IsMissingArtifact returns true when err is caused by a MissingArtifactsError.
+func IsMissingArtifact(err error) bool {
+	var missing *MissingArtifactsError
+	return errors.As(err, &missing)
+}
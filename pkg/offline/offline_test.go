@@ -0,0 +1,68 @@
+package offline_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/heroku/color"
+	perrors "github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/offline"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestOffline(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Offline", testOffline, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testOffline(t *testing.T, when spec.G, it spec.S) {
+	when("#MissingArtifactsError", func() {
+		it("describes a single artifact", func() {
+			err := offline.NewMissingArtifactError(offline.KindImage, "some/image")
+			h.AssertEq(t, err.Error(), "running offline, image 'some/image' is not available locally")
+		})
+
+		it("lists the artifacts added to it", func() {
+			missing := &offline.MissingArtifactsError{}
+			h.AssertNil(t, missing.ErrOrNil())
+
+			h.AssertTrue(t, missing.Add(perrors.Wrap(offline.NewMissingArtifactError(offline.KindImage, "some/image"), "fetching image")))
+			h.AssertTrue(t, missing.Add(offline.NewMissingArtifactError(offline.KindDownload, "https://example.com/buildpack.tgz")))
+			h.AssertTrue(t, missing.Add(offline.NewMissingArtifactError(offline.KindImage, "some/image")))
+			h.AssertEq(t, missing.Add(errors.New("some-error")), false)
+			h.AssertEq(t, missing.Add(nil), false)
+
+			h.AssertEq(t, missing.ErrOrNil().Error(), "running offline, the following artifacts are not available locally:\n"+
+				"  - image 'some/image'\n"+
+				"  - download 'https://example.com/buildpack.tgz'")
+		})
+	})
+
+	when("#IsMissingArtifact", func() {
+		it("finds wrapped errors", func() {
+			err := perrors.Wrap(offline.NewMissingArtifactError(offline.KindRegistry, "https://example.com/registry"), "refreshing cache")
+			h.AssertTrue(t, offline.IsMissingArtifact(err))
+			h.AssertEq(t, offline.IsMissingArtifact(errors.New("some-error")), false)
+		})
+	})
+
+	when("#WrapMissingArtifact", func() {
+		it("keeps the cause in the chain of the error", func() {
+			cause := errors.New("some-cause")
+			err := offline.WrapMissingArtifact(perrors.Wrap(cause, "fetching image"), offline.KindImage, "some/image")
+
+			h.AssertEq(t, err.Error(), "running offline, image 'some/image' is not available locally")
+			h.AssertTrue(t, offline.IsMissingArtifact(perrors.Wrap(err, "some-context")))
+			h.AssertTrue(t, errors.Is(err, cause))
+			h.AssertTrue(t, perrors.Cause(err) == cause)
+
+			missing := &offline.MissingArtifactsError{}
+			h.AssertTrue(t, missing.Add(err))
+			h.AssertEq(t, len(missing.Artifacts), 1)
+		})
+	})
+}
@@ -1,18 +1,25 @@
 package project
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/BurntSushi/toml"
 	"github.com/pkg/errors"
 
+	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/pkg/project/types"
 	v01 "github.com/buildpacks/pack/pkg/project/v01"
 	v02 "github.com/buildpacks/pack/pkg/project/v02"
+	v03 "github.com/buildpacks/pack/pkg/project/v03"
 )
 
+// LatestSchemaVersion is the schema version used when writing project descriptors
+const LatestSchemaVersion = v03.SchemaVersion
+
 type Project struct {
 	Version string `toml:"schema-version"`
 }
@@ -24,6 +31,51 @@ type VersionDescriptor struct {
 var parsers = map[string]func(string) (types.Descriptor, error){
 	"0.1": v01.NewDescriptor,
 	"0.2": v02.NewDescriptor,
+	"0.3": v03.NewDescriptor,
+}
+
+var schemas = map[string]func() interface{}{
+	"0.1": func() interface{} { return &v01.Descriptor{} },
+	"0.2": func() interface{} { return &v02.Descriptor{} },
+	"0.3": func() interface{} { return &v03.Descriptor{} },
+}
+
+// tables holds the names of the TOML tables used by a schema version, so that validation errors can point to the
+// line where the offending value is declared
+type tables struct {
+	build      string
+	buildpacks string
+	licenses   string
+	launchEnv  string
+	targets    string
+	cache      string
+}
+
+var schemaTables = map[string]tables{
+	"0.1": {build: "build", buildpacks: "build.buildpacks", licenses: "project.licenses"},
+	"0.2": {build: "io.buildpacks", buildpacks: "io.buildpacks.group", licenses: "_.licenses"},
+	"0.3": {
+		build:      "io.buildpacks",
+		buildpacks: "io.buildpacks.group",
+		licenses:   "_.licenses",
+		launchEnv:  "io.buildpacks.launch.env",
+		targets:    "io.buildpacks.targets",
+		cache:      "io.buildpacks.cache",
+	},
+}
+
+// ValidationError is returned when a project descriptor is well formed but contains invalid values.
+// Line is the line of the project descriptor the error refers to, or 0 when it could not be determined.
+type ValidationError struct {
+	Line    int
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("project.toml:%d: %s", e.Line, e.Message)
+	}
+	return fmt.Sprintf("project.toml: %s", e.Message)
 }
 
 func ReadProjectDescriptor(pathToFile string) (types.Descriptor, error) {
@@ -32,15 +84,95 @@ func ReadProjectDescriptor(pathToFile string) (types.Descriptor, error) {
 		return types.Descriptor{}, err
 	}
 
+	return ParseProjectDescriptor(string(projectTomlContents))
+}
+
+// ParseProjectDescriptor parses and validates the contents of a project descriptor of any supported schema version
+func ParseProjectDescriptor(projectTomlContents string) (types.Descriptor, error) {
+	version, err := schemaVersion(projectTomlContents)
+	if err != nil {
+		return types.Descriptor{}, err
+	}
+
+	descriptor, err := parsers[version](projectTomlContents)
+	if err != nil {
+		return types.Descriptor{}, err
+	}
+
+	return descriptor, validate(descriptor, projectTomlContents, schemaTables[version])
+}
+
+// FindUnknownKeys returns an error for each key of a project descriptor that is not defined by its schema version.
+// Metadata tables may hold arbitrary keys and are never reported. From schema version 0.2 onwards, tables other than
+// `_` and `io.buildpacks` belong to other tools and are not reported either.
+func FindUnknownKeys(projectTomlContents string) ([]*ValidationError, error) {
+	version, err := schemaVersion(projectTomlContents)
+	if err != nil {
+		return nil, err
+	}
+
+	md, err := toml.Decode(projectTomlContents, schemas[version]())
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		unknownKeys []*ValidationError
+		reported    []string
+	)
+	for _, key := range md.Undecoded() {
+		if !isSchemaKey(version, key) || hasPrefixKey(key.String(), reported) {
+			continue
+		}
+		reported = append(reported, key.String())
+
+		table, name := strings.Join(key[:len(key)-1], "."), key[len(key)-1]
+		line := lineOf(projectTomlContents, table, anyEntry, name)
+		if line == 0 {
+			line = lineOf(projectTomlContents, key.String(), anyEntry, "")
+		}
+		unknownKeys = append(unknownKeys, &ValidationError{
+			Line:    line,
+			Message: fmt.Sprintf("unknown key %s", style.Symbol(key.String())),
+		})
+	}
+	return unknownKeys, nil
+}
+
+func isSchemaKey(version string, key toml.Key) bool {
+	if version == "0.1" {
+		return key[0] != "metadata"
+	}
+
+	switch {
+	case key[0] == "_":
+		return len(key) < 2 || key[1] != "metadata"
+	case key[0] == "io":
+		return len(key) < 2 || key[1] == "buildpacks"
+	default:
+		return false
+	}
+}
+
+func hasPrefixKey(key string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(key, prefix+".") {
+			return true
+		}
+	}
+	return false
+}
+
+func schemaVersion(projectTomlContents string) (string, error) {
 	var versionDescriptor struct {
 		Project struct {
 			Version string `toml:"schema-version"`
 		} `toml:"_"`
 	}
 
-	_, err = toml.Decode(string(projectTomlContents), &versionDescriptor)
+	_, err := toml.Decode(projectTomlContents, &versionDescriptor)
 	if err != nil {
-		return types.Descriptor{}, errors.Wrapf(err, "parsing schema version")
+		return "", errors.Wrapf(err, "parsing schema version")
 	}
 
 	version := versionDescriptor.Project.Version
@@ -49,38 +181,234 @@ func ReadProjectDescriptor(pathToFile string) (types.Descriptor, error) {
 	}
 
 	if _, ok := parsers[version]; !ok {
-		return types.Descriptor{}, fmt.Errorf("unknown project descriptor schema version %s", version)
+		return "", fmt.Errorf("unknown project descriptor schema version %s", version)
 	}
+	return version, nil
+}
 
-	descriptor, err := parsers[version](string(projectTomlContents))
+// EncodeProjectDescriptor encodes a project descriptor using the latest schema version
+func EncodeProjectDescriptor(descriptor types.Descriptor) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	if err := toml.NewEncoder(buf).Encode(v03.FromDescriptor(descriptor)); err != nil {
+		return nil, errors.Wrap(err, "encoding project descriptor")
+	}
+	return buf.Bytes(), nil
+}
+
+// MigrateProjectDescriptor rewrites the project descriptor at pathToFile using the latest schema version.
+// Comments and formatting of the original file are not preserved, but the tables of other tools are. It refuses to
+// migrate a descriptor with keys its schema version does not define, as they would be lost. It returns false if the
+// descriptor already uses the latest schema version, in which case the file is left untouched.
+func MigrateProjectDescriptor(pathToFile string) (bool, error) {
+	original, err := os.ReadFile(filepath.Clean(pathToFile))
 	if err != nil {
-		return types.Descriptor{}, err
+		return false, err
+	}
+
+	descriptor, err := ParseProjectDescriptor(string(original))
+	if err != nil {
+		return false, err
+	}
+
+	version := descriptor.SchemaVersion.String()
+	if version == LatestSchemaVersion {
+		return false, nil
+	}
+
+	unknownKeys, err := FindUnknownKeys(string(original))
+	if err != nil {
+		return false, err
+	}
+	if len(unknownKeys) > 0 {
+		return false, errors.Wrapf(unknownKeys[0], "cannot migrate %s without losing unknown keys", style.Symbol(pathToFile))
+	}
+
+	contents, err := EncodeProjectDescriptor(descriptor)
+	if err != nil {
+		return false, err
 	}
 
-	return descriptor, validate(descriptor)
+	if version != "0.1" {
+		if contents, err = keepToolTables(version, string(original), contents); err != nil {
+			return false, err
+		}
+	}
+
+	info, err := os.Stat(pathToFile)
+	if err != nil {
+		return false, err
+	}
+
+	if err := os.WriteFile(pathToFile, contents, info.Mode()); err != nil {
+		return false, errors.Wrapf(err, "writing %s", style.Symbol(pathToFile))
+	}
+	return true, nil
 }
 
-func validate(p types.Descriptor) error {
-	if p.Build.Exclude != nil && p.Build.Include != nil {
-		return errors.New("project.toml: cannot have both include and exclude defined")
+// keepToolTables adds the tables of other tools found in the original project descriptor to the migrated one
+func keepToolTables(version, original string, migrated []byte) ([]byte, error) {
+	var originalTree, migratedTree map[string]interface{}
+	if _, err := toml.Decode(original, &originalTree); err != nil {
+		return nil, err
 	}
+	if _, err := toml.Decode(string(migrated), &migratedTree); err != nil {
+		return nil, err
+	}
+
+	var keep func(key toml.Key, from, to map[string]interface{})
+	keep = func(key toml.Key, from, to map[string]interface{}) {
+		for name, value := range from {
+			childKey := append(key[:len(key):len(key)], name)
+			if !isSchemaKey(version, childKey) {
+				if _, ok := to[name]; !ok {
+					to[name] = value
+				}
+				continue
+			}
 
-	if len(p.Project.Licenses) > 0 {
-		for _, license := range p.Project.Licenses {
-			if license.Type == "" && license.URI == "" {
-				return errors.New("project.toml: must have a type or uri defined for each license")
+			fromTable, isTable := value.(map[string]interface{})
+			if !isTable {
+				continue
 			}
+			toTable, ok := to[name].(map[string]interface{})
+			if !ok {
+				toTable = map[string]interface{}{}
+			}
+			keep(childKey, fromTable, toTable)
+			if len(toTable) > 0 {
+				to[name] = toTable
+			}
+		}
+	}
+	keep(nil, originalTree, migratedTree)
+
+	buf := &bytes.Buffer{}
+	if err := toml.NewEncoder(buf).Encode(migratedTree); err != nil {
+		return nil, errors.Wrap(err, "encoding project descriptor")
+	}
+	return buf.Bytes(), nil
+}
+
+func validate(p types.Descriptor, contents string, t tables) error {
+	invalid := func(line int, format string, args ...interface{}) error {
+		return &ValidationError{Line: line, Message: fmt.Sprintf(format, args...)}
+	}
+
+	if p.Build.Exclude != nil && p.Build.Include != nil {
+		return invalid(lineOf(contents, t.build, -1, "exclude"), "cannot have both include and exclude defined")
+	}
+
+	for i, license := range p.Project.Licenses {
+		if license.Type == "" && license.URI == "" {
+			return invalid(lineOf(contents, t.licenses, i, ""), "must have a type or uri defined for each license")
 		}
 	}
 
-	for _, bp := range p.Build.Buildpacks {
+	for i, bp := range p.Build.Buildpacks {
 		if bp.ID == "" && bp.URI == "" {
-			return errors.New("project.toml: buildpacks must have an id or url defined")
+			return invalid(lineOf(contents, t.buildpacks, i, ""), "buildpacks must have an id or url defined")
 		}
 		if bp.URI != "" && bp.Version != "" {
-			return errors.New("project.toml: buildpacks cannot have both uri and version defined")
+			return invalid(lineOf(contents, t.buildpacks, i, "version"), "buildpacks cannot have both uri and version defined")
+		}
+	}
+
+	for i, envVar := range p.Launch.Env {
+		if envVar.Name == "" {
+			return invalid(lineOf(contents, t.launchEnv, i, ""), "launch env vars must have a name defined")
+		}
+		if strings.ContainsAny(envVar.Name, "=/") {
+			return invalid(lineOf(contents, t.launchEnv, i, "name"), "launch env var %s has an invalid name", style.Symbol(envVar.Name))
+		}
+		if strings.Contains(envVar.Process, "/") {
+			return invalid(lineOf(contents, t.launchEnv, i, "process"), "launch env var %s has an invalid process type %s", style.Symbol(envVar.Name), style.Symbol(envVar.Process))
+		}
+	}
+
+	platforms := map[string]bool{}
+	for i, target := range p.Build.Targets {
+		if target.OS == "" {
+			return invalid(lineOf(contents, t.targets, i, ""), "targets must have an os defined")
+		}
+		if platforms[target.Platform()] {
+			return invalid(lineOf(contents, t.targets, i, ""), "target %s is declared more than once", style.Symbol(target.Platform()))
+		}
+		platforms[target.Platform()] = true
+	}
+
+	cacheTypes := map[string]bool{}
+	for i, c := range p.Build.Cache {
+		switch c.Type {
+		case "build", "launch":
+		default:
+			return invalid(lineOf(contents, t.cache, i, "type"), "cache type %s is invalid, must be one of build or launch", style.Symbol(c.Type))
+		}
+		if cacheTypes[c.Type] {
+			return invalid(lineOf(contents, t.cache, i, "type"), "cache type %s is declared more than once", style.Symbol(c.Type))
+		}
+		cacheTypes[c.Type] = true
+
+		switch c.Format {
+		case "volume":
+		case "image":
+			if c.Name == "" {
+				return invalid(lineOf(contents, t.cache, i, ""), "image caches must have a name defined")
+			}
+		case "bind":
+			if c.Source == "" {
+				return invalid(lineOf(contents, t.cache, i, ""), "bind caches must have a source defined")
+			}
+		default:
+			return invalid(lineOf(contents, t.cache, i, "format"), "cache format %s is invalid, must be one of image, volume or bind", style.Symbol(c.Format))
 		}
 	}
 
 	return nil
 }
+
+// anyEntry matches every entry of an array of tables, as well as plain tables, when passed as index to lineOf
+const anyEntry = -2
+
+// lineOf returns the line number at which key is declared in the given table of a project descriptor, or the line of
+// the table header when key is empty. For arrays of tables, index selects the entry; it must be -1 for plain tables.
+// It returns 0 when the declaration cannot be found, such as when dotted keys or inline tables are used.
+func lineOf(contents, table string, index int, key string) int {
+	if table == "" {
+		return 0
+	}
+
+	current, occurrence, entry := "", -1, -1
+	for i, line := range strings.Split(contents, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "[["):
+			end := strings.Index(line, "]]")
+			if end < 0 {
+				continue
+			}
+			current = strings.TrimSpace(line[2:end])
+			if current != table {
+				continue
+			}
+			occurrence++
+			entry = occurrence
+			if (entry == index || index == anyEntry) && key == "" {
+				return i + 1
+			}
+		case strings.HasPrefix(line, "["):
+			end := strings.Index(line, "]")
+			if end < 0 {
+				continue
+			}
+			current, entry = strings.TrimSpace(line[1:end]), -1
+			if current == table && (index == -1 || index == anyEntry) && key == "" {
+				return i + 1
+			}
+		case current == table && (entry == index || index == anyEntry) && key != "":
+			if rest := strings.TrimPrefix(line, key); rest != line && strings.HasPrefix(strings.TrimSpace(rest), "=") {
+				return i + 1
+			}
+		}
+	}
+	return 0
+}
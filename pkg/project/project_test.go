@@ -1,16 +1,20 @@
 package project
 
 import (
+	"errors"
 	"log"
 	"os"
 	"reflect"
 	"testing"
 
+	"github.com/BurntSushi/toml"
 	"github.com/buildpacks/lifecycle/api"
 	"github.com/heroku/color"
 	"github.com/sclevine/spec"
 	"github.com/sclevine/spec/report"
 
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/project/types"
 	h "github.com/buildpacks/pack/testhelpers"
 )
 
@@ -24,6 +28,75 @@ func TestProject(t *testing.T) {
 
 func testProject(t *testing.T, when spec.G, it spec.S) {
 	when("#ReadProjectDescriptor", func() {
+		it("should parse a valid v0.3 project.toml file", func() {
+			projectToml := `
+[_]
+name = "gallant 0.3"
+version = "1.0.2"
+source-url = "https://github.com/buildpacks/pack"
+schema-version = "0.3"
+[[_.licenses]]
+type = "MIT"
+[_.metadata]
+pipeline = "Lucerne"
+[io.buildpacks]
+builder = "some/builder"
+run-image = "some/run-image"
+exclude = [ "*.jar" ]
+[[io.buildpacks.group]]
+id = "example/lua"
+version = "1.0"
+[[io.buildpacks.build.env]]
+name = "JAVA_OPTS"
+value = "-Xmx300m"
+[io.buildpacks.launch]
+default-process = "worker"
+[[io.buildpacks.launch.env]]
+name = "LOG_LEVEL"
+value = "debug"
+[[io.buildpacks.launch.env]]
+name = "PORT"
+value = "8080"
+process = "web"
+[[io.buildpacks.targets]]
+os = "linux"
+arch = "arm64"
+variant = "v8"
+[[io.buildpacks.cache]]
+type = "build"
+format = "image"
+name = "registry.example.com/cache"
+`
+			tmpProjectToml, err := createTmpProjectTomlFile(projectToml)
+			h.AssertNil(t, err)
+
+			projectDescriptor, err := ReadProjectDescriptor(tmpProjectToml.Name())
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, projectDescriptor.SchemaVersion, api.MustParse("0.3"))
+			h.AssertEq(t, projectDescriptor.Project, types.Project{
+				Name:      "gallant 0.3",
+				Version:   "1.0.2",
+				SourceURL: "https://github.com/buildpacks/pack",
+				Licenses:  []types.License{{Type: "MIT"}},
+			})
+			h.AssertEq(t, projectDescriptor.Metadata["pipeline"], "Lucerne")
+			h.AssertEq(t, projectDescriptor.Build.Builder, "some/builder")
+			h.AssertEq(t, projectDescriptor.Build.RunImage, "some/run-image")
+			h.AssertEq(t, projectDescriptor.Build.Exclude, []string{"*.jar"})
+			h.AssertEq(t, projectDescriptor.Build.Buildpacks, []types.Buildpack{{ID: "example/lua", Version: "1.0"}})
+			h.AssertEq(t, projectDescriptor.Build.Env, []types.EnvVar{{Name: "JAVA_OPTS", Value: "-Xmx300m"}})
+			h.AssertEq(t, projectDescriptor.Build.Targets, []dist.Target{{OS: "linux", Arch: "arm64", ArchVariant: "v8"}})
+			h.AssertEq(t, projectDescriptor.Build.Cache, []types.Cache{{Type: "build", Format: "image", Name: "registry.example.com/cache"}})
+			h.AssertEq(t, projectDescriptor.Launch, types.Launch{
+				Env: []types.LaunchEnvVar{
+					{Name: "LOG_LEVEL", Value: "debug"},
+					{Name: "PORT", Value: "8080", Process: "web"},
+				},
+				DefaultProcess: "worker",
+			})
+		})
+
 		it("should parse a valid v0.2 project.toml file", func() {
 			projectToml := `
 [_]
@@ -357,6 +430,98 @@ version = "1.2.3"
 			}
 		})
 
+		it("should report the line of the offending value", func() {
+			projectToml := `
+[project]
+name = "bad excludes and includes"
+
+[build]
+include = [ "*.jpg" ]
+exclude = [ "*.jar" ]
+`
+			tmpProjectToml, err := createTmpProjectTomlFile(projectToml)
+			h.AssertNil(t, err)
+
+			_, err = ReadProjectDescriptor(tmpProjectToml.Name())
+			h.AssertError(t, err, "project.toml:7: cannot have both include and exclude defined")
+
+			var validationErr *ValidationError
+			h.AssertTrue(t, errors.As(err, &validationErr))
+			h.AssertEq(t, validationErr.Line, 7)
+		})
+
+		it("should report the line of the offending array entry", func() {
+			projectToml := `
+[_]
+schema-version = "0.3"
+
+[[io.buildpacks.group]]
+id = "example/lua"
+
+[[io.buildpacks.group]]
+uri = "https://example.com/buildpack"
+version = "1.2.3"
+`
+			tmpProjectToml, err := createTmpProjectTomlFile(projectToml)
+			h.AssertNil(t, err)
+
+			_, err = ReadProjectDescriptor(tmpProjectToml.Name())
+			h.AssertError(t, err, "project.toml:10: buildpacks cannot have both uri and version defined")
+		})
+
+		when("schema version is 0.3", func() {
+			for _, tc := range []struct {
+				name     string
+				contents string
+				err      string
+			}{
+				{
+					name:     "launch env var without a name",
+					contents: "[[io.buildpacks.launch.env]]\nvalue = \"debug\"",
+					err:      "project.toml:3: launch env vars must have a name defined",
+				},
+				{
+					name:     "launch env var with an invalid process type",
+					contents: "[[io.buildpacks.launch.env]]\nname = \"PORT\"\nprocess = \"web/api\"",
+					err:      "project.toml:5: launch env var 'PORT' has an invalid process type 'web/api'",
+				},
+				{
+					name:     "target without an os",
+					contents: "[[io.buildpacks.targets]]\narch = \"arm64\"",
+					err:      "project.toml:3: targets must have an os defined",
+				},
+				{
+					name:     "duplicate targets",
+					contents: "[[io.buildpacks.targets]]\nos = \"linux\"\n[[io.buildpacks.targets]]\nos = \"linux\"",
+					err:      "project.toml:5: target 'linux' is declared more than once",
+				},
+				{
+					name:     "cache with an invalid type",
+					contents: "[[io.buildpacks.cache]]\ntype = \"run\"\nformat = \"volume\"",
+					err:      "project.toml:4: cache type 'run' is invalid, must be one of build or launch",
+				},
+				{
+					name:     "cache with an invalid format",
+					contents: "[[io.buildpacks.cache]]\ntype = \"build\"\nformat = \"tarball\"",
+					err:      "project.toml:5: cache format 'tarball' is invalid, must be one of image, volume or bind",
+				},
+				{
+					name:     "image cache without a name",
+					contents: "[[io.buildpacks.cache]]\ntype = \"build\"\nformat = \"image\"",
+					err:      "project.toml:3: image caches must have a name defined",
+				},
+			} {
+				tc := tc
+				it("should fail for a "+tc.name, func() {
+					tmpProjectToml, err := createTmpProjectTomlFile("[_]\nschema-version = \"0.3\"\n" + tc.contents + "\n")
+					h.AssertNil(t, err)
+
+					_, err = ReadProjectDescriptor(tmpProjectToml.Name())
+					h.AssertError(t, err, tc.err)
+				})
+			}
+		})
+
 		it("should require either a type or uri for licenses", func() {
 			projectToml := `
 [project]
@@ -375,6 +540,153 @@ name = "licenses should have either a type or uri defined"
 			}
 		})
 	})
+
+	when("#MigrateProjectDescriptor", func() {
+		it("should rewrite a v0.1 project.toml file using the latest schema version", func() {
+			projectToml := `
+[project]
+name = "gallant"
+version = "1.0.2"
+source-url = "https://github.com/buildpacks/pack"
+
+[[project.licenses]]
+type = "MIT"
+
+[build]
+builder = "some/builder"
+exclude = [ "*.jar" ]
+
+[[build.buildpacks]]
+id = "example/lua"
+version = "1.0"
+
+[[build.env]]
+name = "JAVA_OPTS"
+value = "-Xmx300m"
+
+[metadata]
+pipeline = "Lucerne"
+`
+			tmpProjectToml, err := createTmpProjectTomlFile(projectToml)
+			h.AssertNil(t, err)
+
+			original, err := ReadProjectDescriptor(tmpProjectToml.Name())
+			h.AssertNil(t, err)
+
+			migrated, err := MigrateProjectDescriptor(tmpProjectToml.Name())
+			h.AssertNil(t, err)
+			h.AssertTrue(t, migrated)
+
+			contents, err := os.ReadFile(tmpProjectToml.Name())
+			h.AssertNil(t, err)
+			h.AssertContains(t, string(contents), `schema-version = "0.3"`)
+			h.AssertContains(t, string(contents), "[[io.buildpacks.group]]")
+
+			descriptor, err := ReadProjectDescriptor(tmpProjectToml.Name())
+			h.AssertNil(t, err)
+			h.AssertEq(t, descriptor.SchemaVersion, api.MustParse("0.3"))
+			h.AssertEq(t, descriptor.Project, original.Project)
+			h.AssertEq(t, descriptor.Build, original.Build)
+			h.AssertEq(t, descriptor.Metadata, original.Metadata)
+		})
+
+		it("should keep the tables of other tools", func() {
+			projectToml := `
+[_]
+schema-version = "0.2"
+name = "gallant"
+
+[_.metadata]
+pipeline = "Lucerne"
+
+[io.buildpacks]
+builder = "some/builder"
+
+[io.other-tool]
+some-key = "some-value"
+
+[other-tool]
+other-key = 1
+`
+			tmpProjectToml, err := createTmpProjectTomlFile(projectToml)
+			h.AssertNil(t, err)
+
+			migrated, err := MigrateProjectDescriptor(tmpProjectToml.Name())
+			h.AssertNil(t, err)
+			h.AssertTrue(t, migrated)
+
+			contents, err := os.ReadFile(tmpProjectToml.Name())
+			h.AssertNil(t, err)
+			h.AssertContains(t, string(contents), `schema-version = "0.3"`)
+
+			var tree struct {
+				IO struct {
+					Buildpacks struct {
+						Builder string `toml:"builder"`
+					} `toml:"buildpacks"`
+					OtherTool map[string]string `toml:"other-tool"`
+				} `toml:"io"`
+				OtherTool map[string]int `toml:"other-tool"`
+			}
+			_, err = toml.Decode(string(contents), &tree)
+			h.AssertNil(t, err)
+			h.AssertEq(t, tree.IO.Buildpacks.Builder, "some/builder")
+			h.AssertEq(t, tree.IO.OtherTool, map[string]string{"some-key": "some-value"})
+			h.AssertEq(t, tree.OtherTool, map[string]int{"other-key": 1})
+
+			descriptor, err := ReadProjectDescriptor(tmpProjectToml.Name())
+			h.AssertNil(t, err)
+			h.AssertEq(t, descriptor.Project.Name, "gallant")
+			h.AssertEq(t, descriptor.Metadata["pipeline"], "Lucerne")
+		})
+
+		it("should not rewrite a project.toml file with unknown keys", func() {
+			projectToml := `
+[_]
+schema-version = "0.2"
+name = "gallant"
+
+[io.buildpacks]
+builder = "some/builder"
+some-unknown-key = "some-value"
+`
+			tmpProjectToml, err := createTmpProjectTomlFile(projectToml)
+			h.AssertNil(t, err)
+
+			_, err = MigrateProjectDescriptor(tmpProjectToml.Name())
+			h.AssertError(t, err, "without losing unknown keys: project.toml:8: unknown key 'io.buildpacks.some-unknown-key'")
+
+			contents, err := os.ReadFile(tmpProjectToml.Name())
+			h.AssertNil(t, err)
+			h.AssertEq(t, string(contents), projectToml)
+		})
+
+		it("should not rewrite a project.toml file using the latest schema version", func() {
+			projectToml := `
+# keep this comment
+[_]
+schema-version = "0.3"
+`
+			tmpProjectToml, err := createTmpProjectTomlFile(projectToml)
+			h.AssertNil(t, err)
+
+			migrated, err := MigrateProjectDescriptor(tmpProjectToml.Name())
+			h.AssertNil(t, err)
+			h.AssertFalse(t, migrated)
+
+			contents, err := os.ReadFile(tmpProjectToml.Name())
+			h.AssertNil(t, err)
+			h.AssertEq(t, string(contents), projectToml)
+		})
+
+		it("should fail for an invalid project.toml file", func() {
+			tmpProjectToml, err := createTmpProjectTomlFile("[project\n")
+			h.AssertNil(t, err)
+
+			_, err = MigrateProjectDescriptor(tmpProjectToml.Name())
+			h.AssertNotNil(t, err)
+		})
+	})
 }
 
 func createTmpProjectTomlFile(projectToml string) (*os.File, error) {
@@ -2,19 +2,21 @@ package types
 
 import (
 	"github.com/buildpacks/lifecycle/api"
+
+	"github.com/buildpacks/pack/pkg/dist"
 )
 
 type Script struct {
-	API    string `toml:"api"`
-	Inline string `toml:"inline"`
-	Shell  string `toml:"shell"`
+	API    string `toml:"api,omitempty"`
+	Inline string `toml:"inline,omitempty"`
+	Shell  string `toml:"shell,omitempty"`
 }
 
 type Buildpack struct {
-	ID      string `toml:"id"`
-	Version string `toml:"version"`
-	URI     string `toml:"uri"`
-	Script  Script `toml:"script"`
+	ID      string `toml:"id,omitempty"`
+	Version string `toml:"version,omitempty"`
+	URI     string `toml:"uri,omitempty"`
+	Script  Script `toml:"script,omitempty"`
 }
 
 type EnvVar struct {
@@ -22,16 +24,40 @@ type EnvVar struct {
 	Value string `toml:"value"`
 }
 
+// LaunchEnvVar is an environment variable made available to the app image at runtime.
+// When Process is set the variable is only available to that process type.
+type LaunchEnvVar struct {
+	Name    string `toml:"name"`
+	Value   string `toml:"value"`
+	Process string `toml:"process,omitempty"`
+}
+
+// Cache describes a preferred cache for the build, using the same options as the `--cache` flag of `pack build`
+type Cache struct {
+	Type   string `toml:"type"`
+	Format string `toml:"format"`
+	Name   string `toml:"name,omitempty"`
+	Source string `toml:"source,omitempty"`
+}
+
 type Build struct {
-	Include    []string    `toml:"include"`
-	Exclude    []string    `toml:"exclude"`
-	Buildpacks []Buildpack `toml:"buildpacks"`
-	Env        []EnvVar    `toml:"env"`
-	Builder    string      `toml:"builder"`
+	Include    []string      `toml:"include"`
+	Exclude    []string      `toml:"exclude"`
+	Buildpacks []Buildpack   `toml:"buildpacks"`
+	Env        []EnvVar      `toml:"env"`
+	Builder    string        `toml:"builder"`
+	RunImage   string        `toml:"-"`
+	Targets    []dist.Target `toml:"-"`
+	Cache      []Cache       `toml:"-"`
 	Pre        GroupAddition
 	Post       GroupAddition
 }
 
+type Launch struct {
+	Env            []LaunchEnvVar
+	DefaultProcess string
+}
+
 type Project struct {
 	Name      string    `toml:"name"`
 	Version   string    `toml:"version"`
@@ -40,17 +66,18 @@ type Project struct {
 }
 
 type License struct {
-	Type string `toml:"type"`
-	URI  string `toml:"uri"`
+	Type string `toml:"type,omitempty"`
+	URI  string `toml:"uri,omitempty"`
 }
 
 type Descriptor struct {
 	Project       Project                `toml:"project"`
 	Build         Build                  `toml:"build"`
+	Launch        Launch                 `toml:"-"`
 	Metadata      map[string]interface{} `toml:"metadata"`
 	SchemaVersion *api.Version
 }
 
 type GroupAddition struct {
-	Buildpacks []Buildpack `toml:"group"`
+	Buildpacks []Buildpack `toml:"group,omitempty"`
 }
@@ -0,0 +1,116 @@
+package v03
+
+import (
+	"github.com/BurntSushi/toml"
+	"github.com/buildpacks/lifecycle/api"
+
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/project/types"
+)
+
+const SchemaVersion = "0.3"
+
+type Buildpacks struct {
+	Include  []string            `toml:"include,omitempty"`
+	Exclude  []string            `toml:"exclude,omitempty"`
+	Group    []types.Buildpack   `toml:"group,omitempty"`
+	Build    Build               `toml:"build,omitempty"`
+	Launch   Launch              `toml:"launch,omitempty"`
+	Builder  string              `toml:"builder,omitempty"`
+	RunImage string              `toml:"run-image,omitempty"`
+	Targets  []dist.Target       `toml:"targets,omitempty"`
+	Cache    []types.Cache       `toml:"cache,omitempty"`
+	Pre      types.GroupAddition `toml:"pre,omitempty"`
+	Post     types.GroupAddition `toml:"post,omitempty"`
+}
+
+type Build struct {
+	Env []types.EnvVar `toml:"env,omitempty"`
+}
+
+type Launch struct {
+	Env            []types.LaunchEnvVar `toml:"env,omitempty"`
+	DefaultProcess string               `toml:"default-process,omitempty"`
+}
+
+type Project struct {
+	Name          string                 `toml:"name,omitempty"`
+	Version       string                 `toml:"version,omitempty"`
+	SourceURL     string                 `toml:"source-url,omitempty"`
+	Licenses      []types.License        `toml:"licenses,omitempty"`
+	Metadata      map[string]interface{} `toml:"metadata,omitempty"`
+	SchemaVersion string                 `toml:"schema-version"`
+}
+
+type IO struct {
+	Buildpacks Buildpacks `toml:"buildpacks"`
+}
+
+type Descriptor struct {
+	Project Project `toml:"_"`
+	IO      IO      `toml:"io"`
+}
+
+func NewDescriptor(projectTomlContents string) (types.Descriptor, error) {
+	versionedDescriptor := &Descriptor{}
+	_, err := toml.Decode(projectTomlContents, versionedDescriptor)
+	if err != nil {
+		return types.Descriptor{}, err
+	}
+
+	return types.Descriptor{
+		Project: types.Project{
+			Name:      versionedDescriptor.Project.Name,
+			Version:   versionedDescriptor.Project.Version,
+			SourceURL: versionedDescriptor.Project.SourceURL,
+			Licenses:  versionedDescriptor.Project.Licenses,
+		},
+		Build: types.Build{
+			Include:    versionedDescriptor.IO.Buildpacks.Include,
+			Exclude:    versionedDescriptor.IO.Buildpacks.Exclude,
+			Buildpacks: versionedDescriptor.IO.Buildpacks.Group,
+			Env:        versionedDescriptor.IO.Buildpacks.Build.Env,
+			Builder:    versionedDescriptor.IO.Buildpacks.Builder,
+			RunImage:   versionedDescriptor.IO.Buildpacks.RunImage,
+			Targets:    versionedDescriptor.IO.Buildpacks.Targets,
+			Cache:      versionedDescriptor.IO.Buildpacks.Cache,
+			Pre:        versionedDescriptor.IO.Buildpacks.Pre,
+			Post:       versionedDescriptor.IO.Buildpacks.Post,
+		},
+		Launch: types.Launch{
+			Env:            versionedDescriptor.IO.Buildpacks.Launch.Env,
+			DefaultProcess: versionedDescriptor.IO.Buildpacks.Launch.DefaultProcess,
+		},
+		Metadata:      versionedDescriptor.Project.Metadata,
+		SchemaVersion: api.MustParse(SchemaVersion),
+	}, nil
+}
+
+// FromDescriptor converts a parsed project descriptor of any schema version into its schema version 0.3 representation
+func FromDescriptor(descriptor types.Descriptor) Descriptor {
+	return Descriptor{
+		Project: Project{
+			Name:          descriptor.Project.Name,
+			Version:       descriptor.Project.Version,
+			SourceURL:     descriptor.Project.SourceURL,
+			Licenses:      descriptor.Project.Licenses,
+			Metadata:      descriptor.Metadata,
+			SchemaVersion: SchemaVersion,
+		},
+		IO: IO{
+			Buildpacks: Buildpacks{
+				Include:  descriptor.Build.Include,
+				Exclude:  descriptor.Build.Exclude,
+				Group:    descriptor.Build.Buildpacks,
+				Build:    Build{Env: descriptor.Build.Env},
+				Launch:   Launch{Env: descriptor.Launch.Env, DefaultProcess: descriptor.Launch.DefaultProcess},
+				Builder:  descriptor.Build.Builder,
+				RunImage: descriptor.Build.RunImage,
+				Targets:  descriptor.Build.Targets,
+				Cache:    descriptor.Build.Cache,
+				Pre:      descriptor.Build.Pre,
+				Post:     descriptor.Build.Post,
+			},
+		},
+	}
+}
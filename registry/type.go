@@ -3,9 +3,13 @@ package registry
 const (
 	TypeGit    = "git"
 	TypeGitHub = "github"
+	TypeHTTP   = "http"
+	TypeOCI    = "oci"
 )
 
 var Types = []string{
 	TypeGit,
 	TypeGitHub,
+	TypeHTTP,
+	TypeOCI,
 }